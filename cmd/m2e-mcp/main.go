@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"io"
 	"log"
@@ -12,6 +13,7 @@ import (
 	"sync"
 
 	"github.com/sammcj/m2e/pkg/converter"
+	"github.com/sammcj/m2e/pkg/report"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -64,76 +66,59 @@ func validateFilePath(filePath string) error {
 	return nil
 }
 
-// isPlainTextFile checks if a file extension indicates it's a plain text file
-// that can be safely converted entirely (not just comments)
-func isPlainTextFile(filePath string) bool {
-	ext := strings.ToLower(filepath.Ext(filePath))
-	plainTextExtensions := []string{
-		".txt", ".md", ".markdown", ".rst", ".text", ".doc", ".rtf",
-		".tex", ".latex", ".org", ".adoc", ".asciidoc",
-	}
-	return slices.Contains(plainTextExtensions, ext)
-}
+// File statuses reported by convert_files
+const (
+	fileStatusConverted = "converted"
+	fileStatusUnchanged = "unchanged"
+	fileStatusError     = "error"
+)
 
-// convertFileContentWithOptions converts file content based on file type with custom options
-func convertFileContentWithOptions(conv *converter.Converter, content, filePath string, normaliseSmartQuotes bool) string {
-	if isPlainTextFile(filePath) {
-		// For plain text files, use code-aware processing which:
-		// - Converts all regular text
-		// - Only converts comments within code blocks (preserving code)
-		return conv.ProcessCodeAware(content, normaliseSmartQuotes)
-	} else {
-		// For code/config files, only convert comments to preserve functionality
-		return convertOnlyCommentsWithOptions(conv, content, normaliseSmartQuotes)
-	}
+// fileResult is the outcome of converting one file
+type fileResult struct {
+	Path    string `json:"path"`
+	Status  string `json:"status"`
+	Changes int    `json:"changes"` // spelling, unit and quote changes made
+	Error   string `json:"error,omitempty"`
 }
 
-// convertOnlyCommentsWithOptions converts only comments in code with custom options
-func convertOnlyCommentsWithOptions(conv *converter.Converter, code string, normaliseSmartQuotes bool) string {
-	comments := conv.ExtractComments(code, "")
+// batchResult is the outcome of a convert_files call, with a result for every file requested
+type batchResult struct {
+	Files     []fileResult `json:"files"`
+	Converted int          `json:"converted"`
+	Unchanged int          `json:"unchanged"`
+	Failed    int          `json:"failed"`
+}
 
-	if len(comments) == 0 {
-		return code
+// add records a file's result and counts it in the totals
+func (b *batchResult) add(result fileResult) {
+	b.Files = append(b.Files, result)
+	switch result.Status {
+	case fileStatusConverted:
+		b.Converted++
+	case fileStatusUnchanged:
+		b.Unchanged++
+	default:
+		b.Failed++
 	}
+}
 
-	// Work backwards through comments so positions don't shift
-	result := code
-	for i := len(comments) - 1; i >= 0; i-- {
-		comment := comments[i]
-
-		// Get the original comment text
-		originalComment := code[comment.Start:comment.End]
-
-		// Convert only the comment content
-		convertedComment := conv.ConvertToBritish(comment.Content, normaliseSmartQuotes)
-
-		// Preserve the comment structure (e.g., //, /* */, #, etc.)
-		// by replacing just the content part
-		if len(originalComment) > len(comment.Content) {
-			// This handles cases where the comment has prefix/suffix (like /* */)
-			prefix := ""
-			suffix := ""
-
-			// Find where the actual content starts and ends
-			contentStart := strings.Index(originalComment, strings.TrimSpace(comment.Content))
-			if contentStart >= 0 {
-				prefix = originalComment[:contentStart]
-				suffix = originalComment[contentStart+len(strings.TrimSpace(comment.Content)):]
-				convertedComment = prefix + convertedComment + suffix
-			} else {
-				// Fallback: just use the converted comment
-				convertedComment = originalComment[:len(originalComment)-len(comment.Content)] + convertedComment
-			}
-		}
-
-		// Replace this comment in the code
-		result = result[:comment.Start] + convertedComment + result[comment.End:]
+// conversionOptions reads the optional convert_units (default false) and
+// normalise_smart_quotes (default true) parameters shared by the conversion tools
+func conversionOptions(req mcp.CallToolRequest) (convertUnits, normaliseSmartQuotes bool) {
+	normaliseSmartQuotes = true
+	if val, err := req.RequireString("convert_units"); err == nil {
+		convertUnits = strings.ToLower(val) == "true"
 	}
-
-	return result
+	if val, err := req.RequireString("normalise_smart_quotes"); err == nil {
+		normaliseSmartQuotes = strings.ToLower(val) != "false"
+	}
+	return convertUnits, normaliseSmartQuotes
 }
 
 func main() {
+	noContextual := flag.Bool("no-contextual", false, "Disable contextual word detection (license/licence, practice/practise...)")
+	flag.Parse()
+
 	s := server.NewMCPServer(
 		"M2E - 'Murican to English Converter",
 		"1.0.0",
@@ -143,13 +128,101 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to create converter: %v", err)
 	}
+	conv.SetContextualWordDetectionEnabled(!*noContextual)
 	var convMu sync.Mutex // protects mutable converter state during concurrent requests
 
+	// M2E_LOG names a JSON lines file recording every file convert_file processes
+	var convLog *report.ConversionLog
+	if logPath := os.Getenv("M2E_LOG"); logPath != "" {
+		convLog, err = report.OpenConversionLog(logPath)
+		if err != nil {
+			log.Fatalf("Failed to open conversion log: %v", err)
+		}
+		defer convLog.Close()
+	}
+	analyser := report.NewAnalyser(conv.GetAmericanToBritishDictionary())
+	logConversion := func(filePath string, stats report.ChangeStats, written bool) {
+		if convLog == nil {
+			return
+		}
+		if err := convLog.Record(report.NewLogRecord("mcp", filePath, stats, written)); err != nil {
+			log.Printf("Failed to log conversion of %s: %v", filePath, err)
+		}
+	}
+
+	// convertFileContentWithOptions converts the content of a file, inferring its type from
+	// filePath, with the options of a single tool call
+	convertFileContentWithOptions := func(content, filePath string, convertUnits, normaliseSmartQuotes bool) string {
+		// Lock around mutable state mutation + conversion for concurrent safety
+		convMu.Lock()
+		defer convMu.Unlock()
+		conv.SetUnitProcessingEnabled(convertUnits)
+		return conv.ConvertFileContent(content, filePath, normaliseSmartQuotes)
+	}
+
+	// convertFileInPlace converts a file and writes it back with its original permissions. A
+	// failure is reported in the result's Error rather than returned, so a batch can carry on.
+	convertFileInPlace := func(filePath string, convertUnits, normaliseSmartQuotes bool) fileResult {
+		result := fileResult{Path: filePath, Status: fileStatusError}
+
+		// Validate the file path for security
+		if err := validateFilePath(filePath); err != nil {
+			log.Printf("Rejected file path %q: %v", filePath, err)
+			result.Error = fmt.Sprintf("File path rejected: %v", err)
+			return result
+		}
+
+		// Check if file exists and get its permissions
+		fileInfo, err := os.Stat(filePath)
+		if os.IsNotExist(err) {
+			result.Error = fmt.Sprintf("File does not exist: %s", filePath)
+			return result
+		}
+		if err != nil {
+			result.Error = fmt.Sprintf("Error accessing file %s: %v", filePath, err)
+			return result
+		}
+		if fileInfo.IsDir() {
+			result.Error = fmt.Sprintf("Path is a directory, not a file: %s", filePath)
+			return result
+		}
+		originalMode := fileInfo.Mode()
+
+		// Read the original file content
+		originalContent, err := os.ReadFile(filePath)
+		if err != nil {
+			result.Error = fmt.Sprintf("Error reading file %s: %v", filePath, err)
+			return result
+		}
+
+		convertedContent := convertFileContentWithOptions(string(originalContent), filePath, convertUnits, normaliseSmartQuotes)
+
+		// Check if there were any changes
+		if string(originalContent) == convertedContent {
+			logConversion(filePath, analyser.AnalyseChanges(convertedContent, convertedContent), false)
+			result.Status = fileStatusUnchanged
+			return result
+		}
+
+		// Write the converted content back to the file, preserving original permissions
+		if err := os.WriteFile(filePath, []byte(convertedContent), originalMode.Perm()); err != nil {
+			result.Error = fmt.Sprintf("Error writing to file %s: %v", filePath, err)
+			return result
+		}
+		stats := analyser.AnalyseChanges(string(originalContent), convertedContent)
+		logConversion(filePath, stats, true)
+
+		result.Status = fileStatusConverted
+		result.Changes = stats.TotalChanges()
+		return result
+	}
+
 	convertTool := mcp.NewTool("convert_text",
 		mcp.WithDescription("Convert American English text to British English with optional unit conversion"),
 		mcp.WithString("text", mcp.Required(), mcp.Description("The text to convert")),
 		mcp.WithString("convert_units", mcp.Description("Freedom Unit Conversion (true/false, default: false)")),
 		mcp.WithString("normalise_smart_quotes", mcp.Description("Normalise smart quotes to regular quotes (true/false, default: true)")),
+		mcp.WithString("filename", mcp.Description("Optional filename used to infer the content type (e.g. main.go); code/config files only have their comments converted")),
 	)
 	s.AddTool(convertTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		text, err := req.RequireString("text")
@@ -157,21 +230,22 @@ func main() {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		// Get optional parameters with defaults
-		convertUnits := false
-		if val, err := req.RequireString("convert_units"); err == nil {
-			convertUnits = strings.ToLower(val) == "true"
-		}
+		convertUnits, normaliseSmartQuotes := conversionOptions(req)
 
-		normaliseSmartQuotes := true
-		if val, err := req.RequireString("normalise_smart_quotes"); err == nil {
-			normaliseSmartQuotes = strings.ToLower(val) != "false"
+		filename := ""
+		if val, err := req.RequireString("filename"); err == nil {
+			filename = val
 		}
 
 		// Lock around mutable state mutation + conversion for concurrent safety
 		convMu.Lock()
 		conv.SetUnitProcessingEnabled(convertUnits)
-		convertedText := conv.ConvertToBritish(text, normaliseSmartQuotes)
+		var convertedText string
+		if filename != "" {
+			convertedText = conv.ConvertFileContent(text, filename, normaliseSmartQuotes)
+		} else {
+			convertedText = conv.ConvertToBritish(text, normaliseSmartQuotes)
+		}
 		convMu.Unlock()
 
 		return mcp.NewToolResultText(convertedText), nil
@@ -189,57 +263,44 @@ func main() {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		// Validate the file path for security
-		if err := validateFilePath(filePath); err != nil {
-			log.Printf("Rejected file path %q: %v", filePath, err)
-			return mcp.NewToolResultError(fmt.Sprintf("File path rejected: %v", err)), nil
-		}
-
-		// Get optional parameters with defaults
-		convertUnits := false
-		if val, err := req.RequireString("convert_units"); err == nil {
-			convertUnits = strings.ToLower(val) == "true"
-		}
-
-		normaliseSmartQuotes := true
-		if val, err := req.RequireString("normalise_smart_quotes"); err == nil {
-			normaliseSmartQuotes = strings.ToLower(val) != "false"
+		convertUnits, normaliseSmartQuotes := conversionOptions(req)
+		result := convertFileInPlace(filePath, convertUnits, normaliseSmartQuotes)
+		switch result.Status {
+		case fileStatusError:
+			return mcp.NewToolResultError(result.Error), nil
+		case fileStatusUnchanged:
+			return mcp.NewToolResultText(fmt.Sprintf("File %s processed but no changes were needed - already in British English", filePath)), nil
+		default:
+			return mcp.NewToolResultText(fmt.Sprintf("File %s completed processing to international / British English, the file has been updated.", filePath)), nil
 		}
+	})
 
-		// Check if file exists and get its permissions
-		fileInfo, err := os.Stat(filePath)
-		if os.IsNotExist(err) {
-			return mcp.NewToolResultError(fmt.Sprintf("File does not exist: %s", filePath)), nil
-		}
+	convertFilesTool := mcp.NewTool("convert_files",
+		mcp.WithDescription("Convert several files from American English to International / British English, saving each back, like convert_file. Every file is attempted even if others fail, and the result is JSON with each file's status (converted, unchanged or error), its number of changes and any error, plus totals."),
+		mcp.WithArray("file_paths", mcp.Required(), mcp.WithStringItems(), mcp.Description("The fully qualified paths of the files to convert")),
+		mcp.WithString("convert_units", mcp.Description("Freedom Unit Conversion (true/false, default: false)")),
+		mcp.WithString("normalise_smart_quotes", mcp.Description("Normalise smart quotes to regular quotes (true/false, default: true)")),
+	)
+	s.AddTool(convertFilesTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		filePaths, err := req.RequireStringSlice("file_paths")
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Error accessing file %s: %v", filePath, err)), nil
+			return mcp.NewToolResultError(err.Error()), nil
 		}
-		originalMode := fileInfo.Mode()
-
-		// Read the original file content
-		originalContent, err := os.ReadFile(filePath)
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Error reading file %s: %v", filePath, err)), nil
+		if len(filePaths) == 0 {
+			return mcp.NewToolResultError("file_paths must list at least one file"), nil
 		}
 
-		// Lock around mutable state mutation + conversion for concurrent safety
-		convMu.Lock()
-		conv.SetUnitProcessingEnabled(convertUnits)
-		convertedContent := convertFileContentWithOptions(conv, string(originalContent), filePath, normaliseSmartQuotes)
-		convMu.Unlock()
-
-		// Check if there were any changes
-		if string(originalContent) == convertedContent {
-			return mcp.NewToolResultText(fmt.Sprintf("File %s processed but no changes were needed - already in British English", filePath)), nil
+		convertUnits, normaliseSmartQuotes := conversionOptions(req)
+		batch := batchResult{Files: make([]fileResult, 0, len(filePaths))}
+		for _, filePath := range filePaths {
+			batch.add(convertFileInPlace(filePath, convertUnits, normaliseSmartQuotes))
 		}
 
-		// Write the converted content back to the file, preserving original permissions
-		err = os.WriteFile(filePath, []byte(convertedContent), originalMode.Perm())
+		result, err := mcp.NewToolResultJSON(batch)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Error writing to file %s: %v", filePath, err)), nil
+			return mcp.NewToolResultError(err.Error()), nil
 		}
-
-		return mcp.NewToolResultText(fmt.Sprintf("File %s completed processing to international / British English, the file has been updated.", filePath)), nil
+		return result, nil
 	})
 
 	dictionaryResource := mcp.NewResource("dictionary://american-to-british", "American to British Dictionary")
@@ -259,6 +320,17 @@ func main() {
 		}, nil
 	})
 
+	contextualResource := mcp.NewResource("contextual://rules", "Contextual Word Rules")
+	s.AddResource(contextualResource, func(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		return []mcp.ResourceContents{
+			mcp.TextResourceContents{
+				URI:      "contextual://rules",
+				MIMEType: "text/plain",
+				Text:     converter.FormatContextualRules(conv.ContextualRules()),
+			},
+		}, nil
+	})
+
 	transport := os.Getenv("MCP_TRANSPORT")
 	if transport == "stdio" {
 		// In stdio mode, we should not log to stdout/stderr.
@@ -1,22 +1,33 @@
 package main
 
 import (
+	"context"
+	"crypto/subtle"
 	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"os"
+	"os/signal"
+	"strconv"
 	"strings"
 	"sync"
-	"unicode"
+	"syscall"
+	"time"
+	"unicode/utf8"
 
 	"github.com/sammcj/m2e/pkg/converter"
+	"github.com/sammcj/m2e/pkg/report"
 )
 
 type ConvertRequest struct {
 	Text                 string `json:"text"`
 	ConvertUnits         *bool  `json:"convert_units,omitempty"`
 	NormaliseSmartQuotes *bool  `json:"normalise_smart_quotes,omitempty"`
+	Filename             string `json:"filename,omitempty"` // optional, infers code-aware handling from the extension
 }
 
 type ConvertResponse struct {
@@ -24,15 +35,30 @@ type ConvertResponse struct {
 	Changes []ChangeInfo `json:"changes,omitempty"`
 }
 
+// DiffRequest is a ConvertRequest for /api/v1/diff, which can ask for an inline diff
+type DiffRequest struct {
+	ConvertRequest
+	Inline bool `json:"inline,omitempty"` // character-level diff with ANSI colours instead of line-based
+}
+
+type DiffResponse struct {
+	Diff string `json:"diff"`
+}
+
 type ChangeInfo struct {
-	Position     int    `json:"position"`
+	Position     int    `json:"position"` // byte offset in the original text
+	Line         int    `json:"line"`     // 1-based line in the original text
+	Column       int    `json:"column"`   // 1-based column in characters (Unicode code points)
 	Original     string `json:"original"`
 	Converted    string `json:"converted"`
-	Type         string `json:"type"` // "spelling" or "unit"
+	Type         string `json:"type"` // "spelling", "phrase", "unit", "punctuation" or "other"
 	IsContextual bool   `json:"is_contextual,omitempty"`
 }
 
 func main() {
+	noContextual := flag.Bool("no-contextual", false, "Disable contextual word detection (license/licence, practice/practise...)")
+	flag.Parse()
+
 	port := os.Getenv("API_PORT")
 	if port == "" {
 		port = "8080"
@@ -42,27 +68,116 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to create converter: %v", err)
 	}
+	conv.SetContextualWordDetectionEnabled(!*noContextual)
 
 	corsOrigin := os.Getenv("CORS_ORIGIN")
 	if corsOrigin == "" {
 		corsOrigin = "*"
 	}
 
-	http.HandleFunc("/api/v1/health", withCORS(healthHandler, corsOrigin))
-	http.HandleFunc("/api/v1/convert", withCORS(makeConvertHandler(conv), corsOrigin))
+	// PUT /api/v1/config writes the user's config file, so it's off unless a token is set, and
+	// any web page could use it from a browser if every origin were allowed
+	configToken := os.Getenv("API_CONFIG_TOKEN")
+	if configToken != "" && corsOrigin == "*" {
+		log.Fatalf("API_CONFIG_TOKEN needs CORS_ORIGIN set to a specific origin rather than *")
+	}
+	corsMethods := "GET, POST, OPTIONS"
+	if configToken != "" {
+		corsMethods = "GET, POST, PUT, OPTIONS"
+	}
 
-	log.Printf("Server starting on port %s\n", port)
-	if err := http.ListenAndServe(":"+port, nil); err != nil {
-		log.Fatalf("Server failed to start: %v", err)
+	// Conversions and config updates share the converter, so they're serialised by one mutex
+	var mu sync.Mutex
+	metrics := newServerMetrics()
+	handle := func(path string, handler http.HandlerFunc) {
+		http.HandleFunc(path, metrics.instrument(path, withCORS(handler, corsOrigin, corsMethods)))
+	}
+	handle("/api/v1/health", healthHandler)
+	handle("/api/v1/ready", makeReadyHandler(conv))
+	handle("/api/v1/convert", makeConvertHandler(conv, &mu, metrics))
+	handle("/api/v1/diff", makeDiffHandler(conv, &mu, metrics))
+	handle("/api/v1/config", makeConfigHandler(conv, &mu, configToken))
+	http.HandleFunc("/metrics", metrics.handler)
+
+	maxRequestBytes = envBytes("API_MAX_REQUEST_BYTES", maxRequestBytes)
+
+	server := &http.Server{
+		Addr:              ":" + port,
+		ReadHeaderTimeout: envDuration("API_READ_HEADER_TIMEOUT", 10*time.Second),
+		ReadTimeout:       envDuration("API_READ_TIMEOUT", 30*time.Second),
+		WriteTimeout:      envDuration("API_WRITE_TIMEOUT", 60*time.Second),
+		IdleTimeout:       envDuration("API_IDLE_TIMEOUT", 120*time.Second),
+	}
+	if err := serve(server, envDuration("API_SHUTDOWN_TIMEOUT", 30*time.Second)); err != nil {
+		log.Fatalf("Server failed: %v", err)
 	}
 }
 
-// withCORS wraps a handler with CORS headers.
-func withCORS(next http.HandlerFunc, origin string) http.HandlerFunc {
+// serve runs server until it receives SIGINT or SIGTERM, then stops accepting connections and
+// waits up to drain for in-flight requests to finish before returning
+func serve(server *http.Server, drain time.Duration) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	serveErr := make(chan error, 1)
+	go func() {
+		log.Printf("Server starting on port %s\n", strings.TrimPrefix(server.Addr, ":"))
+		serveErr <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+	// A second signal kills the server straight away
+	stop()
+
+	log.Printf("Shutting down, waiting up to %s for in-flight requests\n", drain)
+	drainCtx, cancel := context.WithTimeout(context.Background(), drain)
+	defer cancel()
+	if err := server.Shutdown(drainCtx); err != nil {
+		_ = server.Close()
+		return fmt.Errorf("requests still running after %s: %w", drain, err)
+	}
+	log.Printf("Server stopped\n")
+	return nil
+}
+
+// envBytes reads a positive size in bytes from an environment variable, returning def when it
+// isn't set
+func envBytes(name string, def int64) int64 {
+	value := os.Getenv(name)
+	if value == "" {
+		return def
+	}
+	size, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || size <= 0 {
+		log.Fatalf("Invalid %s %q: expected a positive number of bytes", name, value)
+	}
+	return size
+}
+
+// envDuration reads a duration such as "30s" from an environment variable, returning def when
+// it isn't set
+func envDuration(name string, def time.Duration) time.Duration {
+	value := os.Getenv(name)
+	if value == "" {
+		return def
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		log.Fatalf("Invalid %s %q: expected a duration such as 30s", name, value)
+	}
+	return duration
+}
+
+// withCORS wraps a handler with CORS headers allowing origin to use methods.
+func withCORS(next http.HandlerFunc, origin, methods string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", origin)
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+		w.Header().Set("Access-Control-Allow-Methods", methods)
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Accept, Authorization")
 
 		if r.Method == http.MethodOptions {
 			w.WriteHeader(http.StatusNoContent)
@@ -72,6 +187,7 @@ func withCORS(next http.HandlerFunc, origin string) http.HandlerFunc {
 	}
 }
 
+// healthHandler is the liveness probe: it answers as long as the process is serving requests
 func healthHandler(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	if _, err := fmt.Fprint(w, "OK"); err != nil {
@@ -79,124 +195,289 @@ func healthHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// generateChanges analyzes the differences between original and converted text
-func generateChanges(originalText, convertedText string, conv *converter.Converter) []ChangeInfo {
-	var changes []ChangeInfo
+// makeReadyHandler serves the readiness probe, which answers 200 once the converter is
+// initialised with its dictionary loaded and 503 until then
+func makeReadyHandler(conv *converter.Converter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if conv == nil || len(conv.GetAmericanToBritishDictionary()) == 0 {
+			http.Error(w, "NOT READY", http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		if _, err := fmt.Fprint(w, "READY"); err != nil {
+			log.Printf("Error writing ready response: %v", err)
+		}
+	}
+}
+
+// generateChanges describes the changes conversion made, locating each in the original text
+func generateChanges(originalText string, changes []converter.Change) []ChangeInfo {
+	var infos []ChangeInfo
+	positions := lineColumnTracker{text: originalText, line: 1, column: 1}
 
-	if originalText == convertedText {
-		return changes
+	for _, change := range changes {
+		// Contextual words are spelling changes, flagged so clients can highlight them
+		changeType := string(change.Category)
+		if change.Category == converter.ChangeContextual {
+			changeType = string(converter.ChangeSpelling)
+		}
+
+		line, column := positions.at(change.Start)
+		infos = append(infos, ChangeInfo{
+			Position:     change.Start,
+			Line:         line,
+			Column:       column,
+			Original:     change.Original,
+			Converted:    change.Replacement,
+			Type:         changeType,
+			IsContextual: change.Category == converter.ChangeContextual,
+		})
 	}
 
-	// Get the list of contextual words for comparison
-	contextualWords := conv.GetContextualWordDetector().SupportedWords()
-	contextualWordSet := make(map[string]bool)
-	for _, word := range contextualWords {
-		contextualWordSet[strings.ToLower(word)] = true
+	return infos
+}
+
+// lineColumnTracker converts byte offsets into 1-based line and column numbers, counting
+// columns in characters so multi-byte UTF-8 text lines up with what editors show. Offsets must
+// be requested in increasing order; each call continues from the previous one.
+type lineColumnTracker struct {
+	text         string
+	offset       int
+	line, column int
+}
+
+// at returns the line and column of the byte offset
+func (t *lineColumnTracker) at(offset int) (int, int) {
+	for t.offset < offset && t.offset < len(t.text) {
+		r, size := utf8.DecodeRuneInString(t.text[t.offset:])
+		if r == '\n' {
+			t.line++
+			t.column = 1
+		} else {
+			t.column++
+		}
+		t.offset += size
 	}
+	return t.line, t.column
+}
 
-	// Simple word-by-word comparison to find changes
-	originalWords := strings.FieldsFunc(originalText, func(r rune) bool {
-		return !unicode.IsLetter(r) && !unicode.IsNumber(r) && r != '\''
-	})
-	convertedWords := strings.FieldsFunc(convertedText, func(r rune) bool {
-		return !unicode.IsLetter(r) && !unicode.IsNumber(r) && r != '\''
-	})
+// maxRequestBytes is the largest conversion request body accepted, set with API_MAX_REQUEST_BYTES
+var maxRequestBytes int64 = 10 << 20
+
+// decodeConvertRequest checks a conversion request's method and content type and decodes its
+// JSON body into req, writing an error response and returning false if it can't. The body must
+// be no larger than maxRequestBytes (413 otherwise), valid UTF-8 and include "text" (400
+// otherwise).
+func decodeConvertRequest(w http.ResponseWriter, r *http.Request, req any) bool {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return false
+	}
 
-	// Find position in original text and match words
-	originalPos := 0
+	// Validate Content-Type
+	ct := r.Header.Get("Content-Type")
+	if ct != "" && !strings.HasPrefix(ct, "application/json") {
+		http.Error(w, "Content-Type must be application/json", http.StatusUnsupportedMediaType)
+		return false
+	}
 
-	for i := 0; i < len(originalWords) && i < len(convertedWords); i++ {
-		originalWord := originalWords[i]
-		convertedWord := convertedWords[i]
+	// Limit the request body so a large POST can't exhaust memory
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestBytes)
+	defer func() { _ = r.Body.Close() }()
 
-		// Find the actual position in the original text
-		wordStart := strings.Index(originalText[originalPos:], originalWord)
-		if wordStart == -1 {
-			originalPos += len(originalWord) + 1 // Approximate advance
-			continue
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, fmt.Sprintf("Request body is larger than the %d byte limit", tooLarge.Limit), http.StatusRequestEntityTooLarge)
+			return false
 		}
-		actualPos := originalPos + wordStart
-
-		if originalWord != convertedWord {
-			// Determine if this is a contextual word change
-			isContextual := contextualWordSet[strings.ToLower(originalWord)] ||
-				contextualWordSet[strings.ToLower(convertedWord)]
-
-			// Simple heuristic: if contains numbers, likely unit conversion
-			changeType := "spelling"
-			if strings.ContainsAny(originalWord, "0123456789") || strings.ContainsAny(convertedWord, "0123456789") {
-				changeType = "unit"
-				isContextual = false // Unit changes are not contextual spelling
-			}
+		http.Error(w, "Error reading request body", http.StatusBadRequest)
+		return false
+	}
 
-			changes = append(changes, ChangeInfo{
-				Position:     actualPos,
-				Original:     originalWord,
-				Converted:    convertedWord,
-				Type:         changeType,
-				IsContextual: isContextual,
-			})
-		}
+	// The JSON decoder would quietly replace invalid UTF-8 with U+FFFD, changing the text
+	if !utf8.Valid(body) {
+		http.Error(w, "Request body must be valid UTF-8", http.StatusBadRequest)
+		return false
+	}
+	if err := json.Unmarshal(body, req); err != nil {
+		http.Error(w, fmt.Sprintf("Error decoding request body: %v", err), http.StatusBadRequest)
+		return false
+	}
+	var fields struct {
+		Text *string `json:"text"`
+	}
+	if err := json.Unmarshal(body, &fields); err != nil || fields.Text == nil {
+		http.Error(w, `Request body must include "text", the text to convert`, http.StatusBadRequest)
+		return false
+	}
+	return true
+}
 
-		originalPos = actualPos + len(originalWord)
+// convertRequestText converts the text of req with its options applied, returning the changes
+// made along with the converted text. The conversion's duration and changes are recorded in
+// metrics.
+func convertRequestText(conv *converter.Converter, mu *sync.Mutex, metrics *serverMetrics, req ConvertRequest) (string, []converter.Change) {
+	// Get optional parameters with defaults
+	convertUnits := false
+	if req.ConvertUnits != nil {
+		convertUnits = *req.ConvertUnits
 	}
 
-	return changes
+	normaliseSmartQuotes := true
+	if req.NormaliseSmartQuotes != nil {
+		normaliseSmartQuotes = *req.NormaliseSmartQuotes
+	}
+
+	// Mutex protects shared converter state from concurrent requests
+	mu.Lock()
+	defer mu.Unlock()
+	conv.SetUnitProcessingEnabled(convertUnits)
+	start := time.Now()
+	converted, changes := conv.ConvertWithChanges(req.Text, converter.Options{
+		NormaliseSmartQuotes: normaliseSmartQuotes,
+		Filename:             req.Filename,
+	})
+	metrics.observeConversion(time.Since(start), len(changes))
+	return converted, changes
 }
 
-func makeConvertHandler(conv *converter.Converter) http.HandlerFunc {
-	var mu sync.Mutex
+func makeConvertHandler(conv *converter.Converter, mu *sync.Mutex, metrics *serverMetrics) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPost {
-			http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		var req ConvertRequest
+		if !decodeConvertRequest(w, r, &req) {
 			return
 		}
 
-		// Validate Content-Type
-		ct := r.Header.Get("Content-Type")
-		if ct != "" && !strings.HasPrefix(ct, "application/json") {
-			http.Error(w, "Content-Type must be application/json", http.StatusUnsupportedMediaType)
+		convertedText, changes := convertRequestText(conv, mu, metrics, req)
+
+		resp := ConvertResponse{
+			Text:    convertedText,
+			Changes: generateChanges(req.Text, changes),
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			http.Error(w, "Error encoding response", http.StatusInternalServerError)
+		}
+	}
+}
+
+// makeDiffHandler serves /api/v1/diff, which converts like /api/v1/convert but returns only a
+// diff of the changes: line-based and patch compatible, or character-level when "inline" is set
+// in the body or query. The diff is sent as text/x-diff when the Accept header asks for it, and
+// as {"diff": "..."} otherwise. An empty diff means nothing changed.
+func makeDiffHandler(conv *converter.Converter, mu *sync.Mutex, metrics *serverMetrics) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req DiffRequest
+		if !decodeConvertRequest(w, r, &req) {
 			return
 		}
+		if value := r.URL.Query().Get("inline"); value != "" {
+			inline, err := strconv.ParseBool(value)
+			if err != nil {
+				http.Error(w, "inline must be true or false", http.StatusBadRequest)
+				return
+			}
+			req.Inline = inline
+		}
 
-		// Limit request body to 10 MB to prevent abuse
-		r.Body = http.MaxBytesReader(w, r.Body, 10<<20)
-		defer func() { _ = r.Body.Close() }()
+		convertedText, _ := convertRequestText(conv, mu, metrics, req.ConvertRequest)
+		filename := req.Filename
+		if filename == "" {
+			filename = "text"
+		}
+		diff := report.UnifiedDiff(req.Text, convertedText, filename, req.Inline)
 
-		var req ConvertRequest
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			http.Error(w, "Error decoding request body", http.StatusBadRequest)
+		if strings.Contains(r.Header.Get("Accept"), "text/x-diff") {
+			w.Header().Set("Content-Type", "text/x-diff; charset=utf-8")
+			if _, err := fmt.Fprint(w, diff); err != nil {
+				log.Printf("Error writing diff response: %v", err)
+			}
 			return
 		}
 
-		// Get optional parameters with defaults
-		convertUnits := false
-		if req.ConvertUnits != nil {
-			convertUnits = *req.ConvertUnits
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(DiffResponse{Diff: diff}); err != nil {
+			http.Error(w, "Error encoding response", http.StatusInternalServerError)
 		}
+	}
+}
 
-		normaliseSmartQuotes := true
-		if req.NormaliseSmartQuotes != nil {
-			normaliseSmartQuotes = *req.NormaliseSmartQuotes
-		}
+// makeConfigHandler serves the unit config: GET returns the effective config, and PUT validates,
+// saves and applies a new one. Settings missing from a PUT body take their default values. PUT
+// is refused (403) when token is empty, and needs "Authorization: Bearer <token>" otherwise
+// (401).
+func makeConfigHandler(conv *converter.Converter, mu *sync.Mutex, token string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			config, err := converter.LoadConfigWithDefaults()
+			if err != nil {
+				http.Error(w, fmt.Sprintf("Error loading configuration: %v", err), http.StatusInternalServerError)
+				return
+			}
+			writeConfig(w, config)
 
-		// Mutex protects shared converter state from concurrent requests
-		mu.Lock()
-		conv.SetUnitProcessingEnabled(convertUnits)
-		originalText := req.Text
-		convertedText := conv.ConvertToBritish(req.Text, normaliseSmartQuotes)
-		mu.Unlock()
+		case http.MethodPut:
+			if token == "" {
+				http.Error(w, "Config updates are disabled; set API_CONFIG_TOKEN to enable them", http.StatusForbidden)
+				return
+			}
+			given, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				http.Error(w, "Config updates need the API_CONFIG_TOKEN bearer token", http.StatusUnauthorized)
+				return
+			}
 
-		// Generate change information
-		changes := generateChanges(originalText, convertedText, conv)
+			ct := r.Header.Get("Content-Type")
+			if ct != "" && !strings.HasPrefix(ct, "application/json") {
+				http.Error(w, "Content-Type must be application/json", http.StatusUnsupportedMediaType)
+				return
+			}
 
-		resp := ConvertResponse{
-			Text:    convertedText,
-			Changes: changes,
-		}
-		w.Header().Set("Content-Type", "application/json")
-		if err := json.NewEncoder(w).Encode(resp); err != nil {
-			http.Error(w, "Error encoding response", http.StatusInternalServerError)
+			// A config is small, so 1 MB is plenty
+			r.Body = http.MaxBytesReader(w, r.Body, 1<<20)
+			defer func() { _ = r.Body.Close() }()
+
+			config := converter.GetDefaultUnitConfig()
+			if err := json.NewDecoder(r.Body).Decode(config); err != nil {
+				var tooLarge *http.MaxBytesError
+				if errors.As(err, &tooLarge) {
+					http.Error(w, fmt.Sprintf("Request body is larger than the %d byte limit", tooLarge.Limit), http.StatusRequestEntityTooLarge)
+					return
+				}
+				http.Error(w, "Error decoding request body", http.StatusBadRequest)
+				return
+			}
+			if err := converter.ValidateConfig(config); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			mu.Lock()
+			err := converter.SaveUserConfig(config)
+			if err == nil {
+				err = conv.ApplyConfig(config)
+			}
+			mu.Unlock()
+			if err != nil {
+				http.Error(w, fmt.Sprintf("Error saving configuration: %v", err), http.StatusInternalServerError)
+				return
+			}
+			writeConfig(w, config)
+
+		default:
+			http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
 		}
 	}
 }
+
+// writeConfig writes config to w as JSON
+func writeConfig(w http.ResponseWriter, config *converter.UnitConfig) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(config); err != nil {
+		http.Error(w, "Error encoding response", http.StatusInternalServerError)
+	}
+}
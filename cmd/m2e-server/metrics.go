@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// conversionDurationBuckets are the upper bounds, in seconds, of the conversion latency histogram
+var conversionDurationBuckets = []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// requestKey identifies a request counter by path and response status code
+type requestKey struct {
+	path string
+	code int
+}
+
+// serverMetrics counts requests, conversion latency and changes applied, and serves them in the
+// Prometheus text format. It is safe for concurrent use.
+type serverMetrics struct {
+	mu            sync.Mutex
+	requests      map[requestKey]uint64
+	bucketCounts  []uint64 // conversions no slower than each bucket's bound, not cumulative
+	durationSum   float64
+	durationCount uint64
+	changes       uint64
+}
+
+func newServerMetrics() *serverMetrics {
+	return &serverMetrics{
+		requests:     make(map[requestKey]uint64),
+		bucketCounts: make([]uint64, len(conversionDurationBuckets)),
+	}
+}
+
+// statusRecorder remembers the status code a handler writes
+type statusRecorder struct {
+	http.ResponseWriter
+	code int
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	r.code = code
+	r.ResponseWriter.WriteHeader(code)
+}
+
+// instrument wraps a handler so its requests are counted by status code under path
+func (m *serverMetrics) instrument(path string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		recorder := &statusRecorder{ResponseWriter: w, code: http.StatusOK}
+		next(recorder, r)
+
+		m.mu.Lock()
+		m.requests[requestKey{path: path, code: recorder.code}]++
+		m.mu.Unlock()
+	}
+}
+
+// observeConversion records how long a conversion took and how many changes it made
+func (m *serverMetrics) observeConversion(duration time.Duration, changes int) {
+	seconds := duration.Seconds()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if i, _ := slices.BinarySearch(conversionDurationBuckets, seconds); i < len(m.bucketCounts) {
+		m.bucketCounts[i]++
+	}
+	m.durationSum += seconds
+	m.durationCount++
+	m.changes += uint64(changes)
+}
+
+// write renders the metrics in the Prometheus text exposition format
+func (m *serverMetrics) write(b *strings.Builder) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	b.WriteString("# HELP m2e_http_requests_total HTTP requests by path and status code.\n")
+	b.WriteString("# TYPE m2e_http_requests_total counter\n")
+	keys := make([]requestKey, 0, len(m.requests))
+	for key := range m.requests {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, func(a, b requestKey) int {
+		if c := strings.Compare(a.path, b.path); c != 0 {
+			return c
+		}
+		return a.code - b.code
+	})
+	for _, key := range keys {
+		fmt.Fprintf(b, "m2e_http_requests_total{path=%q,code=\"%d\"} %d\n", key.path, key.code, m.requests[key])
+	}
+
+	b.WriteString("# HELP m2e_conversion_duration_seconds Time taken to convert the text of a request.\n")
+	b.WriteString("# TYPE m2e_conversion_duration_seconds histogram\n")
+	var cumulative uint64
+	for i, bound := range conversionDurationBuckets {
+		cumulative += m.bucketCounts[i]
+		fmt.Fprintf(b, "m2e_conversion_duration_seconds_bucket{le=\"%g\"} %d\n", bound, cumulative)
+	}
+	fmt.Fprintf(b, "m2e_conversion_duration_seconds_bucket{le=\"+Inf\"} %d\n", m.durationCount)
+	fmt.Fprintf(b, "m2e_conversion_duration_seconds_sum %g\n", m.durationSum)
+	fmt.Fprintf(b, "m2e_conversion_duration_seconds_count %d\n", m.durationCount)
+
+	b.WriteString("# HELP m2e_changes_applied_total Changes made by conversions.\n")
+	b.WriteString("# TYPE m2e_changes_applied_total counter\n")
+	fmt.Fprintf(b, "m2e_changes_applied_total %d\n", m.changes)
+}
+
+// handler serves /metrics
+func (m *serverMetrics) handler(w http.ResponseWriter, r *http.Request) {
+	var b strings.Builder
+	m.write(&b)
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	if _, err := fmt.Fprint(w, b.String()); err != nil {
+		log.Printf("Error writing metrics response: %v", err)
+	}
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sammcj/m2e/pkg/converter"
+	"github.com/sammcj/m2e/pkg/fileutil"
+	"github.com/sammcj/m2e/pkg/report"
+)
+
+// archiveEntry is the conversion of one text file inside an archive
+type archiveEntry struct {
+	path         string // the archive path joined with the entry name, e.g. docs.zip/guide.md
+	original     string
+	converted    string
+	stats        report.ChangeStats
+	explanations []converter.Explanation
+}
+
+// handleArchive converts the text files inside a zip or tar archive using the same routing as
+// files on disk, copying every other entry verbatim. The converted archive is written to
+// outputFile, or over the original with saveInPlace; otherwise the changes each entry needs are
+// shown in the selected output mode.
+func handleArchive(archivePath string, conv *converter.Converter, normaliseSmartQuotes bool,
+	outputFile string, showDiff, showDiffInline, showDiffWord, showRaw, showRawChanges, showExplain, showStats, saveInPlace, exitOnChange bool, maxFileSize, statsDetail, maxChanges int,
+	convLog *report.ConversionLog) error {
+
+	content, err := fileutil.ReadFileContentWithMaxSize(archivePath, maxFileSize)
+	if err != nil {
+		return fmt.Errorf("failed to read file %s: %w", archivePath, err)
+	}
+
+	analyser := report.NewAnalyser(conv.GetAmericanToBritishDictionary())
+	var entries []archiveEntry
+	var limitExceeded []string // Entries left untouched because of -max-changes
+	archive, err := fileutil.RewriteArchive(archivePath, []byte(content), int64(maxFileSize)*1024, func(name string, data []byte) []byte {
+		if !fileutil.IsTextData(name, data) {
+			return data
+		}
+
+		// Entries are converted as if extracted beside the archive, so the nearest .m2e.json applies
+		entryPath := filepath.Join(archivePath, filepath.FromSlash(name))
+		original := string(data)
+		converted, stats := convertFileWithStats(conv, analyser, original, entryPath, normaliseSmartQuotes)
+		explanations := conv.TakeExplanations()
+
+		if err := report.CheckMaxChanges(stats, maxChanges); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: Skipping %s: %v; entry left untouched\n", entryPath, err)
+			limitExceeded = append(limitExceeded, entryPath)
+			return data
+		}
+		entries = append(entries, archiveEntry{entryPath, original, converted, stats, explanations})
+		return []byte(converted)
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", archivePath, err)
+	}
+
+	var changed []archiveEntry
+	var totalStats report.ChangeStats
+	for _, entry := range entries {
+		if entry.original != entry.converted {
+			changed = append(changed, entry)
+		}
+		totalStats.TotalWords += entry.stats.TotalWords
+		totalStats.SpellingChanges += entry.stats.SpellingChanges
+		totalStats.UnitConversions += entry.stats.UnitConversions
+		totalStats.QuoteChanges += entry.stats.QuoteChanges
+		totalStats.ChangeDetails = report.MergeChangeDetails(totalStats.ChangeDetails, entry.stats.ChangeDetails)
+	}
+	hasChanges := len(changed) > 0
+
+	written := false
+	summaryOnly := false
+	switch {
+	case outputFile != "":
+		if err := os.WriteFile(outputFile, archive, 0644); err != nil {
+			return fmt.Errorf("failed to write to output file %s: %w", outputFile, err)
+		}
+		written = true
+		printArchiveEntries("Converted entries", changed, "")
+	case saveInPlace:
+		if !hasChanges {
+			fmt.Printf("No changes needed: %s\n", archivePath)
+			break
+		}
+		if err := os.WriteFile(archivePath, archive, 0644); err != nil {
+			return fmt.Errorf("failed to save changes to file %s: %w", archivePath, err)
+		}
+		written = true
+		printArchiveEntries("Converted entries", changed, "")
+		fmt.Printf("Saved changes to: %s\n", archivePath)
+		fmt.Println()
+		if err := showStatsOutputWithMode(totalStats, true); err != nil {
+			return err
+		}
+	case showDiff || showDiffInline || showDiffWord || showRaw || showRawChanges || showExplain:
+		for _, entry := range changed {
+			fmt.Printf("=== %s ===\n", entry.path)
+			switch {
+			case showDiff || showDiffInline:
+				fmt.Print(report.UnifiedDiff(entry.original, entry.converted, entry.path, showDiffInline))
+			case showDiffWord:
+				fmt.Print(report.WordDiff(entry.original, entry.converted))
+			case showRaw:
+				fmt.Print(entry.converted)
+			case showRawChanges:
+				fmt.Print(formatChangedLines(entry.original, entry.converted))
+			case showExplain:
+				fmt.Print(formatExplanations(entry.explanations))
+			}
+			fmt.Println()
+		}
+	case showStats:
+		if err := showStatsOutputWithDetail(totalStats, statsDetail); err != nil {
+			return err
+		}
+	default:
+		summaryOnly = true
+		if hasChanges {
+			printArchiveEntries("Entries requiring changes", changed, " needed")
+			fmt.Println("\nTo apply these changes, use the -save flag, or -o to write a converted copy.")
+		} else {
+			fmt.Println("No entries require changes.")
+		}
+		fmt.Println()
+		if err := showStatsOutput(totalStats); err != nil {
+			return err
+		}
+	}
+
+	for _, entry := range entries {
+		logConversion(convLog, entry.path, entry.stats, written && entry.original != entry.converted)
+	}
+
+	if len(limitExceeded) > 0 {
+		return changesErrorf("%d entries exceeded -max-changes and were left untouched: %s",
+			len(limitExceeded), strings.Join(limitExceeded, ", "))
+	}
+
+	// Like a directory scan, the default summary exits with status 1 if changes are required
+	if hasChanges && (exitOnChange || summaryOnly) {
+		os.Exit(exitChanges)
+	}
+	return nil
+}
+
+// printArchiveEntries lists entries with their change counts under a heading, each count
+// followed by suffix
+func printArchiveEntries(heading string, entries []archiveEntry, suffix string) {
+	if len(entries) == 0 {
+		return
+	}
+	fmt.Printf("%s (%d):\n", heading, len(entries))
+	for _, entry := range entries {
+		fmt.Printf("  %s: %d spelling change(s)%s", entry.path, entry.stats.SpellingChanges, suffix)
+		if entry.stats.UnitConversions > 0 {
+			fmt.Printf(", %d unit conversion(s)%s", entry.stats.UnitConversions, suffix)
+		}
+		if entry.stats.QuoteChanges > 0 {
+			fmt.Printf(", %d quote change(s)%s", entry.stats.QuoteChanges, suffix)
+		}
+		fmt.Println()
+	}
+}
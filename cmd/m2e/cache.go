@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sammcj/m2e/pkg/cache"
+	"github.com/sammcj/m2e/pkg/converter"
+	"github.com/sammcj/m2e/pkg/report"
+)
+
+// conversionCache holds converted files between runs when -cache is given; nil otherwise
+var conversionCache *cache.Cache
+
+// fingerprints memoises converter fingerprints, which hash the whole dictionary, by converter
+var fingerprints sync.Map // *converter.Converter -> string
+
+// cachedConversion is a cache entry: the converted content of a file and its statistics
+type cachedConversion struct {
+	Converted string             `json:"converted"`
+	Stats     report.ChangeStats `json:"stats"`
+}
+
+// fingerprint returns the memoised fingerprint of conv
+func fingerprint(conv *converter.Converter) string {
+	if fp, ok := fingerprints.Load(conv); ok {
+		return fp.(string)
+	}
+	fp := conv.Fingerprint()
+	fingerprints.Store(conv, fp)
+	return fp
+}
+
+// convertFileWithStats converts a file like convertFile and analyses the changes. With -cache,
+// a file whose content, name and effective settings match an earlier run is served from the
+// cache instead. Settings cover the dictionary, configs and the nearest .m2e.json, so editing
+// any of them invalidates the entries they affect. With -verbose, the time taken and rule counts
+// are printed to stderr, and with -warnings, the judgement calls made converting the file.
+func convertFileWithStats(conv *converter.Converter, analyser *report.Analyser, content, filePath string, normaliseSmartQuotes bool) (string, report.ChangeStats) {
+	start := time.Now()
+	conv.TakeCounters() // drop counts from conversions outside this file
+	conv.TakeAmbiguityWarnings()
+
+	var key string
+	if conversionCache != nil && !conv.IsExplainEnabled() && !conv.IsAmbiguityWarningsEnabled() {
+		projectConv, _ := conv.ForFile(filePath)
+		base, project := fingerprint(conv), fingerprint(projectConv)
+		if base != "" && project != "" {
+			// The name decides how the file is routed (code, subtitles, notebooks...)
+			key = cache.Key(base, project, filepath.Base(filePath), strconv.FormatBool(normaliseSmartQuotes), content)
+		}
+	}
+
+	var entry cachedConversion
+	if key != "" && conversionCache.Get(key, &entry) {
+		printVerbose(filePath, time.Since(start), converter.ConversionCounters{}, true)
+		return entry.Converted, entry.Stats
+	}
+
+	converted := convertFile(conv, content, filePath, normaliseSmartQuotes)
+	printVerbose(filePath, time.Since(start), conv.TakeCounters(), false)
+	printAmbiguityWarnings(conv, filePath, content)
+	stats := analyser.AnalyseChanges(content, converted)
+	if key != "" {
+		if err := conversionCache.Put(key, cachedConversion{Converted: converted, Stats: stats}); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+	}
+	return converted, stats
+}
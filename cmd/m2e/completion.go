@@ -0,0 +1,188 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+)
+
+// completionFlag is the hidden flag used to request a shell completion script.
+// It is excluded from the generated scripts and from the help text.
+const completionFlag = "completion"
+
+// completionValues maps each flag that takes one of a fixed set of values to those values, and
+// completionPathFlags lists the flags whose values are file or directory paths. They're filled
+// by enumFlag and pathFlag where each flag is defined, so the scripts can't drift from the flags.
+var (
+	completionValues    = map[string][]string{}
+	completionPathFlags = map[string]bool{}
+)
+
+// enumFlag defines a string flag whose value is one of values, offered by the completion scripts
+func enumFlag(name, value, usage string, values ...string) *string {
+	completionValues[name] = values
+	return flag.String(name, value, usage)
+}
+
+// pathFlag defines a string flag whose value is a file or directory path
+func pathFlag(name, value, usage string) *string {
+	completionPathFlags[name] = true
+	return flag.String(name, value, usage)
+}
+
+// pathFlagVar defines a string flag stored in p whose value is a file or directory path
+func pathFlagVar(p *string, name, value, usage string) {
+	completionPathFlags[name] = true
+	flag.StringVar(p, name, value, usage)
+}
+
+// completionFlagInfo describes a single flag for the completion generators
+type completionFlagInfo struct {
+	Name        string
+	Description string
+	TakesValue  bool
+	IsPath      bool     // value should complete file/directory paths
+	Values      []string // the values the flag takes, if they're a fixed set
+}
+
+// collectCompletionFlags builds the flag list from the registered flag set so the
+// generated scripts always reflect the actual CLI flags
+func collectCompletionFlags(fs *flag.FlagSet) []completionFlagInfo {
+	var flags []completionFlagInfo
+	fs.VisitAll(func(f *flag.Flag) {
+		if f.Name == completionFlag {
+			return
+		}
+
+		info := completionFlagInfo{
+			Name:        f.Name,
+			Description: f.Usage,
+			TakesValue:  true,
+		}
+		if bf, ok := f.Value.(interface{ IsBoolFlag() bool }); ok && bf.IsBoolFlag() {
+			info.TakesValue = false
+		}
+		if info.TakesValue {
+			info.IsPath = completionPathFlags[f.Name]
+			info.Values = completionValues[f.Name]
+		}
+		flags = append(flags, info)
+	})
+	return flags
+}
+
+// generateCompletionScript returns the completion script for the given shell
+func generateCompletionScript(shell string, fs *flag.FlagSet) (string, error) {
+	flags := collectCompletionFlags(fs)
+
+	switch shell {
+	case "bash":
+		return generateBashCompletion(flags), nil
+	case "zsh":
+		return generateZshCompletion(flags), nil
+	case "fish":
+		return generateFishCompletion(flags), nil
+	default:
+		return "", fmt.Errorf("unsupported shell %q (supported: bash, zsh, fish)", shell)
+	}
+}
+
+func generateBashCompletion(flags []completionFlagInfo) string {
+	var allFlags, pathFlags, valueFlags []string
+	var enumFlags []completionFlagInfo
+	for _, f := range flags {
+		allFlags = append(allFlags, "-"+f.Name)
+		switch {
+		case f.IsPath:
+			pathFlags = append(pathFlags, "-"+f.Name)
+		case len(f.Values) > 0:
+			enumFlags = append(enumFlags, f)
+		case f.TakesValue:
+			valueFlags = append(valueFlags, "-"+f.Name)
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("# bash completion for m2e\n")
+	b.WriteString("_m2e() {\n")
+	b.WriteString("    local cur prev\n")
+	b.WriteString("    cur=\"${COMP_WORDS[COMP_CWORD]}\"\n")
+	b.WriteString("    prev=\"${COMP_WORDS[COMP_CWORD-1]}\"\n\n")
+	b.WriteString("    case \"$prev\" in\n")
+	if len(pathFlags) > 0 {
+		fmt.Fprintf(&b, "        %s)\n", strings.Join(pathFlags, "|"))
+		b.WriteString("            COMPREPLY=( $(compgen -f -- \"$cur\") )\n")
+		b.WriteString("            return 0\n")
+		b.WriteString("            ;;\n")
+	}
+	for _, f := range enumFlags {
+		fmt.Fprintf(&b, "        -%s)\n", f.Name)
+		fmt.Fprintf(&b, "            COMPREPLY=( $(compgen -W \"%s\" -- \"$cur\") )\n", strings.Join(f.Values, " "))
+		b.WriteString("            return 0\n")
+		b.WriteString("            ;;\n")
+	}
+	if len(valueFlags) > 0 {
+		fmt.Fprintf(&b, "        %s)\n", strings.Join(valueFlags, "|"))
+		b.WriteString("            return 0\n")
+		b.WriteString("            ;;\n")
+	}
+	b.WriteString("    esac\n\n")
+	b.WriteString("    if [[ \"$cur\" == -* ]]; then\n")
+	fmt.Fprintf(&b, "        COMPREPLY=( $(compgen -W \"%s\" -- \"$cur\") )\n", strings.Join(allFlags, " "))
+	b.WriteString("        return 0\n")
+	b.WriteString("    fi\n\n")
+	b.WriteString("    COMPREPLY=( $(compgen -f -- \"$cur\") )\n")
+	b.WriteString("}\n")
+	b.WriteString("complete -o filenames -F _m2e m2e\n")
+	return b.String()
+}
+
+func generateZshCompletion(flags []completionFlagInfo) string {
+	var b strings.Builder
+	b.WriteString("#compdef m2e\n\n")
+	b.WriteString("_arguments \\\n")
+	for _, f := range flags {
+		spec := fmt.Sprintf("-%s[%s]", f.Name, zshEscapeDescription(f.Description))
+		switch {
+		case f.IsPath:
+			spec += ":" + f.Name + ":_files"
+		case len(f.Values) > 0:
+			spec += ":" + f.Name + ":(" + strings.Join(f.Values, " ") + ")"
+		case f.TakesValue:
+			spec += ":" + f.Name + ": "
+		}
+		fmt.Fprintf(&b, "  %s \\\n", shellSingleQuote(spec))
+	}
+	b.WriteString("  '*:file or directory:_files'\n")
+	return b.String()
+}
+
+func generateFishCompletion(flags []completionFlagInfo) string {
+	var b strings.Builder
+	b.WriteString("# fish completion for m2e\n")
+	for _, f := range flags {
+		line := "complete -c m2e -o " + f.Name
+		switch {
+		case f.IsPath:
+			line += " -r -F"
+		case len(f.Values) > 0:
+			line += " -x -a " + shellSingleQuote(strings.Join(f.Values, " "))
+		case f.TakesValue:
+			line += " -x"
+		}
+		line += " -d " + shellSingleQuote(f.Description)
+		b.WriteString(line + "\n")
+	}
+	return b.String()
+}
+
+// zshEscapeDescription escapes characters with special meaning inside an _arguments description
+func zshEscapeDescription(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "[", `\[`, "]", `\]`).Replace(s)
+}
+
+// shellSingleQuote wraps s in single quotes, escaping embedded single quotes in a way
+// understood by bash, zsh and fish
+func shellSingleQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/sammcj/m2e/pkg/converter"
+	"github.com/sammcj/m2e/pkg/fileutil"
+)
+
+// handleCountOnly counts the changes converting the files under paths would make, without
+// building the converted text or diffing it, and prints the count for each file that needs
+// changes and the total. Nothing is modified.
+func handleCountOnly(paths []string, conv *converter.Converter, normaliseSmartQuotes, exitOnChange bool, maxFileSize int) error {
+	conv.SetCountOnly(true)
+	conv.TakeCounters() // drop counts from conversions outside these files
+
+	var total converter.ConversionCounters
+	files, changedFiles := 0, 0
+	countFile := func(filePath, displayPath string) {
+		content, err := fileutil.ReadFileContentWithMaxSize(filePath, maxFileSize)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Skipping %s: %v\n", displayPath, err)
+			return
+		}
+
+		start := time.Now()
+		convertFile(conv, content, filePath, normaliseSmartQuotes)
+		counters := conv.TakeCounters()
+		printVerbose(filePath, time.Since(start), counters, false)
+
+		files++
+		total.DictionaryHits += counters.DictionaryHits
+		total.ContextualHits += counters.ContextualHits
+		total.UnitConversions += counters.UnitConversions
+		if counters.Changes() > 0 {
+			changedFiles++
+			fmt.Printf("%s: %s\n", displayPath, describeCounts(counters))
+		}
+	}
+
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Skipping %s: %v\n", path, err)
+			continue
+		}
+		if !info.IsDir() {
+			countFile(path, path)
+			continue
+		}
+
+		found, err := findTextFiles(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Skipping %s: %v\n", path, err)
+			continue
+		}
+		for _, file := range found {
+			countFile(file.Path, filepath.Join(path, file.RelativePath))
+		}
+	}
+
+	fmt.Printf("Total: %s in %d of %d file(s)\n", describeCounts(total), changedFiles, files)
+
+	if exitOnChange && total.Changes() > 0 {
+		os.Exit(exitChanges)
+	}
+	return nil
+}
+
+// describeCounts describes counted changes, such as "3 change(s) (2 spelling, 1 unit)"
+func describeCounts(counters converter.ConversionCounters) string {
+	spelling := counters.DictionaryHits + counters.ContextualHits
+	return fmt.Sprintf("%d change(s) (%d spelling, %d unit)", counters.Changes(), spelling, counters.UnitConversions)
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+// dictDiffCommand is the subcommand that compares two versions of a dictionary file
+const dictDiffCommand = "dict-diff"
+
+// handleDictDiff prints the mappings added, removed and changed between two dictionary files,
+// as text or with -json as JSON, so data changes can be reviewed before they're merged
+func handleDictDiff(args []string) error {
+	fs := flag.NewFlagSet(dictDiffCommand, flag.ContinueOnError)
+	jsonOutput := fs.Bool("json", false, "Print the differences as JSON")
+	exitOnChange := fs.Bool("exit-on-change", false, "Exit with code 1 if the dictionaries differ")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: m2e %s [-json] [-exit-on-change] old.json new.json\n", dictDiffCommand)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return usageError{err}
+	}
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return usageErrorf("%s needs two dictionary files, got %d", dictDiffCommand, fs.NArg())
+	}
+
+	oldDict, err := loadDictionaryForDiff(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	newDict, err := loadDictionaryForDiff(fs.Arg(1))
+	if err != nil {
+		return err
+	}
+
+	diff := converter.DiffDictionaries(oldDict, newDict)
+	if *jsonOutput {
+		data, err := json.MarshalIndent(diff, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode differences: %w", err)
+		}
+		fmt.Println(string(data))
+	} else {
+		printDictionaryDiff(diff)
+	}
+
+	if *exitOnChange && !diff.IsEmpty() {
+		os.Exit(exitChanges)
+	}
+	return nil
+}
+
+// loadDictionaryForDiff loads a dictionary file, treating a file that can't be read as an I/O
+// error and one that isn't a valid dictionary as a config error
+func loadDictionaryForDiff(path string) (map[string]string, error) {
+	dict, err := converter.LoadDictionaryFile(path)
+	var pathErr *fs.PathError
+	if err != nil && !errors.As(err, &pathErr) {
+		return nil, configError{err}
+	}
+	return dict, err
+}
+
+// printDictionaryDiff prints the differences between two dictionaries as plain text
+func printDictionaryDiff(diff converter.DictionaryDiff) {
+	if diff.IsEmpty() {
+		fmt.Println("No differences")
+		return
+	}
+
+	if len(diff.Added) > 0 {
+		fmt.Printf("Added (%d):\n", len(diff.Added))
+		for _, entry := range diff.Added {
+			fmt.Printf("  + %s → %s\n", entry.American, entry.British)
+		}
+	}
+	if len(diff.Removed) > 0 {
+		fmt.Printf("Removed (%d):\n", len(diff.Removed))
+		for _, entry := range diff.Removed {
+			fmt.Printf("  - %s → %s\n", entry.American, entry.British)
+		}
+	}
+	if len(diff.Changed) > 0 {
+		fmt.Printf("Changed (%d):\n", len(diff.Changed))
+		for _, change := range diff.Changed {
+			fmt.Printf("  ~ %s: %s → %s\n", change.American, change.Old, change.New)
+		}
+	}
+	if len(diff.Warnings) > 0 {
+		fmt.Printf("Warnings (%d):\n", len(diff.Warnings))
+		for _, warning := range diff.Warnings {
+			fmt.Printf("  ! %s → %s: %s\n", warning.American, warning.British, warning.Reason)
+		}
+	}
+
+	fmt.Printf("\n%d added, %d removed, %d changed\n", len(diff.Added), len(diff.Removed), len(diff.Changed))
+}
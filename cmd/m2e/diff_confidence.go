@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sammcj/m2e/pkg/converter"
+	"github.com/sammcj/m2e/pkg/report"
+)
+
+// diffConfidence is set by -diff-confidence to annotate -diff output with the confidence of
+// each contextual word and unit change
+var diffConfidence bool
+
+// unifiedDiff returns the -diff output for a file, annotated with confidence scores when
+// -diff-confidence is set
+func unifiedDiff(original, converted, filename string, explanations []converter.Explanation) string {
+	if !diffConfidence {
+		return report.UnifiedDiff(original, converted, filename, false)
+	}
+	return report.AnnotatedLineDiff(original, converted, filename, confidenceNotes(original, converted, explanations))
+}
+
+// showUnifiedDiff prints the -diff output for a file
+func showUnifiedDiff(original, converted, filename string, explanations []converter.Explanation) error {
+	if original == converted {
+		return nil // No changes to show
+	}
+	fmt.Print(unifiedDiff(original, converted, filename, explanations))
+	return nil
+}
+
+// confidenceNotes returns a note for each line of converted with a contextual word or unit
+// change on it, listing each such change with the detector's confidence, as in
+// `confidence: "licence" 0.90`. Dictionary changes are never in doubt, so aren't listed.
+func confidenceNotes(original, converted string, explanations []converter.Explanation) map[int]string {
+	changes := make(map[int][]string)
+	shift := 0 // how far converted text has moved from its position in the original
+	for _, change := range converter.ExplainedChanges(original, converted, explanations) {
+		start := change.Start + shift
+		shift += len(change.Replacement) - len(change.Original)
+		if change.Confidence == 0 {
+			continue
+		}
+		line := strings.Count(converted[:start], "\n") + 1
+		changes[line] = append(changes[line], fmt.Sprintf("%q %.2f", change.Replacement, change.Confidence))
+	}
+
+	notes := make(map[int]string, len(changes))
+	for line, scored := range changes {
+		notes[line] = "confidence: " + strings.Join(scored, ", ")
+	}
+	return notes
+}
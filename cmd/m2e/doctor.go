@@ -0,0 +1,130 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+// doctorCommand is the subcommand that prints diagnostics for bug reports
+const doctorCommand = "doctor"
+
+// doctorSampleInput and doctorSampleExpected are used for the round-trip sanity check
+const (
+	doctorSampleInput    = "The color of the organization's center"
+	doctorSampleExpected = "The colour of the organisation's centre"
+)
+
+// handleDoctor prints the state of the dictionaries, configuration and environment m2e runs
+// with, followed by a quick conversion check. It returns an error if any check fails.
+func handleDoctor(args []string) error {
+	fs := flag.NewFlagSet(doctorCommand, flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return usageError{err}
+	}
+
+	// The output is meant to be pasted into issues, so only colour it on a terminal
+	colour := isTerminal(os.Stdout)
+	paint := func(code, text string) string {
+		if !colour {
+			return text
+		}
+		return code + text + ColourReset
+	}
+
+	problems := 0
+	report := func(ok bool, format string, a ...any) {
+		status := paint(ColourGreen, "ok")
+		if !ok {
+			status = paint(ColourRed, "FAIL")
+			problems++
+		}
+		fmt.Printf("  [%s] %s\n", status, fmt.Sprintf(format, a...))
+	}
+
+	fmt.Println(paint(ColourBold, "m2e doctor"))
+	fmt.Printf("  Platform: %s/%s, %s\n\n", runtime.GOOS, runtime.GOARCH, runtime.Version())
+
+	fmt.Println("Dictionary:")
+	conv, err := converter.NewConverter()
+	if err != nil {
+		report(false, "Failed to load dictionaries: %v", err)
+	} else {
+		dict := conv.GetAmericanToBritishDictionary()
+		report(len(dict) > 0, "%d American to British entries loaded", len(dict))
+	}
+
+	fmt.Println("\nUnit configuration:")
+	status, err := converter.GetConfigStatus()
+	if err != nil {
+		report(false, "%v", err)
+	} else {
+		path, _ := status["configPath"].(string)
+		switch {
+		case status["exists"] != true:
+			report(true, "%s (not present, using defaults)", path)
+		case status["valid"] == true:
+			report(true, "%s (valid)", path)
+		default:
+			report(false, "%s (invalid: %v)", path, status["error"])
+		}
+	}
+
+	fmt.Println("\nContextual words:")
+	if conv != nil {
+		detector := conv.GetContextualWordDetector()
+		words := detector.SupportedWords()
+		sort.Strings(words)
+		state := "enabled"
+		if !detector.IsEnabled() {
+			state = "disabled"
+		}
+		report(len(words) > 0, "%d words, %s: %s", len(words), state, strings.Join(words, ", "))
+	}
+
+	fmt.Println("\nClipboard:")
+	if tool, ok := clipboardTool(); ok {
+		report(true, "%s found", tool)
+	} else if tool != "" {
+		// The CLI doesn't need the clipboard, so a missing tool is only informational
+		fmt.Printf("  [%s] %s not found in PATH; clipboard features of the desktop app won't work\n", paint(ColourYellow, "--"), tool)
+	} else {
+		fmt.Printf("  [%s] Clipboard is not supported on %s\n", paint(ColourYellow, "--"), runtime.GOOS)
+	}
+
+	fmt.Println("\nConversion check:")
+	if conv != nil {
+		got := conv.ConvertToBritish(doctorSampleInput, true)
+		report(got == doctorSampleExpected, "%q -> %q", doctorSampleInput, got)
+	}
+
+	fmt.Println()
+	if problems > 0 {
+		return configErrorf("%d check(s) failed", problems)
+	}
+	fmt.Println("All checks passed")
+	return nil
+}
+
+// clipboardTool returns the clipboard command used on this platform and whether it is in PATH.
+// It returns an empty name when the platform isn't supported.
+func clipboardTool() (string, bool) {
+	var tool string
+	switch runtime.GOOS {
+	case "darwin":
+		tool = "pbpaste"
+	case "linux":
+		tool = "xclip"
+	default:
+		return "", false
+	}
+
+	_, err := exec.LookPath(tool)
+	return tool, err == nil
+}
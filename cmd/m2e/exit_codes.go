@@ -0,0 +1,76 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/sammcj/m2e/pkg/report"
+)
+
+// Exit codes returned by the CLI, so scripts can tell changes apart from failures
+const (
+	exitOK      = 0 // no changes needed, or the changes were applied
+	exitChanges = 1 // changes detected (with -exit-on-change, or in directory summary mode)
+	exitUsage   = 2 // invalid flags or arguments
+	exitIO      = 3 // a file, directory, stdin or the clipboard couldn't be read or written
+	exitConfig  = 4 // the dictionary or configuration couldn't be loaded, or a doctor check failed
+)
+
+// exitCodesHelp is the exit code table shown by printUsage
+const exitCodesHelp = `Exit Codes:
+  0  No changes needed, or changes applied
+  1  Changes detected (-exit-on-change, directory summaries, files over -max-changes)
+  2  Usage error: invalid flags or arguments
+  3  I/O error: a file, directory, stdin or the clipboard couldn't be read or written
+  4  Config error: the dictionary or configuration couldn't be loaded, or a doctor check failed
+`
+
+// usageError is an error caused by how the CLI was invoked rather than by the files it read
+type usageError struct {
+	error
+}
+
+// usageErrorf formats an error that exits with exitUsage
+func usageErrorf(format string, a ...any) error {
+	return usageError{fmt.Errorf(format, a...)}
+}
+
+// changesError reports files that need changes which weren't made, such as files left alone
+// by -max-changes
+type changesError struct {
+	error
+}
+
+// changesErrorf formats an error that exits with exitChanges
+func changesErrorf(format string, a ...any) error {
+	return changesError{fmt.Errorf(format, a...)}
+}
+
+// configError is an error caused by the dictionary or configuration, such as a failed doctor check
+type configError struct {
+	error
+}
+
+// configErrorf formats an error that exits with exitConfig
+func configErrorf(format string, a ...any) error {
+	return configError{fmt.Errorf(format, a...)}
+}
+
+// exitCodeFor returns the exit code for an error returned while processing input: exitUsage,
+// exitConfig and exitChanges for usage, config and changes errors, and exitIO for everything else
+func exitCodeFor(err error) int {
+	var usage usageError
+	if errors.As(err, &usage) {
+		return exitUsage
+	}
+	var config configError
+	if errors.As(err, &config) {
+		return exitConfig
+	}
+	var changes changesError
+	var maxChanges *report.MaxChangesError
+	if errors.As(err, &changes) || errors.As(err, &maxChanges) {
+		return exitChanges
+	}
+	return exitIO
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"os"
+
+	"github.com/sammcj/m2e/pkg/fileutil"
+)
+
+// extensionFilter holds the extensions given with -ext and -ext-exclude; directories are only
+// searched for files it lets through
+var extensionFilter fileutil.ExtensionFilter
+
+// extensionList is the value of -ext or -ext-exclude: repeating the flag adds to the
+// comma-separated list rather than replacing it
+type extensionList string
+
+func (l *extensionList) String() string { return string(*l) }
+
+func (l *extensionList) Set(value string) error {
+	if *l != "" {
+		*l += ","
+	}
+	*l += extensionList(value)
+	return nil
+}
+
+// findOptions holds the traversal options given on the command line, such as -include-hidden
+var findOptions fileutil.FindOptions
+
+// findTextFiles finds the text files under path, keeping only those in a directory that pass
+// -ext, -ext-exclude and -since. A path that is a single file is returned as it is, as naming
+// a file is explicit enough.
+func findTextFiles(path string) ([]fileutil.FileInfo, error) {
+	return findFilesWithOptions(path, findOptions)
+}
+
+// findConvertibleFiles finds the files under path as findTextFiles does, along with the Word
+// documents whose text a directory run converts
+func findConvertibleFiles(path string) ([]fileutil.FileInfo, error) {
+	opts := findOptions
+	opts.IncludeDocuments = true
+	return findFilesWithOptions(path, opts)
+}
+
+// findFilesWithOptions is findTextFiles with opts in place of the command line's options
+func findFilesWithOptions(path string, opts fileutil.FindOptions) ([]fileutil.FileInfo, error) {
+	files, err := fileutil.FindTextFilesWithOptions(path, opts)
+	if err != nil {
+		return nil, err
+	}
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		files = fileutil.FilterFiles(files, extensionFilter)
+		if files, err = modifiedSince.apply(path, files); err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
@@ -0,0 +1,259 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/sammcj/m2e/pkg/converter"
+	"github.com/sammcj/m2e/pkg/fileutil"
+	"github.com/sammcj/m2e/pkg/report"
+)
+
+// patchFile is a file named in a patch with the line numbers, in its new version, of the lines
+// the patch adds
+type patchFile struct {
+	Path  string
+	Added map[int]bool
+}
+
+// hunkHeaderPattern matches "@@ -start[,count] +start[,count] @@"
+var hunkHeaderPattern = regexp.MustCompile(`^@@ -\d+(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
+
+// readPatch returns the patch for -git-diff: read from patchPath ("-" for stdin) when it is
+// set, otherwise the output of "git diff --unified=0" with gitArgs (revisions and paths)
+func readPatch(patchPath string, gitArgs []string) (string, error) {
+	switch patchPath {
+	case "":
+	case "-":
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return "", fmt.Errorf("failed to read patch from stdin: %w", err)
+		}
+		return string(data), nil
+	default:
+		data, err := os.ReadFile(patchPath)
+		if err != nil {
+			return "", fmt.Errorf("failed to read patch: %w", err)
+		}
+		return string(data), nil
+	}
+
+	// --relative gives paths relative to the working directory, where the files are read from
+	args := append([]string{"diff", "--unified=0", "--no-color", "--no-ext-diff", "--relative"}, gitArgs...)
+	out, err := exec.Command("git", args...).Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return "", usageErrorf("git diff failed: %s", strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", usageErrorf("git diff failed (is git installed?): %v", err)
+	}
+	return string(out), nil
+}
+
+// parsePatch returns the files a unified diff changes, in patch order, with the lines it adds
+// to each. Hunk headers give the first new line number of each hunk, and the old and new line
+// counts mark where the hunk ends, so added lines starting with "++" aren't read as headers.
+// Deleted files are left out.
+func parsePatch(patch string) ([]patchFile, error) {
+	var files []patchFile
+	current := -1 // index in files of the file being read, or -1
+	newLine, oldLeft, newLeft := 0, 0, 0
+
+	scanner := bufio.NewScanner(strings.NewReader(patch))
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+
+		if oldLeft > 0 || newLeft > 0 {
+			switch {
+			case strings.HasPrefix(line, "+"):
+				if current >= 0 {
+					files[current].Added[newLine] = true
+				}
+				newLine++
+				newLeft--
+			case strings.HasPrefix(line, "-"):
+				oldLeft--
+			case strings.HasPrefix(line, `\`):
+				// "\ No newline at end of file"
+			default:
+				// Context line; some tools strip the space from empty ones
+				newLine++
+				oldLeft--
+				newLeft--
+			}
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "diff "):
+			current = -1
+		case strings.HasPrefix(line, "+++ "):
+			current = -1
+			path, err := patchFileName(strings.TrimPrefix(line, "+++ "))
+			if err != nil {
+				return nil, err
+			}
+			if path != "" {
+				files = append(files, patchFile{Path: path, Added: make(map[int]bool)})
+				current = len(files) - 1
+			}
+		case strings.HasPrefix(line, "@@ "):
+			m := hunkHeaderPattern.FindStringSubmatch(line)
+			if m == nil {
+				return nil, fmt.Errorf("malformed hunk header %q", line)
+			}
+			oldLeft, newLeft = 1, 1
+			if m[1] != "" {
+				oldLeft, _ = strconv.Atoi(m[1])
+			}
+			newLine, _ = strconv.Atoi(m[2])
+			if m[3] != "" {
+				newLeft, _ = strconv.Atoi(m[3])
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read patch: %w", err)
+	}
+	return files, nil
+}
+
+// patchFileName returns the path named after "+++ " in a patch, without the "b/" prefix git adds
+// or a trailing timestamp, or "" for /dev/null
+func patchFileName(name string) (string, error) {
+	if strings.HasPrefix(name, `"`) {
+		unquoted, err := strconv.Unquote(name)
+		if err != nil {
+			return "", fmt.Errorf("malformed file name %s in patch", name)
+		}
+		name = unquoted
+	} else if before, _, found := strings.Cut(name, "\t"); found {
+		name = before
+	}
+	if name == "/dev/null" {
+		return "", nil
+	}
+	return strings.TrimPrefix(name, "b/"), nil
+}
+
+// keepAddedLines returns original with only the given lines (numbered from 1) taken from
+// converted. It reports false when the conversion changed the number of lines, so they can't
+// be matched up.
+func keepAddedLines(original, converted string, added map[int]bool) (string, bool) {
+	originalLines := strings.Split(original, "\n")
+	convertedLines := strings.Split(converted, "\n")
+	if len(originalLines) != len(convertedLines) {
+		return original, false
+	}
+	for i := range originalLines {
+		if added[i+1] {
+			originalLines[i] = convertedLines[i]
+		}
+	}
+	return strings.Join(originalLines, "\n"), true
+}
+
+// handleGitDiff converts and reports Americanisms only on the lines a patch adds, reading each
+// file from the working tree. Pre-existing lines are left alone even when they need
+// conversion, so m2e can be introduced to a repository without flagging all of it at once.
+func handleGitDiff(patch string, conv *converter.Converter, normaliseSmartQuotes bool,
+	showDiff, showRawChanges, showStats, saveInPlace, exitOnChange bool, maxFileSize, statsDetail int) error {
+
+	files, err := parsePatch(patch)
+	if err != nil {
+		return usageError{err}
+	}
+
+	analyser := report.NewAnalyser(conv.GetAmericanToBritishDictionary())
+	var totalStats report.ChangeStats
+	var changedFiles []string
+
+	for _, file := range files {
+		if len(file.Added) == 0 || converter.IsDocxFile(file.Path) || fileutil.IsArchiveFile(file.Path) {
+			continue
+		}
+		if isText, err := fileutil.IsTextFile(file.Path); err != nil || !isText {
+			continue
+		}
+
+		content, err := fileutil.ReadFileContentWithMaxSize(file.Path, maxFileSize)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Failed to read file %s: %v\n", file.Path, err)
+			continue
+		}
+
+		fullyConverted, _ := convertFileWithStats(conv, analyser, content, file.Path, normaliseSmartQuotes)
+		explanations := conv.TakeExplanations()
+		converted, ok := keepAddedLines(content, fullyConverted, file.Added)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "Warning: Skipping %s: conversion changed its line count\n", file.Path)
+			continue
+		}
+		if converted == content {
+			continue
+		}
+
+		changedFiles = append(changedFiles, file.Path)
+		stats := analyser.AnalyseChanges(content, converted)
+		totalStats.TotalWords += stats.TotalWords
+		totalStats.SpellingChanges += stats.SpellingChanges
+		totalStats.UnitConversions += stats.UnitConversions
+		totalStats.QuoteChanges += stats.QuoteChanges
+		totalStats.ChangeDetails = report.MergeChangeDetails(totalStats.ChangeDetails, stats.ChangeDetails)
+
+		switch {
+		case saveInPlace:
+			if err := os.WriteFile(file.Path, []byte(converted), 0644); err != nil {
+				return fmt.Errorf("failed to save changes to file %s: %w", file.Path, err)
+			}
+		case showRawChanges:
+			for _, change := range report.ChangedLines(content, converted) {
+				fmt.Printf("%s:%d:%s\n", file.Path, change.Number, change.Converted)
+			}
+		case showStats:
+			// Only the totals are shown
+		default:
+			var notes map[int]string
+			if diffConfidence {
+				// Lines keep their numbers, so notes on the fully converted file fit the kept lines
+				notes = confidenceNotes(content, fullyConverted, explanations)
+			}
+			fmt.Print(report.AnnotatedLineDiff(content, converted, file.Path, notes))
+		}
+	}
+
+	switch {
+	case showStats:
+		if err := showStatsOutputWithDetail(totalStats, statsDetail); err != nil {
+			return err
+		}
+	case saveInPlace && len(changedFiles) > 0:
+		fmt.Printf("Saved changes to added lines in %d file(s):\n", len(changedFiles))
+		for _, file := range changedFiles {
+			fmt.Printf("  %s\n", file)
+		}
+	case !showDiff && !showRawChanges && !saveInPlace:
+		if len(changedFiles) == 0 {
+			fmt.Println("No changes needed on added lines")
+		} else {
+			fmt.Printf("\nFound changes on added lines in %d file(s)\n", len(changedFiles))
+			if err := showStatsOutput(totalStats); err != nil {
+				return err
+			}
+		}
+	}
+
+	if exitOnChange && len(changedFiles) > 0 {
+		os.Exit(exitChanges)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// installHookCommand is the hidden subcommand that installs the git pre-commit hook
+const installHookCommand = "install-hook"
+
+// preCommitHookTemplate checks the staged version of each added, copied, modified or renamed
+// text file. %s is the quoted path of the m2e binary that installed the hook.
+const preCommitHookTemplate = `#!/bin/sh
+# m2e pre-commit hook: checks staged text files for American English spellings.
+# Installed by 'm2e install-hook'. Set M2E to use a different binary, delete this
+# file to remove the hook, or commit with --no-verify to skip it once.
+
+installed_m2e=%s
+M2E="${M2E:-$installed_m2e}"
+if ! command -v "$M2E" >/dev/null 2>&1; then
+	M2E=m2e
+fi
+
+files=$(mktemp) || exit 1
+output=$(mktemp) || exit 1
+trap 'rm -f "$files" "$output"' EXIT
+
+git diff --cached --name-only --diff-filter=ACMR > "$files"
+
+needs_conversion=""
+while IFS= read -r file; do
+	# git reports binary files as "-" in --numstat
+	case "$(git diff --cached --numstat -- "$file")" in
+		-*) continue ;;
+	esac
+
+	if ! git show ":$file" | "$M2E" -stdin-filename "$file" -diff -exit-on-change > "$output" 2>&1; then
+		echo "=== $file ==="
+		cat "$output"
+		needs_conversion="$needs_conversion  $file
+"
+	fi
+done < "$files"
+
+if [ -n "$needs_conversion" ]; then
+	echo
+	echo "m2e: these staged files need conversion to British English:"
+	printf '%%s' "$needs_conversion"
+	echo "Convert them with 'm2e -save <file>' and stage the result, or commit with --no-verify to skip."
+	exit 1
+fi
+`
+
+// handleInstallHook writes the pre-commit hook into the current git repository, refusing to
+// replace an existing hook unless -force is given
+func handleInstallHook(args []string) error {
+	fs := flag.NewFlagSet(installHookCommand, flag.ContinueOnError)
+	force := fs.Bool("force", false, "Overwrite an existing pre-commit hook")
+	if err := fs.Parse(args); err != nil {
+		return usageError{err}
+	}
+
+	// --git-path respects worktrees and core.hooksPath
+	out, err := exec.Command("git", "rev-parse", "--git-path", "hooks/pre-commit").Output()
+	if err != nil {
+		return usageErrorf("not a git repository (or git is not installed)")
+	}
+	hookPath := strings.TrimSpace(string(out))
+
+	if _, err := os.Stat(hookPath); err == nil && !*force {
+		return usageErrorf("a pre-commit hook already exists at %s; use -force to overwrite it", hookPath)
+	}
+
+	binary := "m2e"
+	if executable, err := os.Executable(); err == nil {
+		binary = executable
+	}
+	// Single quotes keep the path literal in the shell script
+	quoted := "'" + strings.ReplaceAll(binary, "'", `'\''`) + "'"
+
+	if err := os.MkdirAll(filepath.Dir(hookPath), 0755); err != nil {
+		return fmt.Errorf("failed to create hooks directory: %w", err)
+	}
+	if err := os.WriteFile(hookPath, []byte(fmt.Sprintf(preCommitHookTemplate, quoted)), 0755); err != nil {
+		return fmt.Errorf("failed to write pre-commit hook: %w", err)
+	}
+	// WriteFile doesn't change the mode of an existing file
+	if err := os.Chmod(hookPath, 0755); err != nil {
+		return fmt.Errorf("failed to make pre-commit hook executable: %w", err)
+	}
+
+	fmt.Printf("Installed pre-commit hook at %s\n", hookPath)
+	return nil
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sammcj/m2e/pkg/converter"
+	"github.com/sammcj/m2e/pkg/fileutil"
+	"github.com/sammcj/m2e/pkg/report"
+)
+
+// interactiveFile is a file with changes to review in -interactive mode
+type interactiveFile struct {
+	path     string
+	display  string
+	content  string
+	changes  []report.TextChange
+	accepted []report.TextChange
+}
+
+// handleInteractive shows each change the conversion would make to the given files and asks
+// whether to apply it, then writes the accepted changes back. Directories are expanded to the
+// text files they contain.
+func handleInteractive(paths []string, conv *converter.Converter, normaliseSmartQuotes bool, maxFileSize int) error {
+	if !isTerminal(os.Stdin) {
+		return usageErrorf("-interactive needs a terminal to prompt on; use -diff to review changes or -save to apply them all")
+	}
+
+	files, err := collectInteractiveFiles(paths, conv, normaliseSmartQuotes, maxFileSize)
+	if err != nil {
+		return err
+	}
+
+	total := 0
+	for _, file := range files {
+		total += len(file.changes)
+	}
+	if total == 0 {
+		fmt.Println("No changes needed")
+		return nil
+	}
+
+	if err := reviewInteractiveChanges(files, total, os.Stdin, isTerminal(os.Stdout)); err != nil {
+		return err
+	}
+
+	applied, written := 0, 0
+	for _, file := range files {
+		if len(file.accepted) == 0 {
+			continue
+		}
+		content := report.ApplyTextChanges(file.content, file.accepted)
+		if err := os.WriteFile(file.path, []byte(content), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", file.path, err)
+		}
+		applied += len(file.accepted)
+		written++
+	}
+	fmt.Printf("\nApplied %d of %d change(s) in %d file(s)\n", applied, total, written)
+	return nil
+}
+
+// collectInteractiveFiles converts the given files, expanding directories, and keeps those
+// the conversion would change
+func collectInteractiveFiles(paths []string, conv *converter.Converter, normaliseSmartQuotes bool, maxFileSize int) ([]*interactiveFile, error) {
+	var files []*interactiveFile
+	addFile := func(filePath, displayPath string) error {
+		content, err := fileutil.ReadFileContentWithMaxSize(filePath, maxFileSize)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", displayPath, err)
+		}
+		changes := report.TextChanges(content, convertFile(conv, content, filePath, normaliseSmartQuotes))
+		if len(changes) > 0 {
+			files = append(files, &interactiveFile{path: filePath, display: displayPath, content: content, changes: changes})
+		}
+		return nil
+	}
+
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			if err := addFile(path, path); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		dirFiles, err := findTextFiles(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find text files in directory %s: %w", path, err)
+		}
+		for _, file := range dirFiles {
+			if err := addFile(file.Path, filepath.Join(path, file.RelativePath)); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			}
+		}
+	}
+
+	return files, nil
+}
+
+// reviewInteractiveChanges prompts for each change in turn, recording the accepted ones on
+// their file. "a" accepts the remaining changes in every file and "q" stops, keeping the
+// answers given so far. An error is returned if the input ends before every change has been
+// answered, so nothing is written from a review that was cut short.
+func reviewInteractiveChanges(files []*interactiveFile, total int, in io.Reader, colour bool) error {
+	reader := bufio.NewReader(in)
+	applyAll := false
+	index := 0
+
+	for _, file := range files {
+		for _, change := range file.changes {
+			index++
+			if applyAll {
+				file.accepted = append(file.accepted, change)
+				continue
+			}
+
+			fmt.Printf("\n%s:%d (%d/%d)\n", file.display, change.Line, index, total)
+			fmt.Printf("  %s\n", changeContext(file.content, change, colour))
+
+			answer, err := promptInteractive(reader)
+			if err != nil {
+				return err
+			}
+			switch answer {
+			case "y":
+				file.accepted = append(file.accepted, change)
+			case "a":
+				file.accepted = append(file.accepted, change)
+				applyAll = true
+			case "q":
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+// promptInteractive asks whether to apply a change until it gets a valid answer, returning an
+// error if the input ends first
+func promptInteractive(reader *bufio.Reader) (string, error) {
+	for {
+		fmt.Print("Apply this change? [y,n,a,q] ")
+		line, err := reader.ReadString('\n')
+		answer := strings.ToLower(strings.TrimSpace(line))
+		switch answer {
+		case "y", "n", "a", "q":
+			return answer, nil
+		}
+		if err != nil {
+			fmt.Println()
+			if err == io.EOF {
+				return "", errors.New("input ended before every change was answered; no changes were written")
+			}
+			return "", fmt.Errorf("failed to read answer: %w", err)
+		}
+		fmt.Println("y - apply this change\nn - skip this change\na - apply this and all remaining changes\nq - quit, keeping the changes applied so far")
+	}
+}
+
+// changeContext returns the line a change is on with the change highlighted, in colour or
+// marked up as [-old-]{+new+} when colour is false
+func changeContext(content string, change report.TextChange, colour bool) string {
+	lineStart := strings.LastIndex(content[:change.Start], "\n") + 1
+	lineEnd := len(content)
+	if i := strings.Index(content[change.End:], "\n"); i >= 0 {
+		lineEnd = change.End + i
+	}
+
+	var marked string
+	if colour {
+		marked = ColourRed + change.Original + ColourReset + ColourGreen + change.Converted + ColourReset
+	} else {
+		marked = "[-" + change.Original + "-]{+" + change.Converted + "+}"
+	}
+	return content[lineStart:change.Start] + marked + strings.TrimSuffix(content[change.End:lineEnd], "\r")
+}
@@ -44,6 +44,9 @@ Conversion Options:
         Freedom Unit Conversion (default: false)
   -no-smart-quotes
         Disable smart quote normalisation (default: false)
+  -no-contextual
+        Disable contextual word detection, leaving license/licence, practice/practise and
+        similar words as written (default: false)
 
 Output Mode (mutually exclusive):
   -diff
@@ -96,6 +99,7 @@ func main() {
 	flag.StringVar(&outputFileLong, "output", "", "Output file to write to (same as -o)")
 	convertUnits := flag.Bool("units", false, "Freedom Unit Conversion")
 	noSmartQuotes := flag.Bool("no-smart-quotes", false, "Disable smart quote normalisation")
+	noContextual := flag.Bool("no-contextual", false, "Disable contextual word detection (license/licence, practice/practise...)")
 
 	// Legacy flags for backwards compatibility
 	inputFile := flag.String("input", "", "Input file to convert (legacy, use positional argument instead)")
@@ -140,6 +144,7 @@ func main() {
 
 	// Set unit processing based on flag
 	conv.SetUnitProcessingEnabled(*convertUnits)
+	conv.SetContextualWordDetectionEnabled(!*noContextual)
 
 	// Determine smart quotes setting (default is true, disable if flag is set)
 	normaliseSmartQuotes := !*noSmartQuotes
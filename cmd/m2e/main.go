@@ -2,6 +2,7 @@ package main
 
 import (
 	"bytes"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
@@ -9,12 +10,14 @@ import (
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"slices"
+	"strconv"
 	"strings"
 
+	"github.com/sammcj/m2e/pkg/cache"
 	"github.com/sammcj/m2e/pkg/converter"
 	"github.com/sammcj/m2e/pkg/fileutil"
 	"github.com/sammcj/m2e/pkg/report"
-	"github.com/sergi/go-diff/diffmatchpatch"
 )
 
 // ANSI colour codes for diff output
@@ -36,38 +39,209 @@ Usage:
   m2e [options] -o [output] [file]          # Convert file to output file
   m2e [options] [directory]                 # Convert all text files in directory (in-place)
   echo "text" | m2e [options]               # Convert stdin to stdout
+  m2e doctor                                # Print diagnostics to include in bug reports
+  m2e dict-diff [-json] old.json new.json   # Compare two versions of a dictionary file
 
 Conversion Options:
+  -preset docs|code|strict
+        Start from a named set of flags; flags given alongside it still override it:
+          docs    -all-text -units       convert every file as prose, with units and smart quotes
+          code    -only-comments         convert only comments, without units
+          strict  -no-contextual -no-smart-quotes
+                                         change dictionary spellings only
+        Boolean flags also accept =false to turn off a preset's setting, e.g. -units=false
   -o, -output string
         Output file to write to. If not specified, writes to stdout.
         (Not supported when processing directories or with output mode flags)
   -units
         Freedom Unit Conversion (default: false)
+  -units-keep-original
+        With -units, keep the original measurement and add the metric value in parentheses,
+        e.g. "10 feet" → "10 feet (3 metres)" (default: false)
+  -units-cooking
+        With -units, also convert US cooking measures: cups, tablespoons, teaspoons and sticks
+        of butter, e.g. "2 cups of flour" → "473.2 ml of flour"; drinks such as "a cup of tea"
+        are left alone (default: false)
+  -normalise-units
+        Tidy metric units already in the text without changing quantities, e.g. "5kg" → "5 kg",
+        "10KM" → "10 km" (default: false)
+  -normalise-unicode
+        Compose accented letters written with combining marks (Unicode NFC) before converting
+        prose, so "café" matches however it was typed; code is left as is (default: false)
+  -phrases
+        Rewrite American phrases and idioms, e.g. "on the weekend" → "at the weekend" (default: false)
+  -spelling=ise|ize|oxford
+        British form for words spelt -ise or -ize: ise (organise, analyse; default),
+        ize (organize, analyze) or oxford (organize, analyse)
   -no-smart-quotes
         Disable smart quote normalisation (default: false)
+  -dashes=flatten|typographic
+        flatten (default) turns en-dashes and em-dashes into hyphens with the smart quotes;
+        typographic keeps them and writes number ranges with an en-dash ("1990 - 1995" → "1990–1995")
+  -no-contextual
+        Disable contextual word detection, leaving words whose spelling depends on context
+        (license/licence, practice/practise...) as written; other words are still converted
+        (default: false)
+  -convert-proper-nouns
+        Also convert words in Title Case names such as "Department of Labor" or "World Health
+        Organization", which keep their American spelling by default (default: false)
+  -convert-urls
+        Also convert words inside URLs and URL-like words such as "www.color", which are left
+        as they are by default (default: false)
+  -preserve-identifiers
+        Keep the spelling of words inside code identifiers mentioned in prose, such as the
+        camelCase "initializeColor()" or the snake_case "color_value" (default: true). Use
+        -preserve-identifiers=false to convert the words they're made of
+  -quote-punctuation
+        Move a comma or full stop outside the closing quote of a short quoted phrase, as in
+        British usage: called it "simple," which → called it "simple", which. Only clear cases
+        are changed, never quoted speech or code (default: false)
+  -tidy-whitespace
+        Fold runs of spaces that conversion introduces, such as the double space a unit or
+        phrase rewrite can leave, into a single space; spacing already in the text, such as
+        aligned tables and code, is kept (default: false)
+  -regional
+        Also convert American seasons, holidays and date ranges where the context is clear:
+        "in the fall" → "in the autumn", "summer vacation" → "summer holiday" and "Monday
+        through Friday" → "Monday to Friday"; the verbs "fall" and "vacation" are left alone
+        (default: false)
+  -floors
+        Renumber floors the British way, where street level is the ground floor: "first floor"
+        → "ground floor", "2nd floor" → "1st floor". Only use it on text that counts floors the
+        American way, and only once: converting again renumbers again (default: false)
+  -only-words string
+        Only convert words whose American spelling matches one of these comma-separated
+        regular expressions, e.g. 'colou?r,cent(er|re)'
 
 Output Mode (mutually exclusive):
   -diff
         Show only git-style unified diff of changes (patch compatible)
+  -diff-confidence
+        With -diff, end each changed line that has contextual word or unit changes with a
+        comment giving the detector's confidence in each, e.g. # confidence: "licence" 0.90.
+        The annotated diff no longer applies as a patch
   -diff-inline
         Show only character-level inline diff with colours
+  -diff-word
+        Show only word-level inline diff with colours, highlighting whole changed words
   -raw
         Show only the processed plain text
+  -raw-changes
+        Show only the converted lines that changed, prefixed with their line numbers
+  -explain
+        Show each change with the rule that made it (dictionary, contextual pattern, phrase, unit or smart quotes)
+  -warnings
+        Print judgement calls worth reviewing to stderr: low-confidence contextual changes, noun/verb words left as written, and measurements converted next to an idiom
   -stats
         Show only conversion statistics
+  -stats-detail int
+        With -stats, also list the N most frequent substitutions (spelling, unit and quote changes)
+  -stats-format=human|csv|json
+        human (default) prints the -stats summary; csv prints a file,words,spelling,units,quotes
+        row per file and a total row, and json the same counts as a files list and a total
   -save, -s
         Overwrite the input file with converted content
+  -interactive
+        Show each change with its line and prompt to apply (y), skip (n), apply all remaining (a)
+        or quit (q), then write the accepted changes back; needs a terminal
+  -suggest
+        List words that look American (-ize, -yze, -or) but aren't in the dictionary, without converting
+  -list-contextual
+        List the words converted according to context (license/licence, practice/practise...) with
+        their noun and verb spellings, patterns, semantic variants and confidence levels
+  -print-config
+        Print the configuration that takes effect as JSON, then exit: the defaults, the user
+        unit config, the nearest .m2e.json (for the path given, or the current directory) and
+        command-line flags, merged. The output can be saved as a starting config file
   (default: show diff + processed output + stats)
 
 Additional Options:
-  -width int
-        Set output width for formatting (default: 80)
+  -max-line-width int
+        Re-wrap the paragraphs of Markdown and plain text (including stdin) that conversion
+        changes to this width, keeping blank lines, lists, headings, tables and code blocks as
+        they are; -width is an alias (default: 0, off)
   -exit-on-change
         Exit with code 1 if changes are detected
+  -fail-fast
+        With -exit-on-change, stop a directory scan at the first file that needs changes
   -rename
         Rename files that have American spellings in their filename
+  -git-diff
+        Only convert and report lines added in "git diff --unified=0"; arguments are passed to git
+        diff (e.g. m2e -git-diff origin/main...HEAD) and files are read from the working tree. Use
+        with -diff, -raw-changes, -stats, -save or -exit-on-change
+  -patch string
+        With -git-diff, read the patch from this file ('-' for stdin) instead of running git diff
+  -write-patch string
+        Write the changes to the given files and directories as a single patch file ('-' for
+        stdout) that applies with 'git apply', without modifying them
+  -watch
+        Watch the given files and directories and convert each file shortly after it's saved,
+        printing a line per file: report the changes needed, or apply them with -save
+  -count-only
+        Only count the changes each file needs and print the counts per file and in total, without
+        building the converted text or diffs; faster for large CI scans. Use with -exit-on-change
+  -rename-only
+        Only rename files (including non-text files) without converting their contents; use with -save to apply
+  -output-dir string
+        Write converted copies of a directory's files to this directory, leaving the originals untouched
+  -copy-all
+        With -output-dir, also copy non-text files verbatim (by default they are skipped)
+  -max-changes int
+        Leave a file untouched and report it if converting it would make more than N changes
   -size-max-kb int
         Maximum file size to process in KB (default: 10240 KB = 10 MB)
+  -format=json
+        Convert only the string values of .json files (and of stdin or text input), leaving keys untouched
+  -json-keys string
+        With -format=json, only convert values whose key matches this regular expression
+  -csv-columns string
+        Only convert these comma-separated columns of .csv and .tsv files (and of stdin or text
+        input), given as header names or 1-based numbers; the header row and other columns are
+        left untouched
+  -ext string
+        Only process files with these comma-separated extensions when searching directories,
+        e.g. '.md,.txt,.go'; can be repeated
+  -ext-exclude string
+        Leave out files with these comma-separated extensions when searching directories, even
+        if -ext includes them; can be repeated
+  -since string
+        Only process files in directories that were modified after a date or time ('2024-01-01',
+        '2024-01-01T09:00:00Z'), or that changed since a git revision ('HEAD~5', a tag or
+        branch), including uncommitted and untracked files
+  -include-hidden
+        Search hidden directories and include hidden files when searching directories, such as
+        .github; .git is always skipped
+  -stdin-filename string
+        Filename used to infer how stdin is processed (e.g. main.go converts only comments)
+  -convert-inline-code
+        Convert text inside inline code spans (single backticks) like regular prose
+  -no-markdown
+        Convert text as plain prose, without treating backticks, asterisks, underscores or
+        fences as Markdown (default: false)
+  -only-comments
+        Convert only comments in every file, whatever its extension (e.g. a .txt that is really a script)
+  -all-text
+        Convert all text in every file, whatever its extension, overriding the comment-only handling
+        of config files and of code named by -stdin-filename
+  -skip-frontmatter
+        Leave YAML front matter in Markdown files untouched (by default only its values are converted)
+  -md-elements string
+        Only convert these comma-separated element types of Markdown files (and of stdin or text
+        input): headings, paragraphs, blockquotes, lists, tables, links. Everything else, including
+        link text unless links is given, is left untouched (default: all elements)
+  -report=md
+        Write a Markdown report (per-file change counts and collapsible diffs) to stdout or -o
+  -log string
+        Append a JSON lines record (time, path, counts and word changes) of each converted file to this path
+  -cache
+        Keep converted files in ~/.cache/m2e, keyed by their content and the effective settings, so
+        files that haven't changed since an earlier run aren't converted again
+  -no-cache
+        Don't use the conversion cache, even if -cache is given
+  -verbose
+        Print the time spent on each file, its dictionary, contextual and unit changes and whether
+        the dictionary pre-filter skipped it, to stderr
 
 Legacy Options (for backwards compatibility):
   -input string
@@ -80,55 +254,182 @@ Examples:
   m2e document.txt                          # Show diff + processed text + stats
   m2e -diff document.txt                    # Show only unified diff (patch compatible)
   m2e -diff-inline document.txt             # Show only character-level diff with colours
+  m2e -diff-word document.txt               # Show only word-level diff with colours
   m2e -raw document.txt                     # Show only processed text
+  m2e -raw-changes document.txt             # Show only changed lines with line numbers
+  m2e -explain document.txt                 # Show why each change was made
   m2e -stats document.txt                   # Show only conversion statistics
   m2e -save document.txt                    # Overwrite file with converted content
   m2e -s document.txt                       # Same as -save (shorthand)
+  m2e -interactive docs/                    # Review each change and apply the ones you accept
   m2e -o converted.txt document.txt         # Convert file to output file
   m2e -units document.txt                   # Convert with unit conversion
+  m2e -no-contextual docs/                  # Leave license, practice etc. as written
   m2e /path/to/project                      # Process all text files in directory
+  m2e -rename-only -save assets/            # Rename files without touching their contents
+  m2e -git-diff -exit-on-change origin/main...HEAD  # Check only the lines a branch adds
+  m2e -output-dir docs-en-gb docs/          # Write converted copies of docs/ to docs-en-gb/
+  m2e -suggest docs/                        # List possible Americanisms missing from the dictionary
+  m2e -list-contextual                      # Show the rules for context-dependent words
+  m2e -print-config -units docs/            # Show the settings that apply to docs/
   echo "American text" | m2e -units        # Convert stdin with units
+  cat main.go | m2e -stdin-filename main.go # Convert only the comments in Go code from stdin
+  m2e -report=md -o report.md docs/         # Write a Markdown report for a PR description
+  m2e -write-patch m2e.patch docs/          # Write the changes as a patch for git apply
+  m2e -watch -save docs/                    # Convert files in docs/ as they're saved
+  m2e -save -log m2e.log docs/              # Keep an audit trail of files changed in place
 
 CI/CD Examples:
   m2e -exit-on-change /docs/               # Exit with code 1 if changes needed
   m2e -diff -exit-on-change README.md      # Show diff and exit 1 if changes
-`)
+  m2e -count-only -exit-on-change /repo/   # Count the changes needed in a large tree
+  m2e -exit-on-change -fail-fast /repo/    # Stop at the first file that needs changes
+
+`+exitCodesHelp)
 }
 
 func main() {
+	// Hidden subcommand: installs a git pre-commit hook that checks staged files
+	if len(os.Args) > 1 && os.Args[1] == installHookCommand {
+		if err := handleInstallHook(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitCodeFor(err))
+		}
+		return
+	}
+
+	// Diagnostics subcommand for bug reports
+	if len(os.Args) > 1 && os.Args[1] == doctorCommand {
+		if err := handleDoctor(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitCodeFor(err))
+		}
+		return
+	}
+
+	// Dictionary comparison subcommand for reviewing data changes
+	if len(os.Args) > 1 && os.Args[1] == dictDiffCommand {
+		if err := handleDictDiff(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitCodeFor(err))
+		}
+		return
+	}
+
 	// Modern flags
 	var outputFile, outputFileLong string
-	flag.StringVar(&outputFile, "o", "", "Output file to write to. If not specified, writes to stdout.")
-	flag.StringVar(&outputFileLong, "output", "", "Output file to write to (same as -o)")
+	pathFlagVar(&outputFile, "o", "", "Output file to write to. If not specified, writes to stdout.")
+	pathFlagVar(&outputFileLong, "output", "", "Output file to write to (same as -o)")
+	// -preset is expanded into the flags it stands for before parsing; it's registered for the
+	// completion scripts
+	enumFlag("preset", "", "Start from a named set of flags: docs, code or strict", presetNames()...)
 	convertUnits := flag.Bool("units", false, "Freedom Unit Conversion")
+	unitsKeepOriginal := flag.Bool("units-keep-original", false, "With -units, keep the original measurement and add the metric value in parentheses")
+	unitsCooking := flag.Bool("units-cooking", false, "With -units, also convert US cooking measures such as cups and tablespoons")
+	normaliseUnits := flag.Bool("normalise-units", false, "Tidy the spacing and symbols of metric units without changing quantities")
+	normaliseUnicode := flag.Bool("normalise-unicode", false, "Normalise prose to Unicode NFC before converting it")
+	convertPhrases := flag.Bool("phrases", false, "Rewrite American phrases and idioms with British equivalents")
+	spelling := enumFlag("spelling", "ise", "British form for -ise/-ize words: ise, ize or oxford", "ise", "ize", "oxford")
 	noSmartQuotes := flag.Bool("no-smart-quotes", false, "Disable smart quote normalisation")
+	dashes := enumFlag("dashes", "flatten", "Dash handling: flatten (to hyphens) or typographic (keep en/em-dashes)", "flatten", "typographic")
+	noContextual := flag.Bool("no-contextual", false, "Disable contextual word detection (license/licence, practice/practise...)")
+	convertProperNouns := flag.Bool("convert-proper-nouns", false, "Convert words in Title Case names like \"Department of Labor\"")
+	convertURLs := flag.Bool("convert-urls", false, "Convert words inside URLs instead of leaving URLs as they are")
+	preserveIdentifiers := flag.Bool("preserve-identifiers", true, "Keep the spelling of code identifiers like initializeColor or color_value in prose")
+	quotePunctuation := flag.Bool("quote-punctuation", false, "Move commas and full stops outside the closing quotes of short quoted phrases")
+	tidyWhitespace := flag.Bool("tidy-whitespace", false, "Fold runs of spaces introduced by conversion into a single space")
+	regional := flag.Bool("regional", false, "Convert American seasons, holidays and date ranges (fall/autumn, vacation/holiday)")
+	floors := flag.Bool("floors", false, "Renumber floors the British way: first floor → ground floor, 2nd floor → 1st floor")
 
 	// Legacy flags for backwards compatibility
-	inputFile := flag.String("input", "", "Input file to convert (legacy, use positional argument instead)")
+	inputFile := pathFlag("input", "", "Input file to convert (legacy, use positional argument instead)")
 
 	// Output mode flags (mutually exclusive)
 	showDiff := flag.Bool("diff", false, "Show only git-style unified diff of changes (patch compatible)")
 	showDiffInline := flag.Bool("diff-inline", false, "Show only character-level inline diff with colours")
+	showDiffWord := flag.Bool("diff-word", false, "Show only word-level inline diff with colours, highlighting whole changed words")
+	flag.BoolVar(&diffConfidence, "diff-confidence", false, "With -diff, annotate contextual word and unit changes with the detector's confidence")
 	showRaw := flag.Bool("raw", false, "Show only the processed plain text")
+	showRawChanges := flag.Bool("raw-changes", false, "Show only the converted lines that changed, prefixed with their line numbers")
+	showExplain := flag.Bool("explain", false, "Show each change with the rule that made it")
+	showWarnings := flag.Bool("warnings", false, "Print judgement calls worth reviewing to stderr")
 	showStats := flag.Bool("stats", false, "Show only conversion statistics")
+	statsDetail := flag.Int("stats-detail", 0, "With -stats, also list the N most frequent substitutions")
+	statsFormat := enumFlag("stats-format", "human", "Statistics format: human, or csv or json for a table of per-file counts", statsFormats...)
 	saveInPlace := flag.Bool("save", false, "Overwrite the input file with converted content (cannot be used with other output modes)")
 	saveInPlaceShort := flag.Bool("s", false, "Shorthand for -save")
+	suggestMode := flag.Bool("suggest", false, "List likely American spellings that aren't in the dictionary, without converting")
+	interactive := flag.Bool("interactive", false, "Prompt to apply or skip each change, then write the accepted changes back")
+	printConfig := flag.Bool("print-config", false, "Print the effective merged configuration as JSON and exit")
+	listContextual := flag.Bool("list-contextual", false, "List the contextual word rules: noun and verb spellings, patterns, semantic variants and confidence levels")
 
 	// Additional flags
-	width := flag.Int("width", 80, "Set output width for formatting")
+	width := flag.Int("max-line-width", 0, "Re-wrap changed paragraphs of Markdown and plain text to this width (0 disables)")
+	flag.IntVar(width, "width", 0, "Alias for -max-line-width")
 	exitOnChange := flag.Bool("exit-on-change", false, "Exit with code 1 if changes are detected")
+	failFast := flag.Bool("fail-fast", false, "With -exit-on-change, stop a directory scan at the first file that needs changes")
 	renameFiles := flag.Bool("rename", false, "Rename files that have American spellings in their filename")
+	gitDiff := flag.Bool("git-diff", false, "Only convert and report lines added in git diff; arguments are passed to git diff")
+	patchPath := pathFlag("patch", "", "With -git-diff, read the patch from this file ('-' for stdin) instead of running git diff")
+	writePatch := pathFlag("write-patch", "", "Write the changes as a single patch file ('-' for stdout) instead of modifying files")
+	countOnly := flag.Bool("count-only", false, "Only count the changes each file needs, without building converted text or diffs")
+	watch := flag.Bool("watch", false, "Watch files and directories and convert each file when it changes (report, or apply with -save)")
+	renameOnly := flag.Bool("rename-only", false, "Only rename files with American spellings in their filename, leaving contents untouched")
+	outputDir := pathFlag("output-dir", "", "Write converted copies of a directory's files under this directory, leaving the originals untouched")
+	copyAll := flag.Bool("copy-all", false, "With -output-dir, copy non-text files verbatim instead of skipping them")
+	maxChanges := flag.Int("max-changes", 0, "Leave a file untouched if converting it would make more than N changes (0 disables)")
 	maxFileSize := flag.Int("size-max-kb", 10240, "Maximum file size to process in KB (default: 10240)") // 10MB default
+	stdinFilename := pathFlag("stdin-filename", "", "Filename used to infer how stdin is processed (e.g. main.go converts only comments)")
+	convertInlineCode := flag.Bool("convert-inline-code", false, "Convert text inside inline code spans (single backticks) like regular prose")
+	noMarkdown := flag.Bool("no-markdown", false, "Convert text as plain prose, without Markdown-aware handling of code spans, code blocks, emphasis and links")
+	skipFrontMatter := flag.Bool("skip-frontmatter", false, "Leave YAML front matter in Markdown files untouched")
+	mdElements := enumFlag("md-elements", "", "Only convert these comma-separated Markdown element types: headings, paragraphs, blockquotes, lists, tables, links",
+		"headings", "paragraphs", "blockquotes", "lists", "tables", "links")
+	onlyComments := flag.Bool("only-comments", false, "Convert only comments in every file, whatever its extension")
+	allText := flag.Bool("all-text", false, "Convert all text in every file, whatever its extension")
+	inputFormat := enumFlag("format", "", "Treat .json files (and stdin or text input) as JSON and convert only string values (json)", "json")
+	onlyWords := flag.String("only-words", "", "Only convert words matching one of these comma-separated regular expressions")
+	jsonKeys := flag.String("json-keys", "", "With -format=json, only convert values whose key matches this regular expression")
+	var extInclude, extExclude extensionList
+	flag.Var(&extInclude, "ext", "Only process files with these comma-separated extensions when searching directories")
+	flag.Var(&extExclude, "ext-exclude", "Leave out files with these comma-separated extensions when searching directories")
+	since := flag.String("since", "", "Only process files in directories modified after a date or changed since a git revision")
+	includeHidden := flag.Bool("include-hidden", false, "Search hidden directories and files when searching directories (.git is always skipped)")
+	csvColumns := flag.String("csv-columns", "", "Only convert these comma-separated columns (header names or 1-based numbers) of .csv and .tsv files")
+	reportFormat := enumFlag("report", "", "Write a conversion report in the given format (md)", "md")
+	logPath := pathFlag("log", "", "Append a JSON lines record of each converted file to this path")
+	useCache := flag.Bool("cache", false, "Reuse converted files from ~/.cache/m2e when their content and settings are unchanged")
+	noCache := flag.Bool("no-cache", false, "Don't use the conversion cache, even with -cache")
+	verbose := flag.Bool("verbose", false, "Print per-file timing and rule counts to stderr")
+
+	// Hidden flag: prints a shell completion script (bash, zsh or fish)
+	completionShell := flag.String(completionFlag, "", "Print a shell completion script (bash, zsh or fish)")
 
 	help := flag.Bool("help", false, "Show help message")
 	helpShort := flag.Bool("h", false, "Show help message")
 
 	// Custom argument parsing to handle flags after positional arguments
 	var nonFlagArgs []string
-	args := os.Args[1:]
+	spellingSet := false // -spelling given, so it overrides config files
+	// The preset's flags go first so that the flags given alongside it override them
+	args, err := expandPreset(os.Args[1:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitUsage)
+	}
 
 	for i := 0; i < len(args); i++ {
 		arg := args[i]
+		if name, err := setFlag(arg); name != "" {
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(exitUsage)
+			}
+			if name == "spelling" {
+				spellingSet = true
+			}
+			continue
+		}
 		if strings.HasPrefix(arg, "-") {
 			// Handle flags with values
 			switch arg {
@@ -141,40 +442,241 @@ func main() {
 					}
 					i++ // Skip the value
 				}
-			case "-width":
+			case "-max-line-width", "-width":
 				if i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
-					// Parse width manually
-					i++ // Skip the value for now, flag.Parse() will handle it
+					n, err := strconv.Atoi(args[i+1])
+					if err != nil || n < 0 {
+						fmt.Fprintf(os.Stderr, "Error: %s expects a non-negative number, got %q\n", arg, args[i+1])
+						os.Exit(exitUsage)
+					}
+					*width = n
+					i++ // Skip the value
 				}
 			case "-size-max-kb":
 				if i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
-					// Parse size-max-kb manually
-					i++ // Skip the value for now, flag.Parse() will handle it
+					n, err := strconv.Atoi(args[i+1])
+					if err != nil || n < 0 {
+						fmt.Fprintf(os.Stderr, "Error: -size-max-kb expects a non-negative number, got %q\n", args[i+1])
+						os.Exit(exitUsage)
+					}
+					*maxFileSize = n
+					i++ // Skip the value
+				}
+			case "-input":
+				if i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+					*inputFile = args[i+1]
+					i++ // Skip the value
+				}
+			case "-stdin-filename":
+				if i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+					*stdinFilename = args[i+1]
+					i++ // Skip the value
+				}
+			case "-spelling":
+				if i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+					*spelling = args[i+1]
+					spellingSet = true
+					i++ // Skip the value
+				}
+			case "-dashes":
+				if i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+					*dashes = args[i+1]
+					i++ // Skip the value
+				}
+			case "-format":
+				if i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+					*inputFormat = args[i+1]
+					i++ // Skip the value
+				}
+			case "-json-keys":
+				if i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+					*jsonKeys = args[i+1]
+					i++ // Skip the value
+				}
+			case "-csv-columns":
+				if i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+					*csvColumns = args[i+1]
+					i++ // Skip the value
+				}
+			case "-md-elements":
+				if i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+					*mdElements = args[i+1]
+					i++ // Skip the value
+				}
+			case "-since":
+				if i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+					*since = args[i+1]
+					i++ // Skip the value
+				}
+			case "-ext", "-ext-exclude":
+				if i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+					list := &extInclude
+					if arg == "-ext-exclude" {
+						list = &extExclude
+					}
+					_ = list.Set(args[i+1])
+					i++ // Skip the value
+				}
+			case "-only-words":
+				if i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+					*onlyWords = args[i+1]
+					i++ // Skip the value
+				}
+			case "-output-dir":
+				if i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+					*outputDir = args[i+1]
+					i++ // Skip the value
+				}
+			case "-max-changes":
+				if i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+					n, err := strconv.Atoi(args[i+1])
+					if err != nil || n < 0 {
+						fmt.Fprintf(os.Stderr, "Error: -max-changes expects a non-negative number, got %q\n", args[i+1])
+						os.Exit(exitUsage)
+					}
+					*maxChanges = n
+					i++ // Skip the value
+				}
+			case "-stats-detail":
+				if i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+					*statsDetail = parseStatsDetail(args[i+1])
+					i++ // Skip the value
+				}
+			case "-stats-format":
+				if i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+					*statsFormat = args[i+1]
+					i++ // Skip the value
+				}
+			case "-report":
+				if i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+					*reportFormat = args[i+1]
+					i++ // Skip the value
+				}
+			case "-patch":
+				if i+1 < len(args) && (args[i+1] == "-" || !strings.HasPrefix(args[i+1], "-")) {
+					*patchPath = args[i+1]
+					i++ // Skip the value
+				}
+			case "-write-patch":
+				if i+1 < len(args) && (args[i+1] == "-" || !strings.HasPrefix(args[i+1], "-")) {
+					*writePatch = args[i+1]
+					i++ // Skip the value
+				}
+			case "-log":
+				if i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+					*logPath = args[i+1]
+					i++ // Skip the value
+				}
+			case "-completion":
+				if i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+					*completionShell = args[i+1]
+					i++ // Skip the value
 				}
 			case "-s":
 				*saveInPlaceShort = true
 			case "-units":
 				*convertUnits = true
+			case "-units-keep-original":
+				*unitsKeepOriginal = true
+			case "-units-cooking":
+				*unitsCooking = true
+			case "-normalise-unicode":
+				*normaliseUnicode = true
+			case "-normalise-units":
+				*normaliseUnits = true
+			case "-phrases":
+				*convertPhrases = true
 			case "-no-smart-quotes":
 				*noSmartQuotes = true
+			case "-no-contextual":
+				*noContextual = true
+			case "-convert-proper-nouns":
+				*convertProperNouns = true
+			case "-convert-urls":
+				*convertURLs = true
+			case "-preserve-identifiers":
+				*preserveIdentifiers = true
+			case "-regional":
+				*regional = true
+			case "-floors":
+				*floors = true
+			case "-quote-punctuation":
+				*quotePunctuation = true
+			case "-tidy-whitespace":
+				*tidyWhitespace = true
+			case "-cache":
+				*useCache = true
+			case "-no-cache":
+				*noCache = true
+			case "-verbose":
+				*verbose = true
 			case "-save":
 				*saveInPlace = true
 			case "-diff":
 				*showDiff = true
+			case "-diff-confidence":
+				diffConfidence = true
 			case "-diff-inline":
 				*showDiffInline = true
+			case "-diff-word":
+				*showDiffWord = true
 			case "-raw":
 				*showRaw = true
+			case "-raw-changes":
+				*showRawChanges = true
+			case "-explain":
+				*showExplain = true
+			case "-warnings":
+				*showWarnings = true
 			case "-stats":
 				*showStats = true
+			case "-suggest":
+				*suggestMode = true
+			case "-interactive":
+				*interactive = true
+			case "-list-contextual":
+				*listContextual = true
+			case "-print-config":
+				*printConfig = true
 			case "-exit-on-change":
 				*exitOnChange = true
+			case "-fail-fast":
+				*failFast = true
 			case "-rename":
 				*renameFiles = true
+			case "-git-diff":
+				*gitDiff = true
+			case "-rename-only":
+				*renameOnly = true
+			case "-watch":
+				*watch = true
+			case "-count-only":
+				*countOnly = true
+			case "-copy-all":
+				*copyAll = true
+			case "-convert-inline-code":
+				*convertInlineCode = true
+			case "-no-markdown":
+				*noMarkdown = true
+			case "-skip-frontmatter":
+				*skipFrontMatter = true
+			case "-only-comments":
+				*onlyComments = true
+			case "-all-text":
+				*allText = true
+			case "-include-hidden":
+				*includeHidden = true
 			case "-help", "--help":
 				*help = true
 			case "-h":
 				*helpShort = true
+			default:
+				// Boolean flags spelt with two dashes, such as --save; anything else is unknown
+				if !isBoolFlag(strings.TrimLeft(arg, "-")) {
+					fmt.Fprintf(os.Stderr, "Error: unknown flag %s\n", arg)
+					os.Exit(exitUsage)
+				}
+				_ = flag.Set(strings.TrimLeft(arg, "-"), "true")
 			}
 		} else {
 			nonFlagArgs = append(nonFlagArgs, arg)
@@ -187,7 +689,17 @@ func main() {
 
 	if *help || *helpShort {
 		printUsage()
-		os.Exit(0)
+		os.Exit(exitOK)
+	}
+
+	if *completionShell != "" {
+		script, err := generateCompletionScript(*completionShell, flag.CommandLine)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitUsage)
+		}
+		fmt.Print(script)
+		return
 	}
 
 	if os.Getenv("M2E_CLIPBOARD") == "1" || os.Getenv("M2E_CLIPBOARD") == "true" {
@@ -198,18 +710,187 @@ func main() {
 			return
 		}
 		fmt.Fprintf(os.Stderr, "Clipboard functionality is only supported on macOS.\n")
-		os.Exit(1)
+		os.Exit(exitUsage)
+	}
+
+	if *reportFormat != "" && *reportFormat != "md" {
+		fmt.Fprintf(os.Stderr, "Error: unsupported report format %q (supported: md)\n", *reportFormat)
+		os.Exit(exitUsage)
+	}
+	if !slices.Contains(statsFormats, *statsFormat) {
+		fmt.Fprintf(os.Stderr, "Error: unsupported stats format %q (supported: %s)\n", *statsFormat, strings.Join(statsFormats, ", "))
+		os.Exit(exitUsage)
+	}
+
+	spellingVariant, err := converter.ParseSpellingVariant(*spelling)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	dashMode, err := converter.ParseDashMode(*dashes)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	var markdownElements []converter.MarkdownElement
+	if *mdElements != "" {
+		if markdownElements, err = converter.ParseMarkdownElements(*mdElements); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitUsage)
+		}
+	}
+
+	if *inputFormat != "" && *inputFormat != "json" {
+		fmt.Fprintf(os.Stderr, "Error: unsupported format %q (supported: json)\n", *inputFormat)
+		os.Exit(exitUsage)
+	}
+	if *jsonKeys != "" && *inputFormat != "json" {
+		fmt.Fprintf(os.Stderr, "Error: -json-keys requires -format=json\n")
+		os.Exit(exitUsage)
+	}
+	if *onlyComments && *allText {
+		fmt.Fprintf(os.Stderr, "Error: -only-comments and -all-text cannot be used together\n")
+		os.Exit(exitUsage)
+	}
+	if (*onlyComments || *allText) && *inputFormat == "json" {
+		fmt.Fprintf(os.Stderr, "Error: -only-comments and -all-text cannot be used with -format=json\n")
+		os.Exit(exitUsage)
+	}
+	if (*onlyComments || *allText) && *csvColumns != "" {
+		fmt.Fprintf(os.Stderr, "Error: -only-comments and -all-text cannot be used with -csv-columns\n")
+		os.Exit(exitUsage)
+	}
+	extensionFilter = fileutil.ExtensionFilter{
+		Include: fileutil.ParseExtensions(string(extInclude)),
+		Exclude: fileutil.ParseExtensions(string(extExclude)),
+	}
+	if extInclude != "" && len(extensionFilter.Include) == 0 {
+		fmt.Fprintf(os.Stderr, "Error: -ext expects a comma-separated list of extensions, got %q\n", extInclude)
+		os.Exit(exitUsage)
+	}
+	findOptions = fileutil.FindOptions{IncludeHidden: *includeHidden}
+	if *since != "" {
+		if modifiedSince, err = parseSince(*since); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitUsage)
+		}
+	}
+
+	if *failFast && !*exitOnChange {
+		fmt.Fprintf(os.Stderr, "Error: -fail-fast requires -exit-on-change\n")
+		os.Exit(exitUsage)
+	}
+	if *failFast && (*saveInPlace || *saveInPlaceShort) {
+		fmt.Fprintf(os.Stderr, "Error: -fail-fast cannot be used with -save\n")
+		os.Exit(exitUsage)
 	}
 
 	// Initialize converter
 	conv, err := converter.NewConverter()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error initializing converter: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitConfig)
 	}
 
 	// Set unit processing based on flag
 	conv.SetUnitProcessingEnabled(*convertUnits)
+	if *normaliseUnits {
+		conv.SetUnitNormalisationEnabled(true)
+	}
+	if *unitsKeepOriginal {
+		conv.SetUnitKeepOriginal(true)
+	}
+	if *unitsCooking {
+		conv.SetUnitCookingMeasures(true)
+	}
+	if spellingSet {
+		conv.SetSpellingVariant(spellingVariant)
+	}
+	conv.SetContextualWordDetectionEnabled(!*noContextual)
+	conv.SetProperNounConversionEnabled(*convertProperNouns)
+	conv.SetURLConversionEnabled(*convertURLs)
+	conv.SetIdentifierConversionEnabled(!*preserveIdentifiers)
+	conv.SetRegionalWordsEnabled(*regional)
+	conv.SetFloorNumberingEnabled(*floors)
+	conv.SetQuotePunctuationEnabled(*quotePunctuation)
+	conv.SetTidyWhitespaceEnabled(*tidyWhitespace)
+	conv.SetMaxLineWidth(*width)
+	conv.SetDashMode(dashMode)
+	conv.SetUnicodeNormalisationEnabled(*normaliseUnicode)
+	conv.SetExplainEnabled(*showExplain || diffConfidence)
+	if *showWarnings {
+		conv.SetAmbiguityWarningsEnabled(true) // the contextual word config may have enabled them already
+	}
+	conv.SetCountersEnabled(*verbose)
+	conv.SetPhraseProcessingEnabled(*convertPhrases)
+	conv.SetSkipFrontMatter(*skipFrontMatter)
+	conv.SetMarkdownElements(markdownElements)
+	conv.SetConvertInlineCode(*convertInlineCode)
+	conv.SetMarkdownProcessingEnabled(!*noMarkdown)
+	conv.SetJSONValuesOnly(*inputFormat == "json")
+	if *onlyComments {
+		conv.SetContentMode(converter.ContentModeCommentsOnly)
+	} else if *allText {
+		conv.SetContentMode(converter.ContentModeAllText)
+	}
+	if *csvColumns != "" {
+		conv.SetCSVColumns(strings.Split(*csvColumns, ","))
+	}
+	if err := conv.SetJSONKeyPattern(*jsonKeys); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitUsage)
+	}
+	if *onlyWords != "" {
+		if err := conv.SetWordAllowlist(strings.Split(*onlyWords, ",")); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitUsage)
+		}
+	}
+
+	// Files use the nearest .m2e.json; flags given on the command line still win
+	conv.EnableProjectConfig(func(c *converter.Converter) {
+		if *convertUnits {
+			c.SetUnitProcessingEnabled(true)
+		}
+		if *normaliseUnits {
+			c.SetUnitNormalisationEnabled(true)
+		}
+		if *unitsKeepOriginal {
+			c.SetUnitKeepOriginal(true)
+		}
+		if *unitsCooking {
+			c.SetUnitCookingMeasures(true)
+		}
+		if spellingSet {
+			c.SetSpellingVariant(spellingVariant)
+		}
+	})
+
+	// Open the conversion log up front so a bad path fails before any file is touched
+	var convLog *report.ConversionLog
+	if *logPath != "" {
+		convLog, err = report.OpenConversionLog(*logPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitIO)
+		}
+		defer convLog.Close()
+	}
+
+	if *useCache && !*noCache {
+		cacheDir, err := cache.DefaultDir()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Not caching conversions: %v\n", err)
+		} else {
+			conversionCache = cache.New(cacheDir)
+		}
+	}
+
+	if *verbose {
+		verboseOutput = os.Stderr
+	}
 
 	// Determine smart quotes setting (default is true, disable if flag is set)
 	normaliseSmartQuotes := !*noSmartQuotes
@@ -222,10 +903,270 @@ func main() {
 		finalOutputFile = outputFileLong
 	}
 
+	if *listContextual {
+		fmt.Print(converter.FormatContextualRules(conv.ContextualRules()))
+		return
+	}
+
+	if *printConfig {
+		handlePrintConfig(conv, flag.Args())
+		return
+	}
+
+	if *watch {
+		if *showDiff || *showDiffInline || *showDiffWord || *showRaw || *showRawChanges || *showExplain || *showStats || finalOutputFile != "" ||
+			*reportFormat != "" || *gitDiff || *outputDir != "" || *renameFiles || *exitOnChange ||
+			*suggestMode || *interactive || *renameOnly || *writePatch != "" || *countOnly || *statsFormat != "human" {
+			fmt.Fprintf(os.Stderr, "Error: -watch can only be combined with -save\n")
+			os.Exit(exitUsage)
+		}
+		// Saving a watched file changes it, so it's converted again, and floor numbering isn't
+		// idempotent: each pass would renumber the floors once more
+		if *floors && (*saveInPlace || *saveInPlaceShort) {
+			fmt.Fprintf(os.Stderr, "Error: -floors can't be used with -watch -save, as each save would renumber the floors again\n")
+			os.Exit(exitUsage)
+		}
+
+		paths := flag.Args()
+		if len(paths) == 0 && *inputFile != "" {
+			paths = []string{*inputFile}
+		}
+		if len(paths) == 0 {
+			fmt.Fprintf(os.Stderr, "Error: -watch requires file or directory paths\n")
+			os.Exit(exitUsage)
+		}
+		for _, path := range paths {
+			if _, err := os.Stat(path); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: -watch requires file or directory paths: %v\n", err)
+				os.Exit(exitIO)
+			}
+		}
+
+		if err := handleWatch(paths, conv, normaliseSmartQuotes, *saveInPlace || *saveInPlaceShort, *maxFileSize, *maxChanges, convLog); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitCodeFor(err))
+		}
+		return
+	}
+
+	if *countOnly {
+		if *showDiff || *showDiffInline || *showDiffWord || *showRaw || *showRawChanges || *showExplain || *showStats || *saveInPlace || *saveInPlaceShort ||
+			finalOutputFile != "" || *reportFormat != "" || *gitDiff || *outputDir != "" || *renameFiles ||
+			*suggestMode || *interactive || *renameOnly || *writePatch != "" || *statsFormat != "human" {
+			fmt.Fprintf(os.Stderr, "Error: -count-only can only be combined with -exit-on-change\n")
+			os.Exit(exitUsage)
+		}
+
+		paths := flag.Args()
+		if len(paths) == 0 && *inputFile != "" {
+			paths = []string{*inputFile}
+		}
+		if len(paths) == 0 {
+			fmt.Fprintf(os.Stderr, "Error: -count-only requires file or directory paths\n")
+			os.Exit(exitUsage)
+		}
+		for _, path := range paths {
+			if _, err := os.Stat(path); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: -count-only requires file or directory paths: %v\n", err)
+				os.Exit(exitIO)
+			}
+		}
+
+		if err := handleCountOnly(paths, conv, normaliseSmartQuotes, *exitOnChange, *maxFileSize); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitCodeFor(err))
+		}
+		return
+	}
+
+	// CSV and JSON stats are a table of per-file counts, which -stats is implied by
+	if *statsFormat != "human" {
+		if *showDiff || *showDiffInline || *showDiffWord || *showRaw || *showRawChanges || *showExplain || *saveInPlace || *saveInPlaceShort ||
+			finalOutputFile != "" || *reportFormat != "" || *gitDiff || *outputDir != "" || *renameFiles ||
+			*suggestMode || *interactive || *renameOnly || *writePatch != "" {
+			fmt.Fprintf(os.Stderr, "Error: -stats-format %s can only be combined with -stats and -exit-on-change\n", *statsFormat)
+			os.Exit(exitUsage)
+		}
+
+		paths := flag.Args()
+		if len(paths) == 0 && *inputFile != "" {
+			paths = []string{*inputFile}
+		}
+		if len(paths) == 0 {
+			fmt.Fprintf(os.Stderr, "Error: -stats-format %s requires file or directory paths\n", *statsFormat)
+			os.Exit(exitUsage)
+		}
+		for _, path := range paths {
+			if _, err := os.Stat(path); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: -stats-format %s requires file or directory paths: %v\n", *statsFormat, err)
+				os.Exit(exitIO)
+			}
+		}
+
+		if err := handleStatsTable(paths, conv, normaliseSmartQuotes, *statsFormat, *exitOnChange, *maxFileSize, convLog); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitCodeFor(err))
+		}
+		return
+	}
+
+	if *suggestMode {
+		if *showDiff || *showDiffInline || *showDiffWord || *showRaw || *showRawChanges || *showExplain || *showStats || *saveInPlace || *saveInPlaceShort ||
+			finalOutputFile != "" || *reportFormat != "" || *renameOnly {
+			fmt.Fprintf(os.Stderr, "Error: -suggest cannot be used with output mode flags\n")
+			os.Exit(exitUsage)
+		}
+
+		sources, err := collectSuggestionSources(flag.Args(), *inputFile, *maxFileSize)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitIO)
+		}
+		if handleSuggest(sources, conv) > 0 && *exitOnChange {
+			os.Exit(exitChanges)
+		}
+		return
+	}
+
+	if *interactive {
+		if *showDiff || *showDiffInline || *showDiffWord || *showRaw || *showRawChanges || *showExplain || *showStats || *saveInPlace || *saveInPlaceShort ||
+			finalOutputFile != "" || *reportFormat != "" || *renameOnly || *gitDiff || *outputDir != "" {
+			fmt.Fprintf(os.Stderr, "Error: -interactive cannot be used with output mode flags\n")
+			os.Exit(exitUsage)
+		}
+
+		paths := flag.Args()
+		if len(paths) == 0 && *inputFile != "" {
+			paths = []string{*inputFile}
+		}
+		if len(paths) == 0 {
+			fmt.Fprintf(os.Stderr, "Error: -interactive requires a file or directory path\n")
+			os.Exit(exitUsage)
+		}
+
+		if err := handleInteractive(paths, conv, normaliseSmartQuotes, *maxFileSize); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitCodeFor(err))
+		}
+		return
+	}
+
+	if *renameOnly {
+		if *showDiff || *showDiffInline || *showDiffWord || *showRaw || *showRawChanges || *showExplain || *showStats || finalOutputFile != "" || *reportFormat != "" {
+			fmt.Fprintf(os.Stderr, "Error: -rename-only can only be combined with -save and -exit-on-change\n")
+			os.Exit(exitUsage)
+		}
+
+		paths := flag.Args()
+		if len(paths) == 0 && *inputFile != "" {
+			paths = []string{*inputFile}
+		}
+		if len(paths) == 0 {
+			fmt.Fprintf(os.Stderr, "Error: -rename-only requires a file or directory path\n")
+			os.Exit(exitUsage)
+		}
+
+		if err := handleRenameOnly(paths, conv, *saveInPlace || *saveInPlaceShort, *exitOnChange); err != nil {
+			fmt.Fprintf(os.Stderr, "Error renaming files: %v\n", err)
+			os.Exit(exitCodeFor(err))
+		}
+		return
+	}
+
+	if *writePatch != "" {
+		if *showDiff || *showDiffInline || *showDiffWord || *showRaw || *showRawChanges || *showExplain || *showStats || *saveInPlace || *saveInPlaceShort ||
+			finalOutputFile != "" || *reportFormat != "" || *gitDiff || *outputDir != "" || *renameFiles {
+			fmt.Fprintf(os.Stderr, "Error: -write-patch cannot be used with -o, -save, -report, -git-diff, -output-dir, -rename or output mode flags\n")
+			os.Exit(exitUsage)
+		}
+
+		paths := flag.Args()
+		if len(paths) == 0 && *inputFile != "" {
+			paths = []string{*inputFile}
+		}
+		if len(paths) == 0 {
+			fmt.Fprintf(os.Stderr, "Error: -write-patch requires file or directory paths\n")
+			os.Exit(exitUsage)
+		}
+		for _, path := range paths {
+			if _, err := os.Stat(path); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: -write-patch requires file or directory paths: %v\n", err)
+				os.Exit(exitIO)
+			}
+		}
+
+		if err := handleWritePatch(paths, *writePatch, conv, normaliseSmartQuotes, *exitOnChange, *maxFileSize, convLog); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitCodeFor(err))
+		}
+		return
+	}
+
+	if *patchPath != "" && !*gitDiff {
+		fmt.Fprintf(os.Stderr, "Error: -patch requires -git-diff\n")
+		os.Exit(exitUsage)
+	}
+
+	if diffConfidence && !*showDiff {
+		fmt.Fprintf(os.Stderr, "Error: -diff-confidence requires -diff\n")
+		os.Exit(exitUsage)
+	}
+
+	if *gitDiff {
+		if *showDiffInline || *showDiffWord || *showRaw || *showExplain || *renameFiles ||
+			finalOutputFile != "" || *reportFormat != "" || *outputDir != "" {
+			fmt.Fprintf(os.Stderr, "Error: -git-diff can only be combined with -diff, -raw-changes, -stats, -save and -exit-on-change\n")
+			os.Exit(exitUsage)
+		}
+		save := *saveInPlace || *saveInPlaceShort
+		modes := 0
+		for _, set := range []bool{*showDiff, *showRawChanges, *showStats, save} {
+			if set {
+				modes++
+			}
+		}
+		if modes > 1 {
+			fmt.Fprintf(os.Stderr, "Error: Only one output mode flag can be specified at a time\n")
+			os.Exit(exitUsage)
+		}
+		if *patchPath != "" && flag.NArg() > 0 {
+			fmt.Fprintf(os.Stderr, "Error: -patch cannot be combined with git diff arguments\n")
+			os.Exit(exitUsage)
+		}
+
+		patch, err := readPatch(*patchPath, flag.Args())
+		if err == nil {
+			err = handleGitDiff(patch, conv, normaliseSmartQuotes, *showDiff, *showRawChanges, *showStats, save, *exitOnChange, *maxFileSize, *statsDetail)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitCodeFor(err))
+		}
+		return
+	}
+
+	if *copyAll && *outputDir == "" {
+		fmt.Fprintf(os.Stderr, "Error: -copy-all requires -output-dir\n")
+		os.Exit(exitUsage)
+	}
+
+	if *outputDir != "" {
+		if *showDiff || *showDiffInline || *showDiffWord || *showRaw || *showRawChanges || *showExplain || *showStats || *saveInPlace || *saveInPlaceShort ||
+			finalOutputFile != "" || *reportFormat != "" {
+			fmt.Fprintf(os.Stderr, "Error: -output-dir cannot be used with -o, -save, -report or output mode flags\n")
+			os.Exit(exitUsage)
+		}
+		if flag.NArg() > 1 {
+			fmt.Fprintf(os.Stderr, "Error: -output-dir requires a single directory input\n")
+			os.Exit(exitUsage)
+		}
+	}
+
 	// Determine input source with improved logic
 	var inputPath string
 	var isDirectText bool
 	var inputText string
+	var isStdin bool
 
 	// Check if there are non-flag arguments (direct text input or file/directory path)
 	if flag.NArg() > 0 {
@@ -252,13 +1193,23 @@ func main() {
 				}
 			}
 
+			if allFilesValid && *reportFormat != "" {
+				err = handleMarkdownReport(collectReportResults(flag.Args(), conv, normaliseSmartQuotes, *maxFileSize, convLog),
+					finalOutputFile, *exitOnChange)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error writing report: %v\n", err)
+					os.Exit(exitCodeFor(err))
+				}
+				return
+			}
+
 			if allFilesValid {
 				// All arguments are valid files - process them as multiple files
 				err = handleMultipleFiles(flag.Args(), conv, normaliseSmartQuotes, finalOutputFile,
-					*showDiff, *showDiffInline, *showRaw, *showStats, (*saveInPlace || *saveInPlaceShort), *exitOnChange, *width, *maxFileSize)
+					*showDiff, *showDiffInline, *showDiffWord, *showRaw, *showRawChanges, *showExplain, *showStats, (*saveInPlace || *saveInPlaceShort), *exitOnChange, *width, *maxFileSize, *statsDetail, *maxChanges, convLog)
 				if err != nil {
 					fmt.Fprintf(os.Stderr, "Error processing files: %v\n", err)
-					os.Exit(1)
+					os.Exit(exitCodeFor(err))
 				}
 				return // Exit early after processing multiple files
 			} else {
@@ -276,17 +1227,32 @@ func main() {
 		if (stat.Mode() & os.ModeCharDevice) != 0 {
 			// No piped input and no arguments - show usage
 			printUsage()
-			os.Exit(1)
+			os.Exit(exitUsage)
 		}
 
 		// Read from stdin
 		inputBytes, err := io.ReadAll(os.Stdin)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error reading from stdin: %v\n", err)
-			os.Exit(1)
+			os.Exit(exitIO)
 		}
 		inputText = string(inputBytes)
 		isDirectText = true
+		isStdin = true
+	}
+
+	if *outputDir != "" {
+		if isDirectText {
+			fmt.Fprintf(os.Stderr, "Error: -output-dir requires a directory input\n")
+			os.Exit(exitUsage)
+		}
+
+		err = handleOutputDir(inputPath, *outputDir, conv, normaliseSmartQuotes, *copyAll, *renameFiles, *exitOnChange, *maxFileSize, *maxChanges, convLog)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error processing files: %v\n", err)
+			os.Exit(exitCodeFor(err))
+		}
+		return
 	}
 
 	// Determine output mode
@@ -297,9 +1263,18 @@ func main() {
 	if *showDiffInline {
 		outputModeCount++
 	}
+	if *showDiffWord {
+		outputModeCount++
+	}
 	if *showRaw {
 		outputModeCount++
 	}
+	if *showRawChanges {
+		outputModeCount++
+	}
+	if *showExplain {
+		outputModeCount++
+	}
 	if *showStats {
 		outputModeCount++
 	}
@@ -313,59 +1288,96 @@ func main() {
 
 	if outputModeCount > 1 {
 		fmt.Fprintf(os.Stderr, "Error: Only one output mode flag can be specified at a time\n")
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	// Check for incompatible combinations
 	if finalOutputFile != "" && outputModeCount > 0 {
 		fmt.Fprintf(os.Stderr, "Error: Output file (-o) cannot be used with output mode flags\n")
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	// Check if save flag is used with text input (not allowed)
 	if (*saveInPlace || *saveInPlaceShort) && isDirectText {
 		fmt.Fprintf(os.Stderr, "Error: -save flag can only be used with file input, not text input or stdin\n")
-		os.Exit(1)
+		os.Exit(exitUsage)
+	}
+
+	if *reportFormat != "" {
+		if outputModeCount > 0 {
+			fmt.Fprintf(os.Stderr, "Error: -report cannot be used with output mode flags\n")
+			os.Exit(exitUsage)
+		}
+
+		var results []report.FileResult
+		if isDirectText {
+			textFilename := ""
+			if isStdin {
+				textFilename = *stdinFilename
+			}
+			if textFilename == "" && *inputFormat == "json" {
+				textFilename = "input.json"
+			} else if textFilename == "" && *csvColumns != "" {
+				textFilename = "input.csv"
+			}
+			results = []report.FileResult{convertTextForReport(inputText, textFilename, conv, normaliseSmartQuotes)}
+		} else {
+			results = collectReportResults([]string{inputPath}, conv, normaliseSmartQuotes, *maxFileSize, convLog)
+		}
+
+		if err := handleMarkdownReport(results, finalOutputFile, *exitOnChange); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing report: %v\n", err)
+			os.Exit(exitCodeFor(err))
+		}
+		return
 	}
 
 	// Handle different input types
 	if isDirectText {
 		// Handle direct text input (single string or stdin)
-		err = handleSingleText(inputText, conv, normaliseSmartQuotes, finalOutputFile,
-			*showDiff, *showDiffInline, *showRaw, *showStats, (*saveInPlace || *saveInPlaceShort), *exitOnChange, *width)
+		textFilename := ""
+		if isStdin {
+			textFilename = *stdinFilename
+		}
+		if textFilename == "" && *inputFormat == "json" {
+			// Text input has no extension to infer JSON from
+			textFilename = "input.json"
+		} else if textFilename == "" && *csvColumns != "" {
+			textFilename = "input.csv"
+		}
+		err = handleSingleText(inputText, textFilename, conv, normaliseSmartQuotes, finalOutputFile,
+			*showDiff, *showDiffInline, *showDiffWord, *showRaw, *showRawChanges, *showExplain, *showStats, (*saveInPlace || *saveInPlaceShort), *exitOnChange, *width, *statsDetail)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error processing text: %v\n", err)
-			os.Exit(1)
+			os.Exit(exitCodeFor(err))
 		}
 	} else {
 		// Handle file or directory input
 		// Use max file size flag
 		finalMaxFileSize := *maxFileSize
 		err = handleFileOrDirectory(inputPath, conv, normaliseSmartQuotes, finalOutputFile,
-			*showDiff, *showDiffInline, *showRaw, *showStats, (*saveInPlace || *saveInPlaceShort), *exitOnChange, *renameFiles, *width, finalMaxFileSize)
+			*showDiff, *showDiffInline, *showDiffWord, *showRaw, *showRawChanges, *showExplain, *showStats, (*saveInPlace || *saveInPlaceShort), *exitOnChange, *failFast, *renameFiles, *width, finalMaxFileSize, *statsDetail, *maxChanges, convLog)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error processing files: %v\n", err)
-			if *exitOnChange {
-				os.Exit(1)
-			} else {
-				os.Exit(2)
-			}
+			os.Exit(exitCodeFor(err))
 		}
 	}
 }
 
-// handleSingleText processes a single text input (direct text or stdin)
-func handleSingleText(inputText string, conv *converter.Converter, normaliseSmartQuotes bool,
-	outputFile string, showDiff, showDiffInline, showRaw, showStats, saveInPlace, exitOnChange bool, width int) error {
+// handleSingleText processes a single text input (direct text or stdin).
+// If filename is set, it is used to infer the content type so code only has its comments converted.
+func handleSingleText(inputText, filename string, conv *converter.Converter, normaliseSmartQuotes bool,
+	outputFile string, showDiff, showDiffInline, showDiffWord, showRaw, showRawChanges, showExplain, showStats, saveInPlace, exitOnChange bool, width, statsDetail int) error {
 
-	convertedText := conv.ConvertToBritish(inputText, normaliseSmartQuotes)
+	convertedText := convertText(conv, inputText, filename, normaliseSmartQuotes)
+	explanations := conv.TakeExplanations()
 
 	// Check if any changes were made
 	hasChanges := inputText != convertedText
 
 	// Exit early if exitOnChange is set and changes were detected
 	if exitOnChange && hasChanges {
-		defer os.Exit(1)
+		defer os.Exit(exitChanges)
 	}
 
 	// If output file is specified, write converted text and exit
@@ -383,20 +1395,34 @@ func handleSingleText(inputText string, conv *converter.Converter, normaliseSmar
 
 	// Handle specific output modes
 	if showDiff {
-		return showDiffOutput(inputText, convertedText, "stdin", false)
+		return showUnifiedDiff(inputText, convertedText, "stdin", explanations)
 	}
 
 	if showDiffInline {
 		return showDiffOutput(inputText, convertedText, "stdin", true)
 	}
 
+	if showDiffWord {
+		return showWordDiffOutput(inputText, convertedText)
+	}
+
 	if showRaw {
 		fmt.Print(convertedText)
 		return nil
 	}
 
+	if showRawChanges {
+		fmt.Print(formatChangedLines(inputText, convertedText))
+		return nil
+	}
+
+	if showExplain {
+		fmt.Print(formatExplanations(explanations))
+		return nil
+	}
+
 	if showStats {
-		return showStatsOutput(stats)
+		return showStatsOutputWithDetail(stats, statsDetail)
 	}
 
 	// Default mode: show diff + processed output + stats
@@ -420,6 +1446,92 @@ func handleSingleText(inputText string, conv *converter.Converter, normaliseSmar
 	return showStatsOutput(stats)
 }
 
+// convertTextForReport converts text input (direct text or stdin) into a report result
+func convertTextForReport(inputText, filename string, conv *converter.Converter, normaliseSmartQuotes bool) report.FileResult {
+	convertedText := convertText(conv, inputText, filename, normaliseSmartQuotes)
+
+	analyser := report.NewAnalyser(conv.GetAmericanToBritishDictionary())
+	return report.FileResult{
+		FilePath:   "stdin",
+		Original:   inputText,
+		Converted:  convertedText,
+		Stats:      analyser.AnalyseChanges(inputText, convertedText),
+		HasChanges: inputText != convertedText,
+	}
+}
+
+// collectReportResults converts the given files, expanding directories to the text files they contain
+func collectReportResults(paths []string, conv *converter.Converter, normaliseSmartQuotes bool, maxFileSize int,
+	convLog *report.ConversionLog) []report.FileResult {
+	var results []report.FileResult
+	analyser := report.NewAnalyser(conv.GetAmericanToBritishDictionary())
+
+	addFile := func(filePath, displayPath string) {
+		content, err := fileutil.ReadFileContentWithMaxSize(filePath, maxFileSize)
+		if err != nil {
+			results = append(results, report.FileResult{FilePath: displayPath, Error: err})
+			return
+		}
+
+		convertedContent, stats := convertFileWithStats(conv, analyser, content, filePath, normaliseSmartQuotes)
+		logConversion(convLog, filePath, stats, false)
+		results = append(results, report.FileResult{
+			FilePath:   displayPath,
+			Original:   content,
+			Converted:  convertedContent,
+			Stats:      stats,
+			HasChanges: content != convertedContent,
+		})
+	}
+
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			results = append(results, report.FileResult{FilePath: path, Error: err})
+			continue
+		}
+
+		if !info.IsDir() {
+			addFile(path, path)
+			continue
+		}
+
+		files, err := findTextFiles(path)
+		if err != nil {
+			results = append(results, report.FileResult{FilePath: path, Error: err})
+			continue
+		}
+		for _, file := range files {
+			addFile(file.Path, filepath.Join(path, file.RelativePath))
+		}
+	}
+
+	return results
+}
+
+// handleMarkdownReport writes a Markdown conversion report to stdout or outputFile
+func handleMarkdownReport(results []report.FileResult, outputFile string, exitOnChange bool) error {
+	markdown := report.FormatMarkdownReport(results)
+
+	if outputFile != "" {
+		if err := os.WriteFile(outputFile, []byte(markdown), 0644); err != nil {
+			return fmt.Errorf("failed to write report to %s: %w", outputFile, err)
+		}
+	} else {
+		fmt.Print(markdown)
+	}
+
+	if exitOnChange {
+		for _, result := range results {
+			if result.HasChanges {
+				os.Exit(exitChanges)
+			}
+		}
+	}
+
+	return nil
+}
+
 // showDiffOutput displays diff of changes
 func showDiffOutput(original, converted, filename string, inline bool) error {
 	if original == converted {
@@ -427,7 +1539,7 @@ func showDiffOutput(original, converted, filename string, inline bool) error {
 	}
 
 	// Use unified diff format
-	diff := createUnifiedDiff(original, converted, filename, inline)
+	diff := report.UnifiedDiff(original, converted, filename, inline)
 	fmt.Print(diff)
 	return nil
 }
@@ -437,6 +1549,76 @@ func showStatsOutput(stats report.ChangeStats) error {
 	return showStatsOutputWithMode(stats, false)
 }
 
+// showStatsOutputWithDetail displays conversion statistics followed by the limit most frequent
+// substitutions, grouped by category
+func showStatsOutputWithDetail(stats report.ChangeStats, limit int) error {
+	if err := showStatsOutput(stats); err != nil {
+		return err
+	}
+	if limit <= 0 || len(stats.ChangeDetails) == 0 {
+		return nil
+	}
+
+	fmt.Println("----- Top Changes -----")
+	for i, change := range stats.ChangeDetails {
+		if i >= limit {
+			fmt.Printf("  ... and %d more\n", len(stats.ChangeDetails)-limit)
+			break
+		}
+		fmt.Printf("  %d × %s → %s (%s)\n", change.Count, change.Original, change.Converted, change.Category)
+	}
+	return nil
+}
+
+// setFlag sets the flag named by an argument of the form -name=value (or --name=value),
+// returning the flag's name. It returns "" if arg isn't in that form, and an error if the flag
+// doesn't exist or the value isn't valid for it. Number flags can't be negative.
+func setFlag(arg string) (string, error) {
+	if !strings.HasPrefix(arg, "-") {
+		return "", nil
+	}
+	name, value, ok := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+	if !ok {
+		return "", nil
+	}
+	f := flag.Lookup(name)
+	if f == nil {
+		return name, usageErrorf("unknown flag -%s", name)
+	}
+	if err := f.Value.Set(value); err != nil {
+		if isBoolFlag(name) {
+			return name, usageErrorf("invalid value %q for -%s: expected true or false", value, name)
+		}
+		return name, usageErrorf("invalid value %q for -%s", value, name)
+	}
+	if getter, ok := f.Value.(flag.Getter); ok {
+		if n, ok := getter.Get().(int); ok && n < 0 {
+			return name, usageErrorf("-%s expects a non-negative number, got %q", name, value)
+		}
+	}
+	return name, nil
+}
+
+// isBoolFlag reports whether name is a registered boolean flag
+func isBoolFlag(name string) bool {
+	f := flag.Lookup(name)
+	if f == nil {
+		return false
+	}
+	bf, ok := f.Value.(interface{ IsBoolFlag() bool })
+	return ok && bf.IsBoolFlag()
+}
+
+// parseStatsDetail parses the -stats-detail value, exiting on anything but a non-negative integer
+func parseStatsDetail(value string) int {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		fmt.Fprintf(os.Stderr, "Error: -stats-detail expects a non-negative number, got %q\n", value)
+		os.Exit(exitUsage)
+	}
+	return n
+}
+
 // showStatsOutputWithMode displays conversion statistics with context-aware wording
 func showStatsOutputWithMode(stats report.ChangeStats, savedChanges bool) error {
 	if savedChanges {
@@ -463,65 +1645,110 @@ func showStatsOutputWithMode(stats report.ChangeStats, savedChanges bool) error
 	return nil
 }
 
-// createUnifiedDiff creates a proper unified diff using the diffmatchpatch library
-func createUnifiedDiff(original, converted, filename string, inline bool) string {
-	dmp := diffmatchpatch.New()
+// showWordDiffOutput displays a word-level inline diff of changes
+func showWordDiffOutput(original, converted string) error {
+	if original == converted {
+		return nil // No changes to show
+	}
 
-	// Create a proper unified diff
-	diffs := dmp.DiffMain(original, converted, false)
+	fmt.Print(report.WordDiff(original, converted))
+	return nil
+}
 
-	if inline {
-		// Character-level inline diff with colours
-		return dmp.DiffPrettyText(diffs)
-	} else {
-		// Line-based unified diff format (patch compatible)
-		return createLineBasedUnifiedDiff(original, converted, filename)
+// formatChangedLines lists the converted lines that changed for -raw-changes, one per line as
+// "number:line" like grep -n
+func formatChangedLines(original, converted string) string {
+	var result strings.Builder
+	for _, change := range report.ChangedLines(original, converted) {
+		fmt.Fprintf(&result, "%d:%s\n", change.Number, change.Converted)
 	}
+	return result.String()
 }
 
-// createLineBasedUnifiedDiff creates a simple line-based diff showing only lines with actual changes
-func createLineBasedUnifiedDiff(original, converted, filename string) string {
-	originalLines := strings.Split(original, "\n")
-	convertedLines := strings.Split(converted, "\n")
-
-	var result strings.Builder
-	fmt.Fprintf(&result, "--- %s\n", filename+".orig")
-	fmt.Fprintf(&result, "+++ %s\n", filename)
+// formatExplanations lists each change for -explain, one per line as "original → converted (rule)"
+func formatExplanations(explanations []converter.Explanation) string {
+	if len(explanations) == 0 {
+		return "No changes\n"
+	}
+	var b strings.Builder
+	for _, e := range explanations {
+		b.WriteString(converter.FormatExplanation(e))
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
 
-	hasAnyChanges := false
+// convertText converts text input (direct text or stdin). If filename is set it is used to infer
+// the content type, so code only has its comments converted; -only-comments and -all-text
+// override it. With -warnings, the judgement calls made converting it are printed to stderr.
+func convertText(conv *converter.Converter, text, filename string, normaliseSmartQuotes bool) string {
+	var converted string
+	switch {
+	case filename != "" || conv.GetContentMode() != converter.ContentModeAuto:
+		converted = conv.ConvertFileContent(text, filename, normaliseSmartQuotes)
+	default:
+		converted = conv.ReflowFile(text, conv.FilterMarkdownElements(text, conv.ConvertToBritish(text, normaliseSmartQuotes)), "")
+	}
+	printAmbiguityWarnings(conv, "stdin", text)
+	return converted
+}
 
-	// Compare original and converted lines directly
-	lineCount := max(len(originalLines), len(convertedLines))
+// convertFile converts the content of a file with ConvertFileContent, which chooses what to
+// convert from the file's type: prose files are converted in full, code and config files only
+// have their comments converted, and structured formats only their text. -only-comments and
+// -all-text override this routing, and with -max-line-width the paragraphs of Markdown and plain
+// text files that conversion changes are re-wrapped. Settings from the nearest .m2e.json apply,
+// and anything left unconverted because it couldn't be parsed is reported as a warning.
+func convertFile(conv *converter.Converter, content, filePath string, normaliseSmartQuotes bool) string {
+	conv, err := conv.ForFile(filePath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: Ignoring project config: %v\n", err)
+	}
 
-	for i := 0; i < lineCount; i++ {
-		var origLine, convLine string
-		if i < len(originalLines) {
-			origLine = originalLines[i]
-		}
-		if i < len(convertedLines) {
-			convLine = convertedLines[i]
-		}
+	converted, warnings := conv.ConvertFileContentWithWarnings(content, filePath, normaliseSmartQuotes)
+	for _, warning := range warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s: %s\n", filePath, warning)
+	}
+	return converted
+}
 
-		if origLine != convLine {
-			hasAnyChanges = true
-			lineNum := i + 1
-			fmt.Fprintf(&result, "@@ -%d,1 +%d,1 @@\n", lineNum, lineNum)
-			fmt.Fprintf(&result, "-%s\n", origLine)
-			fmt.Fprintf(&result, "+%s\n", convLine)
-		}
+// convertDocxFile converts a Word document, returning its text before and after conversion, for
+// diffs and statistics, and the converted document to write. Settings from the nearest
+// .m2e.json apply.
+func convertDocxFile(conv *converter.Converter, content, filePath string, normaliseSmartQuotes bool) (originalText, convertedText, document string, err error) {
+	conv, err = conv.ForFile(filePath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: Ignoring project config: %v\n", err)
 	}
 
-	// If no changes found, return empty string
-	if !hasAnyChanges {
-		return ""
+	converted, err := conv.ConvertDocx([]byte(content), normaliseSmartQuotes)
+	if err != nil {
+		return "", "", "", fmt.Errorf("%s: %w", filePath, err)
+	}
+	if originalText, err = conv.ExtractDocxText([]byte(content)); err != nil {
+		return "", "", "", fmt.Errorf("%s: %w", filePath, err)
+	}
+	if convertedText, err = conv.ExtractDocxText(converted); err != nil {
+		return "", "", "", fmt.Errorf("%s: %w", filePath, err)
 	}
+	return originalText, convertedText, string(converted), nil
+}
 
-	return result.String()
+// logConversion appends a record of the conversion of filePath to the -log file, if one is open.
+// Failing to log is only a warning, as the conversion itself has already happened.
+func logConversion(convLog *report.ConversionLog, filePath string, stats report.ChangeStats, written bool) {
+	if convLog == nil {
+		return
+	}
+	if err := convLog.Record(report.NewLogRecord("cli", filePath, stats, written)); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
 }
 
 // handleFileOrDirectory processes file or directory input
 func handleFileOrDirectory(inputPath string, conv *converter.Converter, normaliseSmartQuotes bool,
-	outputFile string, showDiff, showDiffInline, showRaw, showStats, saveInPlace, exitOnChange, renameFiles bool, width, maxFileSize int) error {
+	outputFile string, showDiff, showDiffInline, showDiffWord, showRaw, showRawChanges, showExplain, showStats, saveInPlace, exitOnChange, failFast, renameFiles bool, width, maxFileSize, statsDetail, maxChanges int,
+	convLog *report.ConversionLog) error {
 
 	// Check if input is a directory or file
 	info, err := os.Stat(inputPath)
@@ -532,17 +1759,21 @@ func handleFileOrDirectory(inputPath string, conv *converter.Converter, normalis
 	if info.IsDir() {
 		// Directory processing
 		return handleDirectory(inputPath, conv, normaliseSmartQuotes, outputFile,
-			showDiff, showDiffInline, showRaw, showStats, saveInPlace, exitOnChange, renameFiles, width, maxFileSize)
+			showDiff, showDiffInline, showDiffWord, showRaw, showRawChanges, showExplain, showStats, saveInPlace, exitOnChange, failFast, renameFiles, width, maxFileSize, statsDetail, maxChanges, convLog)
+	} else if fileutil.IsArchiveFile(inputPath) {
+		return handleArchive(inputPath, conv, normaliseSmartQuotes, outputFile,
+			showDiff, showDiffInline, showDiffWord, showRaw, showRawChanges, showExplain, showStats, saveInPlace, exitOnChange, maxFileSize, statsDetail, maxChanges, convLog)
 	} else {
 		// Single file processing
 		return handleSingleFile(inputPath, conv, normaliseSmartQuotes, outputFile,
-			showDiff, showDiffInline, showRaw, showStats, saveInPlace, exitOnChange, width, maxFileSize)
+			showDiff, showDiffInline, showDiffWord, showRaw, showRawChanges, showExplain, showStats, saveInPlace, exitOnChange, width, maxFileSize, statsDetail, maxChanges, convLog)
 	}
 }
 
 // handleSingleFile processes a single file
 func handleSingleFile(filePath string, conv *converter.Converter, normaliseSmartQuotes bool,
-	outputFile string, showDiff, showDiffInline, showRaw, showStats, saveInPlace, exitOnChange bool, width, maxFileSize int) error {
+	outputFile string, showDiff, showDiffInline, showDiffWord, showRaw, showRawChanges, showExplain, showStats, saveInPlace, exitOnChange bool, width, maxFileSize, statsDetail, maxChanges int,
+	convLog *report.ConversionLog) error {
 
 	// Read file content
 	content, err := fileutil.ReadFileContentWithMaxSize(filePath, maxFileSize)
@@ -550,30 +1781,52 @@ func handleSingleFile(filePath string, conv *converter.Converter, normaliseSmart
 		return fmt.Errorf("failed to read file %s: %w", filePath, err)
 	}
 
-	// Convert content
-	convertedContent := conv.ConvertToBritish(content, normaliseSmartQuotes)
+	// Create analyser for statistics
+	analyser := report.NewAnalyser(conv.GetAmericanToBritishDictionary())
+
+	// Convert content; for Word documents, content and convertedContent are the document text
+	// and document is the converted package to write
+	var convertedContent, document string
+	var stats report.ChangeStats
+	if converter.IsDocxFile(filePath) {
+		content, convertedContent, document, err = convertDocxFile(conv, content, filePath, normaliseSmartQuotes)
+		if err != nil {
+			return err
+		}
+		stats = analyser.AnalyseChanges(content, convertedContent)
+	} else {
+		convertedContent, stats = convertFileWithStats(conv, analyser, content, filePath, normaliseSmartQuotes)
+		document = convertedContent
+	}
+	explanations := conv.TakeExplanations()
+
+	// Refuse to write anything for files that would change more than allowed
+	if err := report.CheckMaxChanges(stats, maxChanges); err != nil {
+		return fmt.Errorf("%s: %w; file left untouched", filePath, err)
+	}
 
 	// Check if any changes were made
 	hasChanges := content != convertedContent
 
 	// Exit early if exitOnChange is set and changes were detected
 	if exitOnChange && hasChanges {
-		defer os.Exit(1)
+		defer os.Exit(exitChanges)
 	}
 
 	// If output file is specified, write converted text and exit
 	if outputFile != "" {
-		err := os.WriteFile(outputFile, []byte(convertedContent), 0644)
+		err := os.WriteFile(outputFile, []byte(document), 0644)
 		if err != nil {
 			return fmt.Errorf("failed to write to output file %s: %w", outputFile, err)
 		}
+		logConversion(convLog, filePath, stats, true)
 		return nil
 	}
 
 	// If save flag is specified, overwrite the original file
 	if saveInPlace {
 		if hasChanges {
-			err := os.WriteFile(filePath, []byte(convertedContent), 0644)
+			err := os.WriteFile(filePath, []byte(document), 0644)
 			if err != nil {
 				return fmt.Errorf("failed to save changes to file %s: %w", filePath, err)
 			}
@@ -581,29 +1834,42 @@ func handleSingleFile(filePath string, conv *converter.Converter, normaliseSmart
 		} else {
 			fmt.Printf("No changes needed: %s\n", filePath)
 		}
+		logConversion(convLog, filePath, stats, hasChanges)
 		return nil
 	}
 
-	// Create analyser for statistics
-	analyser := report.NewAnalyser(conv.GetAmericanToBritishDictionary())
-	stats := analyser.AnalyseChanges(content, convertedContent)
+	logConversion(convLog, filePath, stats, false)
 
 	// Handle specific output modes
 	if showDiff {
-		return showDiffOutput(content, convertedContent, filePath, false)
+		return showUnifiedDiff(content, convertedContent, filePath, explanations)
 	}
 
 	if showDiffInline {
 		return showDiffOutput(content, convertedContent, filePath, true)
 	}
 
+	if showDiffWord {
+		return showWordDiffOutput(content, convertedContent)
+	}
+
 	if showRaw {
 		fmt.Print(convertedContent)
 		return nil
 	}
 
+	if showRawChanges {
+		fmt.Print(formatChangedLines(content, convertedContent))
+		return nil
+	}
+
+	if showExplain {
+		fmt.Print(formatExplanations(explanations))
+		return nil
+	}
+
 	if showStats {
-		return showStatsOutput(stats)
+		return showStatsOutputWithDetail(stats, statsDetail)
 	}
 
 	// Default mode: show diff + processed output + stats
@@ -627,20 +1893,26 @@ func handleSingleFile(filePath string, conv *converter.Converter, normaliseSmart
 	return showStatsOutput(stats)
 }
 
-// handleDirectory processes all text files in a directory recursively
+// errFailFast stops a -fail-fast directory walk at the first file that needs changes
+var errFailFast = errors.New("stopped at the first file that needs changes")
+
+// handleDirectory processes all text files in a directory recursively. With -verbose, the files
+// it skipped or couldn't process are listed once the directory has been processed.
 func handleDirectory(dirPath string, conv *converter.Converter, normaliseSmartQuotes bool,
-	outputFile string, showDiff, showDiffInline, showRaw, showStats, saveInPlace, exitOnChange, renameFiles bool, width, maxFileSize int) error {
+	outputFile string, showDiff, showDiffInline, showDiffWord, showRaw, showRawChanges, showExplain, showStats, saveInPlace, exitOnChange, failFast, renameFiles bool, width, maxFileSize, statsDetail, maxChanges int,
+	convLog *report.ConversionLog) error {
 
 	if outputFile != "" {
-		return fmt.Errorf("output file not supported when processing directories")
+		return usageErrorf("output file not supported when processing directories")
 	}
 
-	// Find all text files in directory
-	files, err := fileutil.FindTextFiles(dirPath)
+	// Find all text files and Word documents in directory
+	files, err := findConvertibleFiles(dirPath)
 	if err != nil {
 		return fmt.Errorf("failed to find text files in directory %s: %w", dirPath, err)
 	}
 
+	result := &report.ProcessResult{}
 	if len(files) == 0 {
 		fmt.Printf("No text files found in directory: %s\n", dirPath)
 		return nil
@@ -657,72 +1929,127 @@ func handleDirectory(dirPath string, conv *converter.Converter, normaliseSmartQu
 	var changedFiles []string
 	var fileStats []report.ChangeStats
 	var filenameChanges []string // Track files that need renaming
+	var limitExceeded []string   // Files left untouched because of -max-changes
+	var firstChanged string      // With -fail-fast, the file that stopped the walk
 	analyser := report.NewAnalyser(conv.GetAmericanToBritishDictionary())
 
-	for _, file := range files {
-		fmt.Printf("Processing: %s\n", file.RelativePath)
+	progress := newProgressLine()
+	err = fileutil.ProcessFiles(files, func(file fileutil.FileInfo) error {
+		// The counter replaces the per-file line; clear it so any messages for this file start cleanly
+		progress.clear()
+		if !progress.enabled {
+			fmt.Printf("Processing: %s\n", file.RelativePath)
+		}
 
 		// Read file content
 		content, err := fileutil.ReadFileContentWithMaxSize(file.Path, maxFileSize)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Warning: Failed to read file %s: %v\n", file.Path, err)
-			continue
+			result.AddFailed(file.Path, err)
+			return nil
 		}
 
-		// Convert content
-		convertedContent := conv.ConvertToBritish(content, normaliseSmartQuotes)
+		// Convert content; Word documents are compared by their text
+		var convertedContent, document string
+		var stats report.ChangeStats
+		if converter.IsDocxFile(file.Path) {
+			content, convertedContent, document, err = convertDocxFile(conv, content, file.Path, normaliseSmartQuotes)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: Skipping %v\n", err)
+				result.AddFailed(file.Path, err)
+				return nil
+			}
+			stats = analyser.AnalyseChanges(content, convertedContent)
+		} else {
+			convertedContent, stats = convertFileWithStats(conv, analyser, content, file.Path, normaliseSmartQuotes)
+			document = convertedContent
+		}
+		explanations := conv.TakeExplanations()
 		hasChanges := content != convertedContent
 
+		// Leave files that would change more than allowed untouched. Report modes still list and
+		// count them, as they write nothing.
+		if err := report.CheckMaxChanges(stats, maxChanges); err != nil {
+			limitExceeded = append(limitExceeded, file.RelativePath)
+			if saveInPlace {
+				fmt.Fprintf(os.Stderr, "Error: Skipping %s: %v; file left untouched\n", file.RelativePath, err)
+				result.AddSkipped(file.Path, err)
+				return nil
+			}
+			fmt.Fprintf(os.Stderr, "Error: %s: %v; -save would leave it untouched\n", file.RelativePath, err)
+		}
+
 		if hasChanges {
 			anyChanges = true
 		}
-
-		// Generate statistics for this file
-		stats := analyser.AnalyseChanges(content, convertedContent)
+		outcome := report.FileOutcome{Path: file.Path, Status: report.StatusUnchanged, Stats: stats}
 
 		// Handle filename renaming if requested
 		var newFilePath string
 		var filenameChanged bool
 		if renameFiles {
 			newFilePath, filenameChanged = convertFilename(file.Path, conv)
+			conv.TakeExplanations() // only content changes are explained
 			if filenameChanged {
 				anyChanges = true
 			}
 		}
+		if hasChanges || filenameChanged {
+			outcome.Status = report.StatusChanged
+		}
 
 		// Accumulate total stats
 		totalStats.TotalWords += stats.TotalWords
 		totalStats.SpellingChanges += stats.SpellingChanges
 		totalStats.UnitConversions += stats.UnitConversions
 		totalStats.QuoteChanges += stats.QuoteChanges
+		totalStats.ChangeDetails = report.MergeChangeDetails(totalStats.ChangeDetails, stats.ChangeDetails)
+
+		// Save mode logs once it knows whether the file was written
+		if !saveInPlace {
+			logConversion(convLog, file.Path, stats, false)
+		}
 
 		// Handle specific output modes
 		if showDiff && hasChanges {
-			diff := createUnifiedDiff(content, convertedContent, file.RelativePath, false)
+			diff := unifiedDiff(content, convertedContent, file.RelativePath, explanations)
 			allResults = append(allResults, fmt.Sprintf("=== %s ===\n%s", file.RelativePath, diff))
 		} else if showDiffInline && hasChanges {
-			diff := createUnifiedDiff(content, convertedContent, file.RelativePath, true)
+			diff := report.UnifiedDiff(content, convertedContent, file.RelativePath, true)
+			allResults = append(allResults, fmt.Sprintf("=== %s ===\n%s", file.RelativePath, diff))
+		} else if showDiffWord && hasChanges {
+			diff := report.WordDiff(content, convertedContent)
 			allResults = append(allResults, fmt.Sprintf("=== %s ===\n%s", file.RelativePath, diff))
 		} else if showRaw && hasChanges {
 			allResults = append(allResults, fmt.Sprintf("=== %s ===\n%s", file.RelativePath, convertedContent))
+		} else if showRawChanges && hasChanges {
+			allResults = append(allResults, fmt.Sprintf("=== %s ===\n%s", file.RelativePath, formatChangedLines(content, convertedContent)))
+		} else if showExplain && hasChanges {
+			allResults = append(allResults, fmt.Sprintf("=== %s ===\n%s", file.RelativePath, formatExplanations(explanations)))
 		} else if saveInPlace {
 			// Save mode: overwrite files with changes
+			written := false
 			if hasChanges {
-				err = os.WriteFile(file.Path, []byte(convertedContent), 0644)
+				err = os.WriteFile(file.Path, []byte(document), 0644)
 				if err != nil {
 					fmt.Fprintf(os.Stderr, "Warning: Failed to save changes to file %s: %v\n", file.Path, err)
+					outcome.Status, outcome.Err = report.StatusFailed, err
 				} else {
 					fmt.Printf("Saved changes to: %s\n", file.RelativePath)
+					written = true
 				}
 			} else if !filenameChanged {
 				fmt.Printf("No changes needed: %s\n", file.RelativePath)
 			}
+			logConversion(convLog, file.Path, stats, written)
+			outcome.Written = written
 
 			// Handle file renaming if requested and filename needs changing
 			if renameFiles && filenameChanged {
 				err = os.Rename(file.Path, newFilePath)
 				if err != nil {
 					fmt.Fprintf(os.Stderr, "Warning: Failed to rename file %s to %s: %v\n", file.Path, newFilePath, err)
+					outcome.Status, outcome.Err = report.StatusFailed, err
 				} else {
 					// Calculate relative path for display
 					var newRelativePath string
@@ -761,16 +2088,42 @@ func handleDirectory(dirPath string, conv *converter.Converter, normaliseSmartQu
 				}
 			}
 		}
+
+		result.Add(outcome)
+
+		// With -fail-fast, the first file that needs changes is enough to fail
+		if failFast && exitOnChange && (hasChanges || filenameChanged) {
+			firstChanged = file.RelativePath
+			return errFailFast
+		}
+		return nil
+	}, progress.update)
+	progress.clear()
+	printProblems(result)
+	if errors.Is(err, errFailFast) {
+		// Only the file that stopped the walk is reported
+		if showDiff || showDiffInline || showDiffWord || showRaw || showRawChanges || showExplain {
+			for _, result := range allResults {
+				fmt.Print(result)
+				fmt.Println()
+			}
+		} else {
+			fmt.Printf("File requires changes: %s\n", firstChanged)
+		}
+		os.Exit(exitChanges)
+	}
+	if err != nil {
+		return err
 	}
 
 	// Handle output modes
-	if showDiff || showDiffInline || showRaw {
+	if showDiff || showDiffInline || showDiffWord || showRaw || showRawChanges || showExplain {
 		for _, result := range allResults {
 			fmt.Print(result)
 			fmt.Println()
 		}
 	} else if showStats {
-		err := showStatsOutput(totalStats)
+		err := showStatsOutputWithDetail(totalStats, statsDetail)
 		if err != nil {
 			return err
 		}
@@ -824,27 +2177,38 @@ func handleDirectory(dirPath string, conv *converter.Converter, normaliseSmartQu
 		if err != nil {
 			return err
 		}
+	}
 
-		// Default mode exits with status 1 if changes are required
-		if len(changedFiles) > 0 {
-			os.Exit(1)
+	if len(limitExceeded) > 0 {
+		if !saveInPlace {
+			return changesErrorf("%d file(s) exceed -max-changes: %s",
+				len(limitExceeded), strings.Join(limitExceeded, ", "))
 		}
+		return changesErrorf("%d file(s) exceeded -max-changes and were left untouched: %s",
+			len(limitExceeded), strings.Join(limitExceeded, ", "))
+	}
+
+	// Default mode exits with status 1 if changes are required
+	if len(changedFiles) > 0 {
+		os.Exit(exitChanges)
 	}
 
 	// Handle exitOnChange
 	if exitOnChange && anyChanges {
-		os.Exit(1)
+		os.Exit(exitChanges)
 	}
 
 	return nil
 }
 
-// handleMultipleFiles processes multiple individual files
+// handleMultipleFiles processes multiple individual files. With -verbose, the files it skipped
+// or couldn't process are listed once every file has been processed.
 func handleMultipleFiles(filePaths []string, conv *converter.Converter, normaliseSmartQuotes bool,
-	outputFile string, showDiff, showDiffInline, showRaw, showStats, saveInPlace, exitOnChange bool, width, maxFileSize int) error {
+	outputFile string, showDiff, showDiffInline, showDiffWord, showRaw, showRawChanges, showExplain, showStats, saveInPlace, exitOnChange bool, width, maxFileSize, statsDetail, maxChanges int,
+	convLog *report.ConversionLog) error {
 
 	if outputFile != "" {
-		return fmt.Errorf("output file not supported when processing multiple files")
+		return usageErrorf("output file not supported when processing multiple files")
 	}
 
 	// Track changes and files for summary
@@ -852,39 +2216,78 @@ func handleMultipleFiles(filePaths []string, conv *converter.Converter, normalis
 	var totalStats report.ChangeStats
 	var changedFiles []string
 	var unchangedFiles []string
+	var limitExceeded []string // Files left untouched because of -max-changes
 	analyser := report.NewAnalyser(conv.GetAmericanToBritishDictionary())
+	result := &report.ProcessResult{}
 
 	fmt.Printf("Processing %d file(s)...\n", len(filePaths))
 
 	for _, filePath := range filePaths {
+		if fileutil.IsArchiveFile(filePath) {
+			fmt.Fprintf(os.Stderr, "Warning: Skipping %s: archives can only be converted on their own\n", filePath)
+			result.AddSkipped(filePath, errors.New("archives can only be converted on their own"))
+			continue
+		}
+
 		// Read and process file content
 		originalContent, err := fileutil.ReadFileContentWithMaxSize(filePath, maxFileSize)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Warning: Failed to read file %s: %v\n", filePath, err)
+			result.AddFailed(filePath, err)
 			continue
 		}
 
-		// Convert content
-		convertedContent := conv.ConvertToBritish(originalContent, normaliseSmartQuotes)
+		// Convert content; Word documents are compared by their text
+		var convertedContent, document string
+		var stats report.ChangeStats
+		if converter.IsDocxFile(filePath) {
+			originalContent, convertedContent, document, err = convertDocxFile(conv, originalContent, filePath, normaliseSmartQuotes)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: Skipping %v\n", err)
+				result.AddFailed(filePath, err)
+				continue
+			}
+			stats = analyser.AnalyseChanges(originalContent, convertedContent)
+		} else {
+			convertedContent, stats = convertFileWithStats(conv, analyser, originalContent, filePath, normaliseSmartQuotes)
+			document = convertedContent
+		}
+		explanations := conv.TakeExplanations()
 		hasChanges := originalContent != convertedContent
 
+		// Leave files that would change more than allowed untouched. Report modes still list and
+		// count them, as they write nothing.
+		if err := report.CheckMaxChanges(stats, maxChanges); err != nil {
+			limitExceeded = append(limitExceeded, filePath)
+			if saveInPlace {
+				fmt.Fprintf(os.Stderr, "Error: Skipping %s: %v; file left untouched\n", filePath, err)
+				result.AddSkipped(filePath, err)
+				continue
+			}
+			fmt.Fprintf(os.Stderr, "Error: %s: %v; -save would leave it untouched\n", filePath, err)
+		}
+
 		if hasChanges {
 			anyChanges = true
 			changedFiles = append(changedFiles, filePath)
 
 			// Save file if requested
 			if saveInPlace {
-				err = os.WriteFile(filePath, []byte(convertedContent), 0644)
+				err = os.WriteFile(filePath, []byte(document), 0644)
 				if err != nil {
 					fmt.Fprintf(os.Stderr, "Warning: Failed to save changes to file %s: %v\n", filePath, err)
+					logConversion(convLog, filePath, stats, false)
+					result.Add(report.FileOutcome{Path: filePath, Status: report.StatusFailed, Stats: stats, Err: err})
 					continue
 				}
 			}
+			logConversion(convLog, filePath, stats, saveInPlace)
+			result.Add(report.FileOutcome{Path: filePath, Status: report.StatusChanged, Stats: stats, Written: saveInPlace})
 
 			// Handle diff output modes
 			if showDiff {
 				fmt.Printf("=== %s ===\n", filePath)
-				err := showDiffOutput(originalContent, convertedContent, filePath, false)
+				err := showUnifiedDiff(originalContent, convertedContent, filePath, explanations)
 				if err != nil {
 					fmt.Fprintf(os.Stderr, "Warning: Failed to show diff for %s: %v\n", filePath, err)
 				}
@@ -896,20 +2299,32 @@ func handleMultipleFiles(filePaths []string, conv *converter.Converter, normalis
 					fmt.Fprintf(os.Stderr, "Warning: Failed to show diff for %s: %v\n", filePath, err)
 				}
 				fmt.Println()
+			} else if showDiffWord {
+				fmt.Printf("=== %s ===\n", filePath)
+				if err := showWordDiffOutput(originalContent, convertedContent); err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: Failed to show diff for %s: %v\n", filePath, err)
+				}
+				fmt.Println()
 			} else if showRaw {
 				fmt.Printf("=== %s ===\n%s\n", filePath, convertedContent)
+			} else if showRawChanges {
+				fmt.Printf("=== %s ===\n%s\n", filePath, formatChangedLines(originalContent, convertedContent))
+			} else if showExplain {
+				fmt.Printf("=== %s ===\n%s\n", filePath, formatExplanations(explanations))
 			}
 		} else {
 			unchangedFiles = append(unchangedFiles, filePath)
+			logConversion(convLog, filePath, stats, false)
+			result.Add(report.FileOutcome{Path: filePath, Status: report.StatusUnchanged, Stats: stats})
 		}
 
-		// Calculate stats
-		stats := analyser.AnalyseChanges(originalContent, convertedContent)
 		totalStats.TotalWords += stats.TotalWords
 		totalStats.SpellingChanges += stats.SpellingChanges
 		totalStats.UnitConversions += stats.UnitConversions
 		totalStats.QuoteChanges += stats.QuoteChanges
+		totalStats.ChangeDetails = report.MergeChangeDetails(totalStats.ChangeDetails, stats.ChangeDetails)
 	}
+	printProblems(result)
 
 	// Show summary
 	if len(changedFiles) > 0 {
@@ -923,7 +2338,7 @@ func handleMultipleFiles(filePaths []string, conv *converter.Converter, normalis
 		}
 	}
 
-	if len(unchangedFiles) > 0 && !showDiff && !showDiffInline && !showRaw {
+	if len(unchangedFiles) > 0 && !showDiff && !showDiffInline && !showDiffWord && !showRaw && !showRawChanges && !showExplain {
 		fmt.Printf("No changes needed for %d file(s)\n", len(unchangedFiles))
 	}
 
@@ -935,6 +2350,11 @@ func handleMultipleFiles(filePaths []string, conv *converter.Converter, normalis
 			if err != nil {
 				return err
 			}
+		} else if showStats {
+			err := showStatsOutputWithDetail(totalStats, statsDetail)
+			if err != nil {
+				return err
+			}
 		} else {
 			err := showStatsOutputWithMode(totalStats, false)
 			if err != nil {
@@ -943,9 +2363,18 @@ func handleMultipleFiles(filePaths []string, conv *converter.Converter, normalis
 		}
 	}
 
+	if len(limitExceeded) > 0 {
+		if !saveInPlace {
+			return changesErrorf("%d file(s) exceed -max-changes: %s",
+				len(limitExceeded), strings.Join(limitExceeded, ", "))
+		}
+		return changesErrorf("%d file(s) exceeded -max-changes and were left untouched: %s",
+			len(limitExceeded), strings.Join(limitExceeded, ", "))
+	}
+
 	// Handle exitOnChange
 	if exitOnChange && anyChanges {
-		os.Exit(1)
+		os.Exit(exitChanges)
 	}
 
 	return nil
@@ -989,7 +2418,7 @@ func handleClipboard(convertUnits bool, normaliseSmartQuotes bool) {
 	err := pasteCmd.Run()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error reading from clipboard: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitIO)
 	}
 
 	clipboardText := pasteOut.String()
@@ -998,7 +2427,7 @@ func handleClipboard(convertUnits bool, normaliseSmartQuotes bool) {
 	conv, err := converter.NewConverter()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error initializing converter: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitConfig)
 	}
 
 	// Set unit processing based on flag
@@ -1012,7 +2441,7 @@ func handleClipboard(convertUnits bool, normaliseSmartQuotes bool) {
 	err = copyCmd.Run()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error writing to clipboard: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitIO)
 	}
 
 	fmt.Println("Clipboard content converted and updated.")
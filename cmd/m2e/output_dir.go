@@ -0,0 +1,147 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sammcj/m2e/pkg/converter"
+	"github.com/sammcj/m2e/pkg/fileutil"
+	"github.com/sammcj/m2e/pkg/report"
+)
+
+// handleOutputDir mirrors dirPath into outputDir, writing converted copies of its text files
+// and leaving the originals untouched. Non-text files, and files -ext or -ext-exclude leave
+// out, are copied verbatim when copyAll is set and skipped otherwise.
+func handleOutputDir(dirPath, outputDir string, conv *converter.Converter, normaliseSmartQuotes,
+	copyAll, renameFiles, exitOnChange bool, maxFileSize, maxChanges int, convLog *report.ConversionLog) error {
+
+	info, err := os.Stat(dirPath)
+	if err != nil {
+		return fmt.Errorf("failed to stat input path: %w", err)
+	}
+	if !info.IsDir() {
+		return usageErrorf("-output-dir requires a directory input, got file %s", dirPath)
+	}
+
+	absInput, err := filepath.Abs(dirPath)
+	if err != nil {
+		return fmt.Errorf("failed to resolve input directory: %w", err)
+	}
+	absOutput, err := filepath.Abs(outputDir)
+	if err != nil {
+		return fmt.Errorf("failed to resolve output directory: %w", err)
+	}
+	if absInput == absOutput {
+		return usageErrorf("output directory must differ from the input directory")
+	}
+
+	files, err := fileutil.FindFilesWithOptions(dirPath, findOptions)
+	if err != nil {
+		return fmt.Errorf("failed to find files in directory %s: %w", dirPath, err)
+	}
+
+	analyser := report.NewAnalyser(conv.GetAmericanToBritishDictionary())
+	var totalStats report.ChangeStats
+	var converted, copied, skipped int
+	var limitExceeded []string // Files not written because of -max-changes
+
+	progress := newProgressLine()
+	err = fileutil.ProcessFiles(files, func(file fileutil.FileInfo) error {
+		progress.clear()
+
+		// An output directory inside the input may hold results from an earlier run
+		if absPath, err := filepath.Abs(file.Path); err == nil && strings.HasPrefix(absPath, absOutput+string(filepath.Separator)) {
+			return nil
+		}
+
+		targetPath := filepath.Join(outputDir, file.RelativePath)
+		if renameFiles {
+			targetPath, _ = convertFilename(targetPath, conv)
+		}
+
+		isText, err := fileutil.IsTextFile(file.Path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Failed to check file type for %s: %v\n", file.Path, err)
+			return nil
+		}
+		if !isText || !extensionFilter.Matches(file.Path) {
+			if !copyAll {
+				skipped++
+				return nil
+			}
+			if err := fileutil.CopyFile(file.Path, targetPath); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+				return nil
+			}
+			copied++
+			return nil
+		}
+
+		content, err := fileutil.ReadFileContentWithMaxSize(file.Path, maxFileSize)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Failed to read file %s: %v\n", file.Path, err)
+			return nil
+		}
+
+		convertedContent, stats := convertFileWithStats(conv, analyser, content, file.Path, normaliseSmartQuotes)
+		if err := report.CheckMaxChanges(stats, maxChanges); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: Skipping %s: %v; file not written\n", file.RelativePath, err)
+			limitExceeded = append(limitExceeded, file.RelativePath)
+			return nil
+		}
+
+		if err := fileutil.WriteFileContent(targetPath, convertedContent); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			return nil
+		}
+		logConversion(convLog, targetPath, stats, true)
+
+		if convertedContent != content {
+			converted++
+			totalStats.TotalWords += stats.TotalWords
+			totalStats.SpellingChanges += stats.SpellingChanges
+			totalStats.UnitConversions += stats.UnitConversions
+			totalStats.QuoteChanges += stats.QuoteChanges
+			if !progress.enabled {
+				fmt.Printf("Converted: %s\n", file.RelativePath)
+			}
+		} else {
+			copied++
+		}
+		return nil
+	}, progress.update)
+	progress.clear()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Wrote %d file(s) to %s: %d converted, %d copied unchanged", converted+copied, outputDir, converted, copied)
+	if skipped > 0 {
+		if extensionFilter.IsEmpty() {
+			fmt.Printf(", %d non-text file(s) skipped (use -copy-all to copy them)", skipped)
+		} else {
+			fmt.Printf(", %d non-text or filtered file(s) skipped (use -copy-all to copy them)", skipped)
+		}
+	}
+	fmt.Println()
+
+	if totalStats.TotalChanges() > 0 {
+		fmt.Println()
+		if err := showStatsOutputWithMode(totalStats, true); err != nil {
+			return err
+		}
+	}
+
+	if len(limitExceeded) > 0 {
+		return changesErrorf("%d file(s) exceeded -max-changes and were not written: %s",
+			len(limitExceeded), strings.Join(limitExceeded, ", "))
+	}
+
+	if exitOnChange && converted > 0 {
+		os.Exit(exitChanges)
+	}
+
+	return nil
+}
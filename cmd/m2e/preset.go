@@ -0,0 +1,80 @@
+package main
+
+import (
+	"slices"
+	"sort"
+	"strings"
+)
+
+// presets maps each -preset name to the flags it stands for. They're applied before the flags
+// on the command line, so any of those still override the preset.
+var presets = map[string][]string{
+	// Prose: every file converted as text, with units and smart quotes (the default)
+	"docs": {"-all-text", "-units"},
+	// Source code: only comments converted, units left off (the default)
+	"code": {"-only-comments"},
+	// Dictionary spellings only: no contextual words, and quotes and dashes left as written
+	"strict": {"-no-contextual", "-no-smart-quotes"},
+}
+
+// presetConflicts lists, for each flag a preset can set, the flags that replace it when given
+// on the command line, as they can't be combined with it
+var presetConflicts = map[string][]string{
+	"-all-text":      {"-only-comments", "-format", "-csv-columns"},
+	"-only-comments": {"-all-text", "-format", "-csv-columns"},
+}
+
+// presetNames returns the preset names in sorted order
+func presetNames() []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// expandPreset removes -preset from args and puts the flags of the named preset in front of
+// the rest, leaving out any that conflict with a flag given on the command line
+func expandPreset(args []string) ([]string, error) {
+	name := ""
+	var rest []string
+	for i := 0; i < len(args); i++ {
+		if value, ok := strings.CutPrefix(args[i], "-preset="); ok {
+			name = value
+			continue
+		}
+		if args[i] == "-preset" {
+			if i+1 >= len(args) || strings.HasPrefix(args[i+1], "-") {
+				return nil, usageErrorf("-preset needs a name (%s)", strings.Join(presetNames(), ", "))
+			}
+			name = args[i+1]
+			i++ // Skip the value
+			continue
+		}
+		rest = append(rest, args[i])
+	}
+	if name == "" {
+		return args, nil
+	}
+
+	presetFlags, ok := presets[name]
+	if !ok {
+		return nil, usageErrorf("unknown preset %q (supported: %s)", name, strings.Join(presetNames(), ", "))
+	}
+
+	var expanded []string
+	for _, presetFlag := range presetFlags {
+		if !slices.ContainsFunc(presetConflicts[presetFlag], func(conflict string) bool { return hasFlag(rest, conflict) }) {
+			expanded = append(expanded, presetFlag)
+		}
+	}
+	return append(expanded, rest...), nil
+}
+
+// hasFlag reports whether args include the named flag, with or without a value after "="
+func hasFlag(args []string, name string) bool {
+	return slices.ContainsFunc(args, func(arg string) bool {
+		return arg == name || strings.HasPrefix(arg, name+"=")
+	})
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+// handlePrintConfig prints the configuration conv applies to the first path in args, or to the
+// current directory when there is none, as indented JSON. That's the defaults merged with the
+// user unit config, the nearest .m2e.json and the command-line flags, in that order.
+func handlePrintConfig(conv *converter.Converter, args []string) {
+	target := "."
+	if len(args) > 0 {
+		target = args[0]
+	}
+
+	// ForFile looks for a .m2e.json from a file's directory up, so a directory stands in for a
+	// file inside it
+	file := target
+	if info, err := os.Stat(target); err == nil && info.IsDir() {
+		file = filepath.Join(target, converter.ProjectConfigFileName)
+	}
+	effective, err := conv.ForFile(file)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitConfig)
+	}
+
+	data, err := json.MarshalIndent(effective.EffectiveConfig(), "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to encode configuration: %v\n", err)
+		os.Exit(exitConfig)
+	}
+	fmt.Println(string(data))
+}
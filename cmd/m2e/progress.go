@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/x/term"
+)
+
+// progressLine renders a processed/total counter on a single, repeatedly redrawn terminal line.
+// It is disabled when stdout isn't a terminal so piped and redirected output stays clean.
+type progressLine struct {
+	enabled bool
+	visible bool
+}
+
+// newProgressLine returns a progress line that is only enabled when stdout is a terminal
+func newProgressLine() *progressLine {
+	return &progressLine{enabled: isTerminal(os.Stdout)}
+}
+
+// update redraws the counter; it is a fileutil.ProgressFunc
+func (p *progressLine) update(done, total int) {
+	if !p.enabled {
+		return
+	}
+	fmt.Printf("\r\033[K%sProcessed %d/%d files%s", ColourCyan, done, total, ColourReset)
+	p.visible = true
+}
+
+// clear removes the counter so other output starts on a clean line
+func (p *progressLine) clear() {
+	if !p.visible {
+		return
+	}
+	fmt.Print("\r\033[K")
+	p.visible = false
+}
+
+// isTerminal reports whether f is connected to a terminal. Other character devices, such as
+// /dev/null, aren't terminals.
+func isTerminal(f *os.File) bool {
+	return term.IsTerminal(f.Fd())
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/sammcj/m2e/pkg/converter"
+	"github.com/sammcj/m2e/pkg/fileutil"
+)
+
+// filenameRename is a planned rename of a single file
+type filenameRename struct {
+	OldPath string
+	NewPath string
+	Display string // path shown to the user, relative to the input it was found under
+}
+
+// planFilenameRenames finds the files under inputPaths whose names contain American spellings
+// and returns their renames. Renames whose target already exists, or that share a target with
+// another file, are returned as collisions rather than planned.
+func planFilenameRenames(inputPaths []string, conv *converter.Converter) ([]filenameRename, []string, error) {
+	var candidates []filenameRename
+	for _, inputPath := range inputPaths {
+		info, err := os.Stat(inputPath)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to stat input path: %w", err)
+		}
+
+		// Asset directories are the main use case, so every file is considered, not just text files
+		files, err := fileutil.FindFilesWithOptions(inputPath, findOptions)
+		if err != nil {
+			return nil, nil, err
+		}
+		if info.IsDir() {
+			files = fileutil.FilterFiles(files, extensionFilter)
+		}
+
+		for _, file := range files {
+			newPath, changed := convertFilename(file.Path, conv)
+			if !changed {
+				continue
+			}
+			display := file.Path
+			if info.IsDir() {
+				display = filepath.Join(inputPath, file.RelativePath)
+			}
+			candidates = append(candidates, filenameRename{OldPath: file.Path, NewPath: newPath, Display: display})
+		}
+	}
+
+	// Group by target so two files mapping to the same British name are both reported
+	byTarget := make(map[string][]filenameRename)
+	for _, candidate := range candidates {
+		target := filepath.Clean(candidate.NewPath)
+		byTarget[target] = append(byTarget[target], candidate)
+	}
+
+	var renames []filenameRename
+	var collisions []string
+	for _, candidate := range candidates {
+		target := filepath.Clean(candidate.NewPath)
+		if sources := byTarget[target]; len(sources) > 1 {
+			collisions = append(collisions, fmt.Sprintf("%s → %s: %d files would be renamed to the same name",
+				candidate.Display, filepath.Base(candidate.NewPath), len(sources)))
+			continue
+		}
+		if targetInfo, err := os.Stat(candidate.NewPath); err == nil {
+			// Case-insensitive filesystems report the source itself for case-only renames
+			if sourceInfo, err := os.Stat(candidate.OldPath); err != nil || !os.SameFile(sourceInfo, targetInfo) {
+				collisions = append(collisions, fmt.Sprintf("%s → %s: target already exists",
+					candidate.Display, filepath.Base(candidate.NewPath)))
+				continue
+			}
+		}
+		renames = append(renames, candidate)
+	}
+
+	sort.Strings(collisions)
+	return renames, collisions, nil
+}
+
+// handleRenameOnly renames files with American spellings in their names without reading or
+// converting their contents. Without saveInPlace it only lists the renames that would be made.
+func handleRenameOnly(inputPaths []string, conv *converter.Converter, saveInPlace, exitOnChange bool) error {
+	renames, collisions, err := planFilenameRenames(inputPaths, conv)
+	if err != nil {
+		return err
+	}
+
+	for _, collision := range collisions {
+		fmt.Fprintf(os.Stderr, "Error: Skipping rename of %s\n", collision)
+	}
+
+	if saveInPlace {
+		for _, rename := range renames {
+			if err := os.Rename(rename.OldPath, rename.NewPath); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: Failed to rename file %s to %s: %v\n", rename.OldPath, rename.NewPath, err)
+				continue
+			}
+			fmt.Printf("Renamed file: %s → %s\n", rename.Display, filepath.Base(rename.NewPath))
+		}
+		if len(renames) == 0 && len(collisions) == 0 {
+			fmt.Println("No files require filename changes.")
+		}
+	} else if len(renames) > 0 {
+		fmt.Printf("Files requiring filename changes (%d):\n", len(renames))
+		for _, rename := range renames {
+			fmt.Printf("  %s → %s\n", rename.Display, filepath.Base(rename.NewPath))
+		}
+		fmt.Println("\nTo apply these changes, use the -save -rename-only flags.")
+	} else if len(collisions) == 0 {
+		fmt.Println("No files require filename changes.")
+	}
+
+	if len(collisions) > 0 {
+		return fmt.Errorf("%d file(s) skipped due to filename collisions", len(collisions))
+	}
+
+	if exitOnChange && len(renames) > 0 {
+		os.Exit(exitChanges)
+	}
+
+	return nil
+}
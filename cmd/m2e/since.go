@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sammcj/m2e/pkg/fileutil"
+)
+
+// sinceTimeLayouts are the date and time formats -since accepts, tried in order. Times without
+// a zone are in local time.
+var sinceTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// sinceFilter holds -since: directories are only searched for files modified after a time or
+// changed since a git revision. The zero value lets every file through.
+type sinceFilter struct {
+	time     time.Time // files modified after this, when set
+	revision string    // git revision that files must have changed since, when set
+}
+
+// modifiedSince is the -since filter applied when searching directories
+var modifiedSince sinceFilter
+
+// parseSince parses a -since value: a date or time such as "2024-01-01" or
+// "2024-01-01T09:00:00Z", or otherwise a git revision such as "HEAD~5" or a tag
+func parseSince(value string) (sinceFilter, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return sinceFilter{}, usageErrorf("-since needs a date (2024-01-01) or a git revision (HEAD~5)")
+	}
+	for _, layout := range sinceTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return sinceFilter{time: t}, nil
+		}
+	}
+	if strings.HasPrefix(value, "-") {
+		return sinceFilter{}, usageErrorf("invalid -since value %q: expected a date or a git revision", value)
+	}
+	return sinceFilter{revision: value}, nil
+}
+
+// apply returns the files found under dir that pass the filter, in the same order. With a git
+// revision, a file passes when "git diff" shows it changed since the revision, committed or
+// not, or when git doesn't track it yet.
+func (f sinceFilter) apply(dir string, files []fileutil.FileInfo) ([]fileutil.FileInfo, error) {
+	if f.revision == "" {
+		return fileutil.FilterModifiedSince(files, f.time), nil
+	}
+
+	changed, err := gitChangedSince(dir, f.revision)
+	if err != nil {
+		return nil, err
+	}
+	prefix, err := runGit(dir, "rev-parse", "--show-prefix")
+	if err != nil {
+		return nil, err
+	}
+	prefix = strings.TrimSpace(prefix)
+
+	var filtered []fileutil.FileInfo
+	for _, file := range files {
+		if changed[prefix+filepath.ToSlash(file.RelativePath)] {
+			filtered = append(filtered, file)
+		}
+	}
+	return filtered, nil
+}
+
+// gitChangedSince returns the paths, relative to the top of the repository dir is in, of the
+// files that have changed since revision, including uncommitted changes and untracked files
+func gitChangedSince(dir, revision string) (map[string]bool, error) {
+	if _, err := exec.LookPath("git"); err != nil {
+		return nil, usageErrorf("-since %q needs git, which wasn't found: %v", revision, err)
+	}
+	if _, err := runGit(dir, "rev-parse", "--verify", "--quiet", "--end-of-options", revision+"^{commit}"); err != nil {
+		return nil, usageErrorf("-since %q is neither a date nor a git revision in %s", revision, dir)
+	}
+
+	diffed, err := runGit(dir, "diff", "--name-only", "-z", "--no-renames", revision, "--")
+	if err != nil {
+		return nil, err
+	}
+	untracked, err := runGit(dir, "ls-files", "--others", "--exclude-standard", "--full-name", "-z")
+	if err != nil {
+		return nil, err
+	}
+
+	changed := make(map[string]bool)
+	for _, name := range strings.Split(diffed+untracked, "\x00") {
+		if name != "" {
+			changed[name] = true
+		}
+	}
+	return changed, nil
+}
+
+// runGit runs git in dir and returns its output
+func runGit(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && stderr.Len() > 0 {
+			return "", usageErrorf("git %s failed: %s", args[0], strings.TrimSpace(stderr.String()))
+		}
+		return "", usageErrorf("git %s failed (is git installed?): %v", args[0], err)
+	}
+	return string(out), nil
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/sammcj/m2e/pkg/converter"
+	"github.com/sammcj/m2e/pkg/fileutil"
+	"github.com/sammcj/m2e/pkg/report"
+)
+
+// statsFormats lists the -stats-format values: human is the default -stats block, csv and json
+// are tables of per-file counts for spreadsheets and scripts
+var statsFormats = []string{"human", "csv", "json"}
+
+// handleStatsTable converts the files under paths without modifying them and prints a table of
+// each file's word and change counts, with their total, as CSV or JSON
+func handleStatsTable(paths []string, conv *converter.Converter, normaliseSmartQuotes bool, format string, exitOnChange bool,
+	maxFileSize int, convLog *report.ConversionLog) error {
+	analyser := report.NewAnalyser(conv.GetAmericanToBritishDictionary())
+	var table report.StatsTable
+	addFile := func(filePath, displayPath string) {
+		content, err := fileutil.ReadFileContentWithMaxSize(filePath, maxFileSize)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Skipping %s: %v\n", displayPath, err)
+			return
+		}
+		_, stats := convertFileWithStats(conv, analyser, content, filePath, normaliseSmartQuotes)
+		logConversion(convLog, filePath, stats, false)
+		table.Add(displayPath, stats)
+	}
+
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Skipping %s: %v\n", path, err)
+			continue
+		}
+		if !info.IsDir() {
+			addFile(path, path)
+			continue
+		}
+
+		found, err := findTextFiles(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Skipping %s: %v\n", path, err)
+			continue
+		}
+		for _, file := range found {
+			addFile(file.Path, filepath.Join(path, file.RelativePath))
+		}
+	}
+
+	var err error
+	if format == "csv" {
+		err = table.WriteCSV(os.Stdout)
+	} else {
+		err = table.WriteJSON(os.Stdout)
+	}
+	if err != nil {
+		return err
+	}
+
+	if exitOnChange && table.Total.Changes() > 0 {
+		os.Exit(exitChanges)
+	}
+	return nil
+}
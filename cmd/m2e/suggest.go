@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sammcj/m2e/pkg/converter"
+	"github.com/sammcj/m2e/pkg/fileutil"
+)
+
+// suggestionSource is a named piece of input checked for likely Americanisms
+type suggestionSource struct {
+	Name    string
+	Content string
+}
+
+// collectSuggestionSources reads the input for -suggest mode: file and directory arguments,
+// direct text, the legacy -input flag or stdin
+func collectSuggestionSources(args []string, inputFile string, maxFileSize int) ([]suggestionSource, error) {
+	if len(args) == 0 && inputFile != "" {
+		args = []string{inputFile}
+	}
+
+	if len(args) == 0 {
+		stat, _ := os.Stdin.Stat()
+		if (stat.Mode() & os.ModeCharDevice) != 0 {
+			return nil, fmt.Errorf("-suggest requires text, a file or directory path, or piped input")
+		}
+		content, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read from stdin: %w", err)
+		}
+		return []suggestionSource{{Name: "stdin", Content: string(content)}}, nil
+	}
+
+	for _, arg := range args {
+		if _, err := os.Stat(arg); err != nil {
+			// Not all arguments are paths, so treat them as direct text like conversion does
+			return []suggestionSource{{Name: "text", Content: strings.Join(args, " ")}}, nil
+		}
+	}
+
+	var sources []suggestionSource
+	for _, path := range args {
+		files, err := findTextFiles(path)
+		if err != nil {
+			return nil, err
+		}
+
+		info, _ := os.Stat(path)
+		for _, file := range files {
+			content, err := fileutil.ReadFileContentWithMaxSize(file.Path, maxFileSize)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: Failed to read file %s: %v\n", file.Path, err)
+				continue
+			}
+			name := file.Path
+			if info.IsDir() {
+				name = filepath.Join(path, file.RelativePath)
+			}
+			sources = append(sources, suggestionSource{Name: name, Content: content})
+		}
+	}
+	return sources, nil
+}
+
+// handleSuggest prints words that look like Americanisms but aren't in the dictionary, one per
+// line in a file:line: format, and returns the number of suggestions
+func handleSuggest(sources []suggestionSource, conv *converter.Converter) int {
+	total := 0
+	for _, source := range sources {
+		for _, s := range conv.SuggestAmericanisms(source.Content) {
+			fmt.Printf("%s:%d: %s → %s (%s", source.Name, s.Line, s.Word, s.Suggested, s.Rule)
+			if s.Count > 1 {
+				fmt.Printf(", %d occurrences", s.Count)
+			}
+			fmt.Println(")")
+			total++
+		}
+	}
+
+	if total == 0 {
+		fmt.Println("No likely Americanisms found outside the dictionary.")
+	} else {
+		fmt.Printf("\nFound %d possible Americanism(s) not in the dictionary. These are heuristic suggestions;\n", total)
+		fmt.Println("add confirmed ones to ~/.config/m2e/american_spellings.json.")
+	}
+	return total
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/sammcj/m2e/pkg/converter"
+	"github.com/sammcj/m2e/pkg/report"
+)
+
+// verboseOutput receives a line of timing and rule counts per converted file when -verbose is
+// given; nil otherwise. It is stderr, so -raw and -diff output on stdout stays clean.
+var verboseOutput io.Writer
+
+// printVerbose writes the -verbose line for a file converted in elapsed time, with the rule
+// counts the converter collected while converting it
+func printVerbose(filePath string, elapsed time.Duration, counters converter.ConversionCounters, cached bool) {
+	if verboseOutput == nil {
+		return
+	}
+	elapsed = elapsed.Round(time.Microsecond)
+	if cached {
+		fmt.Fprintf(verboseOutput, "%s: %v (cached)\n", filePath, elapsed)
+		return
+	}
+	skipped := "no"
+	if counters.PrefilterSkipped() {
+		skipped = "yes"
+	}
+	fmt.Fprintf(verboseOutput, "%s: %v, %d dictionary, %d contextual, %d units, pre-filter skipped: %s\n",
+		filePath, elapsed, counters.DictionaryHits, counters.ContextualHits, counters.UnitConversions, skipped)
+}
+
+// printProblems writes a line to the -verbose output for each file in result that was skipped
+// or couldn't be processed, so they can be found without searching back through the run
+func printProblems(result *report.ProcessResult) {
+	if verboseOutput == nil {
+		return
+	}
+	for _, outcome := range result.Problems() {
+		fmt.Fprintf(verboseOutput, "%s %s: %v\n", outcome.Status, outcome.Path, outcome.Err)
+	}
+}
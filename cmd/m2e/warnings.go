@@ -0,0 +1,23 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+// printAmbiguityWarnings writes the judgement calls the converter recorded while converting
+// content to stderr, one per line as "Warning: path:line: ...", so -raw and -diff output on
+// stdout stays clean. The line is left out when the warning's context can't be found in content,
+// such as when an earlier stage had already changed it.
+func printAmbiguityWarnings(conv *converter.Converter, filePath, content string) {
+	for _, warning := range conv.TakeAmbiguityWarnings() {
+		location := filePath
+		if i := strings.Index(content, warning.Context); warning.Context != "" && i >= 0 {
+			location = fmt.Sprintf("%s:%d", filePath, strings.Count(content[:i], "\n")+1)
+		}
+		fmt.Fprintf(os.Stderr, "Warning: %s: %s\n", location, warning)
+	}
+}
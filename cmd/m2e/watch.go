@@ -0,0 +1,253 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/sammcj/m2e/pkg/converter"
+	"github.com/sammcj/m2e/pkg/fileutil"
+	"github.com/sammcj/m2e/pkg/report"
+)
+
+// watchDebounce is how long a file has to go without changing before it's converted, so the
+// burst of events an editor makes for a single save is handled once
+const watchDebounce = 300 * time.Millisecond
+
+// watchSummaryChanges is the number of substitutions listed in each -watch summary line
+const watchSummaryChanges = 3
+
+// watcher holds the state of -watch between file events
+type watcher struct {
+	conv                 *converter.Converter
+	analyser             *report.Analyser
+	normaliseSmartQuotes bool
+	save                 bool
+	maxFileSize          int
+	maxChanges           int
+	convLog              *report.ConversionLog
+
+	fsWatcher *fsnotify.Watcher
+	files     map[string]bool   // files named on the command line, watched through their directory
+	dirs      map[string]bool   // directories watched for every text file in them
+	written   map[string]string // content last saved to each file, so its own event is ignored
+}
+
+// handleWatch watches the files and directories in paths, searching directories as a directory
+// run does, and converts each text file after it changes. Changes are reported, or saved with
+// save, with a line per file. It runs until interrupted.
+func handleWatch(paths []string, conv *converter.Converter, normaliseSmartQuotes, save bool, maxFileSize, maxChanges int,
+	convLog *report.ConversionLog) error {
+	fsWatcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to start watching: %w", err)
+	}
+	defer func() {
+		_ = fsWatcher.Close() // Ignore error in defer cleanup
+	}()
+
+	w := &watcher{
+		conv:                 conv,
+		analyser:             report.NewAnalyser(conv.GetAmericanToBritishDictionary()),
+		normaliseSmartQuotes: normaliseSmartQuotes,
+		save:                 save,
+		maxFileSize:          maxFileSize,
+		maxChanges:           maxChanges,
+		convLog:              convLog,
+		fsWatcher:            fsWatcher,
+		files:                map[string]bool{},
+		dirs:                 map[string]bool{},
+		written:              map[string]string{},
+	}
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return fmt.Errorf("failed to stat input path: %w", err)
+		}
+		if info.IsDir() {
+			err = w.addDir(path)
+		} else {
+			w.files[filepath.Clean(path)] = true
+			err = fsWatcher.Add(filepath.Dir(path))
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	mode := "reporting"
+	if save {
+		mode = "saving"
+	}
+	fmt.Printf("Watching %d director(ies) and %d file(s), %s changes (press Ctrl+C to stop)\n", len(w.dirs), len(w.files), mode)
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(interrupt)
+
+	// Each change restarts its file's timer, which hands the file back to this loop once the
+	// file has been quiet for watchDebounce, so conversions never run concurrently
+	settled := make(chan string)
+	timers := map[string]*time.Timer{}
+	for {
+		select {
+		case event, ok := <-fsWatcher.Events:
+			if !ok {
+				return nil
+			}
+			path, ok := w.relevant(event)
+			if !ok {
+				continue
+			}
+			if timer, ok := timers[path]; ok {
+				timer.Reset(watchDebounce)
+			} else {
+				timers[path] = time.AfterFunc(watchDebounce, func() { settled <- path })
+			}
+		case path := <-settled:
+			delete(timers, path)
+			w.convert(path)
+		case err, ok := <-fsWatcher.Errors:
+			if !ok {
+				return nil
+			}
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		case <-interrupt:
+			fmt.Println()
+			return nil
+		}
+	}
+}
+
+// addDir watches dir and the directories under it that a directory run would search
+func (w *watcher) addDir(dir string) error {
+	dirs, err := fileutil.FindDirs(dir, findOptions)
+	if err != nil {
+		return err
+	}
+	for _, dir := range dirs {
+		if err := w.fsWatcher.Add(dir); err != nil {
+			return fmt.Errorf("failed to watch %s: %w", dir, err)
+		}
+		w.dirs[filepath.Clean(dir)] = true
+	}
+	return nil
+}
+
+// relevant returns the file an event is about when it should be converted: a file named on the
+// command line, or one in a watched directory that a directory run would include. Directories
+// created in a watched directory are watched too.
+func (w *watcher) relevant(event fsnotify.Event) (string, bool) {
+	if !event.Has(fsnotify.Write) && !event.Has(fsnotify.Create) {
+		return "", false
+	}
+
+	path := filepath.Clean(event.Name)
+	if w.files[path] {
+		return path, true
+	}
+	if !w.dirs[filepath.Dir(path)] {
+		return "", false // a file beside one named on the command line
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", false
+	}
+	if info.IsDir() {
+		if event.Has(fsnotify.Create) && !findOptions.SkipsDir(info.Name()) {
+			if err := w.addDir(path); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			}
+		}
+		return "", false
+	}
+	if findOptions.SkipsFile(info.Name()) || !extensionFilter.Matches(path) {
+		return "", false
+	}
+	return path, true
+}
+
+// convert converts a file that has changed and prints a line summarising the result
+func (w *watcher) convert(path string) {
+	content, err := fileutil.ReadFileContentWithMaxSize(path, w.maxFileSize)
+	if err != nil {
+		// Editors often write a temporary file and remove it again before it settles
+		if !errors.Is(err, fs.ErrNotExist) {
+			fmt.Fprintf(os.Stderr, "Warning: Failed to read file %s: %v\n", path, err)
+		}
+		return
+	}
+	if written, ok := w.written[path]; ok && written == content {
+		return // the event for our own save
+	}
+	if !w.files[path] {
+		if isText, err := fileutil.IsTextFile(path); err != nil || !isText {
+			return
+		}
+	}
+
+	convertedContent, stats := convertFileWithStats(w.conv, w.analyser, content, path, w.normaliseSmartQuotes)
+	timestamp := time.Now().Format("15:04:05")
+
+	if convertedContent == content {
+		logConversion(w.convLog, path, stats, false)
+		fmt.Printf("%s %s: no changes needed\n", timestamp, path)
+		return
+	}
+	if !w.save {
+		logConversion(w.convLog, path, stats, false)
+		fmt.Printf("%s %s: %s needed%s\n", timestamp, path, describeChangeCounts(stats), describeTopChanges(stats))
+		return
+	}
+
+	if err := report.CheckMaxChanges(stats, w.maxChanges); err != nil {
+		fmt.Fprintf(os.Stderr, "%s Error: Skipping %s: %v; file left untouched\n", timestamp, path, err)
+		return
+	}
+	if err := os.WriteFile(path, []byte(convertedContent), 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: Failed to save changes to file %s: %v\n", path, err)
+		logConversion(w.convLog, path, stats, false)
+		return
+	}
+	w.written[path] = convertedContent
+	logConversion(w.convLog, path, stats, true)
+	fmt.Printf("%s Saved changes to: %s (%s)%s\n", timestamp, path, describeChangeCounts(stats), describeTopChanges(stats))
+}
+
+// describeChangeCounts describes the changes in stats by category, such as "2 spelling
+// change(s), 1 unit conversion(s)"
+func describeChangeCounts(stats report.ChangeStats) string {
+	counts := []string{fmt.Sprintf("%d spelling change(s)", stats.SpellingChanges)}
+	if stats.UnitConversions > 0 {
+		counts = append(counts, fmt.Sprintf("%d unit conversion(s)", stats.UnitConversions))
+	}
+	if stats.QuoteChanges > 0 {
+		counts = append(counts, fmt.Sprintf("%d quote change(s)", stats.QuoteChanges))
+	}
+	return strings.Join(counts, ", ")
+}
+
+// describeTopChanges lists the most frequent substitutions in stats after a colon, such as
+// ": color → colour, center → centre", or returns "" when there are none
+func describeTopChanges(stats report.ChangeStats) string {
+	if len(stats.ChangeDetails) == 0 {
+		return ""
+	}
+	var changes []string
+	for i, change := range stats.ChangeDetails {
+		if i == watchSummaryChanges {
+			changes = append(changes, fmt.Sprintf("and %d more", len(stats.ChangeDetails)-i))
+			break
+		}
+		changes = append(changes, change.Original+" → "+change.Converted)
+	}
+	return ": " + strings.Join(changes, ", ")
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/sammcj/m2e/pkg/converter"
+	"github.com/sammcj/m2e/pkg/report"
+)
+
+// handleWritePatch converts the files under paths without modifying them and writes a single
+// patch covering every changed file to patchFile ('-' for stdout), to be reviewed or applied
+// with "git apply". Paths in the patch are relative to the current directory.
+func handleWritePatch(paths []string, patchFile string, conv *converter.Converter, normaliseSmartQuotes, exitOnChange bool,
+	maxFileSize int, convLog *report.ConversionLog) error {
+	results := collectReportResults(paths, conv, normaliseSmartQuotes, maxFileSize, convLog)
+
+	changed := 0
+	for i, result := range results {
+		if result.Error != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Skipping %s: %v\n", result.FilePath, result.Error)
+			continue
+		}
+		if result.HasChanges {
+			changed++
+		}
+		results[i].FilePath = patchDisplayPath(result.FilePath)
+	}
+
+	patch := report.FormatPatch(results)
+	if patchFile == "-" {
+		fmt.Print(patch)
+	} else {
+		if err := os.WriteFile(patchFile, []byte(patch), 0644); err != nil {
+			return fmt.Errorf("failed to write patch to %s: %w", patchFile, err)
+		}
+		fmt.Fprintf(os.Stderr, "Wrote a patch for %d changed file(s) of %d to %s\n", changed, len(results), patchFile)
+	}
+
+	if exitOnChange && changed > 0 {
+		os.Exit(exitChanges)
+	}
+	return nil
+}
+
+// patchDisplayPath returns path as it's named in a patch: relative to the current directory
+// where it's inside it, and with forward slashes
+func patchDisplayPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		if cwd, err := os.Getwd(); err == nil {
+			if rel, err := filepath.Rel(cwd, abs); err == nil && filepath.IsLocal(rel) {
+				path = rel
+			}
+		}
+	}
+	return filepath.ToSlash(filepath.Clean(path))
+}
@@ -0,0 +1,94 @@
+// Package cache provides an on-disk cache of conversion results, keyed by a hash of the input
+// and of everything that affects its conversion
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Cache stores JSON-encoded values in files under a directory, one file per key. Writes go to
+// a temporary file that is renamed into place, so concurrent runs sharing the directory never
+// see a partly written entry.
+type Cache struct {
+	dir string
+}
+
+// DefaultDir returns the cache directory, ~/.cache/m2e
+func DefaultDir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".cache", "m2e"), nil
+}
+
+// New returns a cache storing its entries under dir, which is created when the first entry is
+// written
+func New(dir string) *Cache {
+	return &Cache{dir: dir}
+}
+
+// Key hashes parts into a cache key. Each part is length-prefixed, so different splits of the
+// same bytes give different keys.
+func Key(parts ...string) string {
+	h := sha256.New()
+	for _, part := range parts {
+		fmt.Fprintf(h, "%d:%s", len(part), part)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// path returns the file holding key, fanned out into subdirectories by its first two characters
+func (c *Cache) path(key string) string {
+	if len(key) < 2 {
+		return filepath.Join(c.dir, key+".json")
+	}
+	return filepath.Join(c.dir, key[:2], key+".json")
+}
+
+// Get decodes the entry for key into v, reporting whether there was one. Missing and
+// unreadable entries are both misses.
+func (c *Cache) Get(key string, v any) bool {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return false
+	}
+	return json.Unmarshal(data, v) == nil
+}
+
+// Put stores v as the entry for key, replacing any existing entry
+func (c *Cache) Put(key string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode cache entry: %w", err)
+	}
+
+	path := c.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".entry-*")
+	if err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+// Package converter provides warnings about judgement calls made during conversion
+package converter
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// lowConfidence is the confidence below which a contextual change is reported as a judgement
+// call; changes below the detector's minimum confidence aren't made at all
+const lowConfidence = 0.8
+
+// AmbiguityWarning describes a judgement call made while converting that may be worth reviewing:
+// a change the converter wasn't sure of, or a word it left alone because its context was unclear
+type AmbiguityWarning struct {
+	Text      string `json:"text"`                // the word or measurement as written
+	Converted string `json:"converted,omitempty"` // what it became; empty when it was left as written
+	Reason    string `json:"reason"`
+	// Context is the text around it, for finding it again
+	Context    string  `json:"context"`
+	Confidence float64 `json:"confidence,omitempty"`
+}
+
+// String formats the warning as `"license" → "licence": reason (confidence 0.75)`, or
+// `"license" left as written: reason` when it wasn't changed
+func (w AmbiguityWarning) String() string {
+	var b strings.Builder
+	if w.Converted != "" {
+		fmt.Fprintf(&b, "%q → %q: %s", w.Text, w.Converted, w.Reason)
+	} else {
+		fmt.Fprintf(&b, "%q left as written: %s", w.Text, w.Reason)
+	}
+	if w.Confidence > 0 {
+		fmt.Fprintf(&b, " (confidence %.2f)", w.Confidence)
+	}
+	return b.String()
+}
+
+// warningLog collects ambiguity warnings as text is converted. It is shared by a converter's
+// copies and safe for concurrent use. A nil log records nothing.
+type warningLog struct {
+	mu      sync.Mutex
+	entries []AmbiguityWarning
+}
+
+// record adds warnings
+func (l *warningLog) record(warnings ...AmbiguityWarning) {
+	if l == nil || len(warnings) == 0 {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, warnings...)
+}
+
+// recordReversed adds warnings collected while working backwards through the text, in text order
+func (l *warningLog) recordReversed(warnings []AmbiguityWarning) {
+	slices.Reverse(warnings)
+	l.record(warnings...)
+}
+
+// take returns the recorded warnings and clears them
+func (l *warningLog) take() []AmbiguityWarning {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	entries := l.entries
+	l.entries = nil
+	return entries
+}
+
+// SetAmbiguityWarningsEnabled makes the converter record the judgement calls it makes, for
+// TakeAmbiguityWarnings to return: contextual changes made with low confidence, noun/verb words
+// such as "license" left alone because no rule matched their context, and measurements converted
+// next to an idiom such as "cold feet". It is enabled from the start when the contextual word
+// config sets preferences.showAmbiguityWarnings. Copies made by Clone and ForFile after it is
+// enabled share the same record.
+func (c *Converter) SetAmbiguityWarningsEnabled(enabled bool) {
+	if !enabled {
+		c.warnings = nil
+	} else if c.warnings == nil {
+		c.warnings = &warningLog{}
+	}
+	if c.unitProcessor != nil {
+		c.unitProcessor.warnings = c.warnings
+	}
+}
+
+// IsAmbiguityWarningsEnabled returns whether the converter records the judgement calls it makes
+func (c *Converter) IsAmbiguityWarningsEnabled() bool {
+	return c.warnings != nil
+}
+
+// TakeAmbiguityWarnings returns the warnings recorded since it was last called, and clears them
+func (c *Converter) TakeAmbiguityWarnings() []AmbiguityWarning {
+	return c.warnings.take()
+}
+
+// nounVerbWordRegex matches whole words, for finding noun/verb words the contextual detector
+// left alone
+var nounVerbWordRegex = regexp.MustCompile(`[A-Za-z]+`)
+
+// warnAmbiguousContext records each noun/verb word in text, such as "license" or "practice",
+// that none of matches covers, so it was left as written because no rule recognised its context
+func (c *Converter) warnAmbiguousContext(text string, matches []ContextualWordMatch) {
+	detector, ok := c.contextualWordDetector.(interface{ nounVerbWords(string) map[string]bool })
+	if !ok {
+		return
+	}
+	words := detector.nounVerbWords(text)
+	if len(words) == 0 {
+		return
+	}
+
+	var warnings []AmbiguityWarning
+	for _, span := range nounVerbWordRegex.FindAllStringIndex(text, -1) {
+		word := text[span[0]:span[1]]
+		if !words[strings.ToLower(word)] || c.isExcludedWord(word) || !c.isAllowedWord(word) {
+			continue
+		}
+		covered := false
+		for _, match := range matches {
+			if match.Start < span[1] && span[0] < match.End {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			warnings = append(warnings, AmbiguityWarning{
+				Text:    word,
+				Reason:  "unclear whether it's a noun or a verb",
+				Context: warningContext(text, span[0], span[1]),
+			})
+		}
+	}
+	c.warnings.record(warnings...)
+}
+
+// warningContext returns the line of text around start and end, trimmed to about 40 bytes on
+// either side
+func warningContext(text string, start, end int) string {
+	from := strings.LastIndexByte(text[:start], '\n') + 1
+	from = max(from, start-40)
+	to := len(text)
+	if i := strings.IndexByte(text[end:], '\n'); i >= 0 {
+		to = end + i
+	}
+	to = min(to, end+40)
+	return strings.TrimSpace(strings.ToValidUTF8(text[from:to], ""))
+}
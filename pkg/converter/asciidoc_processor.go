@@ -0,0 +1,220 @@
+// Package converter provides AsciiDoc processing that converts prose while preserving markup
+package converter
+
+import (
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var (
+	// asciiDocAttributeEntryRegex matches an attribute entry such as ":toc: left" or ":!sectnums:"
+	asciiDocAttributeEntryRegex = regexp.MustCompile(`^:!?\w[\w-]*!?:(?:[ \t]|$)`)
+
+	// asciiDocBlockAttributeRegex matches a block attribute, anchor or style line such as
+	// "[source,go]", "[NOTE]" or "[[install]]"
+	asciiDocBlockAttributeRegex = regexp.MustCompile(`^\[.*\][ \t]*$`)
+
+	// asciiDocBlockMacroRegex matches a block macro or directive such as "image::color.png[]",
+	// "include::setup.adoc[]" or "ifdef::env-github[]"
+	asciiDocBlockMacroRegex = regexp.MustCompile(`^[a-z][\w-]*::\S*\[.*\][ \t]*$`)
+
+	// asciiDocDelimiterRegex matches a delimited block's opening or closing line
+	asciiDocDelimiterRegex = regexp.MustCompile("^(?:-{4,}|\\.{4,}|\\+{4,}|/{4,}|={4,}|\\*{4,}|_{4,}|--)[ \\t]*$")
+
+	// asciiDocFenceRegex matches a Markdown-style ``` fence, which AsciiDoc treats as a source block
+	asciiDocFenceRegex = regexp.MustCompile("^(`{3,})[ \\t]*([\\w+#.-]*)[ \\t]*$")
+
+	// asciiDocListItemRegex matches a list item, which may be indented without being literal text
+	asciiDocListItemRegex = regexp.MustCompile(`^[ \t]+(?:[*.\-]+|\d+\.)[ \t]`)
+
+	// asciiDocInlineRegex matches inline markup whose text, if any, is converted but whose
+	// syntax and targets are preserved: monospace, passthroughs, attribute references, anchors,
+	// cross references (id in group 1, text in group 2), inline macros such as link:url[text]
+	// (name, target and text in groups 3 to 5) and bare URLs
+	asciiDocInlineRegex = regexp.MustCompile("`[^`\\n]+`" +
+		`|\+\+\+.*?\+\+\+|\+[^+\s][^+\n]*\+` +
+		`|\{[\w-]+\}` +
+		`|\[\[[^\]\n]*\]\]` +
+		`|<<([^,>\n]+)(?:,([^>\n]*))?>>` +
+		`|\b([a-z][\w-]*):([^\s\[\]]*)\[([^\]\n]*)\]` +
+		`|\b(?:https?|ftp|irc)://[^\s\[\]<>]+`)
+)
+
+// asciiDocProseMacros lists the inline macros whose bracketed text is prose, such as a link's
+// text or an image's alt text. Other macros, such as kbd:[] and pass:[], are left untouched.
+var asciiDocProseMacros = []string{"link", "xref", "footnote", "image", "mailto", "http", "https", "ftp", "irc"}
+
+// AsciiDocProcessor converts the prose of AsciiDoc documents, preserving attribute entries,
+// block attributes, block macros, macro targets and code
+type AsciiDocProcessor struct{}
+
+// NewAsciiDocProcessor creates a new AsciiDoc processor
+func NewAsciiDocProcessor() *AsciiDocProcessor {
+	return &AsciiDocProcessor{}
+}
+
+// IsAsciiDocFile checks if a file extension indicates an AsciiDoc document
+func IsAsciiDocFile(filePath string) bool {
+	ext := strings.ToLower(filepath.Ext(filePath))
+	return ext == ".adoc" || ext == ".asciidoc"
+}
+
+// ProcessDocument converts text with convertProse, except for:
+//
+//   - attribute entries (":name: value"), block attribute lines ("[source,go]") and block
+//     macros ("image::file.png[]"), which are left as they are
+//   - source and listing blocks (---- or ``` delimited), which are passed to convertCode with
+//     the language from their [source,lang] attribute, so only their comments change
+//   - literal (....) and passthrough (++++) blocks and indented literal paragraphs, which are
+//     left as they are
+//   - inline macro targets, attribute references, anchors, passthroughs and monospace text,
+//     which are left as they are; the text of links, cross references and footnotes is converted
+func (p *AsciiDocProcessor) ProcessDocument(text string, convertProse func(string) string, convertCode func(code, language string) string) string {
+	lines := strings.SplitAfter(text, "\n")
+	var result strings.Builder
+	language := "" // from the last [source,lang] line, for the block that follows it
+	prevBlank := true
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		content := strings.TrimRight(line, "\r\n")
+		ending := line[len(content):]
+
+		switch {
+		case strings.TrimSpace(content) == "":
+			result.WriteString(line)
+			prevBlank = true
+			continue
+
+		case asciiDocAttributeEntryRegex.MatchString(content) || asciiDocBlockMacroRegex.MatchString(content):
+			result.WriteString(line)
+
+		case asciiDocBlockAttributeRegex.MatchString(content):
+			language = asciiDocSourceLanguage(content)
+			result.WriteString(line)
+			prevBlank = false
+			continue
+
+		case asciiDocDelimiterRegex.MatchString(content) || asciiDocFenceRegex.MatchString(content):
+			closing := findAsciiDocClosingDelimiter(lines, i)
+			if closing < 0 {
+				// An unclosed delimiter is just text
+				result.WriteString(convertAsciiDocLine(content, convertProse) + ending)
+				break
+			}
+			body := strings.Join(lines[i+1:closing], "")
+			result.WriteString(line)
+			result.WriteString(p.processBlock(content, body, language, convertProse, convertCode))
+			result.WriteString(lines[closing])
+			i = closing
+
+		case prevBlank && (content[0] == ' ' || content[0] == '\t') && !asciiDocListItemRegex.MatchString(content):
+			// An indented paragraph is literal text, kept as is up to the next blank line
+			for ; i < len(lines) && strings.TrimSpace(lines[i]) != ""; i++ {
+				result.WriteString(lines[i])
+			}
+			i--
+
+		default:
+			result.WriteString(convertAsciiDocLine(content, convertProse) + ending)
+		}
+
+		language = ""
+		prevBlank = false
+	}
+
+	return result.String()
+}
+
+// processBlock converts the body of a delimited block according to its type
+func (p *AsciiDocProcessor) processBlock(delimiter, body, language string, convertProse func(string) string, convertCode func(code, language string) string) string {
+	if fence := asciiDocFenceRegex.FindStringSubmatch(delimiter); fence != nil {
+		if fence[2] != "" {
+			language = fence[2]
+		}
+		return convertCode(body, language)
+	}
+
+	switch strings.TrimSpace(delimiter)[0] {
+	case '-':
+		if strings.TrimSpace(delimiter) == "--" {
+			// An open block holds ordinary content
+			return p.ProcessDocument(body, convertProse, convertCode)
+		}
+		return convertCode(body, language)
+	case '.', '+':
+		// Literal and passthrough blocks are output exactly as written
+		return body
+	case '/':
+		// A comment block is prose that isn't rendered
+		return convertProse(body)
+	default:
+		// Example, sidebar and quote blocks hold ordinary content
+		return p.ProcessDocument(body, convertProse, convertCode)
+	}
+}
+
+// findAsciiDocClosingDelimiter returns the index of the line that closes the delimited block
+// opened at lines[open], or -1 if it isn't closed
+func findAsciiDocClosingDelimiter(lines []string, open int) int {
+	opening := strings.TrimSpace(lines[open])
+	if fence := asciiDocFenceRegex.FindStringSubmatch(opening); fence != nil {
+		opening = fence[1]
+	}
+	for i := open + 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == opening {
+			return i
+		}
+	}
+	return -1
+}
+
+// asciiDocSourceLanguage returns the language of a "[source,lang]" or "[,lang]" block
+// attribute line, or "" for other attribute lines
+func asciiDocSourceLanguage(line string) string {
+	attributes := strings.Split(strings.Trim(strings.TrimSpace(line), "[]"), ",")
+	if len(attributes) < 2 {
+		return ""
+	}
+	style := strings.TrimSpace(attributes[0])
+	if style != "source" && style != "" {
+		return ""
+	}
+	return strings.TrimSpace(attributes[1])
+}
+
+// convertAsciiDocLine converts a line of prose, preserving its inline markup
+func convertAsciiDocLine(line string, convertProse func(string) string) string {
+	matches := asciiDocInlineRegex.FindAllStringSubmatchIndex(line, -1)
+	if len(matches) == 0 {
+		return convertProse(line)
+	}
+
+	var result strings.Builder
+	last := 0
+	for _, match := range matches {
+		start, end := match[0], match[1]
+		if start > last {
+			result.WriteString(convertProse(line[last:start]))
+		}
+
+		switch {
+		case match[4] >= 0:
+			// Cross reference with text: <<id,text>>
+			result.WriteString(line[start:match[4]] + convertProse(line[match[4]:match[5]]) + line[match[5]:end])
+		case match[6] >= 0 && slices.Contains(asciiDocProseMacros, line[match[6]:match[7]]):
+			// Inline macro whose text is prose: name:target[text]
+			result.WriteString(line[start:match[10]] + convertProse(line[match[10]:match[11]]) + line[match[11]:end])
+		default:
+			result.WriteString(line[start:end])
+		}
+		last = end
+	}
+	if last < len(line) {
+		result.WriteString(convertProse(line[last:]))
+	}
+
+	return result.String()
+}
@@ -0,0 +1,246 @@
+// Package converter provides conversion that reports where each change was made and why
+package converter
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// ChangeCategory is the kind of rule that made a change
+type ChangeCategory string
+
+const (
+	// ChangeSpelling is a dictionary spelling change, such as "color" → "colour"
+	ChangeSpelling ChangeCategory = "spelling"
+	// ChangeContextual is a spelling that depends on context, such as "license" → "licence"
+	ChangeContextual ChangeCategory = "contextual"
+	// ChangePhrase is a rewritten phrase, such as "on the weekend" → "at the weekend"
+	ChangePhrase ChangeCategory = "phrase"
+	// ChangeUnit is a converted or normalised measurement, such as "10 feet" → "3 metres"
+	ChangeUnit ChangeCategory = "unit"
+	// ChangePunctuation is a normalised smart quote or dash, or punctuation moved outside a quote
+	ChangePunctuation ChangeCategory = "punctuation"
+	// ChangeOther is a change no recorded rule accounts for
+	ChangeOther ChangeCategory = "other"
+)
+
+// Options are the settings for a single ConvertWithChanges call
+type Options struct {
+	NormaliseSmartQuotes bool
+	// Filename, if set, chooses how the text is converted from its extension, as for
+	// ConvertFileContent, so that only the comments of code change
+	Filename string
+}
+
+// Change is a single change made by ConvertWithChanges
+type Change struct {
+	Start       int            `json:"start"` // byte offset of Original in the original text
+	End         int            `json:"end"`   // byte offset just past Original
+	Original    string         `json:"original"`
+	Replacement string         `json:"replacement"`
+	Category    ChangeCategory `json:"category"`
+	// Confidence is the detector's confidence in a contextual or unit change, from 0 to 1, and
+	// 0 for changes whose rule is never in doubt
+	Confidence float64 `json:"confidence,omitempty"`
+}
+
+// wordTokenRegex splits text into words (including contractions such as "don't"), runs of
+// whitespace and single punctuation characters
+var wordTokenRegex = regexp.MustCompile(`[\p{L}\p{N}_]+(?:'[\p{L}\p{N}_]+)*|\s+|.`)
+
+// ConvertWithChanges converts text and returns each change made to it, in order, with its
+// exact position in the original text and the kind of rule that made it. A change covers whole
+// words, and a phrase or measurement converted as one, such as "10 feet" → "3 metres", is a
+// single change. Unit conversion follows the converter's settings.
+func (c *Converter) ConvertWithChanges(text string, opts Options) (string, []Change) {
+	// Explanations give each change its category; any the caller is collecting are kept
+	explaining := c.IsExplainEnabled()
+	var earlier []Explanation
+	if explaining {
+		earlier = c.TakeExplanations()
+	} else {
+		c.SetExplainEnabled(true)
+		defer c.SetExplainEnabled(false)
+	}
+
+	var converted string
+	if opts.Filename != "" {
+		converted = c.ConvertFileContent(text, opts.Filename, opts.NormaliseSmartQuotes)
+	} else {
+		converted = c.ConvertToBritish(text, opts.NormaliseSmartQuotes)
+	}
+
+	explanations := c.TakeExplanations()
+	if explaining {
+		c.explain.record(append(earlier, explanations...)...)
+	}
+
+	return converted, ExplainedChanges(text, converted, explanations)
+}
+
+// ExplainedChanges returns the changes from original to converted, like ConvertWithChanges, using
+// the explanations recorded while converting to categorise them and give their confidence
+func ExplainedChanges(original, converted string, explanations []Explanation) []Change {
+	return categoriseChanges(mergeChanges(original, WordChanges(original, converted), explanations), explanations)
+}
+
+// WordChanges returns the word-level changes from original to converted in order, each with its
+// position in original and no category
+func WordChanges(original, converted string) []Change {
+	var changes []Change
+	var current *Change
+	pos := 0
+
+	for _, diff := range DiffWords(original, converted) {
+		if diff.Type == diffmatchpatch.DiffEqual {
+			if current != nil {
+				changes = append(changes, *current)
+				current = nil
+			}
+			pos += len(diff.Text)
+			continue
+		}
+
+		if current == nil {
+			current = &Change{Start: pos, End: pos}
+		}
+		if diff.Type == diffmatchpatch.DiffDelete {
+			current.Original += diff.Text
+			current.End += len(diff.Text)
+			pos += len(diff.Text)
+		} else {
+			current.Replacement += diff.Text
+		}
+	}
+	if current != nil {
+		changes = append(changes, *current)
+	}
+
+	return changes
+}
+
+// DiffWords diffs original and converted word by word, so each changed word is shown whole and
+// "color" → "colour" reads as a word swap rather than "colo[u]r". Like DiffLinesToRunes does for
+// lines, each distinct word is encoded as a single rune, the runes are diffed, and the result is
+// decoded back to words.
+func DiffWords(original, converted string) []diffmatchpatch.Diff {
+	var tokens []string
+	tokenRunes := make(map[string]rune)
+	encode := func(text string) []rune {
+		var encoded []rune
+		for _, token := range wordTokenRegex.FindAllString(text, -1) {
+			r, ok := tokenRunes[token]
+			if !ok {
+				r = tokenRune(len(tokens))
+				tokens = append(tokens, token)
+				tokenRunes[token] = r
+			}
+			encoded = append(encoded, r)
+		}
+		return encoded
+	}
+
+	diffs := diffmatchpatch.New().DiffMainRunes(encode(original), encode(converted), false)
+	for i, diff := range diffs {
+		var decoded strings.Builder
+		for _, r := range diff.Text {
+			decoded.WriteString(tokens[tokenIndex(r)])
+		}
+		diffs[i].Text = decoded.String()
+	}
+	return diffs
+}
+
+// tokenRune encodes a token index as a rune, skipping the surrogate range so every index maps
+// to a valid rune that survives conversion to and from a string
+func tokenRune(index int) rune {
+	if index >= 0xD800 {
+		return rune(index + 0x800)
+	}
+	return rune(index)
+}
+
+// tokenIndex reverses tokenRune
+func tokenIndex(r rune) int {
+	if r >= 0xE000 {
+		return int(r) - 0x800
+	}
+	return int(r)
+}
+
+// mergeChanges joins changes separated only by whitespace when a single rule made them, such as
+// the number and unit of "10 feet" → "3 metres", which the word diff splits in two
+func mergeChanges(original string, changes []Change, explanations []Explanation) []Change {
+	var merged []Change
+	for _, change := range changes {
+		if len(merged) > 0 {
+			last := &merged[len(merged)-1]
+			gap := original[last.End:change.Start]
+			if strings.TrimSpace(gap) == "" {
+				joined := Change{
+					Start:       last.Start,
+					End:         change.End,
+					Original:    original[last.Start:change.End],
+					Replacement: last.Replacement + gap + change.Replacement,
+				}
+				if explainedBySingleRule(joined, explanations) {
+					*last = joined
+					continue
+				}
+			}
+		}
+		merged = append(merged, change)
+	}
+	return merged
+}
+
+// explainedBySingleRule reports whether one explanation covers all of a multi-word change
+func explainedBySingleRule(change Change, explanations []Explanation) bool {
+	for _, explanation := range explanations {
+		if strings.Contains(explanation.Original, change.Original) && strings.Contains(explanation.Converted, change.Replacement) {
+			return true
+		}
+	}
+	return false
+}
+
+// categoriseChanges sets the category and confidence of each change from the explanation of
+// the rule that made it, preferring one that matches the change exactly
+func categoriseChanges(changes []Change, explanations []Explanation) []Change {
+	for i, change := range changes {
+		changes[i].Category = ChangeOther
+		replacement := strings.TrimSpace(change.Replacement)
+		for _, explanation := range explanations {
+			if explanation.Original == change.Original && explanation.Converted == change.Replacement {
+				changes[i].Category = ruleCategory(explanation.Rule)
+				changes[i].Confidence = explanation.Confidence
+				break
+			}
+			if changes[i].Category == ChangeOther && strings.Contains(explanation.Original, change.Original) &&
+				strings.Contains(explanation.Converted, replacement) && (change.Original != "" || replacement != "") {
+				changes[i].Category = ruleCategory(explanation.Rule)
+				changes[i].Confidence = explanation.Confidence
+			}
+		}
+	}
+	return changes
+}
+
+// ruleCategory returns the category of an explanation's rule
+func ruleCategory(rule string) ChangeCategory {
+	switch {
+	case strings.HasPrefix(rule, "dictionary"):
+		return ChangeSpelling
+	case strings.HasPrefix(rule, "contextual"):
+		return ChangeContextual
+	case strings.HasPrefix(rule, "phrase"):
+		return ChangePhrase
+	case strings.HasPrefix(rule, "unit"):
+		return ChangeUnit
+	case strings.HasPrefix(rule, "smart quotes") || strings.HasPrefix(rule, "dashes") || strings.HasPrefix(rule, "quote punctuation"):
+		return ChangePunctuation
+	}
+	return ChangeOther
+}
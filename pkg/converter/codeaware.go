@@ -1,7 +1,9 @@
 package converter
 
 import (
+	"cmp"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/alecthomas/chroma/v2/lexers"
@@ -9,10 +11,7 @@ import (
 
 // Pre-compiled regex patterns for code block detection and comment extraction.
 var (
-	backtickFenceRegex = regexp.MustCompile(`(?ms)^` + "`{3}" + `([a-zA-Z0-9+-]*)\n?(.*?)\n?` + "`{3}" + `\s*$`)
-	tildeFenceRegex    = regexp.MustCompile(`(?ms)^~~~([a-zA-Z0-9+-]*)\n?(.*?)\n?~~~\s*$`)
-	inlineCodeRegex    = regexp.MustCompile("`([^`\n]+)`")
-	splitFenceRegex    = regexp.MustCompile(`(?s)` + "`{3}" + `([a-zA-Z0-9+-]*)\n?(.*?)\n?` + "`{3}" + `|(?s)~~~([a-zA-Z0-9+-]*)\n?(.*?)\n?~~~`)
+	inlineCodeRegex = regexp.MustCompile("`([^`\n]+)`")
 
 	lineCommentPatterns = []*regexp.Regexp{
 		regexp.MustCompile(`//.*?(?:\n|$)`),
@@ -63,68 +62,18 @@ func (c *Converter) DetectCodeBlocks(text string) []CodeBlock {
 	return blocks
 }
 
-// detectMarkdownCodeBlocks finds fenced code blocks (``` and ~~~)
+// detectMarkdownCodeBlocks finds fenced (``` and ~~~) and indented code blocks
 func (c *Converter) detectMarkdownCodeBlocks(text string) []CodeBlock {
 	var blocks []CodeBlock
-
-	// Process backtick fenced blocks
-	matches := backtickFenceRegex.FindAllStringSubmatchIndex(text, -1)
-	for _, match := range matches {
-		start := match[0]
-		end := match[1]
-		langStart := match[2]
-		langEnd := match[3]
-		contentStart := match[4]
-		contentEnd := match[5]
-
-		language := ""
-		if langStart >= 0 && langEnd > langStart {
-			language = text[langStart:langEnd]
-		}
-
-		content := ""
-		if contentStart >= 0 && contentEnd > contentStart {
-			content = text[contentStart:contentEnd]
-		}
-
+	for _, block := range findMarkdownCodeBlocks(text) {
 		blocks = append(blocks, CodeBlock{
-			Start:    start,
-			End:      end,
-			Language: language,
-			Content:  content,
+			Start:    block.start,
+			End:      block.end,
+			Language: block.language,
+			Content:  text[block.contentStart:block.contentEnd],
 			IsCode:   true,
 		})
 	}
-
-	// Process tilde fenced blocks
-	matches = tildeFenceRegex.FindAllStringSubmatchIndex(text, -1)
-	for _, match := range matches {
-		start := match[0]
-		end := match[1]
-		langStart := match[2]
-		langEnd := match[3]
-		contentStart := match[4]
-		contentEnd := match[5]
-
-		language := ""
-		if langStart >= 0 && langEnd > langStart {
-			language = text[langStart:langEnd]
-		}
-
-		content := ""
-		if contentStart >= 0 && contentEnd > contentStart {
-			content = text[contentStart:contentEnd]
-		}
-
-		blocks = append(blocks, CodeBlock{
-			Start:    start,
-			End:      end,
-			Language: language,
-			Content:  content,
-			IsCode:   true,
-		})
-	}
-
 	return blocks
 }
 
@@ -233,6 +182,10 @@ func (c *Converter) fillTextSegments(text string, codeBlocks []CodeBlock) []Code
 
 // ExtractComments extracts comment text from code using Chroma
 func (c *Converter) ExtractComments(code, language string) []CommentBlock {
+	if isConfigCommentLanguage(language) {
+		return c.extractConfigComments(code, language)
+	}
+
 	// For now, use manual extraction as it handles newlines better
 	// TODO: Fix Chroma extraction to include proper boundaries
 	return c.extractCommentsManually(code)
@@ -266,12 +219,8 @@ func (c *Converter) extractCommentsManuallyWithConversion(code string, convertUn
 			content = strings.TrimSuffix(content, "\n")
 
 			// Apply unit conversion if requested
-			if convertUnits && c.unitProcessor != nil && c.unitProcessor.IsEnabled() {
-				// Apply spelling conversion first
-				convertedContent := c.ConvertToBritishSimple(content, normaliseSmartQuotes)
-				// Then apply unit conversion
-				convertedContent = c.unitProcessor.ProcessText(convertedContent, false, "")
-				content = convertedContent
+			if convertUnits && c.unitProcessor != nil && c.unitProcessor.IsActive() {
+				content = c.convertProse(content, normaliseSmartQuotes)
 			}
 
 			comments = append(comments, CommentBlock{
@@ -291,12 +240,8 @@ func (c *Converter) extractCommentsManuallyWithConversion(code string, convertUn
 			content := code[start:end]
 
 			// Apply unit conversion if requested
-			if convertUnits && c.unitProcessor != nil && c.unitProcessor.IsEnabled() {
-				// Apply spelling conversion first
-				convertedContent := c.ConvertToBritishSimple(content, normaliseSmartQuotes)
-				// Then apply unit conversion
-				convertedContent = c.unitProcessor.ProcessText(convertedContent, false, "")
-				content = convertedContent
+			if convertUnits && c.unitProcessor != nil && c.unitProcessor.IsActive() {
+				content = c.convertProse(content, normaliseSmartQuotes)
 			}
 
 			comments = append(comments, CommentBlock{
@@ -307,21 +252,33 @@ func (c *Converter) extractCommentsManuallyWithConversion(code string, convertUn
 		}
 	}
 
-	return comments
+	return dropNestedComments(comments)
+}
+
+// dropNestedComments sorts comments by position and drops any that start inside an earlier
+// one, such as the "#1" in "// see #1" or a "//" inside a block comment. Callers replace
+// comments from the last to the first, which only works when they're in order and apart.
+func dropNestedComments(comments []CommentBlock) []CommentBlock {
+	slices.SortStableFunc(comments, func(a, b CommentBlock) int {
+		return cmp.Or(cmp.Compare(a.Start, b.Start), cmp.Compare(b.End, a.End))
+	})
+
+	kept := comments[:0]
+	for _, comment := range comments {
+		if len(kept) > 0 && comment.Start < kept[len(kept)-1].End {
+			continue
+		}
+		kept = append(kept, comment)
+	}
+	return kept
 }
 
 // ProcessCodeAware processes text with code-awareness
 func (c *Converter) ProcessCodeAware(text string, normaliseSmartQuotes bool) string {
 	// Simple approach: check if we have any code blocks at all
 	// If not, use regular conversion with both spelling and unit conversion
-	if !c.containsCodeBlocks(text) {
-		// Apply spelling conversion first
-		result := c.ConvertToBritishSimple(text, normaliseSmartQuotes)
-		// Then apply unit conversion
-		if c.unitProcessor != nil && c.unitProcessor.IsEnabled() {
-			result = c.unitProcessor.ProcessText(result, false, "")
-		}
-		return result
+	if !c.markdownProcessing || !c.containsCodeBlocks(text) {
+		return c.convertProse(text, normaliseSmartQuotes)
 	}
 
 	// Process the text by converting only non-code parts
@@ -334,6 +291,10 @@ func (c *Converter) containsCodeBlocks(text string) bool {
 	if strings.Contains(text, "```") || strings.Contains(text, "~~~") {
 		return true
 	}
+	// Check for indented code blocks, which can't start the text
+	if strings.Contains(text, "\n    ") || strings.Contains(text, "\n\t") {
+		return true
+	}
 	// Check for inline code
 	if strings.Contains(text, "`") {
 		return true
@@ -343,38 +304,18 @@ func (c *Converter) containsCodeBlocks(text string) bool {
 
 // processTextWithCodeBlocks processes text while preserving code blocks
 func (c *Converter) processTextWithCodeBlocks(text string, normaliseSmartQuotes bool) string {
-	// Handle fenced code blocks first (``` and ~~~)
-	result := c.processFencedCodeBlocks(text, normaliseSmartQuotes)
-	// Then handle inline code
-	result = c.processInlineCode(result, normaliseSmartQuotes)
-	return result
-}
-
-// processFencedCodeBlocks handles markdown fenced code blocks
-func (c *Converter) processFencedCodeBlocks(text string, normaliseSmartQuotes bool) string {
-	// Split by fenced code blocks and process each part
+	// Split by fenced and indented code blocks and process each part
 	parts := c.splitByFencedBlocks(text)
 	var result strings.Builder
 
 	for _, part := range parts {
 		if part.IsCode {
-			// This is a code block - only convert comments
+			// This is a code block - only convert comments, keeping the fences exactly as they were
 			convertedContent := c.convertCommentsInCode(part.Content, part.Language, normaliseSmartQuotes)
-
-			// Reconstruct the full block with original fence type
-			if part.Language != "" {
-				result.WriteString(part.FenceType + part.Language + "\n" + convertedContent + "\n" + part.FenceType)
-			} else {
-				result.WriteString(part.FenceType + "\n" + convertedContent + "\n" + part.FenceType)
-			}
+			result.WriteString(part.Opening + convertedContent + part.Closing)
 		} else {
-			// Regular text - apply both spelling and unit conversion
-			converted := c.ConvertToBritishSimple(part.Content, normaliseSmartQuotes)
-			// Then apply unit conversion
-			if c.unitProcessor != nil && c.unitProcessor.IsEnabled() {
-				converted = c.unitProcessor.ProcessText(converted, false, "")
-			}
-			result.WriteString(converted)
+			// Regular text - convert everything but inline code
+			result.WriteString(c.processInlineCode(part.Content, normaliseSmartQuotes))
 		}
 	}
 
@@ -383,20 +324,10 @@ func (c *Converter) processFencedCodeBlocks(text string, normaliseSmartQuotes bo
 
 // processInlineCode handles inline code blocks
 func (c *Converter) processInlineCode(text string, normaliseSmartQuotes bool) string {
-	// If the text contains fenced code blocks, don't process inline code
-	// because the fenced blocks have already been processed correctly
-	if strings.Contains(text, "```") || strings.Contains(text, "~~~") {
-		return text
-	}
-
 	// Check if there are any inline code matches
 	if !inlineCodeRegex.MatchString(text) {
 		// No inline code, process as regular text
-		converted := c.ConvertToBritishSimple(text, normaliseSmartQuotes)
-		if c.unitProcessor != nil && c.unitProcessor.IsEnabled() {
-			converted = c.unitProcessor.ProcessText(converted, false, "")
-		}
-		return converted
+		return c.convertProse(text, normaliseSmartQuotes)
 	}
 
 	// Split the text by inline code blocks and process the non-code parts
@@ -407,15 +338,18 @@ func (c *Converter) processInlineCode(text string, normaliseSmartQuotes bool) st
 	for i, part := range parts {
 		if part != "" {
 			// This is regular text - apply both spelling and unit conversion
-			converted := c.ConvertToBritishSimple(part, normaliseSmartQuotes)
-			if c.unitProcessor != nil && c.unitProcessor.IsEnabled() {
-				converted = c.unitProcessor.ProcessText(converted, false, "")
-			}
-			result.WriteString(converted)
+			result.WriteString(c.convertProse(part, normaliseSmartQuotes))
 		}
 
 		// Add back the inline code block if it exists
 		if i < len(matches) {
+			if c.convertInlineCode {
+				// Backticks are used for emphasis rather than code, so convert the span's content
+				content := matches[i][1 : len(matches[i])-1]
+				result.WriteString("`" + c.convertProse(content, normaliseSmartQuotes) + "`")
+				continue
+			}
+
 			// For inline code, preserve it as-is (don't convert anything)
 			// Inline code should not be processed for spelling changes
 			result.WriteString(matches[i])
@@ -430,69 +364,42 @@ type TextPart struct {
 	Content   string
 	IsCode    bool
 	Language  string
-	FenceType string // "```" or "~~~" for fenced code blocks
+	FenceType string // opening fence of a fenced code block, e.g. "```" or "~~~~"; empty for indented blocks
+	Opening   string // opening fence line of a code block, including its newline
+	Closing   string // newline and closing fence line of a code block
 }
 
-// splitByFencedBlocks splits text by fenced code blocks
+// splitByFencedBlocks splits text by fenced and indented code blocks
 func (c *Converter) splitByFencedBlocks(text string) []TextPart {
 	var parts []TextPart
 
 	lastEnd := 0
-	matches := splitFenceRegex.FindAllStringSubmatchIndex(text, -1)
-
-	for _, match := range matches {
-		start := match[0]
-		end := match[1]
-
+	for _, block := range findMarkdownCodeBlocks(text) {
 		// Add text before this code block
-		if start > lastEnd {
-			textContent := text[lastEnd:start]
-			if textContent != "" {
-				parts = append(parts, TextPart{
-					Content: textContent,
-					IsCode:  false,
-				})
-			}
-		}
-
-		// Determine language, content, and fence type
-		var language, content, fenceType string
-		if match[2] >= 0 { // Backtick fence
-			language = text[match[2]:match[3]]
-			content = text[match[4]:match[5]]
-			fenceType = "```"
-		} else if match[6] >= 0 { // Tilde fence
-			language = text[match[6]:match[7]]
-			content = text[match[8]:match[9]]
-			fenceType = "~~~"
+		if block.start > lastEnd {
+			parts = append(parts, TextPart{
+				Content: text[lastEnd:block.start],
+				IsCode:  false,
+			})
 		}
 
 		// Add the code block
 		parts = append(parts, TextPart{
-			Content:   content,
+			Content:   text[block.contentStart:block.contentEnd],
 			IsCode:    true,
-			Language:  language,
-			FenceType: fenceType,
+			Language:  block.language,
+			FenceType: block.fence,
+			Opening:   text[block.start:block.contentStart],
+			Closing:   text[block.contentEnd:block.end],
 		})
 
-		lastEnd = end
+		lastEnd = block.end
 	}
 
-	// Add remaining text
-	if lastEnd < len(text) {
-		remaining := text[lastEnd:]
-		if remaining != "" {
-			parts = append(parts, TextPart{
-				Content: remaining,
-				IsCode:  false,
-			})
-		}
-	}
-
-	// If no code blocks found, return the entire text as one part
-	if len(parts) == 0 {
+	// Add remaining text, or the entire text if no code blocks were found
+	if lastEnd < len(text) || len(parts) == 0 {
 		parts = append(parts, TextPart{
-			Content: text,
+			Content: text[lastEnd:],
 			IsCode:  false,
 		})
 	}
@@ -516,12 +423,9 @@ func (c *Converter) convertCommentsInCode(code, language string, normaliseSmartQ
 		// Get the original comment block (including any trailing newline)
 		originalBlock := code[comment.Start:comment.End]
 
-		// Convert just the comment content (without newline) - apply both spelling and unit conversion
-		converted := c.ConvertToBritishSimple(comment.Content, normaliseSmartQuotes)
-		// Then apply unit conversion
-		if c.unitProcessor != nil && c.unitProcessor.IsEnabled() {
-			converted = c.unitProcessor.ProcessText(converted, false, "")
-		}
+		// Convert just the comment content (without newline) - apply both spelling and unit
+		// conversion to its words, keeping escapes and quoted strings as they are
+		converted := c.convertCommentText(comment.Content, normaliseSmartQuotes)
 
 		// If the original block had a trailing newline, preserve it
 		if strings.HasSuffix(originalBlock, "\n") {
@@ -536,3 +440,92 @@ func (c *Converter) convertCommentsInCode(code, language string, normaliseSmartQ
 
 	return code
 }
+
+// convertCommentText converts the prose in a comment from a code block, leaving the escape
+// sequences and quoted strings in it verbatim, so // color = "\n" only changes "color".
+// Like inline code, each span between them is converted on its own.
+func (c *Converter) convertCommentText(text string, normaliseSmartQuotes bool) string {
+	spans := commentVerbatimSpans(text)
+	if len(spans) == 0 {
+		return c.convertProse(text, normaliseSmartQuotes)
+	}
+
+	var result strings.Builder
+	last := 0
+	for _, span := range spans {
+		if span[0] > last {
+			result.WriteString(c.convertProse(text[last:span[0]], normaliseSmartQuotes))
+		}
+		result.WriteString(text[span[0]:span[1]])
+		last = span[1]
+	}
+	if last < len(text) {
+		result.WriteString(c.convertProse(text[last:], normaliseSmartQuotes))
+	}
+	return result.String()
+}
+
+// commentVerbatimSpans returns the start and end of each backslash escape and quoted string in
+// a comment, in order. An escape runs from the backslash to the end of the word after it, so
+// \bcolor\b in a regular expression is kept too. Strings are double-quoted, backticked or
+// single-quoted; a single quote only opens a string when it doesn't follow a letter or digit, so
+// apostrophes in "don't" and "the color's" aren't mistaken for one. Runs of quotes such as the
+// """ around a docstring aren't strings, and a quote that is never closed is left as prose.
+func commentVerbatimSpans(text string) [][2]int {
+	var spans [][2]int
+	for i := 0; i < len(text); i++ {
+		switch ch := text[i]; ch {
+		case '\\':
+			end := min(i+2, len(text))
+			for end < len(text) && isWordByte(text[end]) {
+				end++
+			}
+			spans = append(spans, [2]int{i, end})
+			i = end - 1
+		case '"', '`', '\'':
+			if i+1 < len(text) && text[i+1] == ch {
+				for i+1 < len(text) && text[i+1] == ch {
+					i++
+				}
+				continue
+			}
+			if ch == '\'' && i > 0 && isWordByte(text[i-1]) {
+				continue
+			}
+			if end := closingQuote(text, i); end > 0 {
+				spans = append(spans, [2]int{i, end})
+				i = end - 1
+			}
+		}
+	}
+	return spans
+}
+
+// closingQuote returns the index just after the quote that closes the string opened at start,
+// or 0 when it isn't closed on the same line. Backslashes escape the next byte in double-quoted
+// strings, and a single quote only closes a string when no letter or digit follows it.
+func closingQuote(text string, start int) int {
+	quote := text[start]
+	for i := start + 1; i < len(text); i++ {
+		switch text[i] {
+		case '\n':
+			return 0
+		case '\\':
+			if quote == '"' {
+				i++
+			}
+		case quote:
+			if quote == '\'' && i+1 < len(text) && isWordByte(text[i+1]) {
+				continue
+			}
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// isWordByte reports whether b is a letter, digit or underscore, counting any byte of a
+// multi-byte character as a letter
+func isWordByte(b byte) bool {
+	return isLetterByte(b) || b == '_' || b >= '0' && b <= '9'
+}
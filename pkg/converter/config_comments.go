@@ -0,0 +1,98 @@
+package converter
+
+import "strings"
+
+// isConfigCommentLanguage reports whether comments for the language are extracted with
+// extractConfigComments rather than the generic patterns
+func isConfigCommentLanguage(language string) bool {
+	switch strings.ToLower(language) {
+	case "toml", "ini", "cfg":
+		return true
+	}
+	return false
+}
+
+// extractConfigComments finds the comments in TOML and INI files. TOML comments start with #
+// anywhere outside a string; INI comments start with # or ; at the start of a line or after
+// whitespace. TOML strings are skipped, and INI markers need preceding whitespace, so a # inside
+// a value (e.g. a colour hex code) is never mistaken for a comment.
+func (c *Converter) extractConfigComments(code, language string) []CommentBlock {
+	isTOML := strings.EqualFold(language, "toml")
+
+	var comments []CommentBlock
+	var multiLineQuote string // """ or ''' while inside a TOML multi-line string
+
+	lineStart := 0
+	for lineStart <= len(code) {
+		lineEnd := len(code)
+		if idx := strings.IndexByte(code[lineStart:], '\n'); idx >= 0 {
+			lineEnd = lineStart + idx
+		}
+		line := strings.TrimSuffix(code[lineStart:lineEnd], "\r")
+
+		if start := configCommentStart(line, isTOML, &multiLineQuote); start >= 0 {
+			comments = append(comments, CommentBlock{
+				Start:   lineStart + start,
+				End:     lineStart + len(line),
+				Content: line[start:],
+			})
+		}
+
+		lineStart = lineEnd + 1
+	}
+
+	return comments
+}
+
+// configCommentStart returns the index of the comment marker in a config file line, or -1.
+// multiLineQuote carries TOML multi-line string state between lines.
+func configCommentStart(line string, isTOML bool, multiLineQuote *string) int {
+	var quote byte // quote character of the single-line string being scanned, if any
+
+	for i := 0; i < len(line); i++ {
+		ch := line[i]
+
+		if *multiLineQuote != "" {
+			if strings.HasPrefix(line[i:], *multiLineQuote) {
+				i += len(*multiLineQuote) - 1
+				*multiLineQuote = ""
+			} else if ch == '\\' && *multiLineQuote == `"""` {
+				i++
+			}
+			continue
+		}
+
+		if quote != 0 {
+			if ch == '\\' && quote == '"' {
+				i++
+			} else if ch == quote {
+				quote = 0
+			}
+			continue
+		}
+
+		switch ch {
+		case '"', '\'':
+			// INI has no real quoting rules and apostrophes are common in values
+			if !isTOML {
+				continue
+			}
+			if strings.HasPrefix(line[i:], `"""`) || strings.HasPrefix(line[i:], `'''`) {
+				*multiLineQuote = line[i : i+3]
+				i += 2
+				continue
+			}
+			quote = ch
+		case '#':
+			if isTOML || i == 0 || line[i-1] == ' ' || line[i-1] == '\t' {
+				return i
+			}
+		case ';':
+			if !isTOML && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t') {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
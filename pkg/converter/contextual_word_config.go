@@ -82,8 +82,15 @@ func LoadContextualWordConfig() (*ContextualWordConfig, error) {
 		config.MinConfidence = 0.7
 	}
 
+	// Words added to the defaults since the file was written are merged in; the file's own
+	// entries win, so a word is turned off by setting "enabled" to false rather than removing it
 	if config.WordConfigs == nil {
-		config.WordConfigs = GetDefaultContextualWordConfig().WordConfigs
+		config.WordConfigs = make(map[string]WordConfig)
+	}
+	for word, wordConfig := range GetDefaultContextualWordConfig().WordConfigs {
+		if _, exists := config.WordConfigs[word]; !exists {
+			config.WordConfigs[word] = wordConfig
+		}
 	}
 
 	// Populate backward compatibility fields
@@ -172,7 +179,8 @@ func (c *ContextualWordConfig) AddCustomWord(baseWord, nounForm, verbForm string
 	}
 }
 
-// RemoveCustomWord removes a word from contextual conversion
+// RemoveCustomWord removes a word from contextual conversion. A built-in word is merged back in
+// when the configuration is next loaded; use DisableWord to turn one off.
 func (c *ContextualWordConfig) RemoveCustomWord(baseWord string) {
 	if c.WordConfigs != nil {
 		delete(c.WordConfigs, baseWord)
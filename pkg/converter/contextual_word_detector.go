@@ -175,6 +175,12 @@ func (d *ContextAwareWordDetector) findPatternMatches(text string, pattern Conte
 				continue
 			}
 			originalWord = text[start:end]
+
+			// Context belongs to the nearest form of the word, so in "must practise every day
+			// because practice" the modal verb can't reach past "practise" to the noun
+			if d.containsWordForm(text[match[0]:start], pattern.BaseWord) {
+				continue
+			}
 		}
 
 		if originalWord == "" {
@@ -207,6 +213,7 @@ func (d *ContextAwareWordDetector) findPatternMatches(text string, pattern Conte
 				Confidence:   confidence,
 				Context:      context,
 				BaseWord:     pattern.BaseWord,
+				Rule:         pattern.Description,
 			})
 		}
 	}
@@ -214,6 +221,35 @@ func (d *ContextAwareWordDetector) findPatternMatches(text string, pattern Conte
 	return matches
 }
 
+// containsWordForm reports whether text contains, as a whole word, baseWord or one of its
+// configured noun and verb spellings
+func (d *ContextAwareWordDetector) containsWordForm(text, baseWord string) bool {
+	forms := []string{baseWord}
+	if config, ok := d.config.WordConfigs[baseWord]; ok {
+		forms = append(forms, config.Noun, config.Verb)
+	}
+
+	text = strings.ToLower(text)
+	for _, form := range forms {
+		form = strings.ToLower(form)
+		if form == "" {
+			continue
+		}
+		for offset := 0; ; {
+			i := strings.Index(text[offset:], form)
+			if i < 0 {
+				break
+			}
+			start, end := offset+i, offset+i+len(form)
+			if (start == 0 || !isLetter(text[start-1])) && (end == len(text) || !isLetter(text[end])) {
+				return true
+			}
+			offset = end
+		}
+	}
+	return false
+}
+
 // calculateConfidence determines the confidence score for a match
 func (d *ContextAwareWordDetector) calculateConfidence(pattern ContextualWordPattern, context, originalWord string) float64 {
 	confidence := pattern.Confidence
@@ -352,8 +388,11 @@ func (d *ContextAwareWordDetector) filterAndDeduplicateMatches(matches []Context
 		if len(filtered) > 0 {
 			lastMatch := &filtered[len(filtered)-1]
 			if match.Start < lastMatch.End {
-				// Overlapping matches - keep the one with higher confidence
-				if match.Confidence > lastMatch.Confidence {
+				// Overlapping matches - keep the one with higher confidence. On a tie, a rule that
+				// keeps the word as written (such as "Department of Defense") is the more specific
+				// one, so it wins whichever pattern matched first.
+				if match.Confidence > lastMatch.Confidence ||
+					(match.Confidence == lastMatch.Confidence && match.Replacement == match.OriginalWord) {
 					// Replace the last match with current match
 					*lastMatch = match
 				}
@@ -373,6 +412,21 @@ func (d *ContextAwareWordDetector) SupportedWords() []string {
 	return d.config.GetSupportedWords()
 }
 
+// nounVerbWords returns the enabled words spelt differently as a noun and as a verb, such as
+// "license", whose use in text can be judged, or nil when the text is excluded from detection
+func (d *ContextAwareWordDetector) nounVerbWords(text string) map[string]bool {
+	if !d.enabled || d.patterns.IsExcluded(text) {
+		return nil
+	}
+	words := make(map[string]bool)
+	for baseWord, wordConfig := range d.config.WordConfigs {
+		if wordConfig.Enabled && wordConfig.Noun != "" && wordConfig.Verb != "" && wordConfig.Noun != wordConfig.Verb {
+			words[strings.ToLower(baseWord)] = true
+		}
+	}
+	return words
+}
+
 // SetMinConfidence sets the minimum confidence threshold for matches
 func (d *ContextAwareWordDetector) SetMinConfidence(confidence float64) {
 	if confidence >= 0.0 && confidence <= 1.0 {
@@ -429,6 +483,13 @@ func (d *ContextAwareWordDetector) UpdateConfiguration(config *ContextualWordCon
 		}
 	}
 
+	// Regional words are added to whichever configuration is in use
+	if d.regionalWords != nil {
+		d.regionalWords = nil
+		d.SetRegionalWordsEnabled(true)
+		return
+	}
+
 	// Rebuild the quick check word list
 	d.buildQuickCheckWords()
 }
@@ -337,9 +337,6 @@ func (p *ContextualWordPatterns) initialiseExclusionPatterns() {
 		`(?i)(?:I|you|we|they|he|she|it|don't|doesn't|didn't|won't|wouldn't|will|would|can|could|should|might|may)\s+(?:easily\s+|quickly\s+|never\s+|often\s+|sometimes\s+)?tire`,
 		`(?i)tire\s+(?:easily|quickly|of|from|out)`,
 
-		// Meter contexts that should be metre (measurement units)
-		`(?i)(?:\d+(?:\.\d+)?|square|cubic|linear)\s+meter`,
-
 		// Curb contexts that should NOT convert to kerb (restraint usage)
 		`(?i)curb\s+(?:your|his|her|their|our|my|the|this|that)\s+(?:enthusiasm|appetite|spending|desire|impulse|habit)`,
 		`(?i)(?:must|should|need\s+to|have\s+to|ought\s+to)\s+curb`,
@@ -473,6 +470,88 @@ func GetDefaultContextualWordConfig() *ContextualWordConfig {
 				},
 				Enabled: true,
 			},
+			"defense": {
+				// Semantic variants: the noun is always "defence" in British English, in sport ("plays
+				// defense"), law ("the defense rests") and compounds ("defense mechanism"), while the
+				// adjective "defensive" is the same in both. The names of US institutions keep their
+				// spelling, so the rules for them map "Defense" to itself and win over the general rule.
+				SemanticVariants: map[string]string{
+					`(?i)\b(defense)\b`: "defence",
+					`\b(?:Department|Dept\.?|Secretary|Secretaries|Deputy\s+Secretary)\s+of\s+(Defense)\b`:                                                    "Defense",
+					`\b(Defense)\s+(?:Department|Secretary|Intelligence|Logistics|Advanced|Information|Threat|Health|Contract|Finance|Counterintelligence)\b`: "Defense",
+				},
+				Enabled: true,
+			},
+			"offense": {
+				// Semantic variants: the noun is always "offence", whether a crime ("a criminal
+				// offense"), a slight ("no offense") or the attacking side in sport ("on offense"),
+				// while the adjective "offensive" is the same in both
+				SemanticVariants: map[string]string{
+					`(?i)\b(offense)\b`: "offence",
+				},
+				Enabled: true,
+			},
+			"meter": {
+				// Semantic variants: the unit and poetic rhythm are "metre", but a measuring device
+				// is a "meter" in British English too ("gas meter", "read the meter"), so the rules
+				// for devices keep the word as written and win over the general rule
+				SemanticVariants: map[string]string{
+					`(?i)\b(meter)\b`: "metre",
+					`(?i)\b(?:gas|electric|electricity|water|parking|taxi|postage|light|exposure|flow|pressure|smart|prepayment|utility|moisture|sound|signal)\s+(meter)\b`: "meter",
+					`(?i)\b(meter)\s+(?:readings?|readers?|box|boxes|cupboard|maids?|attendants?|needle)\b`:                                                                 "meter",
+					`(?i)\b(?:read|reads|install|installed|installing|fit|fitted|feed|fed|feeding|top\s+up)\s+(?:the\s+|a\s+|your\s+|my\s+|our\s+|their\s+)?(meter)\b`:      "meter",
+				},
+				Enabled: true,
+			},
+			"meters": {
+				// Semantic variants for the plural form, which the dictionary would otherwise convert
+				SemanticVariants: map[string]string{
+					`(?i)\b(meters)\b`: "metres",
+					`(?i)\b(?:gas|electric|electricity|water|parking|taxi|postage|light|exposure|flow|pressure|smart|prepayment|utility|moisture|sound|signal)\s+(meters)\b`: "meters",
+					`(?i)\b(?:read|reads|install|installed|installing|fit|fitted)\s+(?:the\s+|your\s+|my\s+|our\s+|their\s+)?(meters)\b`:                                     "meters",
+				},
+				Enabled: true,
+			},
+			"caliber": {
+				// Semantic variants: the bore of a gun and a person's quality are both "calibre", but
+				// watchmakers' movement names ("Caliber 321") and company names keep their spelling
+				SemanticVariants: map[string]string{
+					`(?i)\b(caliber)\b`:                          "calibre",
+					`\b(Caliber)\s+[A-Z]{0,3}\d`:                 "caliber",
+					`\b(Caliber)\s+(?:Collision|Home\s+Loans)\b`: "caliber",
+				},
+				Enabled: true,
+			},
+			"calibers": {
+				// Semantic variants for the plural form, which the dictionary would otherwise convert
+				SemanticVariants: map[string]string{
+					`(?i)\b(calibers)\b`: "calibres",
+				},
+				Enabled: true,
+			},
+			"fiber": {
+				// Semantic variants: the material (dietary fibre, optical fibre, carbon fibre) is
+				// "fibre", but the lightweight thread of concurrent programming keeps its spelling
+				// (Ruby's Fiber class, React Fiber, a fiber scheduler)
+				SemanticVariants: map[string]string{
+					`(?i)\b(fiber)\b`:         "fibre",
+					`(?i)\bReact\s+(Fiber)\b`: "fiber",
+					`(?i)\b(?:Ruby|lightweight|green|cooperative|user-space|userspace|virtual|current|new|spawn|spawns|spawned|resume|resumes|resumed)\s+(?:a\s+|the\s+)?(fiber)\b`: "fiber",
+					`(?i)\b(fiber)\s+(?:scheduler|schedulers|reconciler|switch|switching|local|pool|runtime|API|framework)\b`:                                                       "fiber",
+					`\b(Fiber)\.\w`: "fiber",
+				},
+				Enabled: true,
+			},
+			"fibers": {
+				// Semantic variants for the plural form, which the dictionary would otherwise convert
+				SemanticVariants: map[string]string{
+					`(?i)\b(fibers)\b`: "fibres",
+					`(?i)\b(?:lightweight|green|cooperative|user-space|userspace|virtual)\s+(fibers)\b`: "fibers",
+					`(?i)\b(?:threads|coroutines)\s+(?:and|or|vs\.?|versus)\s+(fibers)\b`:               "fibers",
+					`(?i)\b(fibers)\s+(?:and|or|vs\.?|versus)\s+(?:threads|coroutines)\b`:               "fibers",
+				},
+				Enabled: true,
+			},
 		},
 		MinConfidence: 0.7,
 		ExcludePatterns: []string{
@@ -618,9 +697,16 @@ func (p *ContextualWordPatterns) GetAllPatterns() map[string][]ContextualWordPat
 	return p.GeneratedPatterns
 }
 
-// IsExcluded checks if the given text matches any exclusion pattern
+// IsExcluded checks if the given text matches any exclusion pattern.
+// Patterns whose required literals don't appear in the text are skipped
+// without running the regex.
 func (p *ContextualWordPatterns) IsExcluded(text string) bool {
-	for _, pattern := range p.ExclusionPatterns {
+	literals := p.exclusionPrefilter()
+	textLower := strings.ToLower(text)
+	for i, pattern := range p.ExclusionPatterns {
+		if !containsAnyLiteral(textLower, literals[i]) {
+			continue
+		}
 		if pattern.MatchString(text) {
 			return true
 		}
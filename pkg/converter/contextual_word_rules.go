@@ -0,0 +1,119 @@
+// Package converter provides a readable listing of the contextual word rules
+package converter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ContextualRule describes how one word is converted according to its context
+type ContextualRule struct {
+	Word string `json:"word"`
+	Noun string `json:"noun,omitempty"` // British spelling when used as a noun
+	Verb string `json:"verb,omitempty"` // British spelling when used as a verb
+	// Patterns are the grammatical patterns, highest confidence first
+	Patterns []ContextualRulePattern `json:"patterns,omitempty"`
+	// SemanticVariants are the patterns that pick a word by meaning rather than grammar
+	SemanticVariants []ContextualRulePattern `json:"semanticVariants,omitempty"`
+}
+
+// ContextualRulePattern describes a single pattern of a contextual rule
+type ContextualRulePattern struct {
+	// Name is the pattern's name, such as "determiner_noun", or for a semantic variant its
+	// regular expression
+	Name        string  `json:"name"`
+	WordType    string  `json:"wordType,omitempty"`
+	Replacement string  `json:"replacement"`
+	Confidence  float64 `json:"confidence"`
+}
+
+// Rules returns the rules for every enabled contextual word, sorted by word
+func (p *ContextualWordPatterns) Rules() []ContextualRule {
+	allPatterns := p.GetAllPatterns()
+	words := make([]string, 0, len(allPatterns))
+	for word := range allPatterns {
+		// Patterns can outlive their word's configuration, but only configured words are detected
+		if p.WordConfigs[word].Enabled {
+			words = append(words, word)
+		}
+	}
+	sort.Strings(words)
+
+	rules := make([]ContextualRule, 0, len(words))
+	for _, word := range words {
+		config := p.WordConfigs[word]
+		rule := ContextualRule{Word: word, Noun: config.Noun, Verb: config.Verb}
+		for _, pattern := range allPatterns[word] {
+			if pattern.WordType == Unknown {
+				rule.SemanticVariants = append(rule.SemanticVariants, ContextualRulePattern{
+					Name:        pattern.Pattern.String(),
+					Replacement: pattern.Replacement,
+					Confidence:  pattern.Confidence,
+				})
+				continue
+			}
+			rule.Patterns = append(rule.Patterns, ContextualRulePattern{
+				Name:        strings.TrimSuffix(pattern.Description, " pattern for "+word),
+				WordType:    pattern.WordType.String(),
+				Replacement: pattern.Replacement,
+				Confidence:  pattern.Confidence,
+			})
+		}
+
+		// Semantic variants come from a map, so sort both lists to keep the listing stable
+		sort.SliceStable(rule.Patterns, func(i, j int) bool {
+			return rule.Patterns[i].Confidence > rule.Patterns[j].Confidence
+		})
+		sort.Slice(rule.SemanticVariants, func(i, j int) bool {
+			return rule.SemanticVariants[i].Name < rule.SemanticVariants[j].Name
+		})
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// Rules returns the rules for every word the detector converts according to context
+func (d *ContextAwareWordDetector) Rules() []ContextualRule {
+	return d.patterns.Rules()
+}
+
+// ContextualRules returns the rules for every word converted according to its context, or nil
+// if the converter's contextual word detector can't list them
+func (c *Converter) ContextualRules() []ContextualRule {
+	if detector, ok := c.contextualWordDetector.(*ContextAwareWordDetector); ok {
+		return detector.Rules()
+	}
+	return nil
+}
+
+// FormatContextualRules formats rules as plain text, a block per word listing its noun and verb
+// spellings, its grammatical patterns with their confidence, and its semantic variants
+func FormatContextualRules(rules []ContextualRule) string {
+	var b strings.Builder
+	for i, rule := range rules {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(rule.Word + "\n")
+		if rule.Noun != "" {
+			fmt.Fprintf(&b, "  noun: %s\n", rule.Noun)
+		}
+		if rule.Verb != "" {
+			fmt.Fprintf(&b, "  verb: %s\n", rule.Verb)
+		}
+		if len(rule.Patterns) > 0 {
+			b.WriteString("  patterns:\n")
+			for _, pattern := range rule.Patterns {
+				fmt.Fprintf(&b, "    %.2f  %-9s %s → %s\n", pattern.Confidence, pattern.WordType, pattern.Name, pattern.Replacement)
+			}
+		}
+		if len(rule.SemanticVariants) > 0 {
+			b.WriteString("  semantic variants:\n")
+			for _, variant := range rule.SemanticVariants {
+				fmt.Fprintf(&b, "    %.2f  %s → %s\n", variant.Confidence, variant.Name, variant.Replacement)
+			}
+		}
+	}
+	return b.String()
+}
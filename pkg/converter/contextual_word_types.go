@@ -1,6 +1,9 @@
 package converter
 
-import "regexp"
+import (
+	"regexp"
+	"sync"
+)
 
 // WordType represents the grammatical role of a word
 type WordType int
@@ -25,6 +28,7 @@ type ContextualWordMatch struct {
 	Confidence   float64  // Confidence score for this match (0.0-1.0)
 	Context      string   // Surrounding context used for detection
 	BaseWord     string   // The base word this match relates to
+	Rule         string   // Description of the pattern that matched, e.g. "determiner_noun pattern for license"
 }
 
 // WordConfig represents the configuration for a contextual word pair
@@ -56,6 +60,11 @@ type ContextualWordPatterns struct {
 	// Exclusion patterns for ambiguous or problematic contexts
 	ExclusionPatterns []*regexp.Regexp
 
+	// Required literals for each exclusion pattern, rebuilt when the slice changes
+	exclusionMu       sync.Mutex
+	exclusionSource   []*regexp.Regexp
+	exclusionLiterals [][]string
+
 	// General pattern templates
 	GeneralPatterns []GeneralPattern
 }
@@ -78,6 +87,7 @@ type ContextAwareWordDetector struct {
 	minConfidence   float64  // Minimum confidence threshold for matches
 	enabled         bool     // Whether contextual detection is enabled
 	quickCheckWords []string // Pre-computed lowercase base words for fast pre-screening
+	regionalWords   []string // Regional words added by SetRegionalWordsEnabled; nil when off
 }
 
 // ContextualWordConfig holds all configuration options for contextual word conversion
@@ -0,0 +1,59 @@
+// Package converter provides conversion of a single word without surrounding text
+package converter
+
+import "strings"
+
+// ConvertWord returns the British spelling of a single word, whether it differs from word and
+// the kind of rule that changed it ("spelling" or "contextual", empty when unchanged). It's a
+// primitive for spell-checkers and autocomplete. Dictionary words are converted as in prose,
+// including possessives and hyphenated words. A word whose spelling depends on context, such as
+// "license", has none, so it takes its default form: the noun spelling, or the verb spelling if
+// the contextual configuration doesn't prefer nouns. The case of word is kept. Excluded words,
+// the word allowlist and the spelling variant apply.
+func (c *Converter) ConvertWord(word string) (british string, changed bool, category string) {
+	if replacement, ok := c.convertContextualWord(word); ok {
+		if replacement == word {
+			return word, false, ""
+		}
+		return replacement, true, string(ChangeContextual)
+	}
+
+	// Prose keeps only the leading capital of an all caps word; a lone word keeps its case
+	british = matchCase(convertToken(word, c.filteredDict), word)
+	if british == word {
+		return word, false, ""
+	}
+	return british, true, string(ChangeSpelling)
+}
+
+// convertContextualWord returns the default form of a word whose spelling depends on context,
+// or its plural, reporting false if word isn't one or contextual detection is off
+func (c *Converter) convertContextualWord(word string) (string, bool) {
+	detector, ok := c.contextualWordDetector.(*ContextAwareWordDetector)
+	if !ok || !detector.IsEnabled() || c.isExcludedWord(word) || !c.isAllowedWord(word) {
+		return "", false
+	}
+	config := detector.GetConfiguration()
+
+	lower := strings.ToLower(word)
+	plural := ""
+	wordConfig, ok := config.WordConfigs[lower]
+	if !ok && strings.HasSuffix(lower, "s") {
+		wordConfig, ok = config.WordConfigs[strings.TrimSuffix(lower, "s")]
+		plural = "s"
+	}
+	if !ok || !wordConfig.Enabled {
+		return "", false
+	}
+
+	replacement := wordConfig.Verb
+	if config.Preferences.PreferNounOnAmbiguity || replacement == "" {
+		replacement = wordConfig.Noun
+	}
+	if replacement == "" {
+		// Words told apart only by meaning, such as "principal", are kept
+		return word, true
+	}
+
+	return matchCase(replacement+plural, word), true
+}
@@ -3,10 +3,17 @@ package converter
 
 import (
 	"embed"
+	"fmt"
 	"maps"
+	"path/filepath"
+	"regexp"
 	"runtime"
+	"slices"
 	"strings"
 	"sync"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
 )
 
 //go:embed data/*.json
@@ -31,6 +38,36 @@ func isURL(s string) bool {
 	return false
 }
 
+// urlRegex matches a URL at the start of a token, after any opening brackets or quotes. The URL
+// stops at whitespace, brackets and quotes, and doesn't end with punctuation, so in
+// "(https://example.com/gray)color," only "https://example.com/gray" is the URL.
+var urlRegex = regexp.MustCompile(`(?i)^[(<\[{"'“‘]*((?:https?://|www\.)[^\s<>"'“”‘’()\[\]{}]*[^\s<>"'“”‘’()\[\]{}.,;:!?])`)
+
+// urlSpan returns the start and end of the URL in a token, if it has one
+func urlSpan(token string) (int, int, bool) {
+	if !isURL(strings.TrimLeft(token, "(<[{\"'“‘")) {
+		return 0, 0, false
+	}
+	m := urlRegex.FindStringSubmatchIndex(token)
+	if m == nil {
+		return 0, 0, false
+	}
+	return m[2], m[3], true
+}
+
+// urlWordRegex matches the words inside a URL
+var urlWordRegex = regexp.MustCompile(`[A-Za-z]+`)
+
+// convertURLWords converts each dictionary word inside a URL, such as the "color" of "www.color"
+func convertURLWords(url string, dict map[string]string) string {
+	return urlWordRegex.ReplaceAllStringFunc(url, func(word string) string {
+		if repl, ok := lookupWithCase(word, dict); ok {
+			return repl
+		}
+		return word
+	})
+}
+
 // Dictionaries holds the mapping for American to British English spellings
 type Dictionaries struct {
 	AmericanToBritish map[string]string
@@ -39,11 +76,48 @@ type Dictionaries struct {
 // Converter provides methods to convert between American and British English
 type Converter struct {
 	dict                   *Dictionaries
+	baseDict               map[string]string // dictionary as loaded, before the spelling variant is applied
 	filteredDict           map[string]string // dictionary with contextual words removed
+	filteredWords          *wordFilter       // skips lines that can't contain a filteredDict key
+	spellingVariant        SpellingVariant
+	excludedWords          []string         // dictionary words never converted
+	wordAllowlist          []*regexp.Regexp // when set, only words matching one of these are converted
 	unitProcessor          *UnitProcessor
 	contextualWordDetector ContextualWordDetector
 	ignoreProcessor        *CommentIgnoreProcessor
 	markdownProcessor      *MarkdownProcessor
+	rtfProcessor           *RTFProcessor
+	phraseProcessor        *PhraseProcessor
+	jsonProcessor          *JSONProcessor
+	csvProcessor           *CSVProcessor
+	srtProcessor           *SRTProcessor
+	vttProcessor           *VTTProcessor
+	asciiDocProcessor      *AsciiDocProcessor
+	rstProcessor           *RSTProcessor
+	svgProcessor           *SVGProcessor
+	docxProcessor          *DocxProcessor
+	notebookProcessor      *NotebookProcessor
+	projectConfigs         *projectConfigs
+	skipFrontMatter        bool // leave YAML front matter untouched instead of converting its values
+	convertInlineCode      bool // convert inline code spans like prose instead of preserving them
+	markdownProcessing     bool // keep Markdown code, emphasis and links intact; off converts text as plain prose
+	jsonValuesOnly         bool // convert only the string values of .json files
+	contentMode            ContentMode
+	normaliseUnicode       bool                  // compose prose to Unicode NFC before converting it
+	convertProperNouns     bool                  // convert words in Title Case names like "Department of Labor"
+	convertURLs            bool                  // convert words inside URLs instead of leaving URLs as they are
+	convertIdentifiers     bool                  // convert words inside identifiers like "initializeColor" in prose
+	maxLineWidth           int                   // re-wrap changed prose paragraphs to this width; 0 leaves lines alone
+	quotePunctuation       bool                  // move commas and full stops outside short quoted phrases
+	floorNumbering         bool                  // renumber floors the British way: first floor → ground floor
+	markdownElements       []MarkdownElement     // limit Markdown files to these element types; nil converts all
+	tidyWhitespace         bool                  // fold runs of spaces introduced by conversion into one
+	dashMode               DashMode              // whether smart quote normalisation flattens dashes
+	processors             map[Phase][]Processor // custom passes added with RegisterProcessor
+	explain                *explainLog           // records the rule behind each change, when enabled
+	counters               *conversionCounters   // counts the changes made by each stage, when enabled
+	countOnly              bool                  // count the dictionary stage's changes without making them
+	warnings               *warningLog           // records judgement calls worth reviewing, when enabled
 }
 
 // SmartQuotesMap holds mappings for smart quotes and em-dashes to their normal equivalents
@@ -75,27 +149,77 @@ func NewConverter() (*Converter, error) {
 
 	contextualWordDetector := NewContextAwareWordDetector()
 
-	// Pre-compute filtered dictionary with contextual words removed
-	filtered := make(map[string]string, len(dict.AmericanToBritish))
-	maps.Copy(filtered, dict.AmericanToBritish)
-	if contextualWordDetector != nil {
-		for _, word := range contextualWordDetector.SupportedWords() {
-			delete(filtered, strings.ToLower(word))
+	// Multi-word entries in the user dictionary extend the built-in phrase rules
+	phrases, err := LoadDefaultPhrases()
+	if err != nil {
+		return nil, err
+	}
+	for american, british := range dict.AmericanToBritish {
+		if strings.Contains(strings.TrimSpace(american), " ") {
+			phrases[american] = british
 		}
 	}
 
-	return &Converter{
+	filteredDict := filterContextualWords(dict.AmericanToBritish, contextualWordDetector)
+	c := &Converter{
 		dict:                   dict,
-		filteredDict:           filtered,
+		baseDict:               dict.AmericanToBritish,
+		filteredDict:           filteredDict,
+		filteredWords:          newWordFilter(filteredDict),
 		unitProcessor:          NewUnitProcessor(),
 		contextualWordDetector: contextualWordDetector,
 		ignoreProcessor:        NewCommentIgnoreProcessor(),
 		markdownProcessor:      NewMarkdownProcessor(),
-	}, nil
+		rtfProcessor:           NewRTFProcessor(),
+		phraseProcessor:        NewPhraseProcessor(phrases),
+		jsonProcessor:          NewJSONProcessor(),
+		csvProcessor:           NewCSVProcessor(),
+		srtProcessor:           NewSRTProcessor(),
+		vttProcessor:           NewVTTProcessor(),
+		asciiDocProcessor:      NewAsciiDocProcessor(),
+		rstProcessor:           NewRSTProcessor(),
+		svgProcessor:           NewSVGProcessor(),
+		docxProcessor:          NewDocxProcessor(),
+		notebookProcessor:      NewNotebookProcessor(),
+		markdownProcessing:     true,
+	}
+
+	// The user config may choose a spelling variant and exclude words
+	if config := c.unitProcessor.GetConfig(); config != nil && (config.SpellingVariant != "" || len(config.ExcludedWords) > 0) {
+		if variant, err := ParseSpellingVariant(config.SpellingVariant); err == nil {
+			c.spellingVariant = variant
+		}
+		c.excludedWords = config.ExcludedWords
+		c.rebuildDictionaries()
+	}
+
+	if contextualWordDetector.GetConfig().Preferences.ShowAmbiguityWarnings {
+		c.SetAmbiguityWarningsEnabled(true)
+	}
+
+	return c, nil
+}
+
+// filterContextualWords returns a copy of dict without the words handled by the contextual
+// word detector, which are converted based on their usage instead
+func filterContextualWords(dict map[string]string, detector ContextualWordDetector) map[string]string {
+	filtered := make(map[string]string, len(dict))
+	maps.Copy(filtered, dict)
+	if detector != nil {
+		for _, word := range detector.SupportedWords() {
+			delete(filtered, strings.ToLower(word))
+		}
+	}
+	return filtered
 }
 
 // ConvertToBritish converts American English text to British English
 func (c *Converter) ConvertToBritish(text string, normaliseSmartQuotes bool) string {
+	// RTF documents only have their visible text converted so control words survive
+	if c.rtfProcessor.IsRTF(text) {
+		return c.ConvertRTF(text, normaliseSmartQuotes)
+	}
+
 	// Process ignore comments first
 	return c.ConvertToBritishWithIgnoreComments(text, normaliseSmartQuotes)
 }
@@ -110,7 +234,17 @@ func (c *Converter) ConvertToBritishWithIgnoreComments(text string, normaliseSma
 		return text
 	}
 
-	// Apply selective ignore using the ignore processor
+	// YAML front matter only has its values converted so keys expected by tooling survive
+	if frontMatter, body, ok := c.markdownProcessor.SplitFrontMatter(text); ok {
+		bodyMatches := c.ignoreProcessor.ProcessIgnoreComments(body)
+		return c.convertFrontMatter(frontMatter, normaliseSmartQuotes) + c.applySelectiveIgnore(body, bodyMatches, normaliseSmartQuotes)
+	}
+
+	return c.applySelectiveIgnore(text, ignoreMatches, normaliseSmartQuotes)
+}
+
+// applySelectiveIgnore converts each line that isn't excluded by an ignore directive
+func (c *Converter) applySelectiveIgnore(text string, ignoreMatches []IgnoreMatch, normaliseSmartQuotes bool) string {
 	return c.ignoreProcessor.ApplySelectiveIgnore(text, ignoreMatches, func(lineText string) string {
 		// Use code-aware processing for each non-ignored line
 		return c.ProcessCodeAware(lineText, normaliseSmartQuotes)
@@ -120,10 +254,10 @@ func (c *Converter) ConvertToBritishWithIgnoreComments(text string, normaliseSma
 // ConvertToBritishSimple converts text without code-awareness (for internal use)
 func (c *Converter) ConvertToBritishSimple(text string, normaliseSmartQuotes bool) string {
 	// Wrap the entire conversion in markdown processing to preserve formatting
-	if c.markdownProcessor != nil {
-		return c.markdownProcessor.ProcessWithMarkdown(text, func(innerText string) string {
+	if c.markdownProcessor != nil && c.markdownProcessing {
+		return c.markdownProcessor.Process(text, WithSmartQuotes(normaliseSmartQuotes), WithConvert(func(innerText string) string {
 			return c.convertWithoutMarkdown(innerText, normaliseSmartQuotes)
-		})
+		}))
 	}
 
 	// Fallback if markdown processor is not available
@@ -132,19 +266,39 @@ func (c *Converter) ConvertToBritishSimple(text string, normaliseSmartQuotes boo
 
 // convertWithoutMarkdown performs conversion without markdown processing
 func (c *Converter) convertWithoutMarkdown(text string, normaliseSmartQuotes bool) string {
-	// First normalise smart quotes if needed
+	// Compose accents written with combining marks so words like "café" match either way
 	processedText := text
+	if c.normaliseUnicode && !norm.NFC.IsNormalString(processedText) {
+		processedText = norm.NFC.String(processedText)
+	}
+
+	// Then normalise smart quotes if needed
 	if normaliseSmartQuotes {
-		processedText = c.normaliseSmartQuotes(text)
+		c.explainSmartQuotes(processedText)
+		processedText = c.normaliseSmartQuotes(processedText)
 	}
 
+	processedText = c.convertNumberRanges(processedText)
+
+	processedText = c.runProcessors(PhasePreSpelling, processedText, normaliseSmartQuotes)
+
+	// Rewrite American phrases before single words so their rules see the original wording
+	if c.phraseProcessor != nil && c.phraseProcessor.IsEnabled() {
+		processedText = c.phraseProcessor.ProcessText(processedText)
+	}
+	processedText = c.convertFloorNumbers(processedText)
+
 	// Apply contextual word conversion if enabled
 	if c.contextualWordDetector != nil && c.contextualWordDetector.IsEnabled() {
 		processedText = c.applyContextualWordConversion(processedText)
 	}
 
 	// Apply standard dictionary conversion using pre-computed filtered dictionary
-	return c.convert(processedText, c.filteredDict)
+	processedText = c.convert(processedText, c.filteredDict, c.filteredWords)
+
+	processedText = c.convertQuotePunctuation(processedText)
+
+	return c.runProcessors(PhasePostSpelling, processedText, normaliseSmartQuotes)
 }
 
 // GetAmericanToBritishDictionary returns the American to British dictionary
@@ -155,6 +309,93 @@ func (c *Converter) GetAmericanToBritishDictionary() map[string]string {
 	return c.dict.AmericanToBritish
 }
 
+// SetSpellingVariant selects the British form emitted for words spelt with either -ise or -ize
+// (SpellingISE by default) and re-resolves the dictionary for it
+func (c *Converter) SetSpellingVariant(variant SpellingVariant) {
+	c.spellingVariant = variant
+	c.rebuildDictionaries()
+}
+
+// GetSpellingVariant returns the spelling variant used for the -ise/-ize family
+func (c *Converter) GetSpellingVariant() SpellingVariant {
+	return c.spellingVariant
+}
+
+// SetExcludedWords sets dictionary words that are never converted, matched case-insensitively
+// against the American spelling. It replaces any previously excluded words.
+func (c *Converter) SetExcludedWords(words []string) {
+	c.excludedWords = slices.Clone(words)
+	c.rebuildDictionaries()
+}
+
+// GetExcludedWords returns the dictionary words that are never converted
+func (c *Converter) GetExcludedWords() []string {
+	return slices.Clone(c.excludedWords)
+}
+
+// isExcludedWord reports whether word is one of the excluded words, ignoring case
+func (c *Converter) isExcludedWord(word string) bool {
+	for _, excluded := range c.excludedWords {
+		if strings.EqualFold(strings.TrimSpace(excluded), word) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetWordAllowlist restricts dictionary and contextual conversion to American spellings that
+// match one of the regular expressions, such as "colou?r". Each pattern must match the whole
+// word and is case-insensitive. It is the inverse of SetExcludedWords; an empty allowlist
+// converts every word.
+func (c *Converter) SetWordAllowlist(patterns []string) error {
+	var allowlist []*regexp.Regexp
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+		re, err := regexp.Compile(`(?i)^(?:` + pattern + `)$`)
+		if err != nil {
+			return fmt.Errorf("invalid word allowlist pattern %q: %w", pattern, err)
+		}
+		allowlist = append(allowlist, re)
+	}
+	c.wordAllowlist = allowlist
+	c.rebuildDictionaries()
+	return nil
+}
+
+// isAllowedWord reports whether word may be converted: it matches the word allowlist, or
+// there is no allowlist
+func (c *Converter) isAllowedWord(word string) bool {
+	if len(c.wordAllowlist) == 0 {
+		return true
+	}
+	for _, re := range c.wordAllowlist {
+		if re.MatchString(word) {
+			return true
+		}
+	}
+	return false
+}
+
+// rebuildDictionaries resolves the loaded dictionary for the spelling variant, excluded words
+// and word allowlist
+func (c *Converter) rebuildDictionaries() {
+	dict := ApplySpellingVariant(c.baseDict, c.spellingVariant)
+	for _, word := range c.excludedWords {
+		delete(dict, strings.ToLower(strings.TrimSpace(word)))
+	}
+	if len(c.wordAllowlist) > 0 {
+		maps.DeleteFunc(dict, func(american, _ string) bool {
+			return !c.isAllowedWord(american)
+		})
+	}
+	c.dict = &Dictionaries{AmericanToBritish: dict}
+	c.filteredDict = filterContextualWords(dict, c.contextualWordDetector)
+	c.filteredWords = newWordFilter(c.filteredDict)
+}
+
 // GetUnitProcessor returns the unit processor instance
 func (c *Converter) GetUnitProcessor() *UnitProcessor {
 	return c.unitProcessor
@@ -167,6 +408,291 @@ func (c *Converter) SetUnitProcessingEnabled(enabled bool) {
 	}
 }
 
+// SetUnitNormalisationEnabled enables or disables tidying the spacing and symbol case of metric
+// units already in the text ("5kg" → "5 kg"), independently of unit conversion
+func (c *Converter) SetUnitNormalisationEnabled(enabled bool) {
+	if c.unitProcessor != nil {
+		c.unitProcessor.SetNormaliseEnabled(enabled)
+	}
+}
+
+// SetUnitKeepOriginal controls whether converted units keep the original measurement, with
+// the metric value after it in parentheses: "10 feet (3 metres)"
+func (c *Converter) SetUnitKeepOriginal(enabled bool) {
+	if c.unitProcessor != nil {
+		c.unitProcessor.SetKeepOriginal(enabled)
+	}
+}
+
+// SetUnitCookingMeasures controls whether unit conversion covers US cooking measures too:
+// "2 cups of flour" → "473.2 ml of flour", while "a cup of coffee" is left alone
+func (c *Converter) SetUnitCookingMeasures(enabled bool) {
+	if c.unitProcessor != nil {
+		c.unitProcessor.SetCookingMeasures(enabled)
+	}
+}
+
+// GetPhraseProcessor returns the phrase processor instance
+func (c *Converter) GetPhraseProcessor() *PhraseProcessor {
+	return c.phraseProcessor
+}
+
+// SetPhraseProcessingEnabled enables or disables rewriting American phrases and idioms
+// (e.g. "on the weekend" to "at the weekend")
+func (c *Converter) SetPhraseProcessingEnabled(enabled bool) {
+	if c.phraseProcessor != nil {
+		c.phraseProcessor.SetEnabled(enabled)
+	}
+}
+
+// SetUnicodeNormalisationEnabled controls whether prose is normalised to Unicode NFC before
+// it is converted, so accented letters written as a base letter and a combining mark match the
+// dictionary. Code, inline code and other preserved text keep their exact bytes.
+func (c *Converter) SetUnicodeNormalisationEnabled(enabled bool) {
+	c.normaliseUnicode = enabled
+}
+
+// IsUnicodeNormalisationEnabled reports whether prose is normalised to Unicode NFC
+func (c *Converter) IsUnicodeNormalisationEnabled() bool {
+	return c.normaliseUnicode
+}
+
+// SetProperNounConversionEnabled controls whether dictionary words inside multi-word Title Case
+// names, such as "Department of Labor" or "World Health Organization", are converted. By default
+// they keep their American spelling; a capitalised word on its own or at the start of a sentence
+// is still converted.
+func (c *Converter) SetProperNounConversionEnabled(enabled bool) {
+	c.convertProperNouns = enabled
+}
+
+// IsProperNounConversionEnabled reports whether words in Title Case names are converted
+func (c *Converter) IsProperNounConversionEnabled() bool {
+	return c.convertProperNouns
+}
+
+// SetURLConversionEnabled controls whether dictionary words inside URLs, such as "www.color"
+// used as an example word, are converted. By default URLs are left as they are; the text around
+// a URL is converted either way.
+func (c *Converter) SetURLConversionEnabled(enabled bool) {
+	c.convertURLs = enabled
+}
+
+// IsURLConversionEnabled reports whether words inside URLs are converted
+func (c *Converter) IsURLConversionEnabled() bool {
+	return c.convertURLs
+}
+
+// SetIdentifierConversionEnabled controls whether dictionary words inside code identifiers
+// mentioned in prose, such as the "Color" of "initializeColor()" or "color_value", are converted.
+// By default camelCase, PascalCase and snake_case tokens keep their spelling; a plain word is
+// converted either way.
+func (c *Converter) SetIdentifierConversionEnabled(enabled bool) {
+	c.convertIdentifiers = enabled
+}
+
+// IsIdentifierConversionEnabled reports whether words inside code identifiers are converted
+func (c *Converter) IsIdentifierConversionEnabled() bool {
+	return c.convertIdentifiers
+}
+
+// SetSkipFrontMatter controls whether YAML front matter is left untouched. By default only
+// its values are converted and keys are always preserved.
+func (c *Converter) SetSkipFrontMatter(skip bool) {
+	c.skipFrontMatter = skip
+}
+
+// SetConvertInlineCode controls whether the content of inline code spans (single backticks)
+// is converted like regular prose, for text that uses backticks for emphasis rather than code.
+// Fenced code blocks are always preserved.
+func (c *Converter) SetConvertInlineCode(enabled bool) {
+	c.convertInlineCode = enabled
+}
+
+// SetMarkdownProcessingEnabled controls whether text is treated as Markdown. By default code
+// spans and fenced or indented code blocks are preserved, and bold, italic and link text is
+// converted apart from its markers. Disabled, text is converted as plain prose, for text whose
+// stray backticks or asterisks aren't Markdown; code files still only have their comments
+// converted.
+func (c *Converter) SetMarkdownProcessingEnabled(enabled bool) {
+	c.markdownProcessing = enabled
+}
+
+// IsMarkdownProcessingEnabled reports whether text is treated as Markdown
+func (c *Converter) IsMarkdownProcessingEnabled() bool {
+	return c.markdownProcessing
+}
+
+// SetJSONValuesOnly controls whether ConvertFileContent converts only the string values of
+// .json files, leaving keys and all other tokens untouched
+func (c *Converter) SetJSONValuesOnly(enabled bool) {
+	c.jsonValuesOnly = enabled
+}
+
+// IsJSONValuesOnly returns whether .json files only have their string values converted
+func (c *Converter) IsJSONValuesOnly() bool {
+	return c.jsonValuesOnly
+}
+
+// SetContentMode overrides whether ConvertFileContent converts the whole of a file or only its
+// comments, for files whose extension is misleading
+func (c *Converter) SetContentMode(mode ContentMode) {
+	c.contentMode = mode
+}
+
+// GetContentMode returns the content mode set with SetContentMode
+func (c *Converter) GetContentMode() ContentMode {
+	return c.contentMode
+}
+
+// SetJSONKeyPattern limits JSON value conversion to values whose key matches the regular
+// expression. An empty pattern converts all string values.
+func (c *Converter) SetJSONKeyPattern(pattern string) error {
+	if pattern == "" {
+		c.jsonProcessor.SetKeyPattern(nil)
+		return nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("invalid JSON key pattern: %w", err)
+	}
+	c.jsonProcessor.SetKeyPattern(re)
+	return nil
+}
+
+// ConvertJSONValues converts only the string values of a JSON document, preserving keys,
+// numbers, booleans, formatting and escaping. Invalid JSON is returned unchanged with an error.
+func (c *Converter) ConvertJSONValues(content string, normaliseSmartQuotes bool) (string, error) {
+	return c.jsonProcessor.ProcessValues(content, func(value string) string {
+		return c.ProcessCodeAware(value, normaliseSmartQuotes)
+	})
+}
+
+// SetCSVColumns chooses the columns of .csv and .tsv files that ConvertFileContent converts, each
+// a header name or a 1-based column number; the header and every other column are left alone.
+// No columns leaves CSV files to be converted like any other file.
+func (c *Converter) SetCSVColumns(columns []string) {
+	c.csvProcessor.SetColumns(columns)
+}
+
+// CSVColumns returns the columns chosen with SetCSVColumns
+func (c *Converter) CSVColumns() []string {
+	return c.csvProcessor.Columns()
+}
+
+// ConvertCSV converts the cells of the columns chosen with SetCSVColumns in a CSV file, or a TSV
+// file when filePath ends in .tsv. Only the cells that change are rewritten, quoted as needed,
+// so embedded delimiters, quotes and line breaks round-trip. Invalid CSV, or a chosen column
+// missing from the header, is returned unchanged with an error.
+func (c *Converter) ConvertCSV(content, filePath string, normaliseSmartQuotes bool) (string, error) {
+	return c.csvProcessor.ProcessCells(content, csvDelimiter(filePath), func(cell string) string {
+		return c.ProcessCodeAware(cell, normaliseSmartQuotes)
+	})
+}
+
+// ConvertSubtitles converts only the dialogue of an SRT or WebVTT file, chosen by the extension
+// of filePath. Cue numbers, identifiers, timing lines and WebVTT headers are preserved exactly;
+// malformed or missing timing lines are left untouched and returned as warnings.
+func (c *Converter) ConvertSubtitles(content, filePath string, normaliseSmartQuotes bool) (string, []SubtitleWarning) {
+	convertFunc := func(line string) string {
+		return c.ConvertToBritish(line, normaliseSmartQuotes)
+	}
+	if strings.EqualFold(filepath.Ext(filePath), ".vtt") {
+		return c.vttProcessor.ProcessCues(content, convertFunc)
+	}
+	return c.srtProcessor.ProcessCues(content, convertFunc)
+}
+
+// ConvertAsciiDoc converts the prose of an AsciiDoc document. Attribute entries, block
+// attributes, block macros and macro targets are kept, source blocks only have their comments
+// converted, and literal and passthrough blocks are left untouched.
+func (c *Converter) ConvertAsciiDoc(content string, normaliseSmartQuotes bool) string {
+	ignoreMatches := c.ignoreProcessor.ProcessIgnoreComments(content)
+	if c.ignoreProcessor.ShouldIgnoreFile(ignoreMatches) {
+		return content
+	}
+
+	converted := c.asciiDocProcessor.ProcessDocument(content,
+		func(prose string) string {
+			return c.convertProse(prose, normaliseSmartQuotes)
+		},
+		func(code, language string) string {
+			return c.convertCommentsInCode(code, language, normaliseSmartQuotes)
+		})
+	return c.ignoreProcessor.RestoreIgnoredLines(content, converted, ignoreMatches)
+}
+
+// ConvertRST converts the prose of a reStructuredText document. Directive names, arguments and
+// options, roles, literal blocks and tables are kept as they are, and code-block directives
+// only have their comments converted.
+func (c *Converter) ConvertRST(content string, normaliseSmartQuotes bool) string {
+	ignoreMatches := c.ignoreProcessor.ProcessIgnoreComments(content)
+	if c.ignoreProcessor.ShouldIgnoreFile(ignoreMatches) {
+		return content
+	}
+
+	converted := c.rstProcessor.ProcessDocument(content,
+		func(prose string) string {
+			return c.convertProse(prose, normaliseSmartQuotes)
+		},
+		func(code, language string) string {
+			return c.convertCommentsInCode(code, language, normaliseSmartQuotes)
+		})
+	return c.ignoreProcessor.RestoreIgnoredLines(content, converted, ignoreMatches)
+}
+
+// ConvertSVG converts the text labels of an SVG drawing: the content of its text elements,
+// including tspan and textPath, and of its title and desc elements. Geometry, ids, styling,
+// namespaces and the XML declaration are kept exactly as they are.
+func (c *Converter) ConvertSVG(content string, normaliseSmartQuotes bool) string {
+	ignoreMatches := c.ignoreProcessor.ProcessIgnoreComments(content)
+	if c.ignoreProcessor.ShouldIgnoreFile(ignoreMatches) {
+		return content
+	}
+
+	converted := c.svgProcessor.ProcessDocument(content, func(text string) string {
+		return c.convertProse(text, normaliseSmartQuotes)
+	})
+	return c.ignoreProcessor.RestoreIgnoredLines(content, converted, ignoreMatches)
+}
+
+// ConvertDocx converts the text of a Word (.docx) document's body, headers and footers, returning
+// the rewritten package. Only w:t text nodes change; styles, fields, relationships and all other
+// parts are copied as they are. An error is returned if data isn't a valid Word package.
+func (c *Converter) ConvertDocx(data []byte, normaliseSmartQuotes bool) ([]byte, error) {
+	return c.docxProcessor.ProcessPackage(data, func(text string) string {
+		return c.convertProse(text, normaliseSmartQuotes)
+	})
+}
+
+// ConvertNotebook converts a Jupyter notebook (.ipynb): Markdown cells are converted like
+// Markdown files and code cells only have their comments converted. Outputs, metadata and the
+// notebook's formatting are untouched. An error is returned if content isn't valid JSON.
+func (c *Converter) ConvertNotebook(content string, normaliseSmartQuotes bool) (string, error) {
+	return c.notebookProcessor.ProcessCells(content,
+		func(source string) string {
+			return c.convertPlainText(source, normaliseSmartQuotes)
+		},
+		func(source string) string {
+			return c.ConvertCommentsOnly(source, normaliseSmartQuotes)
+		})
+}
+
+// ExtractDocxText returns the text of a Word (.docx) document's body, headers and footers with
+// a line per paragraph, for showing what converting it changes
+func (c *Converter) ExtractDocxText(data []byte) (string, error) {
+	return c.docxProcessor.ExtractText(data)
+}
+
+// convertFrontMatter converts the values of YAML front matter unless it should be skipped
+func (c *Converter) convertFrontMatter(frontMatter string, normaliseSmartQuotes bool) string {
+	if c.skipFrontMatter {
+		return frontMatter
+	}
+	return c.markdownProcessor.ProcessFrontMatterValues(frontMatter, func(value string) string {
+		return c.ProcessCodeAware(value, normaliseSmartQuotes)
+	})
+}
+
 // GetContextualWordDetector returns the contextual word detector instance
 func (c *Converter) GetContextualWordDetector() ContextualWordDetector {
 	return c.contextualWordDetector
@@ -209,6 +735,9 @@ func (c *Converter) ConvertToBritishWithoutIgnores(text string, normaliseSmartQu
 
 // normaliseSmartQuotes converts smart quotes and em-dashes to their normal equivalents
 func (c *Converter) normaliseSmartQuotes(text string) string {
+	if c.dashMode == DashModeTypographic {
+		return quoteOnlyReplacer.Replace(text)
+	}
 	return smartQuoteReplacer.Replace(text)
 }
 
@@ -458,6 +987,20 @@ func convertToken(word string, dict map[string]string) string {
 		return repl
 	}
 
+	// Words joined by en-dashes or em-dashes, as kept by the typographic dash mode
+	if strings.ContainsRune(word, '\u2013') || strings.ContainsRune(word, '\u2014') {
+		if repl, ok := convertDashedWord(word, dict); ok {
+			return repl
+		}
+	}
+
+	// Curly apostrophes and single quotes, left in place when smart quotes aren't normalised
+	if strings.ContainsRune(word, '\u2019') || strings.ContainsRune(word, '\u2018') {
+		if repl, ok := convertCurlyQuotedWord(word, dict); ok {
+			return repl
+		}
+	}
+
 	// Fast path: if the word has no special characters (quotes, hyphens, trailing
 	// punctuation), none of the fallback strategies can possibly match, so skip them.
 	if !hasSpecialChars(word) {
@@ -487,41 +1030,183 @@ func convertToken(word string, dict map[string]string) string {
 	return word
 }
 
+// convertCurlyQuotedWord converts a word containing curly apostrophes or single quotes, such as
+// "color’s" or ‘color’, by matching it as if they were ASCII apostrophes, so possessives,
+// contractions and quoted words are handled the same whichever quotes are used. The original
+// quote characters are put back in the result.
+func convertCurlyQuotedWord(word string, dict map[string]string) (string, bool) {
+	var quotes []rune
+	for _, r := range word {
+		if r == '\'' || r == '\u2018' || r == '\u2019' {
+			quotes = append(quotes, r)
+		}
+	}
+
+	converted := convertToken(curlyApostropheReplacer.Replace(word), dict)
+	if strings.Count(converted, "'") != len(quotes) {
+		return "", false
+	}
+
+	var b strings.Builder
+	b.Grow(len(word))
+	next := 0
+	for _, r := range converted {
+		if r == '\'' {
+			r = quotes[next]
+			next++
+		}
+		b.WriteRune(r)
+	}
+	result := b.String()
+	return result, result != word
+}
+
+// curlyApostropheReplacer turns curly single quotes into ASCII apostrophes for matching
+var curlyApostropheReplacer = strings.NewReplacer("\u2018", "'", "\u2019", "'")
+
 // parallelLineThreshold is the minimum number of lines before we use parallel processing.
 const parallelLineThreshold = 500
 
-// convertLine processes a single line through tokenisation and dictionary lookup.
-func convertLine(line string, dict map[string]string) string {
+// convertLine processes a single line through tokenisation and dictionary lookup, recording each
+// change in explain and counting it in counters. With guardProperNouns, words in multi-word
+// Title Case names are kept. Identifiers such as "initializeColor" are kept unless
+// convertIdentifiers is set. URLs are kept unless convertURLs is set, but the text around a URL
+// in the same token, such as the "color" of "(https://example.com)color", is converted.
+func convertLine(line string, dict map[string]string, explain *explainLog, counters *conversionCounters, guardProperNouns, convertURLs, convertIdentifiers bool) string {
 	if line == "" {
 		return ""
 	}
 
 	tokens, wsFlags := tokeniseLine(line)
 
+	var properNouns []bool
+	if guardProperNouns {
+		properNouns = properNounTokens(tokens, wsFlags)
+	}
+
 	for i := range tokens {
 		if wsFlags[i] {
 			continue
 		}
-		if isURL(tokens[i]) {
+		if properNouns != nil && properNouns[i] {
 			continue
 		}
-		tokens[i] = convertToken(tokens[i], dict)
+		converted := convertLineToken(tokens[i], dict, convertURLs, convertIdentifiers)
+		if converted != tokens[i] {
+			if explain != nil {
+				explain.record(wordExplanation(tokens[i], converted))
+			}
+			counters.add(countDictionary, 1)
+		}
+		tokens[i] = converted
 	}
 
 	return strings.Join(tokens, "")
 }
 
-// convert performs the actual conversion using the provided dictionary.
-// For large texts, lines are processed in parallel across available CPU cores.
-func (c *Converter) convert(text string, dict map[string]string) string {
+// countLine counts the words convertLine would change in a line without building the converted
+// line
+func countLine(line string, dict map[string]string, counters *conversionCounters, guardProperNouns, convertURLs, convertIdentifiers bool) {
+	if line == "" {
+		return
+	}
+
+	tokens, wsFlags := tokeniseLine(line)
+
+	var properNouns []bool
+	if guardProperNouns {
+		properNouns = properNounTokens(tokens, wsFlags)
+	}
+
+	changed := 0
+	for i, token := range tokens {
+		if wsFlags[i] || (properNouns != nil && properNouns[i]) {
+			continue
+		}
+		if convertLineToken(token, dict, convertURLs, convertIdentifiers) != token {
+			changed++
+		}
+	}
+	counters.add(countDictionary, changed)
+}
+
+// convertLineToken converts a token of a line. A URL is kept unless convertURLs is set, but the
+// word after it is converted. An identifier is kept unless convertIdentifiers is set, when the
+// words it's made of are converted.
+func convertLineToken(token string, dict map[string]string, convertURLs, convertIdentifiers bool) string {
+	start, end, ok := urlSpan(token)
+	if !ok {
+		// The dictionary only matches whole words, so the words inside an identifier are only
+		// looked up when asked for; identifiers that are dictionary words themselves still match
+		converted := convertToken(token, dict)
+		if converted == token && convertIdentifiers && looksLikeIdentifier(token) {
+			return convertIdentifierWords(token, dict)
+		}
+		return converted
+	}
+
+	url := token[start:end]
+	if convertURLs {
+		url = convertURLWords(url, dict)
+	}
+	// The closing bracket or punctuation after the URL is kept apart from the word it joins
+	rest := token[end:]
+	wordStart := strings.IndexFunc(rest, unicode.IsLetter)
+	if wordStart < 0 {
+		wordStart = len(rest)
+	}
+	converted := token[:start] + url + rest[:wordStart]
+	if wordStart < len(rest) {
+		converted += convertToken(rest[wordStart:], dict)
+	}
+	return converted
+}
+
+// convertFilteredLine converts a line unless filter shows it has no dictionary words
+func convertFilteredLine(line string, dict map[string]string, filter *wordFilter, explain *explainLog, counters *conversionCounters, guardProperNouns, convertURLs, convertIdentifiers bool) string {
+	if !filter.mayContainWord(line) {
+		return line
+	}
+	return convertLine(line, dict, explain, counters, guardProperNouns, convertURLs, convertIdentifiers)
+}
+
+// countFilteredLine counts the words convertFilteredLine would change in a line
+func countFilteredLine(line string, dict map[string]string, filter *wordFilter, counters *conversionCounters, guardProperNouns, convertURLs, convertIdentifiers bool) {
+	if filter.mayContainWord(line) {
+		countLine(line, dict, counters, guardProperNouns, convertURLs, convertIdentifiers)
+	}
+}
+
+// convert performs the actual conversion using the provided dictionary. Lines that filter
+// rules out are copied without being tokenised; a nil filter converts every line.
+// For large texts, lines are processed in parallel across available CPU cores, unless changes
+// are being explained, which keeps the explanations in text order. With SetCountOnly, the
+// changes are counted and text is returned as it is.
+func (c *Converter) convert(text string, dict map[string]string, filter *wordFilter) string {
+	if !filter.mayContainWord(text) {
+		c.counters.add(countFiltered, 1)
+		return text
+	}
+	c.counters.add(countScanned, 1)
+
 	lines := strings.Split(text, "\n")
-	resultLines := make([]string, len(lines))
+	var resultLines []string
+	if !c.countOnly {
+		resultLines = make([]string, len(lines))
+	}
+	convertLines := func(start, end int, explain *explainLog) {
+		for i := start; i < end; i++ {
+			if c.countOnly {
+				countFilteredLine(lines[i], dict, filter, c.counters, !c.convertProperNouns, c.convertURLs, c.convertIdentifiers)
+			} else {
+				resultLines[i] = convertFilteredLine(lines[i], dict, filter, explain, c.counters, !c.convertProperNouns, c.convertURLs, c.convertIdentifiers)
+			}
+		}
+	}
 
-	if len(lines) < parallelLineThreshold {
+	if len(lines) < parallelLineThreshold || c.explain != nil {
 		// Sequential path for small/medium texts
-		for lineIdx, line := range lines {
-			resultLines[lineIdx] = convertLine(line, dict)
-		}
+		convertLines(0, len(lines), c.explain)
 	} else {
 		// Parallel path for large texts
 		numWorkers := runtime.GOMAXPROCS(0)
@@ -541,14 +1226,15 @@ func (c *Converter) convert(text string, dict map[string]string) string {
 			wg.Add(1)
 			go func(start, end int) {
 				defer wg.Done()
-				for i := start; i < end; i++ {
-					resultLines[i] = convertLine(lines[i], dict)
-				}
+				convertLines(start, end, nil)
 			}(start, end)
 		}
 		wg.Wait()
 	}
 
+	if c.countOnly {
+		return text
+	}
 	return strings.Join(resultLines, "\n")
 }
 
@@ -560,12 +1246,17 @@ func (c *Converter) applyContextualWordConversion(text string) string {
 
 	// Detect contextual word matches
 	matches := c.contextualWordDetector.DetectWords(text)
+	if c.warnings != nil {
+		c.warnAmbiguousContext(text, matches)
+	}
 	if len(matches) == 0 {
 		return text
 	}
 
 	// Process matches in reverse order to maintain positions
 	result := text
+	var explanations []Explanation
+	var warnings []AmbiguityWarning
 	for i := len(matches) - 1; i >= 0; i-- {
 		match := matches[i]
 
@@ -574,6 +1265,12 @@ func (c *Converter) applyContextualWordConversion(text string) string {
 			continue
 		}
 
+		// Excluded words, and words missing from an allowlist, are never converted, even in a
+		// matching context
+		if c.isExcludedWord(match.OriginalWord) || !c.isAllowedWord(match.OriginalWord) {
+			continue
+		}
+
 		// Skip words that would result in no change
 		// This prevents unnecessary processing
 
@@ -581,7 +1278,27 @@ func (c *Converter) applyContextualWordConversion(text string) string {
 		before := result[:match.Start]
 		after := result[match.End:]
 		result = before + match.Replacement + after
+		c.counters.add(countContextual, 1)
+		if c.explain != nil {
+			explanations = append(explanations, Explanation{
+				Original:   match.OriginalWord,
+				Converted:  match.Replacement,
+				Rule:       "contextual: " + match.Rule,
+				Confidence: match.Confidence,
+			})
+		}
+		if c.warnings != nil && match.Confidence < lowConfidence {
+			warnings = append(warnings, AmbiguityWarning{
+				Text:       match.OriginalWord,
+				Converted:  match.Replacement,
+				Reason:     "low-confidence contextual decision (" + match.Rule + ")",
+				Context:    warningContext(text, match.Start, match.End),
+				Confidence: match.Confidence,
+			})
+		}
 	}
+	c.explain.explainReversed(explanations)
+	c.warnings.recordReversed(warnings)
 
 	return result
 }
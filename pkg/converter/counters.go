@@ -0,0 +1,101 @@
+// Package converter provides counters of the work a conversion did
+package converter
+
+import "sync/atomic"
+
+// ConversionCounters counts the changes made by each stage of conversion and how much prose
+// the dictionary pre-filter let through, for performance debugging
+type ConversionCounters struct {
+	DictionaryHits  int // words changed by the dictionary
+	ContextualHits  int // words changed by the contextual word detector
+	UnitConversions int // measurements converted to metric
+	FilteredText    int // pieces of prose the pre-filter showed had no dictionary words
+	ScannedText     int // pieces of prose that were tokenised and looked up in the dictionary
+}
+
+// Changes returns the number of changes counted across the dictionary, contextual and unit
+// stages
+func (c ConversionCounters) Changes() int {
+	return c.DictionaryHits + c.ContextualHits + c.UnitConversions
+}
+
+// PrefilterSkipped reports whether the pre-filter ruled out all of the prose, so none of it was
+// tokenised
+func (c ConversionCounters) PrefilterSkipped() bool {
+	return c.ScannedText == 0 && c.FilteredText > 0
+}
+
+// counterKind identifies one of the conversion counters
+type counterKind int
+
+const (
+	countDictionary counterKind = iota
+	countContextual
+	countUnits
+	countFiltered
+	countScanned
+	numCounterKinds
+)
+
+// conversionCounters collects ConversionCounters as text is converted. It is shared by a
+// converter's copies and safe for concurrent use. A nil counter counts nothing.
+type conversionCounters struct {
+	counts [numCounterKinds]atomic.Int64
+}
+
+// add increments the kind counter by n
+func (c *conversionCounters) add(kind counterKind, n int) {
+	if c == nil || n == 0 {
+		return
+	}
+	c.counts[kind].Add(int64(n))
+}
+
+// take returns the counts and resets them
+func (c *conversionCounters) take() ConversionCounters {
+	if c == nil {
+		return ConversionCounters{}
+	}
+	return ConversionCounters{
+		DictionaryHits:  int(c.counts[countDictionary].Swap(0)),
+		ContextualHits:  int(c.counts[countContextual].Swap(0)),
+		UnitConversions: int(c.counts[countUnits].Swap(0)),
+		FilteredText:    int(c.counts[countFiltered].Swap(0)),
+		ScannedText:     int(c.counts[countScanned].Swap(0)),
+	}
+}
+
+// SetCountersEnabled makes the converter count the changes made by each stage, for
+// TakeCounters to return. Copies made by Clone and ForFile after it is enabled share the same
+// counters.
+func (c *Converter) SetCountersEnabled(enabled bool) {
+	if !enabled {
+		c.counters = nil
+	} else if c.counters == nil {
+		c.counters = &conversionCounters{}
+	}
+	if c.unitProcessor != nil {
+		c.unitProcessor.counters = c.counters
+	}
+}
+
+// TakeCounters returns the counts since it was last called and resets them
+func (c *Converter) TakeCounters() ConversionCounters {
+	return c.counters.take()
+}
+
+// SetCountOnly makes the converter count the words its dictionary stage would change instead of
+// changing them, so large inputs can be checked without building the converted text. Counting is
+// enabled with it, for TakeCounters to return. The other stages still make their changes, so the
+// text a count-only converter returns is only partly converted and should be discarded.
+func (c *Converter) SetCountOnly(enabled bool) {
+	c.countOnly = enabled
+	if enabled {
+		c.SetCountersEnabled(true)
+	}
+}
+
+// IsCountOnly reports whether the converter counts dictionary changes without making them
+func (c *Converter) IsCountOnly() bool {
+	return c.countOnly
+}
@@ -0,0 +1,172 @@
+// Package converter provides CSV processing that converts only chosen columns
+package converter
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// CSVProcessor converts the cells of chosen columns of CSV and TSV files, such as a description
+// column in an export, leaving the header, every other column and the file's quoting alone.
+// Cells are rewritten in place in the original text, so only the cells that change are
+// re-quoted.
+type CSVProcessor struct {
+	columns []string // header names (case-insensitive) or 1-based column numbers
+}
+
+// NewCSVProcessor creates a new CSV processor with no columns chosen
+func NewCSVProcessor() *CSVProcessor {
+	return &CSVProcessor{}
+}
+
+// SetColumns chooses the columns to convert, each a header name, matched case-insensitively,
+// or a 1-based column number. No columns turns CSV processing off.
+func (p *CSVProcessor) SetColumns(columns []string) {
+	p.columns = nil
+	for _, column := range columns {
+		if column = strings.TrimSpace(column); column != "" {
+			p.columns = append(p.columns, column)
+		}
+	}
+}
+
+// Columns returns the chosen columns
+func (p *CSVProcessor) Columns() []string {
+	return p.columns
+}
+
+// IsEnabled reports whether any columns are chosen
+func (p *CSVProcessor) IsEnabled() bool {
+	return len(p.columns) > 0
+}
+
+// IsCSVFile reports whether a file is CSV or TSV, judging by its extension
+func IsCSVFile(filePath string) bool {
+	ext := strings.ToLower(filepath.Ext(filePath))
+	return ext == ".csv" || ext == ".tsv"
+}
+
+// csvDelimiter returns the field delimiter for a CSV or TSV file
+func csvDelimiter(filePath string) rune {
+	if strings.EqualFold(filepath.Ext(filePath), ".tsv") {
+		return '\t'
+	}
+	return ','
+}
+
+// ProcessCells converts the cells of the chosen columns with convertFunc. The first row is the
+// header, which names the columns and is never converted. It returns an error without changing
+// anything if the data isn't valid CSV or a chosen column isn't in it.
+func (p *CSVProcessor) ProcessCells(data string, delimiter rune, convertFunc func(string) string) (string, error) {
+	reader := csv.NewReader(strings.NewReader(data))
+	reader.Comma = delimiter
+	reader.FieldsPerRecord = -1 // rows may have fewer cells than the header
+
+	lineStarts := []int{0}
+	for i := 0; i < len(data); i++ {
+		if data[i] == '\n' {
+			lineStarts = append(lineStarts, i+1)
+		}
+	}
+
+	var result strings.Builder
+	result.Grow(len(data))
+	var selected []bool
+	written := 0 // bytes of data copied to result so far
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return data, fmt.Errorf("invalid CSV: %w", err)
+		}
+		recordEnd := int(reader.InputOffset())
+
+		if selected == nil {
+			if selected, err = p.selectColumns(record); err != nil {
+				return data, err
+			}
+			continue
+		}
+
+		for i, cell := range record {
+			if i >= len(selected) || !selected[i] {
+				continue
+			}
+			converted := convertFunc(cell)
+			if converted == cell {
+				continue
+			}
+
+			line, column := reader.FieldPos(i)
+			start := lineStarts[line-1] + column - 1
+			end := csvFieldEnd(data, recordEnd)
+			if i+1 < len(record) {
+				nextLine, nextColumn := reader.FieldPos(i + 1)
+				end = lineStarts[nextLine-1] + nextColumn - 1 - len(string(delimiter))
+			}
+
+			result.WriteString(data[written:start])
+			result.WriteString(quoteCSVField(converted, delimiter, strings.HasPrefix(data[start:end], `"`)))
+			written = end
+		}
+	}
+
+	result.WriteString(data[written:])
+	return result.String(), nil
+}
+
+// selectColumns returns which columns of the header are chosen
+func (p *CSVProcessor) selectColumns(header []string) ([]bool, error) {
+	names := make([]string, len(header))
+	for i, name := range header {
+		names[i] = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+	}
+
+	selected := make([]bool, len(header))
+	for _, column := range p.columns {
+		if number, err := strconv.Atoi(column); err == nil {
+			if number < 1 || number > len(header) {
+				return nil, fmt.Errorf("CSV column %d is out of range (the header has %d columns)", number, len(header))
+			}
+			selected[number-1] = true
+			continue
+		}
+		index := slices.Index(names, strings.ToLower(column))
+		if index < 0 {
+			return nil, fmt.Errorf("CSV column %q isn't in the header", column)
+		}
+		selected[index] = true
+	}
+	return selected, nil
+}
+
+// csvFieldEnd returns the end of the last field of a record that ends at recordEnd, before its
+// line ending
+func csvFieldEnd(data string, recordEnd int) int {
+	if recordEnd > 0 && data[recordEnd-1] == '\n' {
+		recordEnd--
+		if recordEnd > 0 && data[recordEnd-1] == '\r' {
+			recordEnd--
+		}
+	}
+	return recordEnd
+}
+
+// quoteCSVField returns value as a CSV field, quoted if it was quoted before or needs to be
+// because it holds the delimiter, a quote or a line break, or starts with a space
+func quoteCSVField(value string, delimiter rune, quoted bool) string {
+	if !quoted && !strings.ContainsRune(value, delimiter) && !strings.ContainsAny(value, "\"\r\n") &&
+		!strings.HasPrefix(value, " ") {
+		return value
+	}
+	return `"` + strings.ReplaceAll(value, `"`, `""`) + `"`
+}
@@ -0,0 +1,113 @@
+// Package converter provides dash handling for smart quote normalisation
+package converter
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// DashMode selects what happens to en-dashes (–) and em-dashes (—) in prose
+type DashMode int
+
+const (
+	// DashModeFlatten replaces en-dashes and em-dashes with hyphens when smart quotes are
+	// normalised. This is the default.
+	DashModeFlatten DashMode = iota
+	// DashModeTypographic keeps en-dashes and em-dashes, and turns a spaced hyphen between
+	// numbers into an en-dash, so "1990 - 1995" becomes "1990–1995"
+	DashModeTypographic
+)
+
+// String returns the name used for the mode on the command line
+func (m DashMode) String() string {
+	if m == DashModeTypographic {
+		return "typographic"
+	}
+	return "flatten"
+}
+
+// ParseDashMode parses a dash mode name: flatten or typographic
+func ParseDashMode(name string) (DashMode, error) {
+	switch strings.ToLower(name) {
+	case "flatten":
+		return DashModeFlatten, nil
+	case "typographic":
+		return DashModeTypographic, nil
+	}
+	return DashModeFlatten, fmt.Errorf("unknown dash mode %q (supported: flatten, typographic)", name)
+}
+
+// quoteOnlyReplacer normalises smart quotes but leaves dashes, for DashModeTypographic
+var quoteOnlyReplacer = strings.NewReplacer(
+	"\u201C", "\"",
+	"\u201D", "\"",
+	"\u2018", "'",
+	"\u2019", "'",
+)
+
+// numberRangePattern matches a hyphen with a space either side between two numbers
+var numberRangePattern = regexp.MustCompile(`(\d) - (\d)`)
+
+// SetDashMode sets how en-dashes and em-dashes in prose are handled. Inline code spans are
+// always left alone, but fenced and indented code blocks are only recognised by the entry points
+// that see the whole document, ProcessCodeAware and ConvertFileContent. ConvertToBritish works
+// line by line, so with DashModeTypographic it turns "10 - 2" on a line of a code block into
+// "10–2".
+func (c *Converter) SetDashMode(mode DashMode) {
+	c.dashMode = mode
+}
+
+// GetDashMode returns how en-dashes and em-dashes in prose are handled
+func (c *Converter) GetDashMode() DashMode {
+	return c.dashMode
+}
+
+// isKeptDash reports whether s is a dash that the dash mode keeps when smart quotes are
+// normalised
+func (c *Converter) isKeptDash(s string) bool {
+	return c.dashMode == DashModeTypographic && (s == "\u2013" || s == "\u2014")
+}
+
+// convertNumberRanges turns "1990 - 1995" into "1990–1995" in DashModeTypographic. It only sees
+// prose, so code is never touched, and a URL can't hold the spaces it looks for.
+func (c *Converter) convertNumberRanges(text string) string {
+	if c.dashMode != DashModeTypographic || !strings.Contains(text, " - ") {
+		return text
+	}
+	return numberRangePattern.ReplaceAllStringFunc(text, func(match string) string {
+		if c.explain != nil {
+			c.explain.record(Explanation{Original: " - ", Converted: "\u2013", Rule: "dashes"})
+		}
+		return match[:1] + "\u2013" + match[len(match)-1:]
+	})
+}
+
+// convertDashedWord converts each part of a token joined by en-dashes or em-dashes, such as
+// "color—gray", which the typographic dash mode leaves in the text
+func convertDashedWord(word string, dict map[string]string) (string, bool) {
+	var b strings.Builder
+	changed := false
+	convertPart := func(part string) {
+		if part == "" {
+			return
+		}
+		converted := convertToken(part, dict)
+		changed = changed || converted != part
+		b.WriteString(converted)
+	}
+
+	start := 0
+	for i, r := range word {
+		if r != '–' && r != '—' {
+			continue
+		}
+		convertPart(word[start:i])
+		b.WriteRune(r)
+		start = i + utf8.RuneLen(r)
+	}
+	convertPart(word[start:])
+
+	return b.String(), changed
+}
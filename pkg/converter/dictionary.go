@@ -4,6 +4,7 @@ package converter
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
 )
@@ -83,19 +84,117 @@ func loadUserDictionary() (map[string]string, error) {
 	return userDict, nil
 }
 
-// LoadDictionaries loads the American to British spelling dictionary from the embedded JSON file
-// and merges it with the user's custom dictionary
-func LoadDictionaries() (*Dictionaries, error) {
-	// Load built-in American to British dictionary
-	amToBrData, err := dictFS.ReadFile("data/american_spellings.json")
+// DictPathEnv names the environment variable that points at a JSON file, or a directory of
+// JSON files, to use in place of the embedded dictionaries
+const DictPathEnv = "M2E_DICT_PATH"
+
+const (
+	spellingsDataFile = "american_spellings.json"
+	phrasesDataFile   = "american_phrases.json"
+)
+
+// loadDataDictionary loads one of the built-in data files, taking it from M2E_DICT_PATH instead
+// of the embedded data when set:
+//
+//   - A file replaces the embedded spelling dictionary; phrases still come from the embedded data.
+//   - A directory replaces each embedded file that it has a file of the same name for. Its other
+//     .json files are merged into the spelling dictionary in name order, later files winning.
+func loadDataDictionary(name string) (map[string]string, error) {
+	dictPath := os.Getenv(DictPathEnv)
+	if dictPath == "" {
+		return loadEmbeddedDictionary(name)
+	}
+
+	info, err := os.Stat(dictPath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read built-in American spellings dictionary: %w", err)
+		return nil, fmt.Errorf("failed to read %s: %w", DictPathEnv, err)
+	}
+	if !info.IsDir() {
+		if name != spellingsDataFile {
+			return loadEmbeddedDictionary(name)
+		}
+		return LoadDictionaryFile(dictPath)
 	}
 
-	// Parse the built-in dictionary
-	amToBr := make(map[string]string)
-	if err := json.Unmarshal(amToBrData, &amToBr); err != nil {
-		return nil, fmt.Errorf("failed to parse built-in American spellings dictionary: %w", err)
+	var dict map[string]string
+	replacement := filepath.Join(dictPath, name)
+	if _, err := os.Stat(replacement); err == nil {
+		dict, err = LoadDictionaryFile(replacement)
+		if err != nil {
+			return nil, err
+		}
+	} else if os.IsNotExist(err) {
+		if dict, err = loadEmbeddedDictionary(name); err != nil {
+			return nil, err
+		}
+	} else {
+		return nil, fmt.Errorf("failed to read %s: %w", replacement, err)
+	}
+
+	if name != spellingsDataFile {
+		return dict, nil
+	}
+	extras, err := filepath.Glob(filepath.Join(dictPath, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dictionaries in %s: %w", dictPath, err)
+	}
+	for _, extra := range extras {
+		if base := filepath.Base(extra); base == spellingsDataFile || base == phrasesDataFile {
+			continue
+		}
+		entries, err := LoadDictionaryFile(extra)
+		if err != nil {
+			return nil, err
+		}
+		maps.Copy(dict, entries)
+	}
+	return dict, nil
+}
+
+// loadEmbeddedDictionary parses one of the data files embedded at build time
+func loadEmbeddedDictionary(name string) (map[string]string, error) {
+	data, err := dictFS.ReadFile("data/" + name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read built-in dictionary %s: %w", name, err)
+	}
+
+	dict := make(map[string]string)
+	if err := json.Unmarshal(data, &dict); err != nil {
+		return nil, fmt.Errorf("failed to parse built-in dictionary %s: %w", name, err)
+	}
+	return dict, nil
+}
+
+// LoadDictionaryFile reads a dictionary file, such as one given through M2E_DICT_PATH, checking
+// that it is a JSON object mapping each American word to a non-empty British string
+func LoadDictionaryFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dictionary %s: %w", path, err)
+	}
+
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("dictionary %s must be a JSON object of American to British words: %w", path, err)
+	}
+
+	dict := make(map[string]string, len(raw))
+	for american, value := range raw {
+		british, ok := value.(string)
+		if american == "" || !ok || british == "" {
+			return nil, fmt.Errorf("dictionary %s: entry %q must map a word to a non-empty string", path, american)
+		}
+		dict[american] = british
+	}
+	return dict, nil
+}
+
+// LoadDictionaries loads the American to British spelling dictionary, from the embedded JSON file
+// or M2E_DICT_PATH, and merges it with the user's custom dictionary
+func LoadDictionaries() (*Dictionaries, error) {
+	amToBr, err := loadDataDictionary(spellingsDataFile)
+	if err != nil {
+		return nil, err
 	}
 
 	// Load user dictionary
@@ -0,0 +1,86 @@
+// Package converter provides comparison of two versions of a dictionary
+package converter
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// DictionaryEntry is a single American to British mapping
+type DictionaryEntry struct {
+	American string `json:"american"`
+	British  string `json:"british"`
+}
+
+// DictionaryChange is a mapping whose British spelling differs between two dictionaries
+type DictionaryChange struct {
+	American string `json:"american"`
+	Old      string `json:"old"`
+	New      string `json:"new"`
+}
+
+// DictionaryWarning is an added or changed mapping that looks like a mistake
+type DictionaryWarning struct {
+	American string `json:"american"`
+	British  string `json:"british"`
+	Reason   string `json:"reason"`
+}
+
+// DictionaryDiff lists the mappings added, removed and changed between two versions of a
+// dictionary, each sorted by American word, along with warnings about the new version's entries
+type DictionaryDiff struct {
+	Added    []DictionaryEntry   `json:"added"`
+	Removed  []DictionaryEntry   `json:"removed"`
+	Changed  []DictionaryChange  `json:"changed"`
+	Warnings []DictionaryWarning `json:"warnings"`
+}
+
+// IsEmpty reports whether the two dictionaries have the same mappings
+func (d DictionaryDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// DiffDictionaries compares two versions of an American to British dictionary. Added and
+// changed mappings are checked for two common mistakes: a word mapped to itself, and a word
+// mapped to a spelling the new dictionary would convert again.
+func DiffDictionaries(oldDict, newDict map[string]string) DictionaryDiff {
+	diff := DictionaryDiff{
+		Added:    []DictionaryEntry{},
+		Removed:  []DictionaryEntry{},
+		Changed:  []DictionaryChange{},
+		Warnings: []DictionaryWarning{},
+	}
+
+	for _, american := range slices.Sorted(maps.Keys(newDict)) {
+		british := newDict[american]
+		old, existed := oldDict[american]
+		switch {
+		case !existed:
+			diff.Added = append(diff.Added, DictionaryEntry{American: american, British: british})
+		case old != british:
+			diff.Changed = append(diff.Changed, DictionaryChange{American: american, Old: old, New: british})
+		default:
+			continue
+		}
+
+		if strings.EqualFold(american, british) {
+			diff.Warnings = append(diff.Warnings, DictionaryWarning{American: american, British: british, Reason: "maps to itself"})
+		} else if again, ok := newDict[british]; ok {
+			diff.Warnings = append(diff.Warnings, DictionaryWarning{
+				American: american,
+				British:  british,
+				Reason:   fmt.Sprintf("%q is converted again to %q", british, again),
+			})
+		}
+	}
+
+	for _, american := range slices.Sorted(maps.Keys(oldDict)) {
+		if _, kept := newDict[american]; !kept {
+			diff.Removed = append(diff.Removed, DictionaryEntry{American: american, British: oldDict[american]})
+		}
+	}
+
+	return diff
+}
@@ -0,0 +1,287 @@
+// Package converter provides Word (.docx) processing that converts document text while
+// preserving every other part of the package
+package converter
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"html"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+var (
+	// docxTextPartRegex matches the parts of a Word package whose text is converted: the main
+	// document, headers and footers
+	docxTextPartRegex = regexp.MustCompile(`^word/(?:document|header\d*|footer\d*)\.xml$`)
+
+	// docxTokenRegex matches, in document order, a text node (opening tag in group 1, escaped
+	// text in group 2), a tab or line break, and the end of a paragraph. Other elements, such as
+	// field instructions (w:instrText) and deleted text (w:delText), are never matched.
+	docxTokenRegex = regexp.MustCompile(`(<w:t(?:\s[^>]*)?>)([^<]*)</w:t>|<w:(?:tab|br|cr)(?:\s[^>]*)?/>|</w:p>`)
+
+	docxTextEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+)
+
+// docxTextNode is a w:t element in a document part
+type docxTextNode struct {
+	start, end int    // span of the whole element
+	tag        string // opening tag, such as <w:t xml:space="preserve">
+	text       string // unescaped text
+}
+
+// DocxProcessor converts the text of Word documents, rewriting only w:t text nodes so styles,
+// fields, relationships and every other part of the package are untouched
+type DocxProcessor struct{}
+
+// NewDocxProcessor creates a new Word document processor
+func NewDocxProcessor() *DocxProcessor {
+	return &DocxProcessor{}
+}
+
+// IsDocxFile checks if a file extension indicates a Word document
+func IsDocxFile(filePath string) bool {
+	return strings.EqualFold(filepath.Ext(filePath), ".docx")
+}
+
+// openPackage opens data as a Word package, refusing anything that isn't one
+func (p *DocxProcessor) openPackage(data []byte) (*zip.Reader, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("not a valid Office Open XML package: %w", err)
+	}
+
+	hasDocument := false
+	var contentTypes *zip.File
+	for _, f := range archive.File {
+		switch f.Name {
+		case "[Content_Types].xml":
+			contentTypes = f
+		case "word/document.xml":
+			hasDocument = true
+		}
+	}
+	if contentTypes == nil || !hasDocument {
+		return nil, fmt.Errorf("not a valid Office Open XML package: missing [Content_Types].xml or word/document.xml")
+	}
+	types, err := readZipFile(contentTypes)
+	if err != nil {
+		return nil, fmt.Errorf("not a valid Office Open XML package: %w", err)
+	}
+	if !strings.Contains(types, "wordprocessingml") {
+		return nil, fmt.Errorf("not a valid Office Open XML package: not a Word document")
+	}
+	return archive, nil
+}
+
+// ProcessPackage returns a copy of the Word package in data with the text of its document,
+// headers and footers converted by convertFunc. Every other part is copied byte for byte, as
+// are text parts that don't change. An error is returned if data isn't a Word package.
+func (p *DocxProcessor) ProcessPackage(data []byte, convertFunc func(string) string) ([]byte, error) {
+	archive, err := p.openPackage(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	if err := writer.SetComment(archive.Comment); err != nil {
+		return nil, err
+	}
+
+	for _, f := range archive.File {
+		if docxTextPartRegex.MatchString(f.Name) {
+			part, err := readZipFile(f)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s: %w", f.Name, err)
+			}
+			if converted := p.processPart(part, convertFunc); converted != part {
+				header := f.FileHeader
+				w, err := writer.CreateHeader(&header)
+				if err != nil {
+					return nil, err
+				}
+				if _, err := io.WriteString(w, converted); err != nil {
+					return nil, err
+				}
+				continue
+			}
+		}
+
+		if err := copyZipFile(writer, f); err != nil {
+			return nil, fmt.Errorf("failed to copy %s: %w", f.Name, err)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ExtractText returns the text of a Word package's document, headers and footers, with a line
+// per paragraph, so changes can be shown as a diff
+func (p *DocxProcessor) ExtractText(data []byte) (string, error) {
+	archive, err := p.openPackage(data)
+	if err != nil {
+		return "", err
+	}
+
+	var text strings.Builder
+	for _, f := range archive.File {
+		if !docxTextPartRegex.MatchString(f.Name) {
+			continue
+		}
+		part, err := readZipFile(f)
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s: %w", f.Name, err)
+		}
+		for _, match := range docxTokenRegex.FindAllStringSubmatch(part, -1) {
+			switch {
+			case match[1] != "":
+				text.WriteString(html.UnescapeString(match[2]))
+			case strings.HasPrefix(match[0], "<w:tab"):
+				text.WriteString("\t")
+			default:
+				text.WriteString("\n")
+			}
+		}
+	}
+	return text.String(), nil
+}
+
+// processPart converts the text nodes of a document part. Each paragraph's text, up to any tab
+// or line break, is converted as a whole so words split across runs are still found, and the
+// result is spread back over the runs.
+func (p *DocxProcessor) processPart(part string, convertFunc func(string) string) string {
+	var nodes []docxTextNode
+	var replacements []string
+	var group []docxTextNode
+
+	flush := func() {
+		if len(group) > 0 {
+			replacements = append(replacements, convertDocxRuns(group, convertFunc)...)
+			nodes = append(nodes, group...)
+			group = nil
+		}
+	}
+	for _, match := range docxTokenRegex.FindAllStringSubmatchIndex(part, -1) {
+		if match[2] < 0 {
+			flush()
+			continue
+		}
+		group = append(group, docxTextNode{
+			start: match[0],
+			end:   match[1],
+			tag:   part[match[2]:match[3]],
+			text:  html.UnescapeString(part[match[4]:match[5]]),
+		})
+	}
+	flush()
+
+	var result strings.Builder
+	last := 0
+	for i, node := range nodes {
+		if replacements[i] == node.text {
+			continue
+		}
+		tag := node.tag
+		if strings.TrimSpace(replacements[i]) != replacements[i] && !strings.Contains(tag, "xml:space") {
+			// Word drops leading and trailing spaces unless they are marked as significant
+			tag = `<w:t xml:space="preserve">`
+		}
+		result.WriteString(part[last:node.start])
+		result.WriteString(tag + docxTextEscaper.Replace(replacements[i]) + "</w:t>")
+		last = node.end
+	}
+	if last == 0 {
+		return part
+	}
+	result.WriteString(part[last:])
+	return result.String()
+}
+
+// convertDocxRuns converts the joined text of a paragraph's runs and returns each run's new
+// text. Unchanged text stays in its run, keeping its formatting; inserted text goes to the run
+// holding the character before it, and deleted text is removed from whichever runs held it.
+func convertDocxRuns(runs []docxTextNode, convertFunc func(string) string) []string {
+	ends := make([]int, len(runs))
+	var joined strings.Builder
+	for i, run := range runs {
+		joined.WriteString(run.text)
+		ends[i] = joined.Len()
+	}
+	original := joined.String()
+
+	texts := make([]string, len(runs))
+	converted := convertFunc(original)
+	if converted == original {
+		for i, run := range runs {
+			texts[i] = run.text
+		}
+		return texts
+	}
+
+	// runAt returns the run holding the byte at pos
+	runAt := func(pos int) int {
+		for i, end := range ends {
+			if pos < end {
+				return i
+			}
+		}
+		return len(runs) - 1
+	}
+
+	builders := make([]strings.Builder, len(runs))
+	pos := 0
+	for _, diff := range diffmatchpatch.New().DiffMain(original, converted, false) {
+		switch diff.Type {
+		case diffmatchpatch.DiffEqual:
+			for end := pos + len(diff.Text); pos < end; {
+				run := runAt(pos)
+				next := min(ends[run], end)
+				builders[run].WriteString(original[pos:next])
+				pos = next
+			}
+		case diffmatchpatch.DiffDelete:
+			pos += len(diff.Text)
+		case diffmatchpatch.DiffInsert:
+			builders[runAt(max(pos-1, 0))].WriteString(diff.Text)
+		}
+	}
+
+	for i := range builders {
+		texts[i] = builders[i].String()
+	}
+	return texts
+}
+
+// readZipFile returns the uncompressed content of a file in a zip archive
+func readZipFile(f *zip.File) (string, error) {
+	r, err := f.Open()
+	if err != nil {
+		return "", err
+	}
+	defer r.Close()
+	content, err := io.ReadAll(r)
+	return string(content), err
+}
+
+// copyZipFile copies a file's compressed data, and its header, to writer unchanged
+func copyZipFile(writer *zip.Writer, f *zip.File) error {
+	r, err := f.OpenRaw()
+	if err != nil {
+		return err
+	}
+	w, err := writer.CreateRaw(&f.FileHeader)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, r)
+	return err
+}
@@ -0,0 +1,151 @@
+package converter
+
+import (
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"unicode"
+)
+
+// exclusionPrefilter returns the required literals of each exclusion pattern,
+// in the same order as ExclusionPatterns. ExclusionPatterns is exported and
+// appended to directly, so the literals are recomputed whenever the slice no
+// longer holds the patterns they were computed from.
+func (p *ContextualWordPatterns) exclusionPrefilter() [][]string {
+	p.exclusionMu.Lock()
+	defer p.exclusionMu.Unlock()
+
+	if len(p.exclusionLiterals) == len(p.ExclusionPatterns) && samePatterns(p.exclusionSource, p.ExclusionPatterns) {
+		return p.exclusionLiterals
+	}
+
+	literals := make([][]string, len(p.ExclusionPatterns))
+	for i, pattern := range p.ExclusionPatterns {
+		literals[i] = requiredLiteralsFor(pattern)
+	}
+	p.exclusionSource = append([]*regexp.Regexp(nil), p.ExclusionPatterns...)
+	p.exclusionLiterals = literals
+
+	return literals
+}
+
+// samePatterns reports whether a and b hold the same compiled patterns in the same order
+func samePatterns(a, b []*regexp.Regexp) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// containsAnyLiteral reports whether textLower contains one of literals.
+// A nil set means the pattern has no usable literal and must always be run.
+func containsAnyLiteral(textLower string, literals []string) bool {
+	if literals == nil {
+		return true
+	}
+	for _, literal := range literals {
+		if strings.Contains(textLower, literal) {
+			return true
+		}
+	}
+	return false
+}
+
+// requiredLiteralsFor returns lowercase strings of which at least one appears
+// in the lowercased form of any text the pattern matches, or nil if no such
+// set can be worked out.
+func requiredLiteralsFor(pattern *regexp.Regexp) []string {
+	parsed, err := syntax.Parse(pattern.String(), syntax.Perl)
+	if err != nil {
+		return nil
+	}
+	return requiredLiterals(parsed.Simplify())
+}
+
+// requiredLiterals walks a parsed regex collecting literals every match must contain
+func requiredLiterals(re *syntax.Regexp) []string {
+	switch re.Op {
+	case syntax.OpLiteral:
+		literal := foldSafeLiteral(re.Rune, re.Flags&syntax.FoldCase != 0)
+		if literal == "" {
+			return nil
+		}
+		return []string{literal}
+	case syntax.OpCapture, syntax.OpPlus:
+		return requiredLiterals(re.Sub[0])
+	case syntax.OpRepeat:
+		if re.Min < 1 {
+			return nil
+		}
+		return requiredLiterals(re.Sub[0])
+	case syntax.OpConcat:
+		// Every part of a concatenation is matched, so any part's literals will
+		// do; prefer the set whose shortest literal is longest
+		var best []string
+		for _, sub := range re.Sub {
+			literals := requiredLiterals(sub)
+			if literals != nil && (best == nil || shortestLength(literals) > shortestLength(best)) {
+				best = literals
+			}
+		}
+		return best
+	case syntax.OpAlternate:
+		var all []string
+		for _, sub := range re.Sub {
+			literals := requiredLiterals(sub)
+			if literals == nil {
+				return nil
+			}
+			all = append(all, literals...)
+		}
+		return all
+	}
+	return nil
+}
+
+// shortestLength returns the length of the shortest string in literals
+func shortestLength(literals []string) int {
+	shortest := len(literals[0])
+	for _, literal := range literals[1:] {
+		shortest = min(shortest, len(literal))
+	}
+	return shortest
+}
+
+// foldSafeLiteral lowercases a literal for matching against lowercased text.
+// Case-insensitive literals are cut at runes such as 's' and 'k' that also
+// match characters which lowercase to something else (ſ, K), keeping the
+// longest remaining run.
+func foldSafeLiteral(runes []rune, foldCase bool) string {
+	var best, current []rune
+	for _, r := range runes {
+		if foldCase && !foldsToSameLower(r) {
+			if len(current) > len(best) {
+				best = current
+			}
+			current = nil
+			continue
+		}
+		current = append(current, r)
+	}
+	if len(current) > len(best) {
+		best = current
+	}
+	return strings.ToLower(string(best))
+}
+
+// foldsToSameLower reports whether every case variant of r lowercases to the same rune
+func foldsToSameLower(r rune) bool {
+	lower := unicode.ToLower(r)
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		if unicode.ToLower(f) != lower {
+			return false
+		}
+	}
+	return true
+}
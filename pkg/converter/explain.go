@@ -0,0 +1,139 @@
+// Package converter provides explanations of why each change was made
+package converter
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Explanation describes a single change and the rule that made it
+type Explanation struct {
+	Original  string `json:"original"`
+	Converted string `json:"converted"`
+	// Rule is the stage that made the change, with detail where there is any: "dictionary",
+	// "phrase", "smart quotes", "contextual: determiner_noun pattern for license",
+	// "unit: feet→metres" or "unit: normalised metric symbol"
+	Rule string `json:"rule"`
+	// Confidence is how sure a detector was that the change is right, from 0 to 1, for contextual
+	// words and units. It is 0 for rules that are never in doubt, such as the dictionary.
+	Confidence float64 `json:"confidence,omitempty"`
+}
+
+// explainLog collects explanations as text is converted. It is shared by a converter's copies
+// and safe for concurrent use. A nil log records nothing.
+type explainLog struct {
+	mu      sync.Mutex
+	entries []Explanation
+}
+
+// record adds explanations, skipping any that don't change the text
+func (l *explainLog) record(explanations ...Explanation) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, e := range explanations {
+		if e.Original != e.Converted {
+			l.entries = append(l.entries, e)
+		}
+	}
+}
+
+// take returns the recorded explanations and clears them
+func (l *explainLog) take() []Explanation {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	entries := l.entries
+	l.entries = nil
+	return entries
+}
+
+// SetExplainEnabled makes the converter record the rule behind each change it makes, for
+// TakeExplanations to return. Copies made by Clone and ForFile after it is enabled share the
+// same record.
+func (c *Converter) SetExplainEnabled(enabled bool) {
+	if !enabled {
+		c.explain = nil
+	} else if c.explain == nil {
+		c.explain = &explainLog{}
+	}
+	if c.unitProcessor != nil {
+		c.unitProcessor.explain = c.explain
+	}
+	if c.phraseProcessor != nil {
+		c.phraseProcessor.explain = c.explain
+	}
+}
+
+// IsExplainEnabled returns whether the converter records the rule behind each change
+func (c *Converter) IsExplainEnabled() bool {
+	return c.explain != nil
+}
+
+// TakeExplanations returns the changes made since it was last called, each with the rule that
+// made it, and clears them. Changes are listed stage by stage, in the order the stages run, and
+// in text order within each stage.
+func (c *Converter) TakeExplanations() []Explanation {
+	return c.explain.take()
+}
+
+// explainSmartQuotes records each smart quote and dash that normalising text will replace
+func (c *Converter) explainSmartQuotes(text string) {
+	if c.explain == nil {
+		return
+	}
+	var explanations []Explanation
+	for _, r := range text {
+		if plain, ok := SmartQuotesMap[string(r)]; ok && !c.isKeptDash(string(r)) {
+			explanations = append(explanations, Explanation{Original: string(r), Converted: plain, Rule: "smart quotes"})
+		}
+	}
+	c.explain.record(explanations...)
+}
+
+// explainReversed records explanations gathered while editing text from its end backwards, so
+// they are listed in text order
+func (l *explainLog) explainReversed(explanations []Explanation) {
+	slices.Reverse(explanations)
+	l.record(explanations...)
+}
+
+// wordExplanation explains a dictionary change to a token, leaving out the punctuation around
+// the word that the conversion kept, so "color," → "colour," is explained as "color" → "colour"
+func wordExplanation(token, converted string) Explanation {
+	for token != "" && converted != "" && token[0] == converted[0] && !isLetterByte(token[0]) {
+		token, converted = token[1:], converted[1:]
+	}
+	for token != "" && converted != "" && token[len(token)-1] == converted[len(converted)-1] && !isLetterByte(token[len(token)-1]) {
+		token, converted = token[:len(token)-1], converted[:len(converted)-1]
+	}
+	return Explanation{Original: token, Converted: converted, Rule: "dictionary"}
+}
+
+// isLetterByte reports whether b is an ASCII letter or part of a multi-byte character, which is
+// never split
+func isLetterByte(b byte) bool {
+	return b >= utf8.RuneSelf || isLetter(b)
+}
+
+// FormatExplanation formats an explanation as "original → converted (rule)", quoting values
+// that are whitespace or punctuation only so they stay visible
+func FormatExplanation(e Explanation) string {
+	return quoteIfBare(e.Original) + " → " + quoteIfBare(e.Converted) + " (" + e.Rule + ")"
+}
+
+// quoteIfBare quotes s if it has no letters or digits
+func quoteIfBare(s string) string {
+	if strings.IndexFunc(s, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) < 0 {
+		return strconv.Quote(s)
+	}
+	return s
+}
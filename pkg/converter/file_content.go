@@ -0,0 +1,191 @@
+// Package converter provides file-type aware conversion for file content
+package converter
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// plainTextExtensions lists extensions whose entire content is prose and can be converted,
+// as opposed to code/config files where only comments should be touched
+var plainTextExtensions = []string{
+	".txt", ".md", ".markdown", ".rst", ".text", ".doc", ".rtf",
+	".tex", ".latex", ".org", ".adoc", ".asciidoc",
+}
+
+// configFileExtensions lists TOML and INI-style config files, whose comments are detected with
+// their own rules (# and ; markers, quoted values skipped)
+var configFileExtensions = []string{".toml", ".ini", ".cfg"}
+
+// IsConfigFile checks if a file extension indicates a TOML or INI-style config file, which
+// only ever has its comments converted
+func IsConfigFile(filePath string) bool {
+	ext := strings.ToLower(filepath.Ext(filePath))
+	return slices.Contains(configFileExtensions, ext)
+}
+
+// IsPlainTextFile checks if a file extension indicates it's a plain text file
+// that can be safely converted entirely (not just comments)
+func IsPlainTextFile(filePath string) bool {
+	ext := strings.ToLower(filepath.Ext(filePath))
+	return slices.Contains(plainTextExtensions, ext)
+}
+
+// ContentMode controls how ConvertFileContent decides what to convert in a file
+type ContentMode int
+
+const (
+	// ContentModeAuto infers from the file extension whether the whole file or only its
+	// comments are converted. This is the default.
+	ContentModeAuto ContentMode = iota
+	// ContentModeCommentsOnly converts only comments, whatever the file's extension
+	ContentModeCommentsOnly
+	// ContentModeAllText converts the whole file like plain text, whatever its extension
+	ContentModeAllText
+)
+
+// ConvertFileContent converts file content based on the file type inferred from filePath.
+// RTF documents only have their visible text converted, plain text files get code-aware
+// processing (all prose converted, code blocks preserved), while code/config files only
+// have their comments converted to preserve functionality. Subtitle files only have their
+// dialogue converted, and AsciiDoc and reStructuredText documents keep their markup. SVG drawings
+// only have their text labels, titles and descriptions converted. Jupyter notebooks have their
+// Markdown cells and code cell comments converted. With SetJSONValuesOnly, .json files only
+// have their string values converted, and with SetCSVColumns only the chosen columns of .csv
+// and .tsv files are converted. With SetMarkdownElements, only the chosen element types of
+// Markdown files are converted.
+// SetContentMode overrides the choice between converting the whole file and only its comments.
+// With SetMaxLineWidth, the paragraphs of Markdown and plain text files that conversion changes
+// are re-wrapped. The file's CRLF or CR line endings are kept.
+func (c *Converter) ConvertFileContent(content, filePath string, normaliseSmartQuotes bool) string {
+	converted, _ := c.ConvertFileContentWithWarnings(content, filePath, normaliseSmartQuotes)
+	return converted
+}
+
+// ConvertFileContentWithWarnings is ConvertFileContent, also returning a warning for each part
+// of the file that was left untouched because it couldn't be parsed: a whole notebook, CSV or
+// JSON file, or a malformed subtitle cue
+func (c *Converter) ConvertFileContentWithWarnings(content, filePath string, normaliseSmartQuotes bool) (string, []string) {
+	converted, warnings := c.convertFileContentByType(content, filePath, normaliseSmartQuotes)
+	return PreserveLineEndings(content, c.ReflowFile(content, converted, filePath)), warnings
+}
+
+// convertFileContentByType routes file content to the conversion for its type
+func (c *Converter) convertFileContentByType(content, filePath string, normaliseSmartQuotes bool) (string, []string) {
+	if c.rtfProcessor.IsRTF(content) {
+		return c.ConvertRTF(content, normaliseSmartQuotes), nil
+	}
+	switch c.contentMode {
+	case ContentModeCommentsOnly:
+		return c.convertFileComments(content, filePath, normaliseSmartQuotes), nil
+	case ContentModeAllText:
+		return c.convertPlainText(content, normaliseSmartQuotes), nil
+	}
+	if IsSubtitleFile(filePath) {
+		converted, subtitleWarnings := c.ConvertSubtitles(content, filePath, normaliseSmartQuotes)
+		var warnings []string
+		for _, warning := range subtitleWarnings {
+			warnings = append(warnings, warning.String()+"; left untouched")
+		}
+		return converted, warnings
+	}
+	if IsAsciiDocFile(filePath) {
+		return c.ConvertAsciiDoc(content, normaliseSmartQuotes), nil
+	}
+	if IsRSTFile(filePath) {
+		return c.ConvertRST(content, normaliseSmartQuotes), nil
+	}
+	if IsSVGFile(filePath) {
+		return c.ConvertSVG(content, normaliseSmartQuotes), nil
+	}
+	if IsNotebookFile(filePath) {
+		// Invalid notebooks are left alone rather than risk corrupting them
+		converted, err := c.ConvertNotebook(content, normaliseSmartQuotes)
+		if err != nil {
+			return content, []string{"left unchanged: " + err.Error()}
+		}
+		return converted, nil
+	}
+	if IsPlainTextFile(filePath) {
+		converted := c.convertPlainText(content, normaliseSmartQuotes)
+		if IsMarkdownFile(filePath) {
+			return c.FilterMarkdownElements(content, converted), nil
+		}
+		return converted, nil
+	}
+	if c.csvProcessor.IsEnabled() && IsCSVFile(filePath) {
+		// Invalid CSV is left alone rather than risk corrupting it
+		converted, err := c.ConvertCSV(content, filePath, normaliseSmartQuotes)
+		if err != nil {
+			return content, []string{"left unchanged: " + err.Error()}
+		}
+		return converted, nil
+	}
+	if c.jsonValuesOnly && IsJSONFile(filePath) {
+		// Invalid JSON is left alone rather than risk corrupting it
+		converted, err := c.ConvertJSONValues(content, normaliseSmartQuotes)
+		if err != nil {
+			return content, []string{"left unchanged: " + err.Error()}
+		}
+		return converted, nil
+	}
+	return c.convertFileComments(content, filePath, normaliseSmartQuotes), nil
+}
+
+// convertPlainText converts prose with code-aware processing, converting only the values of
+// any YAML front matter
+func (c *Converter) convertPlainText(content string, normaliseSmartQuotes bool) string {
+	if frontMatter, body, ok := c.markdownProcessor.SplitFrontMatter(content); ok {
+		return c.convertFrontMatter(frontMatter, normaliseSmartQuotes) + c.ProcessCodeAware(body, normaliseSmartQuotes)
+	}
+	return c.ProcessCodeAware(content, normaliseSmartQuotes)
+}
+
+// convertFileComments converts only the comments in content, using the comment rules of
+// config files where filePath is one
+func (c *Converter) convertFileComments(content, filePath string, normaliseSmartQuotes bool) string {
+	if IsConfigFile(filePath) {
+		return c.convertCommentsOnly(content, strings.TrimPrefix(strings.ToLower(filepath.Ext(filePath)), "."), normaliseSmartQuotes)
+	}
+	return c.ConvertCommentsOnly(content, normaliseSmartQuotes)
+}
+
+// ConvertCommentsOnly converts only the comments in code, leaving everything else untouched
+func (c *Converter) ConvertCommentsOnly(code string, normaliseSmartQuotes bool) string {
+	return c.convertCommentsOnly(code, "", normaliseSmartQuotes)
+}
+
+// convertCommentsOnly converts the comments found with the extraction rules for language
+func (c *Converter) convertCommentsOnly(code, language string, normaliseSmartQuotes bool) string {
+	comments := c.ExtractComments(code, language)
+
+	if len(comments) == 0 {
+		return code
+	}
+
+	// Work backwards through comments so positions don't shift
+	result := code
+	for i := len(comments) - 1; i >= 0; i-- {
+		comment := comments[i]
+
+		// Get the original comment text
+		originalComment := code[comment.Start:comment.End]
+
+		// Convert only the trimmed comment content, so the comment structure (//, /* */, #...)
+		// and the whitespace and line ending around it are kept byte for byte
+		trimmed := strings.TrimSpace(comment.Content)
+		contentStart := strings.Index(originalComment, trimmed)
+		if trimmed == "" || contentStart < 0 {
+			continue
+		}
+		convertedComment := originalComment[:contentStart] +
+			c.ConvertToBritish(trimmed, normaliseSmartQuotes) +
+			originalComment[contentStart+len(trimmed):]
+
+		// Replace this comment in the code
+		result = result[:comment.Start] + convertedComment + result[comment.End:]
+	}
+
+	return result
+}
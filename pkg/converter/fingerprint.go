@@ -0,0 +1,102 @@
+// Package converter provides a fingerprint of the settings that decide a converter's output
+package converter
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"maps"
+	"slices"
+)
+
+// fingerprintVersion changes whenever the conversion rules change in a way the settings
+// below don't capture, so results cached by an older build aren't reused
+const fingerprintVersion = "m2e-fingerprint-1"
+
+// Fingerprint returns a hash of everything that decides the converter's output: the resolved
+// dictionary, phrase rules, unit and contextual word configuration and every option. Two
+// converters with the same fingerprint convert the same input identically, so it can key a
+// cache of converted files. It returns "" when the output can't be fingerprinted, because
+// custom processors have been registered with RegisterProcessor.
+func (c *Converter) Fingerprint() string {
+	for _, processors := range c.processors {
+		if len(processors) > 0 {
+			return ""
+		}
+	}
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n", fingerprintVersion)
+	writeSortedMap(h, "dictionary", c.dict.AmericanToBritish)
+	fmt.Fprintf(h, "variant=%d excluded=%q\n", c.spellingVariant, c.excludedWords)
+	for _, re := range c.wordAllowlist {
+		fmt.Fprintf(h, "allow=%q\n", re.String())
+	}
+	fmt.Fprintf(h, "frontmatter=%t inlinecode=%t markdown=%t jsonvalues=%t mode=%d unicode=%t propernouns=%t urls=%t identifiers=%t dashes=%d\n",
+		c.skipFrontMatter, c.convertInlineCode, c.markdownProcessing, c.jsonValuesOnly, c.contentMode, c.normaliseUnicode, c.convertProperNouns,
+		c.convertURLs, c.convertIdentifiers, c.dashMode)
+
+	if c.jsonProcessor != nil && c.jsonProcessor.keyPattern != nil {
+		fmt.Fprintf(h, "jsonkeys=%q\n", c.jsonProcessor.keyPattern.String())
+	}
+	if c.quotePunctuation {
+		fmt.Fprintf(h, "quotepunctuation=true\n")
+	}
+	if c.tidyWhitespace {
+		fmt.Fprintf(h, "tidywhitespace=true\n")
+	}
+	if len(c.markdownElements) > 0 {
+		fmt.Fprintf(h, "markdownelements=%q\n", c.markdownElements)
+	}
+	if c.maxLineWidth > 0 {
+		fmt.Fprintf(h, "maxlinewidth=%d\n", c.maxLineWidth)
+	}
+	if c.IsRegionalWordsEnabled() {
+		fmt.Fprintf(h, "regional=true\n")
+	}
+	if c.floorNumbering {
+		fmt.Fprintf(h, "floors=true\n")
+	}
+	if c.csvProcessor != nil && c.csvProcessor.IsEnabled() {
+		fmt.Fprintf(h, "csvcolumns=%q\n", c.csvProcessor.Columns())
+	}
+	if c.phraseProcessor != nil {
+		fmt.Fprintf(h, "phrases=%t\n", c.phraseProcessor.IsEnabled())
+		writeSortedMap(h, "phrase", c.phraseProcessor.Rules())
+	}
+	if c.unitProcessor != nil {
+		fmt.Fprintf(h, "units=%t normalise=%t keeporiginal=%t\n",
+			c.unitProcessor.IsEnabled(), c.unitProcessor.IsNormaliseEnabled(), c.unitProcessor.IsKeepOriginalEnabled())
+		writeJSON(h, "unitconfig", c.unitProcessor.GetConfig())
+	}
+	if c.contextualWordDetector != nil {
+		fmt.Fprintf(h, "contextual=%t\n", c.contextualWordDetector.IsEnabled())
+		if detector, ok := c.contextualWordDetector.(interface {
+			GetConfiguration() *ContextualWordConfig
+		}); ok {
+			writeJSON(h, "contextualconfig", detector.GetConfiguration())
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// writeSortedMap writes the entries of m to h in key order
+func writeSortedMap(h hash.Hash, name string, m map[string]string) {
+	for _, key := range slices.Sorted(maps.Keys(m)) {
+		fmt.Fprintf(h, "%s %q=%q\n", name, key, m[key])
+	}
+}
+
+// writeJSON writes the JSON encoding of v to h; encoding/json sorts map keys, so equal values
+// always write the same bytes
+func writeJSON(h hash.Hash, name string, v any) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		fmt.Fprintf(h, "%s error=%q\n", name, err.Error())
+		return
+	}
+	fmt.Fprintf(h, "%s=%s\n", name, data)
+}
@@ -0,0 +1,137 @@
+// Package converter provides British numbering of building floors
+package converter
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// floorOrdinals lists the ordinal words for floors, from the ground floor up
+var floorOrdinals = []string{
+	"ground", "first", "second", "third", "fourth", "fifth", "sixth", "seventh", "eighth", "ninth", "tenth",
+	"eleventh", "twelfth", "thirteenth", "fourteenth", "fifteenth", "sixteenth", "seventeenth", "eighteenth",
+	"nineteenth", "twentieth",
+}
+
+// floorOrdinal matches an American floor ordinal: a word from "first" to "twentieth" or a
+// number such as "2nd"
+const floorOrdinal = `(?:first|second|third|fourth|fifth|sixth|seventh|eighth|ninth|tenth|eleventh|twelfth|` +
+	`thirteenth|fourteenth|fifteenth|sixteenth|seventeenth|eighteenth|nineteenth|twentieth|\d+(?:st|nd|rd|th))`
+
+var (
+	// floorPattern matches ordinals naming floors, singly or in a list: "second floor",
+	// "2nd-floor", "third and fourth floors", "1st, 2nd or 3rd floor"
+	floorPattern = regexp.MustCompile(`(?i)\b` + floorOrdinal + `(?:(?:\s*,\s*|\s+(?:and|or|to|through)\s+|\s*,\s*(?:and|or)\s+)` +
+		floorOrdinal + `)*[\s-]+(floors?)\b`)
+
+	// floorOrdinalRegex matches each ordinal within a floorPattern match
+	floorOrdinalRegex = regexp.MustCompile(`(?i)\b` + floorOrdinal + `\b`)
+)
+
+// SetFloorNumberingEnabled controls whether American floor numbers are renumbered the British
+// way, where the floor at street level is the ground floor and the one above it the first:
+// "first floor" → "ground floor", "2nd floor" → "1st floor" and "third and fourth floors" →
+// "second and third floors". This is a cultural convention rather than spelling, and
+// renumbering text that already counts floors the British way would be wrong, so it's off by
+// default and converting the same text twice renumbers it twice.
+func (c *Converter) SetFloorNumberingEnabled(enabled bool) {
+	c.floorNumbering = enabled
+}
+
+// IsFloorNumberingEnabled reports whether floor numbers are renumbered the British way
+func (c *Converter) IsFloorNumberingEnabled() bool {
+	return c.floorNumbering
+}
+
+// convertFloorNumbers renumbers the floors named in text from American to British numbering. It
+// only sees prose, and floors in inline code spans or URLs are left alone.
+func (c *Converter) convertFloorNumbers(text string) string {
+	if !c.floorNumbering || !strings.Contains(strings.ToLower(text), "floor") {
+		return text
+	}
+
+	codeSpans := inlineCodeRegex.FindAllStringIndex(text, -1)
+	var result strings.Builder
+	last := 0
+	for _, m := range floorPattern.FindAllStringSubmatchIndex(text, -1) {
+		start, end := m[0], m[1]
+		if start > 0 && (text[start-1] == '-' || isAlphanumeric(text[start-1])) {
+			continue // the end of "twenty-first floor"
+		}
+		if isURL(tokenAt(text, start)) || overlapsSpan(start, end, codeSpans) {
+			continue
+		}
+
+		match := text[start:end]
+		capitalised := isUpperByte(text[m[2]]) // "Floor" is a name, as in "2nd Floor"
+		replacement := floorOrdinalRegex.ReplaceAllStringFunc(match, func(ordinal string) string {
+			return britishFloorOrdinal(ordinal, capitalised)
+		})
+		if replacement == match {
+			continue
+		}
+		if c.explain != nil {
+			c.explain.record(Explanation{Original: match, Converted: replacement, Rule: "floor numbering"})
+		}
+		result.WriteString(text[last:start])
+		result.WriteString(replacement)
+		last = end
+	}
+	if last == 0 {
+		return text
+	}
+	result.WriteString(text[last:])
+	return result.String()
+}
+
+// britishFloorOrdinal returns the British ordinal for the floor an American one names, one lower:
+// "second" → "first", "2nd" → "1st", and "first" or "1st" → "ground", capitalised when the
+// ordinal or, with capitalised, the word "Floor" is. "0th" and unknown words are kept.
+func britishFloorOrdinal(ordinal string, capitalised bool) string {
+	var british string
+	if n, err := strconv.Atoi(strings.TrimRight(ordinal, "stndrhSTNDRH")); err == nil {
+		switch {
+		case n < 1:
+			return ordinal
+		case n == 1:
+			british = "ground"
+		default:
+			british = strconv.Itoa(n-1) + ordinalSuffix(n-1)
+		}
+	} else {
+		for i, word := range floorOrdinals {
+			if i > 0 && strings.EqualFold(word, ordinal) {
+				british = floorOrdinals[i-1]
+				break
+			}
+		}
+		if british == "" {
+			return ordinal
+		}
+	}
+
+	switch {
+	case len(ordinal) > 1 && isAllCaps(ordinal):
+		return strings.ToUpper(british)
+	case isUpperByte(ordinal[0]) || capitalised:
+		return strings.ToUpper(british[:1]) + british[1:]
+	}
+	return british
+}
+
+// ordinalSuffix returns the English ordinal suffix for n: "st", "nd", "rd" or "th"
+func ordinalSuffix(n int) string {
+	if n%100 >= 11 && n%100 <= 13 {
+		return "th"
+	}
+	switch n % 10 {
+	case 1:
+		return "st"
+	case 2:
+		return "nd"
+	case 3:
+		return "rd"
+	}
+	return "th"
+}
@@ -0,0 +1,75 @@
+// Package converter provides detection of code identifiers mentioned in prose
+package converter
+
+import "strings"
+
+// looksLikeIdentifier reports whether a token contains a code identifier, such as
+// "initializeColor()", "ColorPicker" or "color_value": a lowercase letter followed by an
+// uppercase one, or an underscore between letters or digits. Plain words, including
+// capitalised and all-caps ones, don't.
+func looksLikeIdentifier(token string) bool {
+	for i := 1; i < len(token); i++ {
+		prev, cur := token[i-1], token[i]
+		if isLowerByte(prev) && isUpperByte(cur) {
+			return true
+		}
+		if cur == '_' && isAlphanumeric(prev) && i+1 < len(token) && isAlphanumeric(token[i+1]) {
+			return true
+		}
+	}
+	return false
+}
+
+// convertIdentifierWords converts each dictionary word making up the identifiers in a token,
+// splitting words at underscores and where a lowercase letter is followed by an uppercase one,
+// so "initializeColor()" becomes "initialiseColour()" and "COLOR_VALUE" becomes "COLOUR_VALUE"
+func convertIdentifierWords(token string, dict map[string]string) string {
+	var b strings.Builder
+	b.Grow(len(token))
+	start := -1
+	flush := func(end int) {
+		if start < 0 {
+			return
+		}
+		word := token[start:end]
+		if repl, ok := lookupWithCase(word, dict); ok {
+			if len(word) > 1 && isAllCaps(word) {
+				repl = strings.ToUpper(repl) // constants such as COLOR_VALUE
+			}
+			word = repl
+		}
+		b.WriteString(word)
+		start = -1
+	}
+
+	for i := 0; i < len(token); i++ {
+		if !isLetter(token[i]) {
+			flush(i)
+			b.WriteByte(token[i])
+			continue
+		}
+		if start >= 0 && isLowerByte(token[i-1]) && isUpperByte(token[i]) {
+			flush(i)
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	flush(len(token))
+	return b.String()
+}
+
+// isLowerByte reports whether b is an ASCII lowercase letter
+func isLowerByte(b byte) bool {
+	return 'a' <= b && b <= 'z'
+}
+
+// isUpperByte reports whether b is an ASCII uppercase letter
+func isUpperByte(b byte) bool {
+	return 'A' <= b && b <= 'Z'
+}
+
+// isAlphanumeric reports whether b is an ASCII letter or digit
+func isAlphanumeric(b byte) bool {
+	return isLetter(b) || isDigit(b)
+}
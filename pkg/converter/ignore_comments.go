@@ -260,6 +260,16 @@ func (cip *CommentIgnoreProcessor) RemoveIgnoredLines(text string, ignoreMatches
 	return strings.Join(filteredLines, "\n")
 }
 
+// Process implements Processor, applying the WithConvert option's conversion to each line that
+// isn't excluded by an ignore directive. A file with an ignore-file directive is returned as is.
+func (cip *CommentIgnoreProcessor) Process(text string, opts ...ProcessOption) string {
+	ignoreMatches := cip.ProcessIgnoreComments(text)
+	if cip.ShouldIgnoreFile(ignoreMatches) {
+		return text
+	}
+	return cip.ApplySelectiveIgnore(text, ignoreMatches, NewProcessOptions(opts...).convertOrKeep())
+}
+
 // ApplySelectiveIgnore applies conversion to text while respecting ignore directives
 func (cip *CommentIgnoreProcessor) ApplySelectiveIgnore(text string, ignoreMatches []IgnoreMatch, convertFunc func(string) string) string {
 	// If entire file should be ignored, return original text
@@ -285,6 +295,28 @@ func (cip *CommentIgnoreProcessor) ApplySelectiveIgnore(text string, ignoreMatch
 	return strings.Join(processedLines, "\n")
 }
 
+// RestoreIgnoredLines puts back the original text of each line of converted that an ignore
+// directive excludes. It is for converters that process a whole document at once, and so can't
+// skip ignored lines as they go; converted must have the same lines as original.
+func (cip *CommentIgnoreProcessor) RestoreIgnoredLines(original, converted string, ignoreMatches []IgnoreMatch) string {
+	ignoredLines := cip.buildIgnoredLineSet(ignoreMatches)
+	if len(ignoredLines) == 0 {
+		return converted
+	}
+
+	originalLines := strings.Split(original, "\n")
+	convertedLines := strings.Split(converted, "\n")
+	if len(originalLines) != len(convertedLines) {
+		return converted
+	}
+	for i := range convertedLines {
+		if ignoredLines[i] {
+			convertedLines[i] = originalLines[i]
+		}
+	}
+	return strings.Join(convertedLines, "\n")
+}
+
 // buildIgnoredLineSet pre-computes which line numbers should be ignored.
 func (cip *CommentIgnoreProcessor) buildIgnoredLineSet(ignoreMatches []IgnoreMatch) map[int]bool {
 	if len(ignoreMatches) == 0 {
@@ -0,0 +1,190 @@
+// Package converter provides JSON processing functionality for converting string values only
+package converter
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// jsonEscapePlaceholderBase is the first rune used to stand in for JSON escapes that can't be
+// decoded safely (\uXXXX, \/, \b, \f) while a value is converted. It starts the Supplementary
+// Private Use Area-A, which doesn't appear in normal text.
+const (
+	jsonEscapePlaceholderBase = 0xF0000
+	jsonEscapePlaceholderMax  = 0xFFFFD
+)
+
+// JSONProcessor converts the string values of JSON documents, such as i18n resource files,
+// without touching keys, numbers, booleans or formatting. Values are rewritten in place in
+// the original text, so whitespace, key order and escaping survive.
+type JSONProcessor struct {
+	keyPattern *regexp.Regexp // only convert values whose key matches; nil converts all values
+}
+
+// NewJSONProcessor creates a new JSON processor that converts every string value
+func NewJSONProcessor() *JSONProcessor {
+	return &JSONProcessor{}
+}
+
+// SetKeyPattern limits conversion to string values whose key matches pattern. Array elements
+// use the key of the array. A nil pattern converts all string values.
+func (p *JSONProcessor) SetKeyPattern(pattern *regexp.Regexp) {
+	p.keyPattern = pattern
+}
+
+// jsonContainer tracks an object or array being scanned
+type jsonContainer struct {
+	object bool
+	key    string // last key seen in an object, or the key an array is the value of
+}
+
+// ProcessValues converts the string values in a JSON document with convertFunc. It returns an
+// error without changing anything if the document isn't valid JSON.
+func (p *JSONProcessor) ProcessValues(data string, convertFunc func(string) string) (string, error) {
+	if err := json.Unmarshal([]byte(data), new(json.RawMessage)); err != nil {
+		return data, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	var result strings.Builder
+	result.Grow(len(data))
+
+	stack := []jsonContainer{{}} // the root behaves like an array with no key
+	expectKey := false
+
+	for i := 0; i < len(data); i++ {
+		top := &stack[len(stack)-1]
+
+		switch data[i] {
+		case '{':
+			stack = append(stack, jsonContainer{object: true, key: top.key})
+			expectKey = true
+		case '[':
+			stack = append(stack, jsonContainer{key: top.key})
+		case '}', ']':
+			stack = stack[:len(stack)-1]
+			expectKey = false
+		case ',':
+			expectKey = top.object
+		case ':':
+			expectKey = false
+		case '"':
+			end := jsonStringEnd(data, i)
+			token := data[i:end]
+
+			if expectKey && top.object {
+				var key string
+				if err := json.Unmarshal([]byte(token), &key); err == nil {
+					top.key = key
+				}
+			} else if p.keyPattern == nil || p.keyPattern.MatchString(top.key) {
+				token = convertJSONString(token, convertFunc)
+			}
+
+			result.WriteString(token)
+			i = end - 1
+			continue
+		}
+
+		result.WriteByte(data[i])
+	}
+
+	return result.String(), nil
+}
+
+// jsonStringEnd returns the index just past the closing quote of the string starting at start
+func jsonStringEnd(data string, start int) int {
+	for i := start + 1; i < len(data); i++ {
+		switch data[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1
+		}
+	}
+	return len(data)
+}
+
+// convertJSONString converts a quoted JSON string token, returning it unchanged if nothing
+// changes so its original escaping is kept. Escapes that only ever appear escaped in JSON
+// (quotes, backslashes, newlines, tabs) are decoded so words around them convert normally;
+// the rest are swapped for placeholders and restored verbatim, so \u escapes round-trip.
+func convertJSONString(token string, convertFunc func(string) string) string {
+	content := token[1 : len(token)-1]
+
+	var decoded strings.Builder
+	var escapes []string
+	for i := 0; i < len(content); i++ {
+		ch := content[i]
+		if r, _ := utf8.DecodeRuneInString(content[i:]); r >= jsonEscapePlaceholderBase && r <= jsonEscapePlaceholderMax {
+			return token // would be confused with a placeholder
+		}
+		if ch != '\\' || i+1 >= len(content) {
+			decoded.WriteByte(ch)
+			continue
+		}
+
+		switch content[i+1] {
+		case '"', '\\':
+			decoded.WriteByte(content[i+1])
+		case 'n':
+			decoded.WriteByte('\n')
+		case 't':
+			decoded.WriteByte('\t')
+		case 'r':
+			decoded.WriteByte('\r')
+		default:
+			escape := content[i : i+2]
+			if content[i+1] == 'u' && i+6 <= len(content) {
+				escape = content[i : i+6]
+			}
+			if jsonEscapePlaceholderBase+len(escapes) > jsonEscapePlaceholderMax {
+				return token
+			}
+			decoded.WriteRune(rune(jsonEscapePlaceholderBase + len(escapes)))
+			escapes = append(escapes, escape)
+			i += len(escape) - 2
+		}
+		i++
+	}
+
+	text := decoded.String()
+	converted := convertFunc(text)
+	if converted == text {
+		return token
+	}
+
+	var encoded strings.Builder
+	encoded.WriteByte('"')
+	for _, r := range converted {
+		switch {
+		case r == '"':
+			encoded.WriteString(`\"`)
+		case r == '\\':
+			encoded.WriteString(`\\`)
+		case r == '\n':
+			encoded.WriteString(`\n`)
+		case r == '\t':
+			encoded.WriteString(`\t`)
+		case r == '\r':
+			encoded.WriteString(`\r`)
+		case r >= jsonEscapePlaceholderBase && int(r-jsonEscapePlaceholderBase) < len(escapes):
+			encoded.WriteString(escapes[r-jsonEscapePlaceholderBase])
+		case r < 0x20:
+			fmt.Fprintf(&encoded, `\u%04x`, r)
+		default:
+			encoded.WriteRune(r)
+		}
+	}
+	encoded.WriteByte('"')
+
+	return encoded.String()
+}
+
+// IsJSONFile checks if a file extension indicates a JSON document
+func IsJSONFile(filePath string) bool {
+	return strings.EqualFold(filepath.Ext(filePath), ".json")
+}
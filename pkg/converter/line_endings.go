@@ -0,0 +1,79 @@
+// Package converter provides line ending detection so converted files keep their line endings
+package converter
+
+import "strings"
+
+// LineEnding is the line ending style of a text
+type LineEnding int
+
+const (
+	// LineEndingNone is a text without line breaks
+	LineEndingNone LineEnding = iota
+	// LineEndingLF is Unix style, "\n"
+	LineEndingLF
+	// LineEndingCRLF is Windows style, "\r\n"
+	LineEndingCRLF
+	// LineEndingCR is classic Mac OS style, "\r"
+	LineEndingCR
+	// LineEndingMixed is a text that uses more than one style
+	LineEndingMixed
+)
+
+// String returns the conventional name of the line ending style
+func (e LineEnding) String() string {
+	switch e {
+	case LineEndingLF:
+		return "LF"
+	case LineEndingCRLF:
+		return "CRLF"
+	case LineEndingCR:
+		return "CR"
+	case LineEndingMixed:
+		return "mixed"
+	}
+	return "none"
+}
+
+// DetectLineEnding returns the line ending style text uses
+func DetectLineEnding(text string) LineEnding {
+	crlf := strings.Count(text, "\r\n")
+	lf := strings.Count(text, "\n") - crlf
+	cr := strings.Count(text, "\r") - crlf
+
+	switch {
+	case crlf == 0 && lf == 0 && cr == 0:
+		return LineEndingNone
+	case crlf > 0 && lf == 0 && cr == 0:
+		return LineEndingCRLF
+	case lf > 0 && crlf == 0 && cr == 0:
+		return LineEndingLF
+	case cr > 0 && crlf == 0 && lf == 0:
+		return LineEndingCR
+	}
+	return LineEndingMixed
+}
+
+// PreserveLineEndings returns converted with the line ending style of original, for when a
+// processor rebuilds lines with "\n". Conversion keeps the "\r" of each line it's given, so
+// this only changes anything when the original used CRLF or CR throughout and the converted
+// text gained a bare "\n"; LF and mixed texts are returned unchanged.
+func PreserveLineEndings(original, converted string) string {
+	var ending string
+	switch DetectLineEnding(original) {
+	case LineEndingCRLF:
+		if strings.Count(converted, "\n") == strings.Count(converted, "\r\n") {
+			return converted
+		}
+		ending = "\r\n"
+	case LineEndingCR:
+		if !strings.Contains(converted, "\n") {
+			return converted
+		}
+		ending = "\r"
+	default:
+		return converted
+	}
+
+	converted = strings.ReplaceAll(converted, "\r\n", "\n")
+	return strings.ReplaceAll(converted, "\n", ending)
+}
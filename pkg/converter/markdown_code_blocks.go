@@ -0,0 +1,180 @@
+// Package converter provides line-based detection of fenced and indented Markdown code blocks
+package converter
+
+import (
+	"regexp"
+	"strings"
+)
+
+// markdownListItemRegex matches a bullet or ordered list item, whose indented continuation
+// lines are part of the item rather than an indented code block
+var markdownListItemRegex = regexp.MustCompile(`^ {0,3}(?:[-+*]|\d{1,9}[.)])(?:[ \t]|$)`)
+
+// markdownCodeBlock is a fenced or indented code block, located by byte offsets into the text
+type markdownCodeBlock struct {
+	start, end               int    // the whole block, including fence lines but not the final newline
+	contentStart, contentEnd int    // the code between the fences
+	language                 string // first word of the opening fence's info string
+	fence                    string // opening fence such as "```" or "~~~~"; empty for indented blocks
+}
+
+// findMarkdownCodeBlocks finds the code blocks in text following CommonMark's rules:
+//
+//   - A fence is a line of at least three backticks or tildes, indented by at most three spaces
+//     (or any amount inside a list item), and is closed by a line of the same character that is
+//     at least as long. Shorter fences inside it are code, so a ```` block can show a ``` block.
+//     A fence that is never closed is treated as text.
+//   - An indented block is a run of lines indented by four or more spaces (or a tab) that follows
+//     a blank line outside a list. An indented block at the very start of the text isn't treated
+//     as code, since the text may be a fragment such as a single line or a comment.
+func findMarkdownCodeBlocks(text string) []markdownCodeBlock {
+	lines := strings.SplitAfter(text, "\n")
+	offsets := make([]int, len(lines)+1)
+	for i, line := range lines {
+		offsets[i+1] = offsets[i] + len(line)
+	}
+	// lineEnd is the offset of the end of line i, before its newline
+	lineEnd := func(i int) int {
+		return offsets[i] + len(strings.TrimSuffix(lines[i], "\n"))
+	}
+
+	var blocks []markdownCodeBlock
+	inList := false
+	prevBlank := false
+	for i := 0; i < len(lines); {
+		line := markdownLine(lines[i])
+		if strings.TrimSpace(line) == "" {
+			prevBlank = true
+			i++
+			continue
+		}
+		indent := markdownIndent(line)
+
+		if fence, language, ok := parseOpeningFence(line, indent, inList); ok {
+			if closing := findClosingFence(lines, i+1, fence, indent); closing >= 0 {
+				block := markdownCodeBlock{
+					start:        offsets[i],
+					end:          lineEnd(closing),
+					contentStart: offsets[i+1],
+					contentEnd:   offsets[i+1],
+					language:     language,
+					fence:        fence,
+				}
+				if closing > i+1 {
+					block.contentEnd = lineEnd(closing - 1)
+				}
+				blocks = append(blocks, block)
+				prevBlank = false
+				i = closing + 1
+				continue
+			}
+		}
+
+		if indent >= 4 && prevBlank && !inList {
+			last := i
+			for j := i + 1; j < len(lines); j++ {
+				next := markdownLine(lines[j])
+				if strings.TrimSpace(next) == "" {
+					continue
+				}
+				if markdownIndent(next) < 4 {
+					break
+				}
+				last = j
+			}
+			blocks = append(blocks, markdownCodeBlock{
+				start:        offsets[i],
+				end:          lineEnd(last),
+				contentStart: offsets[i],
+				contentEnd:   lineEnd(last),
+			})
+			prevBlank = false
+			i = last + 1
+			continue
+		}
+
+		if markdownListItemRegex.MatchString(line) {
+			inList = true
+		} else if indent == 0 && prevBlank {
+			// An unindented paragraph after a blank line ends the list
+			inList = false
+		}
+		prevBlank = false
+		i++
+	}
+
+	return blocks
+}
+
+// parseOpeningFence reports whether line opens a fenced code block, returning the fence and
+// the language from its info string
+func parseOpeningFence(line string, indent int, inList bool) (string, string, bool) {
+	if indent > 3 && !inList {
+		return "", "", false
+	}
+	trimmed := strings.TrimLeft(line, " \t")
+	fence := fenceRun(trimmed)
+	if len(fence) < 3 {
+		return "", "", false
+	}
+
+	info := strings.TrimSpace(trimmed[len(fence):])
+	if fence[0] == '`' && strings.Contains(info, "`") {
+		// Backticks in the info string mean this is inline code, e.g. ```code```
+		return "", "", false
+	}
+	language := ""
+	if fields := strings.Fields(info); len(fields) > 0 {
+		language = fields[0]
+	}
+	return fence, language, true
+}
+
+// findClosingFence returns the index of the first line from start that closes fence, or -1
+func findClosingFence(lines []string, start int, fence string, openIndent int) int {
+	for i := start; i < len(lines); i++ {
+		line := markdownLine(lines[i])
+		if markdownIndent(line) >= openIndent+4 {
+			continue
+		}
+		trimmed := strings.TrimLeft(line, " \t")
+		run := fenceRun(trimmed)
+		if len(run) >= len(fence) && run[0] == fence[0] && strings.TrimSpace(trimmed[len(run):]) == "" {
+			return i
+		}
+	}
+	return -1
+}
+
+// fenceRun returns the run of backticks or tildes that line starts with
+func fenceRun(line string) string {
+	if line == "" || (line[0] != '`' && line[0] != '~') {
+		return ""
+	}
+	n := 1
+	for n < len(line) && line[n] == line[0] {
+		n++
+	}
+	return line[:n]
+}
+
+// markdownIndent returns the indentation of line in columns, with tabs stopping every four columns
+func markdownIndent(line string) int {
+	columns := 0
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case ' ':
+			columns++
+		case '\t':
+			columns += 4 - columns%4
+		default:
+			return columns
+		}
+	}
+	return columns
+}
+
+// markdownLine strips the line ending from a line
+func markdownLine(line string) string {
+	return strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+}
@@ -0,0 +1,204 @@
+// Package converter provides scoping of Markdown conversion to chosen element types
+package converter
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	extast "github.com/yuin/goldmark/extension/ast"
+	"github.com/yuin/goldmark/text"
+)
+
+// MarkdownElement is a kind of Markdown element that conversion can be limited to
+type MarkdownElement string
+
+const (
+	// MarkdownHeadings are ATX (#) and setext headings
+	MarkdownHeadings MarkdownElement = "headings"
+	// MarkdownParagraphs are top-level paragraphs
+	MarkdownParagraphs MarkdownElement = "paragraphs"
+	// MarkdownBlockquotes are block quotes and everything inside them
+	MarkdownBlockquotes MarkdownElement = "blockquotes"
+	// MarkdownLists are bulleted and numbered lists and everything inside them
+	MarkdownLists MarkdownElement = "lists"
+	// MarkdownTables are GitHub-style pipe tables
+	MarkdownTables MarkdownElement = "tables"
+	// MarkdownLinks are the text of links and the alt text of images, wherever they appear
+	MarkdownLinks MarkdownElement = "links"
+)
+
+// markdownElements lists every MarkdownElement, in the order they're documented
+var markdownElements = []MarkdownElement{
+	MarkdownHeadings, MarkdownParagraphs, MarkdownBlockquotes, MarkdownLists, MarkdownTables, MarkdownLinks,
+}
+
+// markdownExtensions are the files whose conversion SetMarkdownElements scopes
+var markdownExtensions = []string{".md", ".markdown"}
+
+// markdownParser parses Markdown into an AST, with the table extension so tables are told
+// apart from paragraphs
+var markdownParser = goldmark.New(goldmark.WithExtensions(extension.Table)).Parser()
+
+// ParseMarkdownElements parses a comma-separated list of Markdown element names, such as
+// "headings,paragraphs". Names are case-insensitive and duplicates are dropped.
+func ParseMarkdownElements(list string) ([]MarkdownElement, error) {
+	var elements []MarkdownElement
+	for _, name := range strings.Split(list, ",") {
+		element := MarkdownElement(strings.ToLower(strings.TrimSpace(name)))
+		if element == "" {
+			continue
+		}
+		if !slices.Contains(markdownElements, element) {
+			return nil, fmt.Errorf("unknown Markdown element %q (supported: headings, paragraphs, blockquotes, lists, tables, links)", strings.TrimSpace(name))
+		}
+		if !slices.Contains(elements, element) {
+			elements = append(elements, element)
+		}
+	}
+	if len(elements) == 0 {
+		return nil, fmt.Errorf("no Markdown elements given (supported: headings, paragraphs, blockquotes, lists, tables, links)")
+	}
+	return elements, nil
+}
+
+// IsMarkdownFile reports whether a file is Markdown, by its extension
+func IsMarkdownFile(filePath string) bool {
+	return slices.Contains(markdownExtensions, strings.ToLower(filepath.Ext(filePath)))
+}
+
+// SetMarkdownElements limits the conversion of Markdown files to the text of the given element
+// types, leaving the rest of the document untouched: with headings and paragraphs, block
+// quotes, lists, tables and link text keep their American spellings. Link text is only
+// converted when links are chosen, whichever block it's in. Front matter follows its own
+// settings. nil, the default, converts every element.
+func (c *Converter) SetMarkdownElements(elements []MarkdownElement) {
+	c.markdownElements = slices.Clone(elements)
+}
+
+// GetMarkdownElements returns the Markdown element types conversion is limited to, or nil when
+// every element is converted
+func (c *Converter) GetMarkdownElements() []MarkdownElement {
+	return slices.Clone(c.markdownElements)
+}
+
+// FilterMarkdownElements returns converted with only the changes that fall in the text of the
+// Markdown elements chosen with SetMarkdownElements; every other change is undone. original is
+// the Markdown that was converted. When no elements are chosen, converted is returned as it is.
+func (c *Converter) FilterMarkdownElements(original, converted string) string {
+	if len(c.markdownElements) == 0 || original == converted {
+		return converted
+	}
+
+	ranges := c.markdownProcessor.elementRanges(original, c.markdownElements)
+	inRange := func(pos int, inclusiveEnd bool) bool {
+		for _, r := range ranges {
+			if pos >= r.Start && (pos < r.Stop || inclusiveEnd && pos == r.Stop) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var result strings.Builder
+	last := 0
+	for _, change := range WordChanges(original, converted) {
+		var keep bool
+		if change.Start == change.End {
+			keep = inRange(change.Start, true)
+		} else {
+			keep = inRange(change.Start, false) && inRange(change.End-1, false)
+		}
+		if !keep {
+			continue
+		}
+		result.WriteString(original[last:change.Start])
+		result.WriteString(change.Replacement)
+		last = change.End
+	}
+	result.WriteString(original[last:])
+	return result.String()
+}
+
+// elementRanges returns the byte ranges of text that belong to the chosen element types. Text
+// is placed by the top-level block it's in, so a paragraph inside a list item counts as a list,
+// and link text by whether links are chosen. Front matter is always included; code, HTML and
+// autolinks never are, as they have no text nodes.
+func (mp *MarkdownProcessor) elementRanges(markdown string, elements []MarkdownElement) []text.Segment {
+	var ranges []text.Segment
+	offset := 0
+	if frontMatter, body, ok := mp.SplitFrontMatter(markdown); ok {
+		ranges = append(ranges, text.NewSegment(0, len(frontMatter)))
+		offset = len(frontMatter)
+		markdown = body
+	}
+
+	source := []byte(markdown)
+	document := markdownParser.Parse(text.NewReader(source))
+	for block := document.FirstChild(); block != nil; block = block.NextSibling() {
+		blockElement, ok := markdownBlockElement(block)
+		if !ok {
+			continue
+		}
+		_ = ast.Walk(block, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+			if !entering {
+				return ast.WalkContinue, nil
+			}
+			switch node.Kind() {
+			case ast.KindCodeSpan, ast.KindRawHTML:
+				return ast.WalkSkipChildren, nil
+			case ast.KindLink, ast.KindImage:
+				if !slices.Contains(elements, MarkdownLinks) {
+					return ast.WalkSkipChildren, nil
+				}
+				addTextRanges(node, offset, &ranges)
+				return ast.WalkSkipChildren, nil
+			case ast.KindText:
+				if slices.Contains(elements, blockElement) {
+					segment := node.(*ast.Text).Segment
+					ranges = append(ranges, text.NewSegment(segment.Start+offset, segment.Stop+offset))
+				}
+			}
+			return ast.WalkContinue, nil
+		})
+	}
+	return ranges
+}
+
+// addTextRanges adds the ranges of the text nodes under node, shifted by offset
+func addTextRanges(node ast.Node, offset int, ranges *[]text.Segment) {
+	_ = ast.Walk(node, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		if n.Kind() == ast.KindCodeSpan || n.Kind() == ast.KindRawHTML {
+			return ast.WalkSkipChildren, nil
+		}
+		if textNode, ok := n.(*ast.Text); ok {
+			*ranges = append(*ranges, text.NewSegment(textNode.Segment.Start+offset, textNode.Segment.Stop+offset))
+		}
+		return ast.WalkContinue, nil
+	})
+}
+
+// markdownBlockElement returns the element type of a top-level block, and false for blocks
+// with no text to convert, such as code blocks and HTML
+func markdownBlockElement(block ast.Node) (MarkdownElement, bool) {
+	switch block.Kind() {
+	case ast.KindHeading:
+		return MarkdownHeadings, true
+	case ast.KindParagraph:
+		return MarkdownParagraphs, true
+	case ast.KindBlockquote:
+		return MarkdownBlockquotes, true
+	case ast.KindList:
+		return MarkdownLists, true
+	case extast.KindTable:
+		return MarkdownTables, true
+	}
+	return "", false
+}
@@ -13,6 +13,7 @@ type MarkdownProcessor struct {
 	italicAsteriskPattern   *regexp.Regexp
 	italicUnderscorePattern *regexp.Regexp
 	linkPattern             *regexp.Regexp
+	frontMatterKeyPattern   *regexp.Regexp
 }
 
 // NewMarkdownProcessor creates a new markdown processor
@@ -23,9 +24,17 @@ func NewMarkdownProcessor() *MarkdownProcessor {
 		italicAsteriskPattern:   regexp.MustCompile(`(\s|^)\*([^\s*][^*]*?)\*(\s|$|[,.!?;:])`),
 		italicUnderscorePattern: regexp.MustCompile(`(\s|^)_([^\s_][^_]*?)_(\s|$|[,.!?;:])`),
 		linkPattern:             regexp.MustCompile(`\[([^\]]+)\]\(([^)]+)\)`),
+		// indent, optional list marker, key (plain or quoted), colon and the value
+		frontMatterKeyPattern: regexp.MustCompile(`^(\s*(?:-\s+)?)([^\s:#'"{\[-][^:#]*?|"[^"]*"|'[^']*')(\s*:)(?:(\s+)(.*))?$`),
 	}
 }
 
+// Process implements Processor, applying the WithConvert option's conversion to the text while
+// preserving its Markdown formatting
+func (mp *MarkdownProcessor) Process(text string, opts ...ProcessOption) string {
+	return mp.ProcessWithMarkdown(text, NewProcessOptions(opts...).convertOrKeep())
+}
+
 // ProcessWithMarkdown converts text while preserving markdown formatting
 func (mp *MarkdownProcessor) ProcessWithMarkdown(text string, convertFunc func(string) string) string {
 	if text == "" {
@@ -203,3 +212,108 @@ func (mp *MarkdownProcessor) hasMarkdownPatterns(text string) bool {
 
 	return false
 }
+
+// SplitFrontMatter splits leading YAML front matter (delimited by "---" lines) from the rest
+// of a Markdown document. The returned front matter includes both delimiter lines.
+func (mp *MarkdownProcessor) SplitFrontMatter(text string) (frontMatter, body string, ok bool) {
+	if !strings.HasPrefix(text, "---\n") && !strings.HasPrefix(text, "---\r\n") {
+		return "", text, false
+	}
+
+	pos := strings.IndexByte(text, '\n') + 1
+	sawKey := false
+	for pos < len(text) {
+		lineEnd := len(text)
+		if idx := strings.IndexByte(text[pos:], '\n'); idx >= 0 {
+			lineEnd = pos + idx
+		}
+		next := min(lineEnd+1, len(text))
+		line := strings.TrimRight(text[pos:lineEnd], "\r")
+
+		if line == "---" || line == "..." {
+			if !sawKey {
+				// A thematic break rather than front matter
+				return "", text, false
+			}
+			return text[:next], text[next:], true
+		}
+
+		// Require YAML keys so a document that merely opens with a thematic break isn't mistaken for front matter
+		if mp.frontMatterKeyPattern.MatchString(line) {
+			sawKey = true
+		} else if trimmed := strings.TrimSpace(line); !sawKey && trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+			return "", text, false
+		}
+		pos = next
+	}
+
+	return "", text, false
+}
+
+// ProcessFrontMatterValues converts only the values in YAML front matter, leaving keys, the
+// delimiters and flow mappings untouched so tooling reading the front matter keeps working
+func (mp *MarkdownProcessor) ProcessFrontMatterValues(frontMatter string, convertFunc func(string) string) string {
+	lines := strings.Split(frontMatter, "\n")
+
+	blockIndent := -1 // indentation of the key owning a block scalar (| or >), -1 when not in one
+	for i, rawLine := range lines {
+		line := strings.TrimSuffix(rawLine, "\r")
+		carriageReturn := rawLine[len(line):]
+		trimmed := strings.TrimSpace(line)
+
+		if trimmed == "---" || trimmed == "..." || trimmed == "" {
+			continue
+		}
+
+		indent := len(line) - len(strings.TrimLeft(line, " \t"))
+
+		// Block scalar content is entirely value text
+		if blockIndent >= 0 {
+			if indent > blockIndent {
+				lines[i] = line[:indent] + convertFunc(line[indent:]) + carriageReturn
+				continue
+			}
+			blockIndent = -1
+		}
+
+		if strings.HasPrefix(trimmed, "#") {
+			lines[i] = line[:indent] + convertFunc(line[indent:]) + carriageReturn
+			continue
+		}
+
+		if parts := mp.frontMatterKeyPattern.FindStringSubmatch(line); parts != nil {
+			prefix, key, colon, space, value := parts[1], parts[2], parts[3], parts[4], parts[5]
+			if strings.HasPrefix(value, "|") || strings.HasPrefix(value, ">") {
+				blockIndent = indent
+			} else if isConvertibleYAMLValue(value) {
+				value = convertFunc(value)
+			}
+			lines[i] = prefix + key + colon + space + value + carriageReturn
+			continue
+		}
+
+		// List items and continuation lines of multi-line plain scalars
+		start := indent
+		if rest, isItem := strings.CutPrefix(trimmed, "- "); isItem {
+			start = len(line) - len(rest)
+		}
+		if isConvertibleYAMLValue(line[start:]) {
+			lines[i] = line[:start] + convertFunc(line[start:]) + carriageReturn
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// isConvertibleYAMLValue reports whether a YAML value is text that can be converted, excluding
+// empty values, flow mappings (which contain keys) and anchors, aliases and tags
+func isConvertibleYAMLValue(value string) bool {
+	if value == "" {
+		return false
+	}
+	switch value[0] {
+	case '{', '&', '*', '!':
+		return false
+	}
+	return true
+}
@@ -0,0 +1,223 @@
+// Package converter provides Jupyter notebook (.ipynb) processing that converts cell sources
+// while preserving outputs, metadata and formatting
+package converter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// notebookEdit replaces the span [start, end) of a notebook with text
+type notebookEdit struct {
+	start, end int
+	text       string
+}
+
+// notebookSpan is a JSON value found in a notebook and where it sits
+type notebookSpan struct {
+	start, end int
+	raw        json.RawMessage
+}
+
+// NotebookProcessor converts the cell sources of Jupyter notebooks. Only the "source" of
+// Markdown and code cells is rewritten, in place in the original text, so outputs, metadata,
+// cell order, the nbformat version and the notebook's own indentation are untouched.
+type NotebookProcessor struct{}
+
+// NewNotebookProcessor creates a new Jupyter notebook processor
+func NewNotebookProcessor() *NotebookProcessor {
+	return &NotebookProcessor{}
+}
+
+// IsNotebookFile checks if a file extension indicates a Jupyter notebook
+func IsNotebookFile(filePath string) bool {
+	return strings.EqualFold(filepath.Ext(filePath), ".ipynb")
+}
+
+// ProcessCells converts the source of each Markdown cell with convertMarkdown and of each code
+// cell with convertCode. Raw cells are left alone. It returns an error without changing
+// anything if the notebook isn't valid JSON.
+func (p *NotebookProcessor) ProcessCells(data string, convertMarkdown, convertCode func(string) string) (string, error) {
+	if err := json.Unmarshal([]byte(data), new(json.RawMessage)); err != nil {
+		return data, fmt.Errorf("invalid notebook: %w", err)
+	}
+
+	fields, err := notebookObjectFields(json.RawMessage(data), 0)
+	if err != nil {
+		return data, fmt.Errorf("invalid notebook: %w", err)
+	}
+	cells, ok := fields["cells"]
+	if !ok {
+		return data, nil
+	}
+	elements, err := notebookArrayElements(cells.raw, cells.start)
+	if err != nil {
+		return data, fmt.Errorf("invalid notebook: cells: %w", err)
+	}
+
+	var edits []notebookEdit
+	for i, cell := range elements {
+		cellFields, err := notebookObjectFields(cell.raw, cell.start)
+		if err != nil {
+			return data, fmt.Errorf("invalid notebook: cell %d: %w", i, err)
+		}
+		var cellType string
+		if field, ok := cellFields["cell_type"]; ok {
+			_ = json.Unmarshal(field.raw, &cellType)
+		}
+		source, ok := cellFields["source"]
+		if !ok {
+			continue
+		}
+
+		var convertFunc func(string) string
+		switch cellType {
+		case "markdown":
+			convertFunc = convertMarkdown
+		case "code":
+			convertFunc = convertCode
+		default:
+			continue
+		}
+
+		sourceEdits, err := p.convertSource(source, convertFunc)
+		if err != nil {
+			return data, fmt.Errorf("invalid notebook: cell %d source: %w", i, err)
+		}
+		edits = append(edits, sourceEdits...)
+	}
+
+	if len(edits) == 0 {
+		return data, nil
+	}
+	sort.Slice(edits, func(i, j int) bool { return edits[i].start < edits[j].start })
+
+	var result strings.Builder
+	result.Grow(len(data))
+	last := 0
+	for _, edit := range edits {
+		result.WriteString(data[last:edit.start])
+		result.WriteString(edit.text)
+		last = edit.end
+	}
+	result.WriteString(data[last:])
+	return result.String(), nil
+}
+
+// convertSource converts a cell source, which nbformat allows to be a single string or a list
+// of lines. Lines are converted together so that sentences and comments spanning lines are
+// seen whole, then only the lines that changed are rewritten.
+func (p *NotebookProcessor) convertSource(source notebookSpan, convertFunc func(string) string) ([]notebookEdit, error) {
+	var text string
+	if err := json.Unmarshal(source.raw, &text); err == nil {
+		converted := convertFunc(text)
+		if converted == text {
+			return nil, nil
+		}
+		return []notebookEdit{{source.start, source.end, encodeNotebookString(converted)}}, nil
+	}
+
+	elements, err := notebookArrayElements(source.raw, source.start)
+	if err != nil {
+		return nil, err
+	}
+	lines := make([]string, len(elements))
+	for i, element := range elements {
+		if err := json.Unmarshal(element.raw, &lines[i]); err != nil {
+			return nil, fmt.Errorf("line %d is not a string", i)
+		}
+	}
+
+	joined := strings.Join(lines, "")
+	converted := convertFunc(joined)
+	if converted == joined {
+		return nil, nil
+	}
+	convertedLines := strings.SplitAfter(converted, "\n")
+	if convertedLines[len(convertedLines)-1] == "" {
+		convertedLines = convertedLines[:len(convertedLines)-1]
+	}
+
+	if len(convertedLines) == len(lines) {
+		var edits []notebookEdit
+		for i, line := range convertedLines {
+			if line != lines[i] {
+				edits = append(edits, notebookEdit{elements[i].start, elements[i].end, encodeNotebookString(line)})
+			}
+		}
+		return edits, nil
+	}
+
+	// The number of lines changed, so rebuild the list using the original's layout
+	prefix, separator, suffix := "", ",", ""
+	if len(elements) > 0 {
+		prefix = string(source.raw[1 : elements[0].start-source.start])
+		suffix = string(source.raw[elements[len(elements)-1].end-source.start : len(source.raw)-1])
+		if len(elements) > 1 {
+			separator = string(source.raw[elements[0].end-source.start : elements[1].start-source.start])
+		} else {
+			separator = "," + prefix
+		}
+	}
+	encoded := make([]string, len(convertedLines))
+	for i, line := range convertedLines {
+		encoded[i] = encodeNotebookString(line)
+	}
+	return []notebookEdit{{source.start, source.end, "[" + prefix + strings.Join(encoded, separator) + suffix + "]"}}, nil
+}
+
+// notebookObjectFields returns the values of a JSON object by key, with their spans offset by
+// base
+func notebookObjectFields(raw json.RawMessage, base int) (map[string]notebookSpan, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	if token, err := dec.Token(); err != nil || token != json.Delim('{') {
+		return nil, fmt.Errorf("expected an object")
+	}
+	fields := make(map[string]notebookSpan)
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, _ := token.(string)
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, err
+		}
+		end := int(dec.InputOffset())
+		fields[key] = notebookSpan{base + end - len(value), base + end, value}
+	}
+	return fields, nil
+}
+
+// notebookArrayElements returns the elements of a JSON array, with their spans offset by base
+func notebookArrayElements(raw json.RawMessage, base int) ([]notebookSpan, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	if token, err := dec.Token(); err != nil || token != json.Delim('[') {
+		return nil, fmt.Errorf("expected a list")
+	}
+	var elements []notebookSpan
+	for dec.More() {
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, err
+		}
+		end := int(dec.InputOffset())
+		elements = append(elements, notebookSpan{base + end - len(value), base + end, value})
+	}
+	return elements, nil
+}
+
+// encodeNotebookString encodes s as a JSON string the way Jupyter writes it, leaving <, > and
+// & and non-ASCII characters unescaped
+func encodeNotebookString(s string) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
@@ -0,0 +1,154 @@
+// Package converter provides phrase processing functionality for American idioms with British equivalents
+package converter
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// PhraseProcessor rewrites American phrases and idioms ("on the weekend", "different than")
+// to their British equivalents. Phrases match whole words case-insensitively and may be split
+// across any run of whitespace. It is disabled by default.
+type PhraseProcessor struct {
+	enabled bool
+	rules   map[string]string // normalised American phrase -> British phrase
+	pattern *regexp.Regexp    // matches any American phrase; nil when there are no rules
+	explain *explainLog       // records each rewrite, when the converter explains its changes
+}
+
+// NewPhraseProcessor creates a PhraseProcessor with the given American to British phrase rules
+func NewPhraseProcessor(rules map[string]string) *PhraseProcessor {
+	p := &PhraseProcessor{rules: make(map[string]string)}
+	p.AddRules(rules)
+	return p
+}
+
+// LoadDefaultPhrases returns the built-in American to British phrase rules, or those from
+// M2E_DICT_PATH when it provides them
+func LoadDefaultPhrases() (map[string]string, error) {
+	return loadDataDictionary(phrasesDataFile)
+}
+
+// SetEnabled enables or disables phrase processing
+func (p *PhraseProcessor) SetEnabled(enabled bool) {
+	p.enabled = enabled
+}
+
+// IsEnabled returns whether phrase processing is enabled
+func (p *PhraseProcessor) IsEnabled() bool {
+	return p.enabled
+}
+
+// AddRules adds or replaces phrase rules. Keys are matched case-insensitively; entries with an
+// empty phrase or replacement are ignored.
+func (p *PhraseProcessor) AddRules(rules map[string]string) {
+	for american, british := range rules {
+		key := normalisePhrase(american)
+		if key == "" || strings.TrimSpace(british) == "" {
+			continue
+		}
+		p.rules[key] = british
+	}
+	p.compile()
+}
+
+// Rules returns a copy of the phrase rules, keyed by lowercase American phrase
+func (p *PhraseProcessor) Rules() map[string]string {
+	rules := make(map[string]string, len(p.rules))
+	for american, british := range p.rules {
+		rules[american] = british
+	}
+	return rules
+}
+
+// compile builds a single pattern matching every phrase. Longer phrases come first so
+// "fill out the form" wins over a shorter rule that is a prefix of it.
+func (p *PhraseProcessor) compile() {
+	if len(p.rules) == 0 {
+		p.pattern = nil
+		return
+	}
+
+	phrases := make([]string, 0, len(p.rules))
+	for phrase := range p.rules {
+		phrases = append(phrases, phrase)
+	}
+	sort.Slice(phrases, func(i, j int) bool {
+		if len(phrases[i]) != len(phrases[j]) {
+			return len(phrases[i]) > len(phrases[j])
+		}
+		return phrases[i] < phrases[j]
+	})
+
+	alternatives := make([]string, len(phrases))
+	for i, phrase := range phrases {
+		words := strings.Fields(phrase)
+		for j, word := range words {
+			words[j] = regexp.QuoteMeta(word)
+		}
+		alternatives[i] = strings.Join(words, `\s+`)
+	}
+	p.pattern = regexp.MustCompile(`(?i)\b(?:` + strings.Join(alternatives, "|") + `)\b`)
+}
+
+// ProcessText rewrites the American phrases in text, preserving the capitalisation of the
+// first word. Matches that are part of a URL or overlap an inline code span are left alone.
+func (p *PhraseProcessor) ProcessText(text string) string {
+	if !p.enabled || p.pattern == nil {
+		return text
+	}
+
+	matches := p.pattern.FindAllStringIndex(text, -1)
+	if len(matches) == 0 {
+		return text
+	}
+
+	codeSpans := inlineCodeRegex.FindAllStringIndex(text, -1)
+
+	var result strings.Builder
+	last := 0
+	for _, m := range matches {
+		start, end := m[0], m[1]
+		british, ok := p.rules[normalisePhrase(text[start:end])]
+		if !ok || isURL(tokenAt(text, start)) || isURL(tokenAt(text, end-1)) || overlapsSpan(start, end, codeSpans) {
+			continue
+		}
+		replacement := matchCase(british, text[start:end])
+		p.explain.record(Explanation{Original: text[start:end], Converted: replacement, Rule: "phrase"})
+		result.WriteString(text[last:start])
+		result.WriteString(replacement)
+		last = end
+	}
+	result.WriteString(text[last:])
+
+	return result.String()
+}
+
+// normalisePhrase lowercases a phrase and collapses its whitespace so it can be looked up
+func normalisePhrase(phrase string) string {
+	return strings.ToLower(strings.Join(strings.Fields(phrase), " "))
+}
+
+// overlapsSpan reports whether [start, end) overlaps any of the spans
+func overlapsSpan(start, end int, spans [][]int) bool {
+	for _, span := range spans {
+		if start < span[1] && span[0] < end {
+			return true
+		}
+	}
+	return false
+}
+
+// tokenAt returns the whitespace-delimited token containing the byte at index i
+func tokenAt(text string, i int) string {
+	start := i
+	for start > 0 && !isASCIISpace(text[start-1]) {
+		start--
+	}
+	end := i
+	for end < len(text) && !isASCIISpace(text[end]) {
+		end++
+	}
+	return text[start:end]
+}
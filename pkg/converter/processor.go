@@ -0,0 +1,147 @@
+// Package converter provides the processor interface and the phases that registered processors run in
+package converter
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Processor is a conversion pass over prose. The unit, Markdown and ignore comment processors
+// implement it, and custom passes (such as company-specific jargon) can be added to a
+// converter with RegisterProcessor.
+type Processor interface {
+	Process(text string, opts ...ProcessOption) string
+}
+
+// The built-in processors that wrap or follow spelling conversion
+var (
+	_ Processor = (*UnitProcessor)(nil)
+	_ Processor = (*MarkdownProcessor)(nil)
+	_ Processor = (*CommentIgnoreProcessor)(nil)
+)
+
+// ProcessorFunc adapts an ordinary function to a Processor
+type ProcessorFunc func(text string, opts ...ProcessOption) string
+
+// Process calls f(text, opts...)
+func (f ProcessorFunc) Process(text string, opts ...ProcessOption) string {
+	return f(text, opts...)
+}
+
+// ProcessOptions holds the settings a processor is run with
+type ProcessOptions struct {
+	NormaliseSmartQuotes bool                // smart quotes are being normalised
+	Language             string              // language of code being processed; empty for prose
+	Convert              func(string) string // conversion that wrapping processors apply to the text they don't protect
+}
+
+// ProcessOption sets one of the ProcessOptions
+type ProcessOption func(*ProcessOptions)
+
+// WithSmartQuotes tells the processor whether smart quotes are being normalised
+func WithSmartQuotes(normalise bool) ProcessOption {
+	return func(o *ProcessOptions) { o.NormaliseSmartQuotes = normalise }
+}
+
+// WithLanguage tells the processor the text is code in language, so only its comments should
+// be changed
+func WithLanguage(language string) ProcessOption {
+	return func(o *ProcessOptions) { o.Language = language }
+}
+
+// WithConvert gives wrapping processors, such as the Markdown and ignore comment processors,
+// the conversion to apply to the text they don't protect
+func WithConvert(convert func(string) string) ProcessOption {
+	return func(o *ProcessOptions) { o.Convert = convert }
+}
+
+// NewProcessOptions applies opts to the default options
+func NewProcessOptions(opts ...ProcessOption) ProcessOptions {
+	var options ProcessOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+// convertOrKeep returns the Convert option, or a function returning its input unchanged
+func (o ProcessOptions) convertOrKeep() func(string) string {
+	if o.Convert != nil {
+		return o.Convert
+	}
+	return func(text string) string { return text }
+}
+
+// Phase is a point in the conversion of prose at which registered processors run. For each
+// piece of prose the converter runs, in order:
+//
+//  1. PhasePreSpelling processors, after smart quotes are normalised
+//  2. spelling conversion: phrases, contextual words and the dictionary
+//  3. PhasePostSpelling processors
+//  4. unit conversion and normalisation, when enabled
+//  5. PhasePostUnits processors
+//
+// Code, ignored lines, front matter keys and Markdown link targets are never passed to
+// processors. The spelling phases see prose with its Markdown formatting removed.
+type Phase int
+
+const (
+	PhasePreSpelling Phase = iota
+	PhasePostSpelling
+	PhasePostUnits
+)
+
+// String returns the phase's name
+func (p Phase) String() string {
+	switch p {
+	case PhasePreSpelling:
+		return "pre-spelling"
+	case PhasePostSpelling:
+		return "post-spelling"
+	case PhasePostUnits:
+		return "post-units"
+	default:
+		return fmt.Sprintf("Phase(%d)", int(p))
+	}
+}
+
+// RegisterProcessor adds p to the processors run at phase, after any registered before it.
+// Processors should be registered before the converter is used; registering is not safe to do
+// concurrently with conversion.
+func (c *Converter) RegisterProcessor(p Processor, phase Phase) error {
+	if p == nil {
+		return fmt.Errorf("cannot register a nil processor")
+	}
+	if phase < PhasePreSpelling || phase > PhasePostUnits {
+		return fmt.Errorf("unknown processor phase %s", phase)
+	}
+	if c.processors == nil {
+		c.processors = make(map[Phase][]Processor)
+	}
+	c.processors[phase] = append(c.processors[phase], p)
+	return nil
+}
+
+// Processors returns the processors registered at phase, in the order they run
+func (c *Converter) Processors(phase Phase) []Processor {
+	return slices.Clone(c.processors[phase])
+}
+
+// runProcessors passes text through each processor registered at phase in turn
+func (c *Converter) runProcessors(phase Phase, text string, normaliseSmartQuotes bool) string {
+	for _, p := range c.processors[phase] {
+		text = p.Process(text, WithSmartQuotes(normaliseSmartQuotes))
+	}
+	return text
+}
+
+// convertProse converts a piece of prose: spelling (with the spelling phases' processors), then
+// units, then the post-units processors, then folds any runs of spaces they introduced
+func (c *Converter) convertProse(text string, normaliseSmartQuotes bool) string {
+	result := c.ConvertToBritishSimple(text, normaliseSmartQuotes)
+	if c.unitProcessor != nil && c.unitProcessor.IsActive() {
+		result = c.unitProcessor.Process(result, WithSmartQuotes(normaliseSmartQuotes))
+	}
+	result = c.runProcessors(PhasePostUnits, result, normaliseSmartQuotes)
+	return c.foldIntroducedSpaces(text, result)
+}
@@ -0,0 +1,185 @@
+// Package converter provides per-directory project configuration discovery
+package converter
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+)
+
+// ProjectConfigFileName is the name of the per-directory configuration file. It uses the same
+// format as the user unit configuration and only needs the settings it changes.
+const ProjectConfigFileName = ".m2e.json"
+
+// projectConfigs caches the converter used for each project configuration file
+type projectConfigs struct {
+	mu         sync.Mutex
+	override   func(*Converter)
+	dirs       map[string]string     // directory -> nearest config path ("" if none)
+	converters map[string]*Converter // config path -> configured converter
+}
+
+// FindProjectConfig returns the path of the nearest .m2e.json in dir or one of its parents,
+// or an empty string if there is none
+func FindProjectConfig(dir string) string {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+
+	for {
+		path := filepath.Join(dir, ProjectConfigFileName)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// LoadProjectConfig reads a project configuration file on top of base. Settings missing from
+// the file keep their value from base, which is not modified.
+func LoadProjectConfig(path string, base *UnitConfig) (*UnitConfig, error) {
+	if base == nil {
+		base = GetDefaultUnitConfig()
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read project config %s: %w", path, err)
+	}
+
+	config := base.Clone()
+	if err := config.UnmarshalJSON(data); err != nil {
+		return nil, fmt.Errorf("failed to parse project config %s (please check JSON format): %w", path, err)
+	}
+	if err := ValidateConfig(config); err != nil {
+		return nil, fmt.Errorf("invalid configuration in %s: %w", path, err)
+	}
+
+	return config, nil
+}
+
+// Clone returns a copy of the converter with its own unit configuration, spelling variant and
+// excluded words, so they can be changed without affecting the original
+func (c *Converter) Clone() *Converter {
+	clone := *c
+	clone.excludedWords = slices.Clone(c.excludedWords)
+	clone.projectConfigs = nil
+	if c.processors != nil {
+		clone.processors = make(map[Phase][]Processor, len(c.processors))
+		for phase, processors := range c.processors {
+			clone.processors[phase] = slices.Clone(processors)
+		}
+	}
+	if c.unitProcessor != nil {
+		if config := c.unitProcessor.GetConfig(); config != nil {
+			clone.unitProcessor = NewUnitProcessorWithConfig(config.Clone())
+			clone.unitProcessor.explain = c.explain
+			clone.unitProcessor.counters = c.counters
+			clone.unitProcessor.warnings = c.warnings
+		}
+	}
+	return &clone
+}
+
+// ApplyConfig applies a configuration's unit settings, and its spelling variant and excluded
+// words when set, to the converter
+func (c *Converter) ApplyConfig(config *UnitConfig) error {
+	if config == nil {
+		return nil
+	}
+	if err := ValidateConfig(config); err != nil {
+		return err
+	}
+
+	if config.SpellingVariant != "" {
+		variant, err := ParseSpellingVariant(config.SpellingVariant)
+		if err != nil {
+			return err
+		}
+		c.spellingVariant = variant
+	}
+	c.excludedWords = slices.Clone(config.ExcludedWords)
+	c.rebuildDictionaries()
+
+	if c.unitProcessor != nil {
+		c.unitProcessor.SetConfig(config.Clone())
+	}
+	return nil
+}
+
+// EffectiveConfig returns the configuration the converter uses: its unit settings, with the
+// spelling variant and excluded words it actually applies, whether they came from a config file
+// or were set since. It is the inverse of ApplyConfig, and the result can be saved as a config.
+func (c *Converter) EffectiveConfig() *UnitConfig {
+	config := GetDefaultUnitConfig()
+	if c.unitProcessor != nil && c.unitProcessor.GetConfig() != nil {
+		config = c.unitProcessor.GetConfig().Clone()
+	}
+	config.SpellingVariant = c.spellingVariant.String()
+	config.ExcludedWords = slices.Clone(c.excludedWords)
+	return config
+}
+
+// EnableProjectConfig makes ForFile look for a .m2e.json next to each file or in one of its
+// parent directories. override, if not nil, is called on each project converter after its
+// config is applied, so settings that take precedence (such as command-line flags) can be
+// re-applied.
+func (c *Converter) EnableProjectConfig(override func(*Converter)) {
+	c.projectConfigs = &projectConfigs{
+		override:   override,
+		dirs:       make(map[string]string),
+		converters: make(map[string]*Converter),
+	}
+}
+
+// ForFile returns the converter to use for filePath: a copy configured by the nearest
+// .m2e.json when project configs are enabled and one exists, or c otherwise. A config that
+// fails to load is reported once, the first time it is found, and c is used instead.
+func (c *Converter) ForFile(filePath string) (*Converter, error) {
+	projects := c.projectConfigs
+	if projects == nil {
+		return c, nil
+	}
+
+	projects.mu.Lock()
+	defer projects.mu.Unlock()
+
+	dir := filepath.Dir(filePath)
+	configPath, ok := projects.dirs[dir]
+	if !ok {
+		configPath = FindProjectConfig(dir)
+		projects.dirs[dir] = configPath
+	}
+	if configPath == "" {
+		return c, nil
+	}
+
+	if conv, ok := projects.converters[configPath]; ok {
+		return conv, nil
+	}
+
+	// Failed configs fall back to c without being retried
+	projects.converters[configPath] = c
+	config, err := LoadProjectConfig(configPath, c.unitProcessor.GetConfig())
+	if err != nil {
+		return c, err
+	}
+
+	conv := c.Clone()
+	if err := conv.ApplyConfig(config); err != nil {
+		return c, fmt.Errorf("invalid configuration in %s: %w", configPath, err)
+	}
+	if projects.override != nil {
+		projects.override(conv)
+	}
+	projects.converters[configPath] = conv
+
+	return conv, nil
+}
@@ -0,0 +1,130 @@
+// Package converter provides a guard that keeps American spellings in proper nouns
+package converter
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// properNounConnectors are lowercase words that may join the capitalised words of a name,
+// as in "Department of Labor" or "Bureau for Labor and Industry"
+var properNounConnectors = map[string]bool{
+	"of": true, "the": true, "and": true, "for": true, "&": true, "de": true, "on": true, "in": true,
+}
+
+// properNounTokens reports, for each token of a tokenised line, whether it is a word in a
+// multi-word Title Case sequence such as "World Health Organization" or "Department of Labor".
+// Those are names, so their spelling is kept. A word that only starts a sentence doesn't make
+// the next word part of a name ("They Americanize" still converts), and heading lines
+// (starting with # or =) are Title Case by convention, so nothing in them is guarded.
+func properNounTokens(tokens []string, wsFlags []bool) []bool {
+	var words []int // indexes of the non-whitespace tokens
+	for i := range tokens {
+		if !wsFlags[i] {
+			words = append(words, i)
+		}
+	}
+	if len(words) < 2 || isHeadingMarker(tokens[words[0]]) {
+		return nil
+	}
+
+	capitalised := make([]bool, len(words))
+	connector := make([]bool, len(words))
+	sentenceStart := make([]bool, len(words))
+	breaksAfter := make([]bool, len(words))
+	for k, i := range words {
+		core := trimWordPunctuation(tokens[i])
+		capitalised[k] = isTitleCaseWord(core)
+		connector[k] = properNounConnectors[strings.ToLower(core)]
+		breaksAfter[k] = endsClause(tokens[i])
+		sentenceStart[k] = k == 0 || endsSentence(tokens[words[k-1]]) || !hasLetter(tokens[words[k-1]])
+	}
+
+	// Link each capitalised word to the next one when only connector words lie between them.
+	// The first word of a sentence is capitalised for grammar, so it only starts a name when
+	// a connector follows ("Department of Labor") or when the next word is itself in a name
+	// ("World Health Organization").
+	inName := make([]bool, len(words))
+	var direct []int // sentence-initial words directly followed by a capitalised word
+	for k := range words {
+		if !capitalised[k] {
+			continue
+		}
+		next := k + 1
+		for next < len(words) && !breaksAfter[next-1] && connector[next] && !capitalised[next] {
+			next++
+		}
+		if next >= len(words) || breaksAfter[next-1] || !capitalised[next] {
+			continue
+		}
+		if sentenceStart[k] && next == k+1 {
+			direct = append(direct, k)
+			continue
+		}
+		inName[k], inName[next] = true, true
+	}
+	for _, k := range direct {
+		inName[k] = inName[k+1]
+	}
+
+	var guarded []bool
+	for k, i := range words {
+		if !inName[k] {
+			continue
+		}
+		if guarded == nil {
+			guarded = make([]bool, len(tokens))
+		}
+		guarded[i] = true
+	}
+	return guarded
+}
+
+// isHeadingMarker reports whether token opens a Markdown or AsciiDoc heading ("#", "==")
+func isHeadingMarker(token string) bool {
+	return strings.Trim(token, "#") == "" || strings.Trim(token, "=") == ""
+}
+
+// trimWordPunctuation strips the quotes, brackets and punctuation around a word
+func trimWordPunctuation(token string) string {
+	return strings.TrimFunc(token, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// isTitleCaseWord reports whether word starts with a capital and has lowercase letters, which
+// leaves out all-caps words and the pronoun "I"
+func isTitleCaseWord(word string) bool {
+	first, size := utf8.DecodeRuneInString(word)
+	if !unicode.IsUpper(first) {
+		return false
+	}
+	return strings.IndexFunc(word[size:], unicode.IsLower) >= 0
+}
+
+// endsClause reports whether token ends with punctuation that separates it from the next word
+func endsClause(token string) bool {
+	token = strings.TrimRight(token, `"'”’)]*_`)
+	return strings.ContainsAny(lastRune(token), ",;:.!?")
+}
+
+// endsSentence reports whether token ends a sentence
+func endsSentence(token string) bool {
+	token = strings.TrimRight(token, `"'”’)]*_`)
+	return strings.ContainsAny(lastRune(token), ".!?")
+}
+
+// lastRune returns the last character of s as a string
+func lastRune(s string) string {
+	r, _ := utf8.DecodeLastRuneInString(s)
+	if r == utf8.RuneError {
+		return ""
+	}
+	return string(r)
+}
+
+// hasLetter reports whether s contains a letter
+func hasLetter(s string) bool {
+	return strings.IndexFunc(s, unicode.IsLetter) >= 0
+}
@@ -0,0 +1,98 @@
+// Package converter provides British placement of punctuation around quoted phrases
+package converter
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// quotedPhrasePattern matches a short quoted phrase that starts with a lower-case letter and ends
+// with a comma or full stop inside its closing quote, such as "like this,"
+var quotedPhrasePattern = regexp.MustCompile(`(["“])(\p{Ll}[^"“”.,;:!?\n]{0,40})([.,])(["”])`)
+
+// maxQuotedPhraseWords is the longest quoted phrase, in words, whose punctuation is moved
+const maxQuotedPhraseWords = 4
+
+// speechVerbs introduce quoted speech, whose punctuation belongs to the quote
+var speechVerbs = []string{
+	"said", "says", "say", "asked", "asks", "replied", "replies", "answered", "answers",
+	"shouted", "whispered", "wrote", "writes", "added", "adds",
+}
+
+// SetQuotePunctuationEnabled controls whether a comma or full stop inside the closing quote of a
+// short quoted phrase is moved outside it, as in British usage: called it "simple," which →
+// called it "simple", which. Only clear cases are changed: a phrase of up to four words that
+// starts with a lower-case letter, follows a word and ends a clause, and isn't introduced as
+// speech ("he said 'yes.'") or by a comma or colon. Off by default.
+func (c *Converter) SetQuotePunctuationEnabled(enabled bool) {
+	c.quotePunctuation = enabled
+}
+
+// IsQuotePunctuationEnabled reports whether punctuation is moved outside quoted phrases
+func (c *Converter) IsQuotePunctuationEnabled() bool {
+	return c.quotePunctuation
+}
+
+// convertQuotePunctuation moves the comma or full stop at the end of each short quoted phrase
+// outside the closing quote. It only sees prose, so code is never touched.
+func (c *Converter) convertQuotePunctuation(text string) string {
+	if !c.quotePunctuation || !strings.ContainsAny(text, "\"”") {
+		return text
+	}
+
+	var result strings.Builder
+	last := 0
+	for _, m := range quotedPhrasePattern.FindAllStringSubmatchIndex(text, -1) {
+		start, end := m[0], m[1]
+		open, phrase := text[m[2]:m[3]], text[m[4]:m[5]]
+		punctuation, closing := text[m[6]:m[7]], text[m[8]:m[9]]
+		if (open == "“") != (closing == "”") || !isClearQuotedPhrase(text, start, end, phrase) {
+			continue
+		}
+
+		replacement := open + phrase + closing + punctuation
+		if c.explain != nil {
+			c.explain.record(Explanation{Original: text[start:end], Converted: replacement, Rule: "quote punctuation"})
+		}
+		result.WriteString(text[last:start])
+		result.WriteString(replacement)
+		last = end
+	}
+	if last == 0 {
+		return text
+	}
+	result.WriteString(text[last:])
+	return result.String()
+}
+
+// isClearQuotedPhrase reports whether the quoted phrase at text[start:end] is one whose
+// punctuation can safely be moved: it's short, follows a word that isn't a speech verb, and the
+// closing quote ends the clause
+func isClearQuotedPhrase(text string, start, end int, phrase string) bool {
+	if len(strings.Fields(phrase)) > maxQuotedPhraseWords || strings.TrimSpace(phrase) != phrase {
+		return false
+	}
+
+	// Followed by a space or the end of the text, not by more of the quote
+	if end < len(text) {
+		if r, _ := utf8.DecodeRuneInString(text[end:]); !unicode.IsSpace(r) {
+			return false
+		}
+	}
+
+	// Preceded by a space and a word, so not introduced by a comma, colon or bracket
+	before := text[:start]
+	trimmed := strings.TrimRight(before, " \t")
+	if trimmed == before || trimmed == "" {
+		return false
+	}
+	if r, _ := utf8.DecodeLastRuneInString(trimmed); !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+		return false
+	}
+	fields := strings.Fields(trimmed)
+	previous := strings.TrimLeftFunc(fields[len(fields)-1], func(r rune) bool { return !unicode.IsLetter(r) })
+	return !slices.Contains(speechVerbs, strings.ToLower(previous))
+}
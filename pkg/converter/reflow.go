@@ -0,0 +1,231 @@
+// Package converter provides re-wrapping of converted prose paragraphs to a maximum line width
+package converter
+
+import (
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// reflowExtensions lists the Markdown and plain text files whose paragraphs can be re-wrapped
+var reflowExtensions = []string{".md", ".markdown", ".txt", ".text"}
+
+var (
+	// reflowFenceRegex matches the opening or closing fence of a fenced code block
+	reflowFenceRegex = regexp.MustCompile("^\\s{0,3}(`{3,}|~{3,})")
+	// reflowListItemRegex matches a list item, capturing its indent, marker, spacing and text
+	reflowListItemRegex = regexp.MustCompile(`^(\s*)([-*+]|\d{1,9}[.)])(\s+)(\S.*)$`)
+	// reflowBreakRegex matches a thematic break or a setext heading underline
+	reflowBreakRegex = regexp.MustCompile(`^\s{0,3}(?:(?:-\s*){3,}|(?:\*\s*){3,}|(?:_\s*){3,}|=+\s*|-+\s*)$`)
+	// reflowBlockRegex matches lines that are kept as they are: headings, block quotes, HTML,
+	// tables and link reference definitions
+	reflowBlockRegex = regexp.MustCompile(`^\s{0,3}(?:#|>|<|\||\[[^\]]+\]:)`)
+	// reflowLineStartRegex matches words that would start a list, heading, quote or other block
+	// if wrapping put them at the start of a line
+	reflowLineStartRegex = regexp.MustCompile("^(?:[-*+>#=|]+|\\d{1,9}[.)]|[`~]{3,}.*|<.*)$")
+)
+
+// IsReflowableFile reports whether a file is Markdown or plain text, whose paragraphs can be
+// re-wrapped with SetMaxLineWidth
+func IsReflowableFile(filePath string) bool {
+	ext := strings.ToLower(filepath.Ext(filePath))
+	return slices.Contains(reflowExtensions, ext)
+}
+
+// SetMaxLineWidth sets the width that ConvertFileContent re-wraps the paragraphs of Markdown
+// and plain text files to when conversion changes them, as changed word lengths can break
+// carefully wrapped documents. 0, the default, leaves lines as they are.
+func (c *Converter) SetMaxLineWidth(width int) {
+	c.maxLineWidth = max(width, 0)
+}
+
+// MaxLineWidth returns the width that changed paragraphs are re-wrapped to, or 0 if they aren't
+func (c *Converter) MaxLineWidth() int {
+	return c.maxLineWidth
+}
+
+// ReflowFile re-wraps the paragraphs of converted, the conversion of original, that conversion
+// changed, if a maximum line width is set and filePath is Markdown or plain text. An empty
+// filePath is treated as plain text. Nothing is re-wrapped when only comments are converted.
+func (c *Converter) ReflowFile(original, converted, filePath string) string {
+	if c.maxLineWidth <= 0 || c.contentMode == ContentModeCommentsOnly || (filePath != "" && !IsReflowableFile(filePath)) {
+		return converted
+	}
+	return Reflow(original, converted, c.maxLineWidth)
+}
+
+// Reflow re-wraps each paragraph of converted that differs from original so that no line is
+// longer than width characters, unless a single word is. Paragraphs conversion didn't change
+// are kept as they are, as are blank lines, fenced and indented code blocks, front matter,
+// headings, block quotes, tables, HTML and paragraphs with hard line breaks. List items are
+// re-wrapped with a hanging indent. If conversion changed the number of lines, every paragraph
+// is re-wrapped. Line endings are kept.
+func Reflow(original, converted string, width int) string {
+	if width <= 0 {
+		return converted
+	}
+
+	lines := strings.Split(normaliseLineEndings(converted), "\n")
+	originalLines := strings.Split(normaliseLineEndings(original), "\n")
+	changed := func(start, end int) bool {
+		if len(lines) != len(originalLines) {
+			return true
+		}
+		return !slices.Equal(lines[start:end], originalLines[start:end])
+	}
+
+	var result []string
+	fence := ""     // the fence of the code block the current line is in
+	inList := false // indented lines continue a list rather than being code
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		if fence != "" {
+			if m := reflowFenceRegex.FindStringSubmatch(line); m != nil && m[1][0] == fence[0] &&
+				len(m[1]) >= len(fence) && trimmed == m[1] {
+				fence = ""
+			}
+			result = append(result, line)
+			i++
+			continue
+		}
+
+		if i == 0 && trimmed == "---" {
+			if end := frontMatterEnd(lines); end > 0 {
+				result = append(result, lines[:end+1]...)
+				i = end + 1
+				continue
+			}
+		}
+
+		indent := reflowIndent(line)
+		listItem := reflowListItemRegex.FindStringSubmatch(line)
+		switch {
+		case trimmed == "":
+			result = append(result, line)
+			i++
+			continue
+		case indent >= 4 && !inList:
+			// Indented code block
+			result = append(result, line)
+			i++
+			continue
+		case reflowFenceRegex.MatchString(line):
+			fence = reflowFenceRegex.FindStringSubmatch(line)[1]
+			result = append(result, line)
+			i++
+			continue
+		case reflowBreakRegex.MatchString(line) || reflowBlockRegex.MatchString(line):
+			if indent == 0 {
+				inList = false
+			}
+			result = append(result, line)
+			i++
+			continue
+		}
+
+		// A paragraph or list item runs until a blank line or the start of another block
+		end := i + 1
+		for end < len(lines) && !startsReflowBlock(lines[end]) {
+			end++
+		}
+
+		firstPrefix := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		restPrefix, text := firstPrefix, trimmed
+		if listItem != nil {
+			// Continuation lines line up with the item's text
+			firstPrefix = listItem[1] + listItem[2] + listItem[3]
+			restPrefix = strings.Repeat(" ", indent+utf8.RuneCountInString(listItem[2]+listItem[3]))
+			text = listItem[4]
+			inList = true
+		} else if indent == 0 {
+			inList = false
+		}
+
+		paragraph := lines[i:end]
+		setextHeading := end < len(lines) && reflowBreakRegex.MatchString(lines[end]) && !strings.Contains(strings.TrimSpace(lines[end]), " ")
+		if !changed(i, end) || hasHardBreak(paragraph) || setextHeading {
+			result = append(result, paragraph...)
+			i = end
+			continue
+		}
+
+		words := strings.Fields(text)
+		for _, continuation := range paragraph[1:] {
+			words = append(words, strings.Fields(continuation)...)
+		}
+		result = append(result, wrapWords(words, width, firstPrefix, restPrefix)...)
+		i = end
+	}
+
+	return PreserveLineEndings(original, strings.Join(result, "\n"))
+}
+
+// normaliseLineEndings turns CRLF and CR line endings into LF
+func normaliseLineEndings(text string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(text, "\r\n", "\n"), "\r", "\n")
+}
+
+// frontMatterEnd returns the line that closes YAML front matter opened on the first line, or 0
+// if it isn't closed
+func frontMatterEnd(lines []string) int {
+	for i := 1; i < len(lines); i++ {
+		if trimmed := strings.TrimSpace(lines[i]); trimmed == "---" || trimmed == "..." {
+			return i
+		}
+	}
+	return 0
+}
+
+// reflowIndent returns the width of a line's indentation, counting a tab as four spaces
+func reflowIndent(line string) int {
+	indent := 0
+	for _, r := range line {
+		switch r {
+		case ' ':
+			indent++
+		case '\t':
+			indent += 4
+		default:
+			return indent
+		}
+	}
+	return indent
+}
+
+// startsReflowBlock reports whether a line ends the paragraph before it
+func startsReflowBlock(line string) bool {
+	return strings.TrimSpace(line) == "" || reflowFenceRegex.MatchString(line) || reflowBreakRegex.MatchString(line) ||
+		reflowBlockRegex.MatchString(line) || reflowListItemRegex.MatchString(line)
+}
+
+// hasHardBreak reports whether any line of a paragraph but the last ends with a hard line break
+// (two spaces or a backslash), which re-wrapping would lose
+func hasHardBreak(paragraph []string) bool {
+	for _, line := range paragraph[:len(paragraph)-1] {
+		if strings.HasSuffix(line, "  ") || strings.HasSuffix(line, "\\") {
+			return true
+		}
+	}
+	return false
+}
+
+// wrapWords fills lines with words up to width characters, starting the first line with
+// firstPrefix and the rest with restPrefix. A word that would start a block if it began a line
+// stays on the line before.
+func wrapWords(words []string, width int, firstPrefix, restPrefix string) []string {
+	var lines []string
+	line := firstPrefix + words[0]
+	for _, word := range words[1:] {
+		if utf8.RuneCountInString(line)+1+utf8.RuneCountInString(word) > width && !reflowLineStartRegex.MatchString(word) {
+			lines = append(lines, line)
+			line = restPrefix + word
+			continue
+		}
+		line += " " + word
+	}
+	return append(lines, line)
+}
@@ -0,0 +1,96 @@
+// Package converter provides regional words that are converted only in certain contexts
+package converter
+
+// regionalWeekdays and regionalMonths match the names that mark a range of days or months
+const (
+	regionalWeekdays = `(?:Mon|Tues|Wednes|Thurs|Fri|Satur|Sun)day`
+	regionalMonths   = `(?:January|February|March|April|May|June|July|August|September|October|November|December)`
+)
+
+// regionalWordConfigs returns the contextual rules for American seasons, holidays and date
+// ranges, which aren't spelling differences and so only apply when regional words are enabled.
+// Each is converted only in a context that leaves no doubt, such as "in the fall" or "summer
+// vacation"; the verbs "fall" and "vacation" are left alone.
+func regionalWordConfigs() map[string]WordConfig {
+	return map[string]WordConfig{
+		"fall": {
+			Verb: "fall",
+			SemanticVariants: map[string]string{
+				`(?im)\b(?:in|during|by|until|since|over)\s+the\s+(fall)\s*(?:[.,;:!?)]|$)`:                                               "autumn",
+				`(?i)\b(?:this|last|next|early|late|every|each|coming)\s+(fall)\b`:                                                        "autumn",
+				`(?i)\b(fall)\s+(?:semester|term|season|quarter|colou?rs|foliage|leaves|weather|fashions?|collection|break|of\s+\d{4})\b`: "autumn",
+				`(?i)\b(?:spring|summer|winter),?\s+(?:and|or|to)\s+(fall)\b`:                                                             "autumn",
+				`(?i)\b(fall)\s+(?:and|or|to)\s+(?:spring|summer|winter)\b`:                                                               "autumn",
+			},
+			Enabled: true,
+		},
+		"vacation": {
+			Verb: "vacation",
+			SemanticVariants: map[string]string{
+				`(?i)\b(?:on|a|the|my|our|your|his|her|their|summer|winter|spring|family|school|annual|paid|beach|Christmas)\s+(vacations?)\b`: "holiday",
+				`(?i)\b(vacations?)\s+(?:home|homes|rentals?|plans?|destinations?|spots?|pay|requests?)\b`:                                     "holiday",
+			},
+			Enabled: true,
+		},
+		"through": {
+			// Ranges of days or months, as in "Monday through Friday"
+			SemanticVariants: map[string]string{
+				`(?i)\b` + regionalWeekdays + `\s+(through)\s+` + regionalWeekdays + `\b`: "to",
+				`(?i)\b` + regionalMonths + `\s+(through)\s+` + regionalMonths + `\b`:     "to",
+			},
+			Enabled: true,
+		},
+	}
+}
+
+// SetRegionalWordsEnabled adds or removes the rules for regional words such as "fall" (the
+// season), "vacation" and "through" in date ranges. A word the contextual configuration
+// already defines keeps its own rules.
+func (d *ContextAwareWordDetector) SetRegionalWordsEnabled(enabled bool) {
+	if enabled == (d.regionalWords != nil) {
+		return
+	}
+
+	if enabled {
+		d.regionalWords = []string{}
+		for word, config := range regionalWordConfigs() {
+			if _, ok := d.config.WordConfigs[word]; ok {
+				continue
+			}
+			d.patterns.AddWordConfig(word, config) // the patterns share the configuration's map
+			d.regionalWords = append(d.regionalWords, word)
+		}
+	} else {
+		for _, word := range d.regionalWords {
+			delete(d.config.WordConfigs, word)
+			delete(d.patterns.GeneratedPatterns, word)
+		}
+		d.regionalWords = nil
+	}
+
+	d.quickCheckWords = nil
+	d.buildQuickCheckWords()
+}
+
+// IsRegionalWordsEnabled reports whether the rules for regional words are in use
+func (d *ContextAwareWordDetector) IsRegionalWordsEnabled() bool {
+	return d.regionalWords != nil
+}
+
+// SetRegionalWordsEnabled controls whether American seasons, holidays and date ranges are
+// converted where the context is clear: "in the fall" → "in the autumn", "summer vacation" →
+// "summer holiday" and "Monday through Friday" → "Monday to Friday". The verbs "fall" and
+// "vacation" are never changed. Off by default, as these are regional usage rather than
+// spelling.
+func (c *Converter) SetRegionalWordsEnabled(enabled bool) {
+	if detector, ok := c.contextualWordDetector.(*ContextAwareWordDetector); ok {
+		detector.SetRegionalWordsEnabled(enabled)
+		c.rebuildDictionaries()
+	}
+}
+
+// IsRegionalWordsEnabled reports whether regional words are converted
+func (c *Converter) IsRegionalWordsEnabled() bool {
+	detector, ok := c.contextualWordDetector.(*ContextAwareWordDetector)
+	return ok && detector.IsRegionalWordsEnabled()
+}
@@ -0,0 +1,370 @@
+// Package converter provides reStructuredText processing that converts prose while preserving markup
+package converter
+
+import (
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	// rstDirectiveRegex matches a directive such as ".. code-block:: python" or ".. note::",
+	// capturing its indentation, name and argument
+	rstDirectiveRegex = regexp.MustCompile(`^([ \t]*)\.\.[ \t]+([\w][\w:.+-]*)::(?:[ \t]+(.*?))?[ \t]*$`)
+
+	// rstExplicitMarkupRegex matches any other explicit markup line, which is a comment unless
+	// it's a target, substitution definition, footnote or citation
+	rstExplicitMarkupRegex = regexp.MustCompile(`^([ \t]*)\.\.(?:[ \t]+(.*))?$`)
+
+	// rstTargetRegex matches the text after ".. " of a hyperlink target (".. _name: url") or a
+	// substitution definition (".. |name| image:: color.png"), and anonymous targets ("__ url")
+	rstTargetRegex = regexp.MustCompile(`^(?:_|\|)|^__[ \t]`)
+
+	// rstFootnoteRegex matches the label of a footnote or citation, such as "[1] " or "[#note] "
+	rstFootnoteRegex = regexp.MustCompile(`^\[[^\]\s]+\](?:[ \t]+|$)`)
+
+	// rstFieldRegex matches a field list item or directive option such as ":caption: The color",
+	// capturing the field name; a role such as ":func:`x`" isn't a field
+	rstFieldRegex = regexp.MustCompile("^[ \\t]*:[^:\\s`][^:`]*:(?:[ \\t]|$)")
+
+	// rstGridTableRegex matches the border of a grid table, such as "+------+------+"
+	rstGridTableRegex = regexp.MustCompile(`^[ \t]*\+(?:[-=]+\+)+[ \t]*$`)
+
+	// rstSimpleTableRegex matches the border of a simple table, such as "=====  ====="
+	rstSimpleTableRegex = regexp.MustCompile(`^[ \t]*=+(?:[ \t]+=+)+[ \t]*$`)
+
+	// rstInlineRegex matches inline markup whose text, if any, is converted but whose syntax and
+	// targets are preserved: inline literals, roles, hyperlinks with a target (text in group 1),
+	// interpreted text and phrase references, substitution references, references such as
+	// "name_" and bare URLs
+	rstInlineRegex = regexp.MustCompile("``[^`\\n]+?``" +
+		"|:[\\w:.+-]+:`[^`\\n]*`" +
+		"|`([^`\\n<]*?)[ \\t]*<[^>\\n]+>`__?" +
+		"|`[^`\\n]+`(?::[\\w:.+-]+:|__?)?" +
+		"|\\|[^|\\s][^|\\n]*\\|_{0,2}" +
+		"|\\b[\\w.-]+__?\\b" +
+		"|\\b(?:https?|ftp)://[^\\s<>`]+")
+)
+
+// rstCodeDirectives hold source code, of the language given as their argument, so only its
+// comments are converted
+var rstCodeDirectives = []string{"code-block", "code", "sourcecode"}
+
+// rstLiteralDirectives hold content that isn't prose, such as markup, maths, file lists or data,
+// and are left untouched
+var rstLiteralDirectives = []string{
+	"raw", "math", "include", "literalinclude", "toctree", "highlight", "image",
+	"csv-table", "productionlist", "doctest", "testcode", "testoutput", "graphviz", "digraph",
+}
+
+// rstProseArgumentDirectives take a title or the start of their content as their argument,
+// which is converted; other directives' arguments, such as languages, paths and signatures,
+// are left untouched
+var rstProseArgumentDirectives = []string{
+	"note", "warning", "tip", "important", "hint", "caution", "danger", "error", "attention",
+	"admonition", "topic", "sidebar", "rubric", "seealso", "todo",
+}
+
+// RSTProcessor converts the prose of reStructuredText documents, preserving directives,
+// roles, literal blocks and code
+type RSTProcessor struct{}
+
+// NewRSTProcessor creates a new reStructuredText processor
+func NewRSTProcessor() *RSTProcessor {
+	return &RSTProcessor{}
+}
+
+// IsRSTFile checks if a file extension indicates a reStructuredText document
+func IsRSTFile(filePath string) bool {
+	ext := strings.ToLower(filepath.Ext(filePath))
+	return ext == ".rst" || ext == ".rest"
+}
+
+// ProcessDocument converts text with convertProse, except for:
+//
+//   - directive names, arguments and options, which are left as they are, apart from the
+//     titles of admonitions such as ".. note::"; directive content is converted like the rest
+//     of the document
+//   - code-block, code and sourcecode directives, whose content is passed to convertCode with
+//     the language from their argument, so only its comments change
+//   - the content of directives that don't hold prose, such as raw, math and toctree, literal
+//     blocks introduced by "::", doctest blocks and tables, which are left as they are
+//   - hyperlink targets and substitution definitions, which are left as they are
+//   - roles, inline literals, interpreted text, references and URLs, which are left as they
+//     are; the text of hyperlinks with an embedded URL is converted
+//
+// Section title adornments are lengthened when a converted title outgrows them.
+func (p *RSTProcessor) ProcessDocument(text string, convertProse func(string) string, convertCode func(code, language string) string) string {
+	lines := strings.SplitAfter(text, "\n")
+	var result strings.Builder
+	literalIndent := -1 // indentation of the paragraph that ended with "::", while its block is pending
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		content := strings.TrimRight(line, "\r\n")
+		ending := line[len(content):]
+		trimmed := strings.TrimSpace(content)
+
+		if trimmed == "" {
+			result.WriteString(line)
+			continue
+		}
+
+		indent := rstIndent(content)
+		if literalIndent >= 0 && indent > literalIndent {
+			// A literal block is output exactly as written
+			end := rstBlockEnd(lines, i, literalIndent)
+			result.WriteString(strings.Join(lines[i:end], ""))
+			i = end - 1
+			literalIndent = -1
+			continue
+		}
+		literalIndent = -1
+
+		switch {
+		case rstDirectiveRegex.MatchString(content):
+			end := rstBlockEnd(lines, i+1, indent)
+			result.WriteString(p.processDirective(lines[i:end], convertProse, convertCode))
+			i = end - 1
+
+		case rstExplicitMarkupRegex.MatchString(content):
+			i = p.processExplicitMarkup(lines, i, &result, convertProse) - 1
+
+		case rstGridTableRegex.MatchString(content) || strings.HasPrefix(trimmed, ">>>"):
+			// Tables and doctest blocks are kept as they are up to the next blank line, as
+			// converting table cells would break their alignment
+			for ; i < len(lines) && strings.TrimSpace(lines[i]) != ""; i++ {
+				result.WriteString(lines[i])
+			}
+			i--
+
+		case rstSimpleTableRegex.MatchString(content):
+			end := rstSimpleTableEnd(lines, i)
+			result.WriteString(strings.Join(lines[i:end], ""))
+			i = end - 1
+
+		case isRSTAdornment(trimmed) && i+2 < len(lines) && isRSTTitle(lines[i+1]) &&
+			strings.TrimSpace(lines[i+2]) != "" && strings.TrimSpace(lines[i+2])[0] == trimmed[0] && isRSTAdornment(strings.TrimSpace(lines[i+2])):
+			// A section title with an overline and an underline
+			title := strings.TrimRight(lines[i+1], "\r\n")
+			converted := convertRSTTitle(title, convertProse)
+			result.WriteString(fitRSTAdornment(content, title, converted) + ending)
+			result.WriteString(converted + lines[i+1][len(title):])
+			underline := strings.TrimRight(lines[i+2], "\r\n")
+			result.WriteString(fitRSTAdornment(underline, title, converted) + lines[i+2][len(underline):])
+			i += 2
+
+		case indent == 0 && !isRSTAdornment(trimmed) && i+1 < len(lines) && isRSTUnderline(strings.TrimSpace(lines[i+1]), trimmed):
+			// A section title with an underline
+			converted := convertRSTTitle(content, convertProse)
+			underline := strings.TrimRight(lines[i+1], "\r\n")
+			result.WriteString(converted + ending)
+			result.WriteString(fitRSTAdornment(underline, content, converted) + lines[i+1][len(underline):])
+			i++
+
+		default:
+			result.WriteString(convertRSTLine(content, convertProse) + ending)
+			if strings.HasSuffix(trimmed, "::") {
+				literalIndent = rstParagraphIndent(lines, i)
+			}
+		}
+	}
+
+	return result.String()
+}
+
+// processDirective converts a directive and its content, given as the directive line followed
+// by its indented lines
+func (p *RSTProcessor) processDirective(lines []string, convertProse func(string) string, convertCode func(code, language string) string) string {
+	first := strings.TrimRight(lines[0], "\r\n")
+	match := rstDirectiveRegex.FindStringSubmatchIndex(first)
+	name := strings.ToLower(first[match[4]:match[5]])
+	proseArgument := slices.Contains(rstProseArgumentDirectives, name)
+
+	var result strings.Builder
+	if proseArgument && match[6] >= 0 {
+		result.WriteString(first[:match[6]] + convertRSTLine(first[match[6]:match[7]], convertProse) + first[match[7]:])
+		result.WriteString(lines[0][len(first):])
+	} else {
+		result.WriteString(lines[0])
+	}
+
+	// Options and any continuation of the argument run up to the first blank line
+	body := 1
+	for ; body < len(lines) && strings.TrimSpace(lines[body]) != ""; body++ {
+		option := strings.TrimRight(lines[body], "\r\n")
+		if proseArgument && !rstFieldRegex.MatchString(option) {
+			result.WriteString(convertRSTLine(option, convertProse) + lines[body][len(option):])
+		} else {
+			result.WriteString(lines[body])
+		}
+	}
+
+	content := strings.Join(lines[body:], "")
+	switch {
+	case slices.Contains(rstCodeDirectives, name):
+		language := ""
+		if match[6] >= 0 {
+			if fields := strings.Fields(first[match[6]:match[7]]); len(fields) > 0 {
+				language = fields[0]
+			}
+		}
+		result.WriteString(convertCode(content, language))
+	case slices.Contains(rstLiteralDirectives, name) || strings.HasPrefix(name, "auto"):
+		result.WriteString(content)
+	default:
+		result.WriteString(p.ProcessDocument(content, convertProse, convertCode))
+	}
+
+	return result.String()
+}
+
+// processExplicitMarkup writes the explicit markup block starting at lines[start], other than a
+// directive, and returns the index of the line after it. Targets and substitution definitions
+// are kept, footnotes and citations keep their label, and comments are converted as prose.
+func (p *RSTProcessor) processExplicitMarkup(lines []string, start int, result *strings.Builder, convertProse func(string) string) int {
+	content := strings.TrimRight(lines[start], "\r\n")
+	match := rstExplicitMarkupRegex.FindStringSubmatchIndex(content)
+	if match[4] < 0 {
+		// An empty comment ends at the blank line that follows it
+		result.WriteString(lines[start])
+		return start + 1
+	}
+
+	text := content[match[4]:]
+	end := rstBlockEnd(lines, start+1, rstIndent(content))
+	switch {
+	case rstTargetRegex.MatchString(text):
+		result.WriteString(strings.Join(lines[start:end], ""))
+	case rstFootnoteRegex.MatchString(text):
+		label := match[4] + len(rstFootnoteRegex.FindString(text))
+		result.WriteString(content[:label] + convertRSTLine(content[label:], convertProse) + lines[start][len(content):])
+		return start + 1 // The footnote continues as ordinary indented text
+	default:
+		for _, line := range lines[start:end] {
+			comment := strings.TrimRight(line, "\r\n")
+			result.WriteString(convertProse(comment) + line[len(comment):])
+		}
+	}
+	return end
+}
+
+// rstIndent returns the width of a line's leading whitespace
+func rstIndent(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " \t"))
+}
+
+// rstBlockEnd returns the index just past the last line, from start on, that is indented more
+// than indent, stopping at the first non-blank line that isn't. Trailing blank lines are not
+// part of the block.
+func rstBlockEnd(lines []string, start, indent int) int {
+	end := start
+	for i := start; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == "" {
+			continue
+		}
+		if rstIndent(lines[i]) <= indent {
+			break
+		}
+		end = i + 1
+	}
+	return end
+}
+
+// rstParagraphIndent returns the indentation of the paragraph that lines[last] ends
+func rstParagraphIndent(lines []string, last int) int {
+	first := last
+	for first > 0 && strings.TrimSpace(lines[first-1]) != "" {
+		first--
+	}
+	return rstIndent(lines[first])
+}
+
+// rstSimpleTableEnd returns the index just past the simple table whose top border is
+// lines[start]: the first later border followed by a blank line or the end of the text
+func rstSimpleTableEnd(lines []string, start int) int {
+	for i := start + 1; i < len(lines); i++ {
+		if rstSimpleTableRegex.MatchString(strings.TrimRight(lines[i], "\r\n")) && (i+1 == len(lines) || strings.TrimSpace(lines[i+1]) == "") {
+			return i + 1
+		}
+	}
+	return start + 1
+}
+
+// isRSTAdornment reports whether a trimmed line is a section adornment or transition: at least
+// two of the same punctuation character
+func isRSTAdornment(line string) bool {
+	if len(line) < 2 || !strings.ContainsRune("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", rune(line[0])) {
+		return false
+	}
+	return strings.Count(line, line[:1]) == len(line)
+}
+
+// isRSTUnderline reports whether a trimmed line underlines title. As in docutils, an adornment
+// shorter than both the title and four characters, such as a "::" line, is ordinary text.
+func isRSTUnderline(line, title string) bool {
+	return isRSTAdornment(line) && (len(line) >= 4 || len(line) >= utf8.RuneCountInString(title))
+}
+
+// isRSTTitle reports whether a line can be a section title between adornments
+func isRSTTitle(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return trimmed != "" && !isRSTAdornment(trimmed)
+}
+
+// fitRSTAdornment lengthens an adornment that was at least as long as the original title so
+// that it's at least as long as the converted title, as shorter adornments are an error
+func fitRSTAdornment(adornment, original, converted string) string {
+	trimmed := strings.TrimRight(adornment, " \t")
+	originalWidth := utf8.RuneCountInString(strings.TrimSpace(original))
+	convertedWidth := utf8.RuneCountInString(strings.TrimSpace(converted))
+	if len(trimmed) < originalWidth || len(trimmed) >= convertedWidth {
+		return adornment
+	}
+	return strings.Repeat(trimmed[:1], convertedWidth+len(trimmed)-originalWidth)
+}
+
+// convertRSTTitle converts a section title. Titles are Title Case by convention, so the title is
+// converted as an "=" heading line, which keeps its words from being taken for proper nouns.
+func convertRSTTitle(title string, convertProse func(string) string) string {
+	converted := convertRSTLine("= "+title, convertProse)
+	if rest, ok := strings.CutPrefix(converted, "= "); ok {
+		return rest
+	}
+	return convertRSTLine(title, convertProse)
+}
+
+// convertRSTLine converts a line of prose, preserving its inline markup and any field name
+func convertRSTLine(line string, convertProse func(string) string) string {
+	if field := rstFieldRegex.FindString(line); field != "" {
+		return field + convertRSTLine(line[len(field):], convertProse)
+	}
+
+	matches := rstInlineRegex.FindAllStringSubmatchIndex(line, -1)
+	if len(matches) == 0 {
+		return convertProse(line)
+	}
+
+	var result strings.Builder
+	last := 0
+	for _, match := range matches {
+		start, end := match[0], match[1]
+		if start > last {
+			result.WriteString(convertProse(line[last:start]))
+		}
+		if match[2] >= 0 && match[3] > match[2] {
+			// Hyperlink with an embedded target: `text <url>`_
+			result.WriteString(line[start:match[2]] + convertProse(line[match[2]:match[3]]) + line[match[3]:end])
+		} else {
+			result.WriteString(line[start:end])
+		}
+		last = end
+	}
+	if last < len(line) {
+		result.WriteString(convertProse(line[last:]))
+	}
+
+	return result.String()
+}
@@ -0,0 +1,251 @@
+package converter
+
+import (
+	"strconv"
+	"strings"
+)
+
+// rtfSkipDestinations lists RTF destination groups that hold metadata or binary data rather
+// than visible document text, so their contents are never converted
+var rtfSkipDestinations = map[string]bool{
+	"fonttbl":    true,
+	"colortbl":   true,
+	"stylesheet": true,
+	"listtable":  true,
+	"info":       true,
+	"pict":       true,
+	"object":     true,
+	"themedata":  true,
+	"datastore":  true,
+	"generator":  true,
+	"fldinst":    true, // field instructions; the visible \fldrslt is still converted
+	"xmlnstbl":   true,
+	"rsidtbl":    true,
+}
+
+// rtfSegment is a piece of an RTF document: either raw markup passed through unchanged
+// or a run of visible text that can be converted
+type rtfSegment struct {
+	text    string
+	visible bool
+}
+
+// RTFProcessor handles conversion of RTF documents, converting only the visible text runs
+// and passing control words, control symbols and groups through untouched
+type RTFProcessor struct{}
+
+// NewRTFProcessor creates a new RTF processor
+func NewRTFProcessor() *RTFProcessor {
+	return &RTFProcessor{}
+}
+
+// IsRTF checks if the text looks like an RTF document
+func (rp *RTFProcessor) IsRTF(text string) bool {
+	return strings.HasPrefix(strings.TrimLeft(text, " \t\r\n\ufeff"), `{\rtf`)
+}
+
+// ProcessWithRTF converts the visible text of an RTF document using convertFunc,
+// writing back the original markup around each converted run
+func (rp *RTFProcessor) ProcessWithRTF(text string, convertFunc func(string) string) string {
+	if text == "" {
+		return text
+	}
+
+	var result strings.Builder
+	result.Grow(len(text))
+	for _, seg := range rp.tokenise(text) {
+		if !seg.visible {
+			result.WriteString(seg.text)
+			continue
+		}
+		result.WriteString(convertRTFRun(seg.text, convertFunc))
+	}
+	return result.String()
+}
+
+// ConvertRTF converts the visible text of an RTF document to British English,
+// leaving control words, control symbols and non-text destinations untouched
+func (c *Converter) ConvertRTF(text string, normaliseSmartQuotes bool) string {
+	return c.rtfProcessor.ProcessWithRTF(text, func(run string) string {
+		return c.ConvertToBritishWithIgnoreComments(run, normaliseSmartQuotes)
+	})
+}
+
+// convertRTFRun converts a visible text run, keeping its surrounding whitespace intact
+// as it is significant in RTF (e.g. the space between two runs)
+func convertRTFRun(run string, convertFunc func(string) string) string {
+	trimmed := strings.TrimSpace(run)
+	if trimmed == "" {
+		return run
+	}
+	start := strings.Index(run, trimmed)
+	return run[:start] + convertFunc(trimmed) + run[start+len(trimmed):]
+}
+
+// tokenise splits an RTF document into raw markup and visible text segments
+func (rp *RTFProcessor) tokenise(text string) []rtfSegment {
+	var segments []rtfSegment
+	var raw, visible strings.Builder
+
+	flushVisible := func() {
+		if visible.Len() > 0 {
+			segments = append(segments, rtfSegment{text: visible.String(), visible: true})
+			visible.Reset()
+		}
+	}
+	flushRaw := func() {
+		if raw.Len() > 0 {
+			segments = append(segments, rtfSegment{text: raw.String()})
+			raw.Reset()
+		}
+	}
+	appendRaw := func(s string) {
+		flushVisible()
+		raw.WriteString(s)
+	}
+
+	unicodeSkip := 1 // \ucN: number of fallback characters following each \uN
+	pendingSkip := 0 // fallback characters still to pass through as raw markup
+
+	for i := 0; i < len(text); {
+		ch := text[i]
+
+		switch {
+		case pendingSkip > 0 && ch != '\\' && ch != '{' && ch != '}':
+			appendRaw(text[i : i+1])
+			pendingSkip--
+			i++
+
+		case ch == '{':
+			if end, ok := rp.skippedGroupEnd(text, i); ok {
+				appendRaw(text[i:end])
+				i = end
+				continue
+			}
+			appendRaw("{")
+			i++
+
+		case ch == '}':
+			appendRaw("}")
+			i++
+
+		case ch == '\r' || ch == '\n':
+			// Line breaks are ignored by RTF readers and never part of the visible text
+			appendRaw(text[i : i+1])
+			i++
+
+		case ch == '\\':
+			word, param, end := readRTFControl(text, i)
+			appendRaw(text[i:end])
+			if pendingSkip > 0 {
+				// A control symbol such as \'e9 can act as the fallback character
+				pendingSkip--
+			}
+			switch word {
+			case "uc":
+				if n, err := strconv.Atoi(param); err == nil && n >= 0 {
+					unicodeSkip = n
+				}
+			case "u":
+				pendingSkip = unicodeSkip
+			}
+			i = end
+
+		default:
+			flushRaw()
+			visible.WriteByte(ch)
+			i++
+		}
+	}
+
+	flushVisible()
+	flushRaw()
+	return segments
+}
+
+// skippedGroupEnd reports whether the group starting at pos is a destination whose contents
+// are not visible text, returning the index just past its closing brace
+func (rp *RTFProcessor) skippedGroupEnd(text string, pos int) (int, bool) {
+	i := pos + 1
+	for i < len(text) && (text[i] == ' ' || text[i] == '\r' || text[i] == '\n') {
+		i++
+	}
+	if i >= len(text) || text[i] != '\\' {
+		return 0, false
+	}
+
+	// Ignorable destinations ({\*\destination ...}) are never visible text
+	word, _, _ := readRTFControl(text, i)
+	if word == "*" || rtfSkipDestinations[word] {
+		return findRTFGroupEnd(text, pos), true
+	}
+	return 0, false
+}
+
+// findRTFGroupEnd returns the index just past the brace closing the group opened at pos,
+// or the end of the text if the group is unterminated
+func findRTFGroupEnd(text string, pos int) int {
+	depth := 0
+	for i := pos; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++ // skip the escaped character so \{ and \} don't affect depth
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+	return len(text)
+}
+
+// readRTFControl reads the control word or control symbol starting at pos (a backslash).
+// It returns the control word name (or the symbol itself), its numeric parameter if any,
+// and the index just past the control, including a delimiting space.
+func readRTFControl(text string, pos int) (word, param string, end int) {
+	i := pos + 1
+	if i >= len(text) {
+		return "", "", len(text)
+	}
+
+	if !isASCIILetter(text[i]) {
+		// Control symbol: \\, \{, \}, \~, \-, \*, or hex escape \'hh
+		if text[i] == '\'' {
+			return "'", "", min(i+3, len(text))
+		}
+		return text[i : i+1], "", i + 1
+	}
+
+	start := i
+	for i < len(text) && isASCIILetter(text[i]) {
+		i++
+	}
+	word = text[start:i]
+
+	paramStart := i
+	if i < len(text) && text[i] == '-' {
+		i++
+	}
+	for i < len(text) && text[i] >= '0' && text[i] <= '9' {
+		i++
+	}
+	param = text[paramStart:i]
+	if param == "-" {
+		// A lone hyphen isn't a parameter
+		i = paramStart
+		param = ""
+	}
+
+	// A single space delimits the control word and belongs to it
+	if i < len(text) && text[i] == ' ' {
+		i++
+	}
+	return word, param, i
+}
+
+func isASCIILetter(b byte) bool {
+	return (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z')
+}
@@ -0,0 +1,99 @@
+// Package converter provides spelling variant handling for the -ise/-ize family of words
+package converter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SpellingVariant selects the British form used for words that British English accepts with
+// either -ise or -ize, such as organise/organize
+type SpellingVariant int
+
+const (
+	// SpellingISE converts to -ise and -yse (organise, analyse). This is the default.
+	SpellingISE SpellingVariant = iota
+	// SpellingIZE keeps -ize and -yze (organize, analyze)
+	SpellingIZE
+	// SpellingOxfordIZE follows Oxford spelling: -ize is kept but -yze becomes -yse
+	// (organize, analyse)
+	SpellingOxfordIZE
+)
+
+// String returns the name used for the variant on the command line
+func (v SpellingVariant) String() string {
+	switch v {
+	case SpellingIZE:
+		return "ize"
+	case SpellingOxfordIZE:
+		return "oxford"
+	default:
+		return "ise"
+	}
+}
+
+// ParseSpellingVariant parses a spelling variant name: ise, ize or oxford
+func ParseSpellingVariant(name string) (SpellingVariant, error) {
+	switch strings.ToLower(name) {
+	case "ise":
+		return SpellingISE, nil
+	case "ize":
+		return SpellingIZE, nil
+	case "oxford":
+		return SpellingOxfordIZE, nil
+	}
+	return SpellingISE, fmt.Errorf("unknown spelling variant %q (supported: ise, ize, oxford)", name)
+}
+
+// ApplySpellingVariant returns a copy of an American to British dictionary with the -ise/-ize
+// family resolved for variant. Entries whose British form only differs by -ise are dropped, so
+// the American -ize spelling is kept, while entries with other differences keep those
+// (colorize becomes colourize rather than colourise). Words with only one British form are
+// unaffected.
+func ApplySpellingVariant(dict map[string]string, variant SpellingVariant) map[string]string {
+	resolved := make(map[string]string, len(dict))
+	for american, british := range dict {
+		if zForm, ok := zSpelling(american, british, variant); ok {
+			if zForm == american {
+				continue
+			}
+			british = zForm
+		}
+		resolved[american] = british
+	}
+	return resolved
+}
+
+// zSpelling restores the z of an -ize (or, for SpellingIZE, -yze) suffix that the British form
+// spells with an s. It reports false when the variant converts to -ise or the pair isn't part
+// of the -ise/-ize family.
+func zSpelling(american, british string, variant SpellingVariant) (string, bool) {
+	if variant == SpellingISE {
+		return "", false
+	}
+
+	stems := []string{"iz"}
+	if variant == SpellingIZE {
+		stems = append(stems, "yz")
+	}
+
+	for _, stem := range stems {
+		idx := strings.LastIndex(american, stem)
+		if idx < 0 {
+			continue
+		}
+		// Only suffixes like -ize, -izes, -ization and -izable, not words like "parallelizm"
+		suffix := american[idx+2:]
+		if suffix != "" && !strings.ContainsRune("eai", rune(suffix[0])) {
+			continue
+		}
+
+		sForm := stem[:1] + "s" + suffix
+		if !strings.HasSuffix(british, sForm) {
+			continue
+		}
+		return strings.TrimSuffix(british, sForm) + stem + suffix, true
+	}
+
+	return "", false
+}
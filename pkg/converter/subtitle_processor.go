@@ -0,0 +1,213 @@
+// Package converter provides subtitle processing functionality for SubRip (.srt) and WebVTT (.vtt) captions
+package converter
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var (
+	// srtTimingRegex matches an SRT timing line such as "00:01:02,500 --> 00:01:04,000",
+	// optionally followed by display coordinates
+	srtTimingRegex = regexp.MustCompile(`^\d{2,}:[0-5]\d:[0-5]\d,\d{3} --> \d{2,}:[0-5]\d:[0-5]\d,\d{3}(?:\s.*)?$`)
+
+	// vttTimingRegex matches a WebVTT timing line such as "01:02.500 --> 01:04.000 align:start",
+	// where the hours are optional
+	vttTimingRegex = regexp.MustCompile(`^(?:\d{2,}:)?[0-5]\d:[0-5]\d\.\d{3}[ \t]+-->[ \t]+(?:\d{2,}:)?[0-5]\d:[0-5]\d\.\d{3}(?:[ \t].*)?$`)
+
+	// srtIdentifierRegex matches an SRT cue number
+	srtIdentifierRegex = regexp.MustCompile(`^\d+$`)
+
+	// subtitleTagRegex matches cue formatting: HTML-style tags such as <i>, <font color="..."> and
+	// <v Speaker>, WebVTT cue timestamps, and SSA override codes such as {\an8}
+	subtitleTagRegex = regexp.MustCompile(`<[^<>\n]*>|\{\\[^{}\n]*\}`)
+)
+
+// SubtitleWarning reports part of a subtitle file that couldn't be parsed and was left untouched
+type SubtitleWarning struct {
+	Line    int    // 1-based line number
+	Message string // description of the problem
+}
+
+// String formats the warning as "line N: message"
+func (w SubtitleWarning) String() string {
+	return fmt.Sprintf("line %d: %s", w.Line, w.Message)
+}
+
+// subtitleBlock is a run of non-blank lines, which is a cue or (in WebVTT) a header, note,
+// style or region block
+type subtitleBlock struct {
+	start int      // index of the first line in the file
+	lines []string // lines without their line endings
+}
+
+// subtitleClassifier returns the index of the first dialogue line in a block, or len(block.lines)
+// if the block has no dialogue, along with any problems found
+type subtitleClassifier func(block subtitleBlock, first bool) (int, []SubtitleWarning)
+
+// SRTProcessor converts the dialogue of SubRip (.srt) subtitles, leaving cue numbers and
+// timing lines exactly as they are
+type SRTProcessor struct{}
+
+// NewSRTProcessor creates a new SRT processor
+func NewSRTProcessor() *SRTProcessor {
+	return &SRTProcessor{}
+}
+
+// ProcessCues converts the text lines of each cue with convertFunc. Cues whose timing line is
+// malformed or missing are reported; their timing line, or the whole cue if it has no timing
+// line, is left untouched.
+func (p *SRTProcessor) ProcessCues(text string, convertFunc func(string) string) (string, []SubtitleWarning) {
+	return processSubtitles(text, convertFunc, p.classify)
+}
+
+// classify finds the dialogue of an SRT cue: an optional cue number, a timing line, then text
+func (p *SRTProcessor) classify(block subtitleBlock, _ bool) (int, []SubtitleWarning) {
+	i := 0
+	if srtIdentifierRegex.MatchString(subtitleLine(block.lines[0])) {
+		i++
+	}
+	if i == len(block.lines) {
+		return i, []SubtitleWarning{{Line: block.start + i, Message: "cue has no timing line"}}
+	}
+
+	timing := subtitleLine(block.lines[i])
+	switch {
+	case srtTimingRegex.MatchString(timing):
+		return i + 1, nil
+	case strings.Contains(timing, "-->") || i > 0:
+		// The line after a cue number can only be its timing line
+		return i + 1, []SubtitleWarning{{Line: block.start + i + 1, Message: fmt.Sprintf("malformed timestamp %q", timing)}}
+	default:
+		return len(block.lines), []SubtitleWarning{{Line: block.start + 1, Message: "cue has no timing line"}}
+	}
+}
+
+// VTTProcessor converts the cue text of WebVTT (.vtt) subtitles, leaving the WEBVTT header,
+// cue identifiers, timing lines and NOTE, STYLE and REGION blocks exactly as they are
+type VTTProcessor struct{}
+
+// NewVTTProcessor creates a new WebVTT processor
+func NewVTTProcessor() *VTTProcessor {
+	return &VTTProcessor{}
+}
+
+// ProcessCues converts the text lines of each cue with convertFunc. Cues whose timing line is
+// malformed or missing are reported; their timing line, or the whole cue if it has no timing
+// line, is left untouched.
+func (p *VTTProcessor) ProcessCues(text string, convertFunc func(string) string) (string, []SubtitleWarning) {
+	return processSubtitles(text, convertFunc, p.classify)
+}
+
+// classify finds the dialogue of a WebVTT cue: an optional identifier, a timing line, then text
+func (p *VTTProcessor) classify(block subtitleBlock, first bool) (int, []SubtitleWarning) {
+	firstLine := subtitleLine(block.lines[0])
+	var warnings []SubtitleWarning
+	if first {
+		if hasSubtitleKeyword(firstLine, "WEBVTT") {
+			return len(block.lines), nil
+		}
+		warnings = append(warnings, SubtitleWarning{Line: block.start + 1, Message: "missing WEBVTT header"})
+	}
+	for _, keyword := range []string{"NOTE", "STYLE", "REGION"} {
+		if hasSubtitleKeyword(firstLine, keyword) {
+			return len(block.lines), nil
+		}
+	}
+
+	for i, line := range block.lines {
+		timing := subtitleLine(line)
+		if !strings.Contains(timing, "-->") {
+			continue
+		}
+		if !vttTimingRegex.MatchString(timing) {
+			warnings = append(warnings, SubtitleWarning{Line: block.start + i + 1, Message: fmt.Sprintf("malformed timestamp %q", timing)})
+		}
+		return i + 1, warnings
+	}
+
+	return len(block.lines), append(warnings, SubtitleWarning{Line: block.start + 1, Message: "cue has no timing line"})
+}
+
+// hasSubtitleKeyword reports whether line is keyword on its own or followed by whitespace
+func hasSubtitleKeyword(line, keyword string) bool {
+	rest, ok := strings.CutPrefix(line, keyword)
+	return ok && (rest == "" || rest[0] == ' ' || rest[0] == '\t')
+}
+
+// subtitleLine strips the byte order mark and carriage return that can surround a line
+func subtitleLine(line string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(line, "\ufeff"), "\r")
+}
+
+// processSubtitles splits text into blank-line separated blocks and converts the dialogue lines
+// that classify identifies, keeping every other line and all line endings byte for byte
+func processSubtitles(text string, convertFunc func(string) string, classify subtitleClassifier) (string, []SubtitleWarning) {
+	lines := strings.Split(text, "\n")
+	dialogue := make([]bool, len(lines))
+	var warnings []SubtitleWarning
+
+	first := true
+	for i := 0; i < len(lines); {
+		if strings.TrimSpace(lines[i]) == "" {
+			i++
+			continue
+		}
+		block := subtitleBlock{start: i}
+		for i < len(lines) && strings.TrimSpace(lines[i]) != "" {
+			block.lines = append(block.lines, lines[i])
+			i++
+		}
+
+		textStart, blockWarnings := classify(block, first)
+		warnings = append(warnings, blockWarnings...)
+		for j := textStart; j < len(block.lines); j++ {
+			dialogue[block.start+j] = true
+		}
+		first = false
+	}
+
+	for i, line := range lines {
+		if !dialogue[i] {
+			continue
+		}
+		content, hasCR := strings.CutSuffix(line, "\r")
+		lines[i] = convertSubtitleText(content, convertFunc)
+		if hasCR {
+			lines[i] += "\r"
+		}
+	}
+
+	return strings.Join(lines, "\n"), warnings
+}
+
+// convertSubtitleText converts the text of a dialogue line between its formatting tags, so tag
+// names and attributes are never changed
+func convertSubtitleText(line string, convertFunc func(string) string) string {
+	tags := subtitleTagRegex.FindAllStringIndex(line, -1)
+	if len(tags) == 0 {
+		return convertFunc(line)
+	}
+
+	var result strings.Builder
+	last := 0
+	for _, tag := range tags {
+		if tag[0] > last {
+			result.WriteString(convertFunc(line[last:tag[0]]))
+		}
+		result.WriteString(line[tag[0]:tag[1]])
+		last = tag[1]
+	}
+	if last < len(line) {
+		result.WriteString(convertFunc(line[last:]))
+	}
+	return result.String()
+}
+
+// IsSubtitleFile checks if a file extension indicates an SRT or WebVTT subtitle file
+func IsSubtitleFile(filePath string) bool {
+	ext := strings.ToLower(filepath.Ext(filePath))
+	return ext == ".srt" || ext == ".vtt"
+}
@@ -0,0 +1,147 @@
+package converter
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Suggestion is a word that looks like an American spelling but isn't in the dictionary
+type Suggestion struct {
+	Word      string // word as it first appears in the text
+	Suggested string // likely British spelling
+	Rule      string // heuristic that flagged the word, e.g. "-ize → -ise"
+	Line      int    // line of the first occurrence (1-based)
+	Count     int    // number of occurrences (case-insensitive)
+}
+
+// suggestionRule maps an American suffix to its British equivalent. Longer suffixes come first
+// so "-izations" is matched before "-ize".
+type suggestionRule struct {
+	american string
+	british  string
+	rule     string
+}
+
+var suggestionRules = []suggestionRule{
+	{"izations", "isations", "-ize → -ise"},
+	{"ization", "isation", "-ize → -ise"},
+	{"izing", "ising", "-ize → -ise"},
+	{"izers", "isers", "-ize → -ise"},
+	{"izer", "iser", "-ize → -ise"},
+	{"ized", "ised", "-ize → -ise"},
+	{"izes", "ises", "-ize → -ise"},
+	{"ize", "ise", "-ize → -ise"},
+	{"yzing", "ysing", "-yze → -yse"},
+	{"yzers", "ysers", "-yze → -yse"},
+	{"yzer", "yser", "-yze → -yse"},
+	{"yzed", "ysed", "-yze → -yse"},
+	{"yzes", "yses", "-yze → -yse"},
+	{"yze", "yse", "-yze → -yse"},
+	{"orful", "ourful", "-or → -our"},
+	{"ors", "ours", "-or → -our"},
+	{"or", "our", "-or → -our"},
+}
+
+// izeExceptions are base words ending in -ize that are spelt the same in British English
+var izeExceptions = map[string]bool{
+	"size": true, "resize": true, "oversize": true, "undersize": true, "downsize": true,
+	"upsize": true, "supersize": true, "outsize": true, "bitesize": true, "capsize": true,
+	"seize": true, "prize": true, "baize": true, "maize": true, "assize": true,
+}
+
+// orExceptions are base words ending in -or that keep that ending in British English
+var orExceptions = map[string]bool{
+	"major": true, "minor": true, "junior": true, "senior": true, "superior": true, "inferior": true,
+	"interior": true, "exterior": true, "ulterior": true, "anterior": true, "posterior": true,
+	"prior": true, "warrior": true, "donor": true, "manor": true, "tenor": true, "mayor": true,
+	"anchor": true, "author": true, "corridor": true, "condor": true, "tremor": true, "stupor": true,
+	"torpor": true, "languor": true, "liquor": true, "pallor": true, "squalor": true, "vendor": true,
+	"ambassador": true, "matador": true, "sailor": true, "tailor": true, "councillor": true,
+	"chancellor": true, "bachelor": true, "governor": true, "survivor": true, "decor": true,
+}
+
+// orExcludedPrecedingLetters are letters before "or" that almost always mark an agent noun or
+// a word spelt the same in British English (actor, sponsor, error, floor, razor, meteor...)
+const orExcludedPrecedingLetters = "tsckxreoz"
+
+var suggestionWordRegex = regexp.MustCompile(`[A-Za-z]+`)
+
+// SuggestAmericanisms flags words that look like American spellings based on their endings
+// (-ize, -yze and the colour family's -or) but aren't in the dictionary, so they can be reviewed
+// and added. The text is not changed; suggestions are advisory and may include false positives.
+func (c *Converter) SuggestAmericanisms(text string) []Suggestion {
+	var suggestions []Suggestion
+	seen := make(map[string]int) // lowercase word -> index in suggestions
+
+	for lineIdx, line := range strings.Split(text, "\n") {
+		for _, word := range suggestionWordRegex.FindAllString(line, -1) {
+			lower := strings.ToLower(word)
+			if i, ok := seen[lower]; ok {
+				if i >= 0 {
+					suggestions[i].Count++
+				}
+				continue
+			}
+
+			suggested, rule, ok := c.suggestBritishSpelling(lower)
+			if !ok {
+				seen[lower] = -1
+				continue
+			}
+
+			seen[lower] = len(suggestions)
+			suggestions = append(suggestions, Suggestion{
+				Word:      word,
+				Suggested: matchCase(suggested, word),
+				Rule:      rule,
+				Line:      lineIdx + 1,
+				Count:     1,
+			})
+		}
+	}
+
+	return suggestions
+}
+
+// suggestBritishSpelling applies the suffix heuristics to a lowercase word
+func (c *Converter) suggestBritishSpelling(word string) (string, string, bool) {
+	// Words the dictionary already knows about are converted (or deliberately left alone),
+	// including -ize words kept by the spelling variant
+	if _, known := c.baseDict[word]; known {
+		return "", "", false
+	}
+
+	for _, r := range suggestionRules {
+		stem, found := strings.CutSuffix(word, r.american)
+		if !found || len(stem) < 2 {
+			continue
+		}
+
+		if strings.HasPrefix(r.american, "or") && !isLikelyAmericanOr(stem) {
+			return "", "", false
+		}
+		if strings.HasPrefix(r.american, "iz") && (izeExceptions[stem+"ize"] || c.spellingVariant != SpellingISE) {
+			return "", "", false
+		}
+		if strings.HasPrefix(r.american, "yz") && c.spellingVariant == SpellingIZE {
+			return "", "", false
+		}
+
+		suggested := stem + r.british
+		if suggested == word {
+			return "", "", false
+		}
+		return suggested, r.rule, true
+	}
+
+	return "", "", false
+}
+
+// isLikelyAmericanOr reports whether stem+"or" looks like a colour-family word (color, humor,
+// neighbor) rather than an agent noun or a word spelt the same in British English
+func isLikelyAmericanOr(stem string) bool {
+	if len(stem) < 2 || orExceptions[stem+"or"] {
+		return false
+	}
+	return !strings.ContainsRune(orExcludedPrecedingLetters, rune(stem[len(stem)-1]))
+}
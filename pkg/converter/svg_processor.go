@@ -0,0 +1,105 @@
+// Package converter provides SVG processing that converts text labels while preserving the drawing
+package converter
+
+import (
+	"html"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// svgTokenRegex matches, in document order, the markup of an SVG document: comments, CDATA
+// sections, processing instructions and declarations such as <?xml ...?> and <!DOCTYPE ...>, and
+// tags, with an element's name in group 1 (a closing tag starts with "</", and a self-closing
+// one ends with "/>"). The character data between tokens is text.
+var svgTokenRegex = regexp.MustCompile(`<!--[\s\S]*?-->|<!\[CDATA\[[\s\S]*?\]\]>|<\?[\s\S]*?\?>|<![^>]*>|</?([\w:.-]+)(?:"[^"]*"|'[^']*'|[^'">])*>`)
+
+// svgTextElements hold human-readable text: labels, and an element's title and description.
+// Text within them, including in nested tspan and textPath elements, is converted.
+var svgTextElements = []string{"text", "title", "desc"}
+
+// SVGProcessor converts the text labels of SVG drawings, leaving geometry, ids and styling alone
+type SVGProcessor struct{}
+
+// NewSVGProcessor creates a new SVG processor
+func NewSVGProcessor() *SVGProcessor {
+	return &SVGProcessor{}
+}
+
+// IsSVGFile checks if a file extension indicates an SVG drawing
+func IsSVGFile(filePath string) bool {
+	return strings.EqualFold(filepath.Ext(filePath), ".svg")
+}
+
+// ProcessDocument converts the character data of the text, title and desc elements in an SVG
+// document with convertFunc. Each element's text, across any tspan and textPath elements in it,
+// is converted as a whole so its words keep their context, and the result is spread back over
+// the pieces as for Word runs. Tags, attributes, the XML declaration, comments, CDATA sections,
+// styles and scripts are copied as they are, and only the text that changes is re-escaped.
+func (p *SVGProcessor) ProcessDocument(content string, convertFunc func(string) string) string {
+	var nodes []docxTextNode
+	var replacements []string
+	var group []docxTextNode
+	depth := 0 // nesting within the outermost text element; 0 outside one
+
+	flush := func() {
+		if len(group) > 0 {
+			replacements = append(replacements, convertDocxRuns(group, convertFunc)...)
+			nodes = append(nodes, group...)
+			group = nil
+		}
+	}
+
+	last := 0
+	for _, match := range svgTokenRegex.FindAllStringSubmatchIndex(content, -1) {
+		if depth > 0 && match[0] > last {
+			group = append(group, docxTextNode{
+				start: last,
+				end:   match[0],
+				text:  html.UnescapeString(content[last:match[0]]),
+			})
+		}
+		last = match[1]
+
+		if match[2] < 0 {
+			continue // comments, CDATA and declarations
+		}
+		tag := content[match[0]:match[1]]
+		name := content[match[2]:match[3]]
+		if i := strings.LastIndexByte(name, ':'); i >= 0 {
+			name = name[i+1:] // svg:text
+		}
+		switch {
+		case strings.HasSuffix(tag, "/>"):
+		case strings.HasPrefix(tag, "</"):
+			if depth > 0 {
+				depth--
+				if depth == 0 {
+					flush()
+				}
+			}
+		case depth > 0:
+			depth++
+		case slices.Contains(svgTextElements, name):
+			depth = 1
+		}
+	}
+	flush()
+
+	var result strings.Builder
+	last = 0
+	for i, node := range nodes {
+		if replacements[i] == node.text {
+			continue
+		}
+		result.WriteString(content[last:node.start])
+		result.WriteString(docxTextEscaper.Replace(replacements[i]))
+		last = node.end
+	}
+	if last == 0 {
+		return content
+	}
+	result.WriteString(content[last:])
+	return result.String()
+}
@@ -0,0 +1,48 @@
+// Package converter provides tidying of the spacing that conversion leaves behind
+package converter
+
+import (
+	"strings"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// SetTidyWhitespaceEnabled controls whether runs of spaces that conversion introduces into
+// prose, such as the double space left by a rewrite in "10 feet  wide", are folded into a single
+// space. Spacing already in the text is kept, so aligned tables and code are never touched.
+// Off by default.
+func (c *Converter) SetTidyWhitespaceEnabled(enabled bool) {
+	c.tidyWhitespace = enabled
+}
+
+// IsTidyWhitespaceEnabled reports whether runs of spaces introduced by conversion are folded
+func (c *Converter) IsTidyWhitespaceEnabled() bool {
+	return c.tidyWhitespace
+}
+
+// foldIntroducedSpaces folds each run of two or more spaces in converted that isn't in original
+// into a single space. The texts are diffed word by word, where each run of whitespace is a
+// single token, so a run is only folded when conversion inserted it. Runs that indent a line
+// are kept.
+func (c *Converter) foldIntroducedSpaces(original, converted string) string {
+	if !c.tidyWhitespace || original == converted || !strings.Contains(converted, "  ") {
+		return converted
+	}
+
+	var result strings.Builder
+	for _, diff := range DiffWords(original, converted) {
+		switch diff.Type {
+		case diffmatchpatch.DiffEqual:
+			result.WriteString(diff.Text)
+		case diffmatchpatch.DiffInsert:
+			for _, token := range wordTokenRegex.FindAllString(diff.Text, -1) {
+				atLineStart := result.Len() == 0 || strings.HasSuffix(result.String(), "\n")
+				if len(token) > 1 && strings.Trim(token, " ") == "" && !atLineStart {
+					token = " "
+				}
+				result.WriteString(token)
+			}
+		}
+	}
+	return result.String()
+}
@@ -13,6 +13,13 @@ type UnitConfig struct {
 	// Global enable/disable flag
 	Enabled bool `json:"enabled"`
 
+	// Tidy the spacing and symbols of metric units already in the text, independently of Enabled
+	NormaliseUnits bool `json:"normaliseUnits,omitempty"`
+
+	// Convert US cooking measures (cups, tablespoons, teaspoons and sticks of butter) too. They're
+	// left alone by default since "a cup" is as often a drink as a measure.
+	CookingMeasures bool `json:"cookingMeasures,omitempty"`
+
 	// Unit type specific settings
 	EnabledUnitTypes []UnitType `json:"enabledUnitTypes"`
 
@@ -22,6 +29,11 @@ type UnitConfig struct {
 	// Custom unit mappings (American -> British)
 	CustomMappings map[string]string `json:"customMappings"`
 
+	// Units to convert to in place of the automatic choice, keyed by American unit, optionally
+	// with a lower bound on its value: {"pounds >= 100": "stone", "miles": "keep"}. The entry
+	// with the highest bound the value passes wins.
+	PreferredTargets map[string]string `json:"preferredTargets,omitempty"`
+
 	// Patterns to exclude from conversion (regex patterns)
 	ExcludePatterns []string `json:"excludePatterns"`
 
@@ -30,6 +42,12 @@ type UnitConfig struct {
 
 	// Detection settings
 	Detection DetectionConfig `json:"detection"`
+
+	// Spelling variant for -ise/-ize words: "ise", "ize" or "oxford". Empty keeps the default.
+	SpellingVariant string `json:"spellingVariant,omitempty"`
+
+	// Dictionary words that are never converted (American spellings, matched case-insensitively)
+	ExcludedWords []string `json:"excludedWords,omitempty"`
 }
 
 // DetectionConfig holds configuration for unit detection
@@ -57,6 +75,8 @@ func GetDefaultUnitConfig() *UnitConfig {
 			Volume,
 			Temperature,
 			Area,
+			Speed,
+			Pressure,
 		},
 		Precision: map[string]int{
 			"length":      1,
@@ -64,6 +84,8 @@ func GetDefaultUnitConfig() *UnitConfig {
 			"volume":      1,
 			"temperature": 0,
 			"area":        1,
+			"speed":       0,
+			"pressure":    0,
 		},
 		CustomMappings: make(map[string]string),
 		ExcludePatterns: []string{
@@ -100,6 +122,12 @@ func ValidateConfig(config *UnitConfig) error {
 		return fmt.Errorf("config cannot be nil")
 	}
 
+	if config.SpellingVariant != "" {
+		if _, err := ParseSpellingVariant(config.SpellingVariant); err != nil {
+			return err
+		}
+	}
+
 	// Validate enabled unit types
 	validUnitTypes := map[UnitType]bool{
 		Length:      true,
@@ -107,6 +135,8 @@ func ValidateConfig(config *UnitConfig) error {
 		Volume:      true,
 		Temperature: true,
 		Area:        true,
+		Speed:       true,
+		Pressure:    true,
 	}
 
 	for _, unitType := range config.EnabledUnitTypes {
@@ -115,6 +145,10 @@ func ValidateConfig(config *UnitConfig) error {
 		}
 	}
 
+	if _, err := parsePreferredTargets(config.PreferredTargets); err != nil {
+		return err
+	}
+
 	// Validate precision values
 	for unitTypeStr, precision := range config.Precision {
 		if precision < 0 || precision > 10 {
@@ -140,6 +174,22 @@ func ValidateConfig(config *UnitConfig) error {
 		return fmt.Errorf("roundingThreshold must be between 0.0 and 1.0, got %f", config.Preferences.RoundingThreshold)
 	}
 
+	switch config.Preferences.RoundingStrategy {
+	case RoundingDefault, RoundingNearest, RoundingSignificant, RoundingBankers:
+	default:
+		return fmt.Errorf("invalid rounding strategy %q (valid: nearest, significant, bankers)", config.Preferences.RoundingStrategy)
+	}
+
+	switch config.Preferences.NumberFormat {
+	case "", NumberFormatPlain, NumberFormatGroupedComma, NumberFormatGroupedSpace:
+	default:
+		return fmt.Errorf("invalid number format %q (valid: plain, grouped-comma, grouped-space)", config.Preferences.NumberFormat)
+	}
+
+	if config.Preferences.SignificantFigures < 0 || config.Preferences.SignificantFigures > 15 {
+		return fmt.Errorf("significantFigures must be between 1 and 15, got %d", config.Preferences.SignificantFigures)
+	}
+
 	// Validate temperature format
 	validTempFormats := map[string]bool{
 		"°C":              true,
@@ -205,6 +255,10 @@ func (c *UnitConfig) unitTypeToString(unitType UnitType) string {
 		return "temperature"
 	case Area:
 		return "area"
+	case Speed:
+		return "speed"
+	case Pressure:
+		return "pressure"
 	default:
 		return "unknown"
 	}
@@ -223,6 +277,10 @@ func stringToUnitType(s string) UnitType {
 		return Temperature
 	case "area":
 		return Area
+	case "speed":
+		return Speed
+	case "pressure":
+		return Pressure
 	default:
 		return Length // Default fallback
 	}
@@ -239,37 +297,72 @@ func (c *UnitConfig) MarshalJSON() ([]byte, error) {
 	// Create a temporary struct for JSON marshaling
 	temp := struct {
 		Enabled          bool                  `json:"enabled"`
+		NormaliseUnits   bool                  `json:"normaliseUnits,omitempty"`
+		CookingMeasures  bool                  `json:"cookingMeasures,omitempty"`
 		EnabledUnitTypes []string              `json:"enabledUnitTypes"`
 		Precision        map[string]int        `json:"precision"`
 		CustomMappings   map[string]string     `json:"customMappings"`
+		PreferredTargets map[string]string     `json:"preferredTargets,omitempty"`
 		ExcludePatterns  []string              `json:"excludePatterns"`
 		Preferences      ConversionPreferences `json:"preferences"`
 		Detection        DetectionConfig       `json:"detection"`
+		SpellingVariant  string                `json:"spellingVariant,omitempty"`
+		ExcludedWords    []string              `json:"excludedWords,omitempty"`
 	}{
 		Enabled:          c.Enabled,
+		NormaliseUnits:   c.NormaliseUnits,
+		CookingMeasures:  c.CookingMeasures,
 		EnabledUnitTypes: enabledTypes,
 		Precision:        c.Precision,
 		CustomMappings:   c.CustomMappings,
+		PreferredTargets: c.PreferredTargets,
 		ExcludePatterns:  c.ExcludePatterns,
 		Preferences:      c.Preferences,
 		Detection:        c.Detection,
+		SpellingVariant:  c.SpellingVariant,
+		ExcludedWords:    c.ExcludedWords,
 	}
 
 	return json.Marshal(temp)
 }
 
-// UnmarshalJSON implements custom JSON unmarshaling for UnitConfig
+// UnmarshalJSON implements custom JSON unmarshaling for UnitConfig. Like the standard decoder,
+// fields missing from data keep their current values, so a partial config can be decoded over
+// an existing one.
 func (c *UnitConfig) UnmarshalJSON(data []byte) error {
-	// Create a temporary struct for JSON unmarshaling
+	currentTypes := make([]string, len(c.EnabledUnitTypes))
+	for i, unitType := range c.EnabledUnitTypes {
+		currentTypes[i] = c.unitTypeToString(unitType)
+	}
+
+	// Create a temporary struct for JSON unmarshaling, starting from the current values
 	temp := struct {
 		Enabled          bool                  `json:"enabled"`
+		NormaliseUnits   bool                  `json:"normaliseUnits"`
+		CookingMeasures  bool                  `json:"cookingMeasures"`
 		EnabledUnitTypes []string              `json:"enabledUnitTypes"`
 		Precision        map[string]int        `json:"precision"`
 		CustomMappings   map[string]string     `json:"customMappings"`
+		PreferredTargets map[string]string     `json:"preferredTargets"`
 		ExcludePatterns  []string              `json:"excludePatterns"`
 		Preferences      ConversionPreferences `json:"preferences"`
 		Detection        DetectionConfig       `json:"detection"`
-	}{}
+		SpellingVariant  string                `json:"spellingVariant"`
+		ExcludedWords    []string              `json:"excludedWords"`
+	}{
+		Enabled:          c.Enabled,
+		NormaliseUnits:   c.NormaliseUnits,
+		CookingMeasures:  c.CookingMeasures,
+		EnabledUnitTypes: currentTypes,
+		Precision:        c.Precision,
+		CustomMappings:   c.CustomMappings,
+		PreferredTargets: c.PreferredTargets,
+		ExcludePatterns:  c.ExcludePatterns,
+		Preferences:      c.Preferences,
+		Detection:        c.Detection,
+		SpellingVariant:  c.SpellingVariant,
+		ExcludedWords:    c.ExcludedWords,
+	}
 
 	if err := json.Unmarshal(data, &temp); err != nil {
 		return err
@@ -283,12 +376,17 @@ func (c *UnitConfig) UnmarshalJSON(data []byte) error {
 
 	// Assign values to the config
 	c.Enabled = temp.Enabled
+	c.NormaliseUnits = temp.NormaliseUnits
+	c.CookingMeasures = temp.CookingMeasures
 	c.EnabledUnitTypes = enabledTypes
 	c.Precision = temp.Precision
 	c.CustomMappings = temp.CustomMappings
+	c.PreferredTargets = temp.PreferredTargets
 	c.ExcludePatterns = temp.ExcludePatterns
 	c.Preferences = temp.Preferences
 	c.Detection = temp.Detection
+	c.SpellingVariant = temp.SpellingVariant
+	c.ExcludedWords = temp.ExcludedWords
 
 	return nil
 }
@@ -297,17 +395,24 @@ func (c *UnitConfig) UnmarshalJSON(data []byte) error {
 func (c *UnitConfig) Clone() *UnitConfig {
 	clone := &UnitConfig{
 		Enabled:          c.Enabled,
+		NormaliseUnits:   c.NormaliseUnits,
+		CookingMeasures:  c.CookingMeasures,
 		EnabledUnitTypes: make([]UnitType, len(c.EnabledUnitTypes)),
 		Precision:        make(map[string]int),
 		CustomMappings:   make(map[string]string),
 		ExcludePatterns:  make([]string, len(c.ExcludePatterns)),
 		Preferences:      c.Preferences, // ConversionPreferences is a value type, so this is fine
 		Detection:        c.Detection,   // DetectionConfig is a value type, so this is fine
+		SpellingVariant:  c.SpellingVariant,
 	}
 
 	// Deep copy slices and maps
 	copy(clone.EnabledUnitTypes, c.EnabledUnitTypes)
 	copy(clone.ExcludePatterns, c.ExcludePatterns)
+	if c.ExcludedWords != nil {
+		clone.ExcludedWords = make([]string, len(c.ExcludedWords))
+		copy(clone.ExcludedWords, c.ExcludedWords)
+	}
 
 	for k, v := range c.Precision {
 		clone.Precision[k] = v
@@ -317,6 +422,13 @@ func (c *UnitConfig) Clone() *UnitConfig {
 		clone.CustomMappings[k] = v
 	}
 
+	if c.PreferredTargets != nil {
+		clone.PreferredTargets = make(map[string]string, len(c.PreferredTargets))
+		for k, v := range c.PreferredTargets {
+			clone.PreferredTargets[k] = v
+		}
+	}
+
 	return clone
 }
 
@@ -328,6 +440,8 @@ func (c *UnitConfig) Merge(other *UnitConfig) {
 
 	// Merge simple fields (other takes precedence)
 	c.Enabled = other.Enabled
+	c.NormaliseUnits = other.NormaliseUnits
+	c.CookingMeasures = other.CookingMeasures
 
 	// Merge enabled unit types (replace entirely)
 	if len(other.EnabledUnitTypes) > 0 {
@@ -351,6 +465,14 @@ func (c *UnitConfig) Merge(other *UnitConfig) {
 		c.CustomMappings[k] = v
 	}
 
+	// Merge preferred targets (other overrides)
+	if len(other.PreferredTargets) > 0 && c.PreferredTargets == nil {
+		c.PreferredTargets = make(map[string]string)
+	}
+	for k, v := range other.PreferredTargets {
+		c.PreferredTargets[k] = v
+	}
+
 	// Merge exclude patterns (replace entirely)
 	if len(other.ExcludePatterns) > 0 {
 		c.ExcludePatterns = make([]string, len(other.ExcludePatterns))
@@ -360,6 +482,16 @@ func (c *UnitConfig) Merge(other *UnitConfig) {
 	// Merge preferences and detection config (replace entirely)
 	c.Preferences = other.Preferences
 	c.Detection = other.Detection
+
+	if other.SpellingVariant != "" {
+		c.SpellingVariant = other.SpellingVariant
+	}
+
+	// Merge excluded words (replace entirely)
+	if len(other.ExcludedWords) > 0 {
+		c.ExcludedWords = make([]string, len(other.ExcludedWords))
+		copy(c.ExcludedWords, other.ExcludedWords)
+	}
 }
 
 // GetUserConfigPath returns the path to the user's unit configuration file
@@ -501,23 +633,28 @@ func CreateExampleUserConfig() error {
   "_description": "This file controls how imperial units are converted to metric units",
   "_examples": {
     "enabled": "Set to false to disable all unit conversion",
-    "enabledUnitTypes": "Array of unit types to convert: length, mass, volume, temperature, area",
+    "normaliseUnits": "Tidy metric units already in the text ('5kg' -> '5 kg', '10KM' -> '10 km') without converting anything",
+    "enabledUnitTypes": "Array of unit types to convert: length, mass, volume, temperature, area, speed, pressure",
     "precision": "Decimal places for each unit type",
     "customMappings": "Custom unit mappings (American -> British)",
+    "preferredTargets": "Units to convert to instead of the automatic choice, e.g. {\"pounds >= 100\": \"stone\", \"miles\": \"keep\"}",
     "excludePatterns": "Regex patterns to exclude from conversion (for idiomatic expressions)",
     "preferences": {
       "preferWholeNumbers": "Round to whole numbers when close (e.g., 2.98 -> 3)",
       "maxDecimalPlaces": "Maximum decimal places to show",
       "temperatureFormat": "Format for temperature: '°C' or 'degrees Celsius'",
       "useSpaceBetweenValueAndUnit": "Add space between number and unit: '5 kg' vs '5kg'",
-      "roundingThreshold": "How close to whole number before rounding (0.1 = within 10%)"
+      "roundingThreshold": "How close to whole number before rounding (0.1 = within 10%)",
+      "keepOriginal": "Keep the original measurement with the metric value in parentheses: '10 feet (3 metres)'"
     },
     "detection": {
       "minConfidence": "Minimum confidence (0.0-1.0) to convert a detected unit",
       "maxNumberDistance": "Maximum words between number and unit (1-10)",
       "detectCompoundUnits": "Detect compound units like '6-foot fence'",
       "detectWrittenNumbers": "Detect written numbers like 'five feet'"
-    }
+    },
+    "spellingVariant": "Optional -ise/-ize preference: 'ise', 'ize' or 'oxford'",
+    "excludedWords": "Optional list of American spellings to never convert"
   },
 ` + string(configJSON)[1:] // Remove the opening brace since we added our own
 
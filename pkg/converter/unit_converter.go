@@ -4,6 +4,9 @@ package converter
 import (
 	"fmt"
 	"math"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/martinlindhe/unit"
 )
@@ -17,6 +20,8 @@ const (
 	Volume
 	Temperature
 	Area
+	Speed
+	Pressure
 )
 
 // UnitMatch represents a detected unit in text
@@ -29,6 +34,21 @@ type UnitMatch struct {
 	Context    string
 	Confidence float64
 	IsCompound bool // true if this is a compound unit like "6-foot"
+
+	// Ranges such as "20-30°F", where Value holds the upper bound
+	IsRange        bool
+	RangeLow       float64
+	RangeSeparator string // separator as written, e.g. "-", " to "
+
+	// Dimensions such as "12 ft × 8 ft" or "3x4 feet", where Value holds the largest component
+	IsDimension         bool
+	DimensionValues     []float64 // each component, in order
+	DimensionSeparators []string  // separators as written between components, e.g. " × ", "x", " by "
+	DimensionUnitEach   bool      // true if every component was written with the unit
+
+	// IsSpelledOut is true for a quantity written in words rather than a number, such as
+	// "a foot" or "half a pound"
+	IsSpelledOut bool
 }
 
 // ConversionResult represents the result of a unit conversion
@@ -39,14 +59,49 @@ type ConversionResult struct {
 	Confidence  float64
 }
 
+// RoundingStrategy selects how converted values are rounded
+type RoundingStrategy string
+
+const (
+	// RoundingDefault rounds to the unit type's precision, preferring whole numbers within
+	// RoundingThreshold and dropping decimal places that add little
+	RoundingDefault RoundingStrategy = ""
+	// RoundingNearest rounds to the unit type's precision, halves away from zero (2.25 → 2.3)
+	RoundingNearest RoundingStrategy = "nearest"
+	// RoundingSignificant rounds to SignificantFigures significant figures (1609.3 → 1600)
+	RoundingSignificant RoundingStrategy = "significant"
+	// RoundingBankers rounds to the unit type's precision, halves to the even digit (2.25 → 2.2)
+	RoundingBankers RoundingStrategy = "bankers"
+)
+
+// NumberFormat selects how the whole part of converted values is grouped
+type NumberFormat string
+
+const (
+	// NumberFormatPlain leaves digits ungrouped (1609 m). This is the default, and an empty
+	// NumberFormat means the same.
+	NumberFormatPlain NumberFormat = "plain"
+	// NumberFormatGroupedComma groups thousands with commas (1,609 m)
+	NumberFormatGroupedComma NumberFormat = "grouped-comma"
+	// NumberFormatGroupedSpace groups thousands with thin spaces as SI recommends (1 609 m)
+	NumberFormatGroupedSpace NumberFormat = "grouped-space"
+)
+
+// defaultSignificantFigures is used by RoundingSignificant when SignificantFigures isn't set
+const defaultSignificantFigures = 2
+
 // ConversionPreferences holds user preferences for unit conversion
 type ConversionPreferences struct {
 	PreferWholeNumbers          bool
 	MaxDecimalPlaces            int
 	UseLocalizedUnits           bool
-	TemperatureFormat           string  // "°C" or "degrees Celsius"
-	UseSpaceBetweenValueAndUnit bool    // true: "5 kg", false: "5kg"
-	RoundingThreshold           float64 // threshold for considering a value "close to whole" (default: 0.05)
+	TemperatureFormat           string           // "°C" or "degrees Celsius"
+	UseSpaceBetweenValueAndUnit bool             // true: "5 kg", false: "5kg"
+	RoundingThreshold           float64          // threshold for considering a value "close to whole" (default: 0.05)
+	KeepOriginal                bool             // true: "10 feet (3 metres)", false: "3 metres"
+	RoundingStrategy            RoundingStrategy // how values are rounded (default: RoundingDefault)
+	SignificantFigures          int              // figures kept by RoundingSignificant (default: 2)
+	NumberFormat                NumberFormat     // how thousands are grouped (default: NumberFormatPlain)
 }
 
 // UnitConverter interface defines the contract for unit conversion
@@ -58,8 +113,9 @@ type UnitConverter interface {
 
 // BasicUnitConverter implements the UnitConverter interface using martinlindhe/unit
 type BasicUnitConverter struct {
-	precision   map[UnitType]int
-	preferences ConversionPreferences
+	precision        map[UnitType]int
+	preferences      ConversionPreferences
+	preferredTargets []preferredTarget // units chosen in UnitConfig.PreferredTargets
 }
 
 // NewBasicUnitConverter creates a new BasicUnitConverter with default settings
@@ -71,6 +127,8 @@ func NewBasicUnitConverter() *BasicUnitConverter {
 			Volume:      1, // 1 decimal place for volume
 			Temperature: 0, // whole numbers for temperature
 			Area:        1, // 1 decimal place for area
+			Speed:       0, // whole numbers for speed
+			Pressure:    0, // whole numbers for pressure
 		},
 		preferences: ConversionPreferences{
 			PreferWholeNumbers:          true, // Changed back to true for better formatting
@@ -100,6 +158,16 @@ func (c *BasicUnitConverter) GetPreferences() ConversionPreferences {
 
 // Convert converts a unit match to metric equivalent
 func (c *BasicUnitConverter) Convert(match UnitMatch) (ConversionResult, error) {
+	// A spelled-out quantity is a rough one, so unless a rounding strategy is chosen its
+	// conversion is given to two significant figures ("half a pound" → "230 g", not "226.8 g")
+	if match.IsSpelledOut && c.preferences.RoundingStrategy == RoundingDefault {
+		approximate := *c
+		approximate.preferences.RoundingStrategy = RoundingSignificant
+		approximate.preferences.SignificantFigures = defaultSignificantFigures
+		match.IsSpelledOut = false
+		return approximate.Convert(match)
+	}
+
 	switch match.UnitType {
 	case Length:
 		return c.convertLength(match)
@@ -111,6 +179,10 @@ func (c *BasicUnitConverter) Convert(match UnitMatch) (ConversionResult, error)
 		return c.convertTemperature(match)
 	case Area:
 		return c.convertArea(match)
+	case Speed:
+		return c.convertSpeed(match)
+	case Pressure:
+		return c.convertPressure(match)
 	default:
 		return ConversionResult{}, fmt.Errorf("unsupported unit type: %v", match.UnitType)
 	}
@@ -118,6 +190,10 @@ func (c *BasicUnitConverter) Convert(match UnitMatch) (ConversionResult, error)
 
 // convertLength converts imperial length units to metric
 func (c *BasicUnitConverter) convertLength(match UnitMatch) (ConversionResult, error) {
+	if match.IsDimension {
+		return c.convertDimension(match)
+	}
+
 	var metricValue float64
 	var metricUnit string
 
@@ -141,6 +217,7 @@ func (c *BasicUnitConverter) convertLength(match UnitMatch) (ConversionResult, e
 	default:
 		return ConversionResult{}, fmt.Errorf("unsupported length unit: %s", match.Unit)
 	}
+	metricUnit = c.preferredUnit(match, metricUnit)
 
 	// Adjust value based on selected unit
 	metricValue = c.adjustValueForUnit(metricValue, metricUnit)
@@ -155,6 +232,52 @@ func (c *BasicUnitConverter) convertLength(match UnitMatch) (ConversionResult, e
 	}, nil
 }
 
+// convertDimension converts every component of a dimension to the metric unit that suits the
+// smallest one, so "3 ft x 4 ft" becomes "91.4 cm x 121.9 cm" rather than mixing centimetres and
+// metres. Components written without the unit stay that way ("3x4 feet" → "91.4x121.9 cm"), and
+// a hyphenated unit stays hyphenated ("10 x 12-foot" → "3 x 3.7-metre").
+func (c *BasicUnitConverter) convertDimension(match UnitMatch) (ConversionResult, error) {
+	var perUnit unit.Length
+	switch match.Unit {
+	case "feet":
+		perUnit = unit.Foot
+	case "inches":
+		perUnit = unit.Inch
+	case "yards":
+		perUnit = unit.Yard
+	default:
+		return ConversionResult{}, fmt.Errorf("unsupported dimension unit: %s", match.Unit)
+	}
+	if len(match.DimensionSeparators) != len(match.DimensionValues)-1 {
+		return ConversionResult{}, fmt.Errorf("dimension has %d values but %d separators", len(match.DimensionValues), len(match.DimensionSeparators))
+	}
+
+	smallest := (unit.Length(slices.Min(match.DimensionValues)) * perUnit).Meters()
+	metricUnit := c.preferredUnit(match, c.selectLengthUnit(smallest, match.IsCompound, match.Unit))
+
+	var formatted strings.Builder
+	for i, value := range match.DimensionValues {
+		metric := c.adjustValueForUnit((unit.Length(value) * perUnit).Meters(), metricUnit)
+		component := c.formatValue(metric, Length, metricUnit)
+		if !match.DimensionUnitEach && i < len(match.DimensionValues)-1 {
+			component = strings.TrimSpace(strings.TrimSuffix(component, metricUnit))
+		} else if match.IsCompound {
+			component = strings.TrimSpace(strings.TrimSuffix(component, metricUnit)) + "-" + metricUnit
+		}
+		if i > 0 {
+			formatted.WriteString(match.DimensionSeparators[i-1])
+		}
+		formatted.WriteString(component)
+	}
+
+	return ConversionResult{
+		MetricValue: c.adjustValueForUnit((unit.Length(match.Value) * perUnit).Meters(), metricUnit),
+		MetricUnit:  metricUnit,
+		Formatted:   formatted.String(),
+		Confidence:  match.Confidence,
+	}, nil
+}
+
 // convertMass converts imperial mass units to metric
 func (c *BasicUnitConverter) convertMass(match UnitMatch) (ConversionResult, error) {
 	var metricValue float64
@@ -174,10 +297,24 @@ func (c *BasicUnitConverter) convertMass(match UnitMatch) (ConversionResult, err
 		kg := unit.Mass(match.Value) * unit.ShortHundredweight * 20 // 20 short hundredweight = 1 short ton
 		metricValue = kg.Kilograms()
 		metricUnit = c.selectMassUnit(metricValue)
+	case "sticks", "stick":
+		// US stick of butter, a quarter of a pound
+		metricValue = match.Value * butterStickKilograms
+		metricUnit = c.selectMassUnit(metricValue)
 	default:
 		return ConversionResult{}, fmt.Errorf("unsupported mass unit: %s", match.Unit)
 	}
 
+	if metricUnit = c.preferredUnit(match, metricUnit); metricUnit == "stone" {
+		stones, formatted := c.formatStone(metricValue)
+		return ConversionResult{
+			MetricValue: stones,
+			MetricUnit:  metricUnit,
+			Formatted:   formatted,
+			Confidence:  match.Confidence,
+		}, nil
+	}
+
 	// Adjust value based on selected unit
 	metricValue = c.adjustValueForUnit(metricValue, metricUnit)
 
@@ -215,8 +352,15 @@ func (c *BasicUnitConverter) convertVolume(match UnitMatch) (ConversionResult, e
 		metricValue = litres.Liters()
 		metricUnit = c.selectVolumeUnit(metricValue)
 	default:
-		return ConversionResult{}, fmt.Errorf("unsupported volume unit: %s", match.Unit)
+		// US cooking measures: cups, tablespoons and teaspoons
+		litres, ok := cookingVolumeLitres[match.Unit]
+		if !ok {
+			return ConversionResult{}, fmt.Errorf("unsupported volume unit: %s", match.Unit)
+		}
+		metricValue = match.Value * litres
+		metricUnit = c.selectVolumeUnit(metricValue)
 	}
+	metricUnit = c.preferredUnit(match, metricUnit)
 
 	// Adjust value based on selected unit
 	metricValue = c.adjustValueForUnit(metricValue, metricUnit)
@@ -240,6 +384,10 @@ func (c *BasicUnitConverter) convertTemperature(match UnitMatch) (ConversionResu
 
 		formatted := c.formatValue(metricValue, Temperature, c.preferences.TemperatureFormat)
 
+		if match.IsRange {
+			formatted = c.formatTemperatureRange(match, metricValue, formatted)
+		}
+
 		return ConversionResult{
 			MetricValue: metricValue,
 			MetricUnit:  "°C",
@@ -251,13 +399,28 @@ func (c *BasicUnitConverter) convertTemperature(match UnitMatch) (ConversionResu
 	}
 }
 
+// formatTemperatureRange prefixes the converted upper bound with the converted lower bound,
+// keeping the original separator unless a bare hyphen would read as a minus sign
+func (c *BasicUnitConverter) formatTemperatureRange(match UnitMatch, high float64, formattedHigh string) string {
+	low := unit.FromFahrenheit(match.RangeLow).Celsius()
+	unitSuffix := c.preferences.TemperatureFormat
+	formattedLow := strings.TrimSpace(strings.TrimSuffix(c.formatValue(low, Temperature, unitSuffix), unitSuffix))
+
+	separator := match.RangeSeparator
+	if strings.TrimSpace(separator) == "-" && (low < 0 || high < 0) {
+		separator = " to "
+	}
+
+	return formattedLow + separator + formattedHigh
+}
+
 // convertArea converts imperial area units to metric
 func (c *BasicUnitConverter) convertArea(match UnitMatch) (ConversionResult, error) {
 	var metricValue float64
 	var metricUnit string
 
 	switch match.Unit {
-	case "square feet", "sq ft", "ft²":
+	case "square feet", "sq ft", "sqft", "ft²", "ft2":
 		sqm := unit.Area(match.Value) * unit.SquareFoot
 		metricValue = sqm.SquareMeters()
 		metricUnit = c.selectAreaUnit(metricValue)
@@ -268,6 +431,7 @@ func (c *BasicUnitConverter) convertArea(match UnitMatch) (ConversionResult, err
 	default:
 		return ConversionResult{}, fmt.Errorf("unsupported area unit: %s", match.Unit)
 	}
+	metricUnit = c.preferredUnit(match, metricUnit)
 
 	// Adjust value based on selected unit
 	metricValue = c.adjustValueForUnit(metricValue, metricUnit)
@@ -282,6 +446,43 @@ func (c *BasicUnitConverter) convertArea(match UnitMatch) (ConversionResult, err
 	}, nil
 }
 
+// convertSpeed converts miles per hour to kilometres per hour
+func (c *BasicUnitConverter) convertSpeed(match UnitMatch) (ConversionResult, error) {
+	switch match.Unit {
+	case "miles per hour", "miles an hour", "mph":
+		metricValue := (unit.Speed(match.Value) * unit.MilesPerHour).KilometersPerHour()
+
+		return ConversionResult{
+			MetricValue: metricValue,
+			MetricUnit:  "km/h",
+			Formatted:   c.formatValue(metricValue, Speed, "km/h"),
+			Confidence:  match.Confidence,
+		}, nil
+	default:
+		return ConversionResult{}, fmt.Errorf("unsupported speed unit: %s", match.Unit)
+	}
+}
+
+// convertPressure converts pounds per square inch to kilopascals, or to bar for high pressures
+// such as diving cylinders
+func (c *BasicUnitConverter) convertPressure(match UnitMatch) (ConversionResult, error) {
+	switch match.Unit {
+	case "pounds per square inch", "psi":
+		kPa := (unit.Pressure(match.Value) * unit.PoundsPerSquareInch).Kilopascals()
+		metricUnit := c.preferredUnit(match, c.selectPressureUnit(kPa))
+		metricValue := c.adjustValueForUnit(kPa, metricUnit)
+
+		return ConversionResult{
+			MetricValue: metricValue,
+			MetricUnit:  metricUnit,
+			Formatted:   c.formatValue(metricValue, Pressure, metricUnit),
+			Confidence:  match.Confidence,
+		}, nil
+	default:
+		return ConversionResult{}, fmt.Errorf("unsupported pressure unit: %s", match.Unit)
+	}
+}
+
 // selectLengthUnit chooses the most appropriate metric length unit
 func (c *BasicUnitConverter) selectLengthUnit(metres float64, isCompound bool, originalUnit string) string {
 	absMetres := math.Abs(metres)
@@ -347,6 +548,14 @@ func (c *BasicUnitConverter) selectAreaUnit(sqm float64) string {
 	}
 }
 
+// selectPressureUnit chooses the most appropriate metric pressure unit
+func (c *BasicUnitConverter) selectPressureUnit(kPa float64) string {
+	if math.Abs(kPa) < 1000.0 {
+		return "kPa"
+	}
+	return "bar"
+}
+
 // adjustValueForUnit adjusts the numeric value based on the selected unit
 func (c *BasicUnitConverter) adjustValueForUnit(value float64, unit string) float64 {
 	switch unit {
@@ -366,6 +575,8 @@ func (c *BasicUnitConverter) adjustValueForUnit(value float64, unit string) floa
 		return value * 1000
 	case "hectares":
 		return value / 10000
+	case "bar":
+		return value / 100 // from kPa
 	default:
 		return value
 	}
@@ -380,6 +591,19 @@ func (c *BasicUnitConverter) formatValue(value float64, unitType UnitType, unit
 		precision = c.preferences.MaxDecimalPlaces
 	}
 
+	switch c.preferences.RoundingStrategy {
+	case RoundingNearest:
+		return c.joinValueAndUnit(roundDecimal(value, precision, false), unit)
+	case RoundingBankers:
+		return c.joinValueAndUnit(roundDecimal(value, precision, true), unit)
+	case RoundingSignificant:
+		figures := c.preferences.SignificantFigures
+		if figures < 1 {
+			figures = defaultSignificantFigures
+		}
+		return c.joinValueAndUnit(roundSignificant(value, figures), unit)
+	}
+
 	// Check if we should prefer whole numbers using configurable threshold
 	if c.preferences.PreferWholeNumbers && math.Abs(value-math.Round(value)) < c.preferences.RoundingThreshold {
 		return c.formatWithSpacing("%.0f", math.Round(value), unit)
@@ -408,9 +632,85 @@ func (c *BasicUnitConverter) formatValue(value float64, unitType UnitType, unit
 	return c.formatWithSpacing(format, value, unit)
 }
 
+// roundDecimal rounds value to places decimal places, halves to even when bankers is set and
+// away from zero otherwise, and drops trailing zeros. Values a floating point error away from a
+// half are rounded as the half, so 1.15 rounds like 1.150 rather than like 1.1499999.
+func roundDecimal(value float64, places int, bankers bool) string {
+	scale := math.Pow(10, float64(places))
+	scaled := value * scale
+	if half := math.Round(scaled*2) / 2; math.Abs(scaled-half) < 1e-9*math.Max(1, math.Abs(scaled)) {
+		scaled = half
+	}
+	if bankers {
+		scaled = math.RoundToEven(scaled)
+	} else {
+		scaled = math.Round(scaled)
+	}
+	formatted := strconv.FormatFloat(scaled/scale, 'f', places, 64)
+	if strings.Contains(formatted, ".") {
+		formatted = strings.TrimRight(strings.TrimRight(formatted, "0"), ".")
+	}
+	return formatted
+}
+
+// roundSignificant rounds value to the given number of significant figures, dropping trailing
+// zeros after the decimal point
+func roundSignificant(value float64, figures int) string {
+	if value == 0 {
+		return "0"
+	}
+	places := figures - 1 - int(math.Floor(math.Log10(math.Abs(value))))
+	if places >= 0 {
+		return roundDecimal(value, places, false)
+	}
+	factor := math.Pow(10, float64(-places))
+	return strconv.FormatFloat(math.Round(value/factor)*factor, 'f', 0, 64)
+}
+
 // formatWithSpacing applies spacing preferences between value and unit
 func (c *BasicUnitConverter) formatWithSpacing(format string, value float64, unit string) string {
-	formattedValue := fmt.Sprintf(format, value)
+	return c.joinValueAndUnit(fmt.Sprintf(format, value), unit)
+}
+
+// groupThousands puts separator between each group of three digits in the whole part of a
+// formatted value, such as "-1609.3" → "-1,609.3". The decimal part is left as it is.
+func groupThousands(formattedValue, separator string) string {
+	sign, digits := "", formattedValue
+	if strings.HasPrefix(digits, "-") {
+		sign, digits = "-", digits[1:]
+	}
+	whole, fraction, hasFraction := strings.Cut(digits, ".")
+	if len(whole) <= 3 {
+		return formattedValue
+	}
+
+	var grouped strings.Builder
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			grouped.WriteString(separator)
+		}
+		grouped.WriteRune(digit)
+	}
+	if hasFraction {
+		return sign + grouped.String() + "." + fraction
+	}
+	return sign + grouped.String()
+}
+
+// joinValueAndUnit joins a formatted value and its unit according to the spacing and number
+// format preferences
+func (c *BasicUnitConverter) joinValueAndUnit(formattedValue, unit string) string {
+	if strings.Trim(formattedValue, "-0.") == "" {
+		// Avoid "-0°C" when a small negative value rounds to zero
+		formattedValue = strings.TrimPrefix(formattedValue, "-")
+	}
+
+	switch c.preferences.NumberFormat {
+	case NumberFormatGroupedComma:
+		formattedValue = groupThousands(formattedValue, ",")
+	case NumberFormatGroupedSpace:
+		formattedValue = groupThousands(formattedValue, "\u2009")
+	}
 
 	// Special case for temperature units - no space before °C or °F for consistency with existing tests
 	if unit == "°C" || unit == "°F" || unit == "degrees Celsius" {
@@ -0,0 +1,92 @@
+// Package converter provides detection and conversion of US cooking measures
+package converter
+
+import "strings"
+
+// US cooking measures in litres, and the mass of a US stick of butter (a quarter of a pound)
+// in kilograms
+const (
+	usCupLitres          = 0.2365882365
+	usTablespoonLitres   = usCupLitres / 16
+	usTeaspoonLitres     = usTablespoonLitres / 3
+	butterStickKilograms = 0.45359237 / 4
+)
+
+// cookingVolumeLitres is the volume of one of each US cooking measure of volume, by the names
+// it's detected under
+var cookingVolumeLitres = map[string]float64{
+	"cups": usCupLitres, "cup": usCupLitres,
+	"tablespoons": usTablespoonLitres, "tablespoon": usTablespoonLitres,
+	"tbsps": usTablespoonLitres, "tbsp": usTablespoonLitres, "tbs": usTablespoonLitres,
+	"teaspoons": usTeaspoonLitres, "teaspoon": usTeaspoonLitres,
+	"tsps": usTeaspoonLitres, "tsp": usTeaspoonLitres,
+}
+
+// detectCookingMeasures detects US cooking measures such as "2 cups", "1 1/2 tbsp" or "a stick
+// of butter". A bare "a" or "an" only counts before "of" ("a cup of flour"), and matches that
+// are part of an idiom ("a cup of tea", "cup final") are left alone. Only the idiom itself is
+// checked, so "2 cups of flour and a cup of tea" still converts the flour.
+func (d *ContextualUnitDetector) detectCookingMeasures(text string) []UnitMatch {
+	var matches []UnitMatch
+
+	for _, pattern := range d.patterns.CookingPatterns {
+		for _, idx := range pattern.Pattern.FindAllStringSubmatchIndex(text, -1) {
+			if len(idx) < 6 {
+				continue
+			}
+			// Only the quantity and the unit are replaced, so "2 sticks of butter" keeps "of butter"
+			start, end := idx[0], idx[5]
+			quantity := text[idx[2]:idx[3]]
+			if isPartOfLargerValue(text, idx[2]) {
+				continue
+			}
+
+			value, err := d.parseNumericValue(quantity)
+			isSpelledOut := err != nil
+			if isSpelledOut {
+				words := strings.Fields(strings.ToLower(strings.ReplaceAll(quantity, "-", " ")))
+				if len(words) == 1 && adjacentWord(text, end, true) != "of" {
+					continue // "a cup" on its own is as likely a mug or a trophy
+				}
+				value = spelledQuantityValue(words)
+			}
+
+			if d.patterns.isExcludedAround(text, start, end) {
+				continue
+			}
+
+			context := d.extractContext(text, start, end)
+			confidence := d.calculateConfidence(text[start:end], context, pattern, value)
+			if confidence < d.minConfidence {
+				continue
+			}
+
+			matches = append(matches, UnitMatch{
+				Start:        start,
+				End:          end,
+				Value:        value,
+				Unit:         ExtractUnitFromMatch([]string{text[start:end], "", text[idx[4]:idx[5]]}, pattern.UnitNames),
+				UnitType:     pattern.UnitType,
+				Context:      context,
+				Confidence:   confidence,
+				IsSpelledOut: isSpelledOut,
+			})
+		}
+	}
+
+	return matches
+}
+
+// isExcludedAround reports whether an exclusion pattern matches the text around start and end
+// and overlaps that span, so an idiom elsewhere in the sentence doesn't exclude it
+func (p *UnitPatterns) isExcludedAround(text string, start, end int) bool {
+	from, to := max(0, start-30), min(len(text), end+30)
+	for _, pattern := range p.ExclusionPatterns {
+		for _, loc := range pattern.FindAllStringIndex(text[from:to], -1) {
+			if from+loc[0] < end && from+loc[1] > start {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -2,11 +2,20 @@
 package converter
 
 import (
+	"math"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
+// iso8601Regex matches an ISO 8601 date, date-time or duration such as "2024-05-01T12:00:00Z"
+// or "P1Y2M10DT2H30M"
+var iso8601Regex = regexp.MustCompile(`^(?:\d{4}-\d{2}-\d{2}(?:T\d{2}(?::\d{2}){0,2}(?:\.\d+)?(?:Z|[+-]\d{2}:?\d{2})?)?|` +
+	`P(?:\d+(?:\.\d+)?[YMWD])*(?:T(?:\d+(?:\.\d+)?[HMS])+)?)$`)
+
 // UnitDetector interface defines the contract for unit detection
 type UnitDetector interface {
 	DetectUnits(text string) []UnitMatch
@@ -20,6 +29,7 @@ type ContextualUnitDetector struct {
 	// Configuration for contextual detection
 	maxNumberDistance int     // Maximum words between number and unit
 	minConfidence     float64 // Minimum confidence threshold for matches
+	cookingMeasures   bool    // Whether US cooking measures such as cups are detected
 }
 
 // NewContextualUnitDetector creates a new contextual unit detector
@@ -50,11 +60,28 @@ func (d *ContextualUnitDetector) DetectUnits(text string) []UnitMatch {
 					continue
 				}
 
+				// Get match positions
+				start := regexIndices[i][0]
+				end := regexIndices[i][1]
+
 				// Extract the numeric value
 				var value float64
 				var err error
 				if len(match) > 1 && match[1] != "" {
 					valueStr := match[1]
+					if isTimeValue(text, regexIndices[i][2]) {
+						continue // e.g. "10:30 in the lobby" is a time, not 30 inches
+					}
+					if isPartOfLargerValue(text, regexIndices[i][2]) {
+						continue // e.g. "$1,000 feet" is a price and "1,2 feet" isn't 2 feet
+					}
+					if d.isHyphenNotMinus(text, regexIndices[i][2], valueStr) {
+						// e.g. "x-40°F": the hyphen joins words rather than negating the value
+						valueStr = trimMinusSign(valueStr)
+						if start == regexIndices[i][2] {
+							start += len(match[1]) - len(valueStr)
+						}
+					}
 					value, err = d.parseNumericValue(valueStr)
 					if err != nil {
 						continue // Skip if we can't parse the number
@@ -64,10 +91,6 @@ func (d *ContextualUnitDetector) DetectUnits(text string) []UnitMatch {
 					value = d.estimateQuantityFromContext(match[0])
 				}
 
-				// Get match positions
-				start := regexIndices[i][0]
-				end := regexIndices[i][1]
-
 				// Extract the unit name from the full match
 				unitName := ExtractUnitFromMatch(match, pattern.UnitNames)
 				if unitName == "" {
@@ -85,8 +108,12 @@ func (d *ContextualUnitDetector) DetectUnits(text string) []UnitMatch {
 				// Calculate confidence score
 				confidence := d.calculateConfidence(match[0], context, pattern, value)
 
-				// Check if this is a compound unit (contains hyphen)
-				isCompound := strings.Contains(match[0], "-")
+				// Check if this is a compound unit (hyphen between number and unit, e.g. "6-foot");
+				// a leading minus sign on a negative value doesn't make it compound
+				isCompound := false
+				if len(regexIndices[i]) >= 6 && regexIndices[i][3] >= 0 && regexIndices[i][4] >= regexIndices[i][3] {
+					isCompound = strings.Contains(text[regexIndices[i][3]:regexIndices[i][4]], "-")
+				}
 
 				// Only include matches above minimum confidence threshold
 				if confidence >= d.minConfidence {
@@ -107,31 +134,353 @@ func (d *ContextualUnitDetector) DetectUnits(text string) []UnitMatch {
 		}
 	}
 
+	matches = append(matches, d.detectTemperatureRanges(text)...)
+	matches = append(matches, d.detectSpelledQuantities(text)...)
+	if d.cookingMeasures {
+		matches = append(matches, d.detectCookingMeasures(text)...)
+	}
+
+	// Dimensions replace the separate lengths they're made of, so that every component is
+	// converted to the same unit
+	dimensions := d.detectDimensions(text)
+	matches = append(removeOverlapped(matches, dimensions), dimensions...)
+
 	// Sort matches by position and filter overlapping matches
 	matches = d.filterOverlappingMatches(matches)
 
 	return matches
 }
 
+// detectTemperatureRanges detects ranges where only the upper value carries the unit
+// (e.g. "20-30°F"), so the lower value is converted too rather than left in Fahrenheit
+func (d *ContextualUnitDetector) detectTemperatureRanges(text string) []UnitMatch {
+	var matches []UnitMatch
+
+	for _, pattern := range d.patterns.TemperatureRangePatterns {
+		for _, idx := range pattern.Pattern.FindAllStringSubmatchIndex(text, -1) {
+			if len(idx) < 10 {
+				continue
+			}
+			start, end := idx[0], idx[1]
+
+			lowStr := text[idx[2]:idx[3]]
+			if d.isHyphenNotMinus(text, idx[2], lowStr) {
+				lowStr = trimMinusSign(lowStr)
+				start += idx[3] - idx[2] - len(lowStr)
+			}
+			low, err := d.parseNumericValue(lowStr)
+			if err != nil {
+				continue
+			}
+			high, err := d.parseNumericValue(text[idx[6]:idx[7]])
+			if err != nil {
+				continue
+			}
+
+			if d.patterns.IsExcluded(text[start:end]) {
+				continue
+			}
+
+			unitName := ExtractUnitFromMatch([]string{text[start:end], "", text[idx[8]:idx[9]]}, pattern.UnitNames)
+			if unitName == "" {
+				unitName = pattern.UnitNames[0]
+			}
+
+			context := d.extractContext(text, start, end)
+			confidence := d.calculateConfidence(text[start:end], context, pattern, high)
+			if confidence < d.minConfidence {
+				continue
+			}
+
+			matches = append(matches, UnitMatch{
+				Start:          start,
+				End:            end,
+				Value:          high,
+				Unit:           unitName,
+				UnitType:       Temperature,
+				Context:        context,
+				Confidence:     confidence,
+				IsRange:        true,
+				RangeLow:       low,
+				RangeSeparator: text[idx[4]:idx[5]],
+			})
+		}
+	}
+
+	return matches
+}
+
+// spelledQuantityMeasureWords are the words beside "a foot" or "a pound" that show it's a
+// measurement: the word after ("a foot long", "a pound of butter") or before ("weighs a pound")
+var spelledQuantityMeasureWords = map[string]bool{
+	"long": true, "tall": true, "high": true, "wide": true, "deep": true, "thick": true,
+	"across": true, "of": true, "weighs": true, "weighed": true, "weighing": true,
+	"measures": true, "measured": true, "measuring": true,
+}
+
+// detectSpelledQuantities detects measurements whose quantity is spelled out with an article or a
+// fraction, such as "a foot long" or "half a pound". A fraction is always a quantity, but "a" and
+// "an" only count beside a measurement word, since "a mile" or "a pound" is as often a figure of
+// speech or money. The surrounding text is checked against the exclusion patterns so that idioms
+// such as "a foot in the door" are left alone.
+func (d *ContextualUnitDetector) detectSpelledQuantities(text string) []UnitMatch {
+	var matches []UnitMatch
+
+	for _, pattern := range d.patterns.SpelledQuantityPatterns {
+		for _, idx := range pattern.Pattern.FindAllStringSubmatchIndex(text, -1) {
+			if len(idx) < 6 {
+				continue
+			}
+			start, end := idx[0], idx[1]
+			quantity := strings.Fields(strings.ToLower(strings.ReplaceAll(text[idx[2]:idx[3]], "-", " ")))
+			value := spelledQuantityValue(quantity)
+
+			before, after := adjacentWord(text, start, false), adjacentWord(text, end, true)
+			if after == "and" && strings.HasPrefix(strings.ToLower(strings.TrimSpace(text[end:])), "and a half") {
+				continue // "a foot and a half" is more than a foot
+			}
+			if len(quantity) == 1 && !spelledQuantityMeasureWords[before] && !spelledQuantityMeasureWords[after] {
+				continue
+			}
+			if d.patterns.IsExcluded(text[max(0, start-20):min(len(text), end+20)]) {
+				continue
+			}
+
+			context := d.extractContext(text, start, end)
+			confidence := d.calculateConfidence(text[start:end], context, pattern, value)
+			if confidence < d.minConfidence {
+				continue
+			}
+
+			matches = append(matches, UnitMatch{
+				Start:        start,
+				End:          end,
+				Value:        value,
+				Unit:         strings.ToLower(text[idx[4]:idx[5]]),
+				UnitType:     pattern.UnitType,
+				Context:      context,
+				Confidence:   confidence,
+				IsSpelledOut: true,
+			})
+		}
+	}
+
+	return matches
+}
+
+// spelledQuantityValue returns the value of a spelled-out quantity given as lower case words,
+// e.g. ["half", "a"] is 0.5 and ["an"] is 1
+func spelledQuantityValue(words []string) float64 {
+	switch {
+	case slices.Contains(words, "quarters"):
+		return 0.75
+	case slices.Contains(words, "quarter"):
+		return 0.25
+	case slices.Contains(words, "third"):
+		return 1.0 / 3
+	case slices.Contains(words, "half"):
+		return 0.5
+	}
+	return 1
+}
+
+// adjacentWord returns the lower case word after pos when forward is set, or before it otherwise,
+// skipping the spaces between
+func adjacentWord(text string, pos int, forward bool) string {
+	if forward {
+		for pos < len(text) && text[pos] == ' ' {
+			pos++
+		}
+		end := pos
+		for end < len(text) && isASCIILetter(text[end]) {
+			end++
+		}
+		return strings.ToLower(text[pos:end])
+	}
+	for pos > 0 && text[pos-1] == ' ' {
+		pos--
+	}
+	start := pos
+	for start > 0 && isASCIILetter(text[start-1]) {
+		start--
+	}
+	return strings.ToLower(text[start:pos])
+}
+
+// detectDimensions detects dimensions such as "12 ft × 8 ft", "3x4 feet" or "10 x 12-foot". A dimension whose
+// components are given in different units ("6 ft x 4 in") is left to be converted part by part.
+func (d *ContextualUnitDetector) detectDimensions(text string) []UnitMatch {
+	var matches []UnitMatch
+
+	for _, pattern := range d.patterns.DimensionPatterns {
+		for _, idx := range pattern.Pattern.FindAllStringIndex(text, -1) {
+			start, end := idx[0], idx[1]
+			if isTimeValue(text, start) || d.patterns.IsExcluded(text[start:end]) {
+				continue
+			}
+
+			components := DimensionComponentRegex.FindAllStringSubmatchIndex(text[start:end], -1)
+			var values []float64
+			var separators []string
+			unitName := ""
+			unitEach := true
+			consistent := true
+			for i, component := range components {
+				value, err := d.parseNumericValue(text[start+component[2] : start+component[3]])
+				if err != nil {
+					consistent = false
+					break
+				}
+				values = append(values, value)
+				if i > 0 {
+					separators = append(separators, text[start+components[i-1][1]:start+component[0]])
+				}
+
+				if component[4] < 0 {
+					unitEach = false
+					continue
+				}
+				name := canonicalDimensionUnit(text[start+component[4] : start+component[5]])
+				if unitName != "" && name != unitName {
+					consistent = false
+					break
+				}
+				unitName = name
+			}
+			if !consistent || len(values) < 2 {
+				continue
+			}
+			// A hyphenated unit makes the dimension an adjective, as in "a 10 x 12-foot room"
+			last := components[len(components)-1]
+			compound := last[4] > 0 && text[start+last[4]-1] == '-'
+
+			largest := slices.Max(values)
+			context := d.extractContext(text, start, end)
+			confidence := d.calculateConfidence(text[start:end], context, pattern, largest)
+			if confidence < d.minConfidence {
+				continue
+			}
+
+			matches = append(matches, UnitMatch{
+				Start:               start,
+				End:                 end,
+				Value:               largest,
+				Unit:                unitName,
+				UnitType:            Length,
+				Context:             context,
+				Confidence:          confidence,
+				IsCompound:          compound,
+				IsDimension:         true,
+				DimensionValues:     values,
+				DimensionSeparators: separators,
+				DimensionUnitEach:   unitEach,
+			})
+		}
+	}
+
+	return matches
+}
+
+// canonicalDimensionUnit maps a length unit as written in a dimension to the name used for
+// conversion
+func canonicalDimensionUnit(name string) string {
+	switch strings.ToLower(name) {
+	case "feet", "foot", "ft":
+		return "feet"
+	case "inches", "inch", "in":
+		return "inches"
+	default:
+		return "yards"
+	}
+}
+
+// removeOverlapped returns the matches that don't overlap any of the preferred matches
+func removeOverlapped(matches, preferred []UnitMatch) []UnitMatch {
+	if len(preferred) == 0 {
+		return matches
+	}
+	var kept []UnitMatch
+	for _, match := range matches {
+		overlaps := false
+		for _, p := range preferred {
+			if match.End > p.Start && p.End > match.Start {
+				overlaps = true
+				break
+			}
+		}
+		if !overlaps {
+			kept = append(kept, match)
+		}
+	}
+	return kept
+}
+
+// isTimeValue reports whether the number at pos is part of a clock time ("10:30") or an ISO 8601
+// date, time or duration rather than a measurement
+func isTimeValue(text string, pos int) bool {
+	if pos < 0 {
+		return false
+	}
+	if pos >= 2 && text[pos-1] == ':' && text[pos-2] >= '0' && text[pos-2] <= '9' {
+		return true
+	}
+
+	start, end := pos, pos
+	for start > 0 && !unicode.IsSpace(rune(text[start-1])) {
+		start--
+	}
+	for end < len(text) && !unicode.IsSpace(rune(text[end])) {
+		end++
+	}
+	// Brackets around the token are boundaries, so "[2024-01-12 in the lobby]" is still a date
+	token := strings.TrimLeft(strings.TrimRight(text[start:end], ".,;:!?)]}\"'"), "([{\"'")
+	return strings.ContainsAny(token, "0123456789") && iso8601Regex.MatchString(token)
+}
+
+// isPartOfLargerValue reports whether the value captured at pos follows a currency symbol or
+// continues a number, such as the "2" of "1,2", rather than standing on its own
+func isPartOfLargerValue(text string, pos int) bool {
+	if pos <= 0 {
+		return false
+	}
+	prev, _ := utf8.DecodeLastRuneInString(text[:pos])
+	if unicode.Is(unicode.Sc, prev) {
+		return true
+	}
+	return (prev == ',' || prev == '.') && pos >= 2 && text[pos-2] >= '0' && text[pos-2] <= '9'
+}
+
+// isHyphenNotMinus reports whether a leading "-" on a value captured at pos is a hyphen joining
+// it to the preceding word or number (e.g. "x-40", "10-20") rather than a minus sign
+func (d *ContextualUnitDetector) isHyphenNotMinus(text string, pos int, valueStr string) bool {
+	if !strings.HasPrefix(valueStr, "-") || pos <= 0 {
+		return false
+	}
+	prev := text[pos-1]
+	return (prev >= '0' && prev <= '9') || isASCIILetter(prev)
+}
+
+// trimMinusSign removes a leading minus sign (ASCII hyphen-minus or U+2212)
+func trimMinusSign(valueStr string) string {
+	return strings.TrimPrefix(strings.TrimPrefix(valueStr, "-"), "−")
+}
+
 // SupportedUnits returns the list of supported unit types
 func (d *ContextualUnitDetector) SupportedUnits() []UnitType {
-	return []UnitType{Length, Mass, Volume, Temperature, Area}
+	return []UnitType{Length, Mass, Volume, Temperature, Area, Speed, Pressure}
 }
 
 // parseNumericValue parses various numeric formats including decimals, fractions, and written numbers
 func (d *ContextualUnitDetector) parseNumericValue(valueStr string) (float64, error) {
 	valueStr = strings.TrimSpace(valueStr)
 
-	// Handle written numbers
-	writtenNumbers := map[string]float64{
-		"one": 1, "two": 2, "three": 3, "four": 4, "five": 5,
-		"six": 6, "seven": 7, "eight": 8, "nine": 9, "ten": 10,
-		"eleven": 11, "twelve": 12, "twenty": 20, "thirty": 30,
-		"forty": 40, "fifty": 50, "sixty": 60, "seventy": 70,
-		"eighty": 80, "ninety": 90, "hundred": 100,
-	}
+	// Normalise the typographic minus sign (U+2212) so negative values parse, and drop
+	// thousands separators ("12,000")
+	valueStr = strings.Replace(valueStr, "−", "-", 1)
+	valueStr = strings.ReplaceAll(valueStr, ",", "")
 
-	if val, exists := writtenNumbers[strings.ToLower(valueStr)]; exists {
+	// Handle written numbers, including hyphenated compounds such as "twenty-five"
+	if val, ok := parseWrittenNumber(valueStr); ok {
 		return val, nil
 	}
 
@@ -144,6 +493,30 @@ func (d *ContextualUnitDetector) parseNumericValue(valueStr string) (float64, er
 	return strconv.ParseFloat(valueStr, 64)
 }
 
+// writtenNumbers maps number words to their values
+var writtenNumbers = map[string]float64{
+	"one": 1, "two": 2, "three": 3, "four": 4, "five": 5,
+	"six": 6, "seven": 7, "eight": 8, "nine": 9, "ten": 10,
+	"eleven": 11, "twelve": 12, "thirteen": 13, "fourteen": 14, "fifteen": 15,
+	"sixteen": 16, "seventeen": 17, "eighteen": 18, "nineteen": 19, "twenty": 20, "thirty": 30,
+	"forty": 40, "fifty": 50, "sixty": 60, "seventy": 70,
+	"eighty": 80, "ninety": 90, "hundred": 100,
+}
+
+// parseWrittenNumber parses a number written in words, adding the parts of a hyphenated
+// compound such as "twenty-five"
+func parseWrittenNumber(valueStr string) (float64, bool) {
+	total := 0.0
+	for part := range strings.SplitSeq(strings.ToLower(valueStr), "-") {
+		val, exists := writtenNumbers[part]
+		if !exists {
+			return 0, false
+		}
+		total += val
+	}
+	return total, true
+}
+
 // parseFraction parses fraction formats like "2 1/2" or "1/2"
 func (d *ContextualUnitDetector) parseFraction(valueStr string) (float64, error) {
 	// Handle mixed fractions like "2 1/2"
@@ -218,8 +591,13 @@ func (d *ContextualUnitDetector) calculateConfidence(match, context string, patt
 		confidence += 0.1
 	}
 
-	// Reduce confidence for very large or very small values that might be errors
-	if value > 10000 || value < 0.001 {
+	// Reduce confidence for very large or very small values that might be errors.
+	// Zero and negative temperatures are ordinary readings, so only their magnitude counts.
+	magnitude := value
+	if pattern.UnitType == Temperature {
+		magnitude = math.Abs(value)
+	}
+	if magnitude > 10000 || (magnitude < 0.001 && !(pattern.UnitType == Temperature && value == 0)) {
 		confidence -= 0.2
 	}
 
@@ -227,7 +605,7 @@ func (d *ContextualUnitDetector) calculateConfidence(match, context string, patt
 	confidence += d.getValueRangeBoost(pattern.UnitType, value)
 
 	// Reduce confidence if context suggests idiomatic usage
-	if d.hasIdiomaticContext(lowerContext, pattern.UnitType) {
+	if hasIdiomaticContext(lowerContext, pattern.UnitType) {
 		confidence -= 0.3
 	}
 
@@ -277,12 +655,22 @@ func (d *ContextualUnitDetector) getValueRangeBoost(unitType UnitType, value flo
 		if (value >= 100 && value <= 10000) || (value >= 1 && value <= 1000) {
 			return 0.05
 		}
+	case Speed:
+		// Common ranges: 1-200 mph
+		if value >= 1 && value <= 200 {
+			return 0.05
+		}
+	case Pressure:
+		// Common ranges: 1-5000 psi, from tyres to diving cylinders
+		if value >= 1 && value <= 5000 {
+			return 0.05
+		}
 	}
 	return 0.0
 }
 
-// hasIdiomaticContext checks for idiomatic usage patterns in context
-func (d *ContextualUnitDetector) hasIdiomaticContext(context string, unitType UnitType) bool {
+// hasIdiomaticContext checks for idiomatic usage patterns in a lowercase context
+func hasIdiomaticContext(context string, unitType UnitType) bool {
 	switch unitType {
 	case Length:
 		idioms := []string{
@@ -308,7 +696,9 @@ func (d *ContextualUnitDetector) hasIdiomaticContext(context string, unitType Un
 	return false
 }
 
-// filterOverlappingMatches removes overlapping matches, keeping the highest confidence ones
+// filterOverlappingMatches removes overlapping matches, keeping the highest confidence ones.
+// Between matches of equal confidence the longer one is kept, so "60 miles per hour" is read as
+// a speed rather than a distance and "500 ft²" as an area rather than a length.
 func (d *ContextualUnitDetector) filterOverlappingMatches(matches []UnitMatch) []UnitMatch {
 	if len(matches) <= 1 {
 		return matches
@@ -331,8 +721,9 @@ func (d *ContextualUnitDetector) filterOverlappingMatches(matches []UnitMatch) [
 		// Check if this match overlaps with any already accepted match
 		for _, accepted := range filtered {
 			if d.isOverlapping(match, accepted) {
-				// Keep the one with higher confidence
-				if match.Confidence <= accepted.Confidence {
+				// Keep the one with higher confidence, or the longer one on a tie
+				if match.Confidence < accepted.Confidence ||
+					(match.Confidence == accepted.Confidence && match.End-match.Start <= accepted.End-accepted.Start) {
 					isOverlapping = true
 					break
 				} else {
@@ -361,6 +752,12 @@ func (d *ContextualUnitDetector) isOverlapping(match1, match2 UnitMatch) bool {
 	return match1.End > match2.Start && match2.End > match1.Start
 }
 
+// SetCookingMeasuresEnabled sets whether US cooking measures (cups, tablespoons, teaspoons and
+// sticks of butter) are detected
+func (d *ContextualUnitDetector) SetCookingMeasuresEnabled(enabled bool) {
+	d.cookingMeasures = enabled
+}
+
 // SetMinConfidence sets the minimum confidence threshold
 func (d *ContextualUnitDetector) SetMinConfidence(confidence float64) {
 	d.minConfidence = confidence
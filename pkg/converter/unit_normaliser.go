@@ -0,0 +1,94 @@
+// Package converter provides normalisation of the spacing and symbols of metric units
+package converter
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// metricSymbolRegex matches a number followed by a metric unit symbol, in any case and with any
+// horizontal spacing between them. Single-letter symbols (m, g, l, t) aren't matched because
+// "£5m" and "5G" are more often millions and mobile networks than metres and grams.
+var metricSymbolRegex = regexp.MustCompile(`(?i)(\d+(?:[.,]\d+)*)([ \t\x{00A0}\x{2009}\x{202F}]*)` +
+	`(km/hr?|kmh|kph|(?:km|cm|mm)[²2]|(?:km|kg|cm|mm|mg)s?|ml|[°º] ?c)`)
+
+// canonicalMetricSymbols maps a lowercase symbol, as matched by metricSymbolRegex, to its
+// SI form
+var canonicalMetricSymbols = map[string]string{
+	"km/h": "km/h", "km/hr": "km/h", "kmh": "km/h", "kph": "km/h",
+	"km²": "km²", "km2": "km²", "cm²": "cm²", "cm2": "cm²", "mm²": "mm²", "mm2": "mm²",
+	"km": "km", "kms": "km", "kg": "kg", "kgs": "kg",
+	"cm": "cm", "cms": "cm", "mm": "mm", "mms": "mm", "mg": "mg", "mgs": "mg",
+	"°c": "°C", "° c": "°C", "ºc": "°C", "º c": "°C",
+}
+
+// preservedMetricSymbols lists symbols whose case is kept because each form is a valid SI
+// unit: megametres, megagrams, millilitres (ml, mL) and megalitres (ML, Ml)
+var preservedMetricSymbols = map[string]bool{
+	"Mm": true, "Mg": true, "ml": true, "mL": true, "ML": true, "Ml": true,
+}
+
+// NormaliseUnits tidies metric quantities already in text without changing their values:
+// symbols get their SI case ("10KM" → "10 km", "5Kgs" → "5 kg", "30 kph" → "30 km/h") and the
+// space between number and symbol follows the UseSpaceBetweenValueAndUnit preference
+// ("5kg" → "5 kg"). Temperatures are written without a space ("20 °c" → "20°C"), like
+// converted ones. A non-breaking or thin space already in place is kept.
+func (p *UnitProcessor) NormaliseUnits(text string) string {
+	useSpace := p.config == nil || p.config.Preferences.UseSpaceBetweenValueAndUnit
+
+	matches := metricSymbolRegex.FindAllStringSubmatchIndex(text, -1)
+	if len(matches) == 0 {
+		return text
+	}
+
+	var result strings.Builder
+	last := 0
+	for _, match := range matches {
+		start, end := match[0], match[1]
+		number := text[match[2]:match[3]]
+		space := text[match[4]:match[5]]
+		symbol := text[match[6]:match[7]]
+
+		// The quantity must stand alone, not be part of a word, version number, path or URL
+		if r, _ := utf8.DecodeLastRuneInString(text[:start]); start > 0 && (isWordRune(r) || strings.ContainsRune("./\\_-", r)) {
+			continue
+		}
+		if r, _ := utf8.DecodeRuneInString(text[end:]); end < len(text) && (isWordRune(r) || r == '/') {
+			continue
+		}
+
+		canonical := symbol
+		if !preservedMetricSymbols[symbol] {
+			var ok bool
+			if canonical, ok = canonicalMetricSymbols[strings.ToLower(symbol)]; !ok {
+				continue
+			}
+		}
+
+		switch {
+		case canonical == "°C":
+			space = ""
+		case !useSpace:
+			space = ""
+		case space == "\u00a0" || space == "\u2009" || space == "\u202f":
+			// Typographic spaces are the right separator already
+		default:
+			space = " "
+		}
+
+		p.explain.record(Explanation{Original: text[start:end], Converted: number + space + canonical, Rule: "unit: normalised metric symbol"})
+		result.WriteString(text[last:start])
+		result.WriteString(number + space + canonical)
+		last = end
+	}
+	result.WriteString(text[last:])
+
+	return result.String()
+}
+
+// isWordRune reports whether r can be part of a word or number
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
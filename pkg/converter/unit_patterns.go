@@ -22,6 +22,23 @@ type UnitPatterns struct {
 	VolumePatterns      []UnitPattern
 	TemperaturePatterns []UnitPattern
 	AreaPatterns        []UnitPattern
+	SpeedPatterns       []UnitPattern
+	PressurePatterns    []UnitPattern
+
+	// Range patterns capture low value, separator, high value and unit (e.g. "20-30°F")
+	TemperatureRangePatterns []UnitPattern
+
+	// Dimension patterns match two or three lengths joined by "x", "×" or "by", the last of
+	// which carries the unit (e.g. "12 ft × 8 ft", "3x4 feet", "10 x 12-foot")
+	DimensionPatterns []UnitPattern
+
+	// Spelled quantity patterns capture a quantity written with an article or a fraction and a
+	// singular unit (e.g. "a foot", "half a pound")
+	SpelledQuantityPatterns []UnitPattern
+
+	// Cooking patterns match US cooking measures (cups, tablespoons, teaspoons and sticks of
+	// butter), which are only detected when asked for since "a cup" is as often a drink
+	CookingPatterns []UnitPattern
 
 	// Negative patterns for excluding idiomatic usage
 	ExclusionPatterns []*regexp.Regexp
@@ -35,23 +52,48 @@ func NewUnitPatterns() *UnitPatterns {
 	patterns.initializeVolumePatterns()
 	patterns.initializeTemperaturePatterns()
 	patterns.initializeAreaPatterns()
+	patterns.initializeSpeedPatterns()
+	patterns.initializePressurePatterns()
+	patterns.initializeSpelledQuantityPatterns()
+	patterns.initializeCookingPatterns()
 	patterns.initializeExclusionPatterns()
 	return patterns
 }
 
+// writtenNumber matches a number written in words up to ninety-nine, including hyphenated
+// compounds such as "twenty-five"
+const writtenNumber = `(?:(?:twenty|thirty|forty|fifty|sixty|seventy|eighty|ninety)(?:-(?:one|two|three|four|five|six|seven|eight|nine))?` +
+	`|thirteen|fourteen|fifteen|sixteen|seventeen|eighteen|nineteen` +
+	`|one|two|three|four|five|six|seven|eight|nine|ten|eleven|twelve)`
+
+// spelledQuantity matches a quantity written as an article or a fraction, such as "a", "half a",
+// "a quarter of a" or "three quarters of an"
+const spelledQuantity = `(?:(?:three[\s-]+quarters|(?:an?\s+|one\s+)?(?:quarter|third))\s+of\s+an?` +
+	`|half\s+(?:of\s+)?an?|an?\s+(?:half|quarter)|an?)`
+
+// groupedNumber matches a number with thousands separators, such as "12,000" or "1,500.5", so
+// the whole value is captured rather than the digits after the last comma
+const groupedNumber = `\d{1,3}(?:,\d{3})+(?:\.\d+)?`
+
+// dimensionUnits matches the length units that dimensions are given in
+const dimensionUnits = `(?:feet|foot|ft|inches|inch|in|yards|yard|yd)`
+
+// DimensionComponentRegex matches one component of a dimension: a value and, optionally, its unit
+var DimensionComponentRegex = regexp.MustCompile(`(?i)(\d+(?:\.\d+)?)(?:(?:\s*|-)(` + dimensionUnits + `)\b)?`)
+
 // initializeLengthPatterns creates regex patterns for length units (feet, inches, yards, miles)
 func (p *UnitPatterns) initializeLengthPatterns() {
 	// Feet patterns - capture only number and unit
 	p.LengthPatterns = append(p.LengthPatterns, UnitPattern{
-		Pattern:    regexp.MustCompile(`(?i)\b(\d+(?:\s+\d+/\d+)?|\d+\.\d+|\d+/\d+)\s*(feet|foot|ft)\b`),
+		Pattern:    regexp.MustCompile(`(?i)\b(` + groupedNumber + `|\d+(?:\s+\d+/\d+)?|\d+\.\d+|\d+/\d+)\s*(feet|foot|ft)\b`),
 		UnitType:   Length,
 		UnitNames:  []string{"feet", "foot", "ft"},
 		Confidence: 0.9,
 	})
 
-	// Compound feet patterns (e.g., "6-foot", "six-foot")
+	// Compound feet patterns (e.g., "6-foot", "6.5-foot-tall", "twenty-five-foot")
 	p.LengthPatterns = append(p.LengthPatterns, UnitPattern{
-		Pattern:    regexp.MustCompile(`(?i)\b(\d+|one|two|three|four|five|six|seven|eight|nine|ten|eleven|twelve)-(feet|foot|ft)\b`),
+		Pattern:    regexp.MustCompile(`(?i)\b(` + groupedNumber + `|\d+(?:\.\d+)?|` + writtenNumber + `)-(feet|foot|ft)\b`),
 		UnitType:   Length,
 		UnitNames:  []string{"feet", "foot", "ft"},
 		Confidence: 0.85,
@@ -59,7 +101,7 @@ func (p *UnitPatterns) initializeLengthPatterns() {
 
 	// Written numbers with feet
 	p.LengthPatterns = append(p.LengthPatterns, UnitPattern{
-		Pattern:    regexp.MustCompile(`(?i)\b(one|two|three|four|five|six|seven|eight|nine|ten|eleven|twelve|twenty|thirty|forty|fifty)\s+(feet|foot)\b`),
+		Pattern:    regexp.MustCompile(`(?i)\b(` + writtenNumber + `)\s+(feet|foot)\b`),
 		UnitType:   Length,
 		UnitNames:  []string{"feet", "foot"},
 		Confidence: 0.8,
@@ -67,23 +109,24 @@ func (p *UnitPatterns) initializeLengthPatterns() {
 
 	// Inches patterns - capture only number and unit
 	p.LengthPatterns = append(p.LengthPatterns, UnitPattern{
-		Pattern:    regexp.MustCompile(`(?i)\b(\d+(?:\.\d+)?(?:/\d+)?)\s*(inches?|inch|in)\b`),
+		Pattern:    regexp.MustCompile(`(?i)\b(` + groupedNumber + `|\d+(?:\.\d+)?(?:/\d+)?)\s*(inches?|inch|in)\b`),
 		UnitType:   Length,
 		UnitNames:  []string{"inches", "inch", "in"},
 		Confidence: 0.9,
 	})
 
-	// Compound inches patterns
+	// Compound inches patterns (e.g., "10-inch-wide", "forty-two-inch"); "in" is left out so
+	// "2-in-1" isn't read as a length
 	p.LengthPatterns = append(p.LengthPatterns, UnitPattern{
-		Pattern:    regexp.MustCompile(`(?i)\b(\d+|one|two|three|four|five|six|seven|eight|nine|ten|eleven|twelve)-(inches?|inch|in)\b`),
+		Pattern:    regexp.MustCompile(`(?i)\b(` + groupedNumber + `|\d+(?:\.\d+)?|` + writtenNumber + `)-(inches?|inch)\b`),
 		UnitType:   Length,
-		UnitNames:  []string{"inches", "inch", "in"},
+		UnitNames:  []string{"inches", "inch"},
 		Confidence: 0.85,
 	})
 
 	// Yards patterns - capture only number and unit
 	p.LengthPatterns = append(p.LengthPatterns, UnitPattern{
-		Pattern:    regexp.MustCompile(`(?i)\b(\d+(?:\.\d+)?(?:/\d+)?)\s*(yards?|yd)\b`),
+		Pattern:    regexp.MustCompile(`(?i)\b(` + groupedNumber + `|\d+(?:\.\d+)?(?:/\d+)?)\s*(yards?|yd)\b`),
 		UnitType:   Length,
 		UnitNames:  []string{"yards", "yard", "yd"},
 		Confidence: 0.9,
@@ -91,15 +134,25 @@ func (p *UnitPatterns) initializeLengthPatterns() {
 
 	// Miles patterns - capture only number and unit
 	p.LengthPatterns = append(p.LengthPatterns, UnitPattern{
-		Pattern:    regexp.MustCompile(`(?i)\b(\d+(?:\s+\d+/\d+)?|\d+\.\d+|\d+/\d+)\s*(miles?|mi)\b`),
+		Pattern:    regexp.MustCompile(`(?i)\b(` + groupedNumber + `|\d+(?:\s+\d+/\d+)?|\d+\.\d+|\d+/\d+)\s*(miles?|mi)\b`),
 		UnitType:   Length,
 		UnitNames:  []string{"miles", "mile", "mi"},
 		Confidence: 0.9,
 	})
 
+	// Dimensions: the components are picked apart by DimensionComponentRegex
+	p.DimensionPatterns = append(p.DimensionPatterns, UnitPattern{
+		Pattern: regexp.MustCompile(`(?i)\b\d+(?:\.\d+)?(?:(?:\s*|-)` + dimensionUnits + `\b)?` +
+			`(?:\s*(?:x|×|by)\s*\d+(?:\.\d+)?(?:(?:\s*|-)` + dimensionUnits + `\b)?)?` +
+			`\s*(?:x|×|by)\s*\d+(?:\.\d+)?(?:\s*|-)` + dimensionUnits + `\b`),
+		UnitType:   Length,
+		UnitNames:  []string{"feet", "inches", "yards"},
+		Confidence: 0.95,
+	})
+
 	// Contextual miles patterns (a few miles, several miles) - capture the whole phrase
 	p.LengthPatterns = append(p.LengthPatterns, UnitPattern{
-		Pattern:    regexp.MustCompile(`(?i)\b(?:a\s+few|several|many|about|around|roughly|approximately)\s+(\d+(?:\.\d+)?)\s*(miles?|mi)\b`),
+		Pattern:    regexp.MustCompile(`(?i)\b(?:a\s+few|several|many|about|around|roughly|approximately)\s+(` + groupedNumber + `|\d+(?:\.\d+)?)\s*(miles?|mi)\b`),
 		UnitType:   Length,
 		UnitNames:  []string{"miles", "mile", "mi"},
 		Confidence: 0.75,
@@ -110,7 +163,7 @@ func (p *UnitPatterns) initializeLengthPatterns() {
 func (p *UnitPatterns) initializeMassPatterns() {
 	// Pounds patterns - capture only number and unit
 	p.MassPatterns = append(p.MassPatterns, UnitPattern{
-		Pattern:    regexp.MustCompile(`(?i)\b(\d+(?:\.\d+)?(?:/\d+)?)\s*(pounds?|lbs?|lb)\b`),
+		Pattern:    regexp.MustCompile(`(?i)\b(` + groupedNumber + `|\d+(?:\.\d+)?(?:/\d+)?)\s*(pounds?|lbs?|lb)\b`),
 		UnitType:   Mass,
 		UnitNames:  []string{"pounds", "pound", "lbs", "lb"},
 		Confidence: 0.9,
@@ -118,7 +171,7 @@ func (p *UnitPatterns) initializeMassPatterns() {
 
 	// Ounces patterns - capture only number and unit
 	p.MassPatterns = append(p.MassPatterns, UnitPattern{
-		Pattern:    regexp.MustCompile(`(?i)\b(\d+(?:\.\d+)?(?:/\d+)?)\s*(ounces?|oz)\b`),
+		Pattern:    regexp.MustCompile(`(?i)\b(` + groupedNumber + `|\d+(?:\.\d+)?(?:/\d+)?)\s*(ounces?|oz)\b`),
 		UnitType:   Mass,
 		UnitNames:  []string{"ounces", "ounce", "oz"},
 		Confidence: 0.9,
@@ -126,7 +179,7 @@ func (p *UnitPatterns) initializeMassPatterns() {
 
 	// Tons patterns (US short ton) - capture only number and unit
 	p.MassPatterns = append(p.MassPatterns, UnitPattern{
-		Pattern:    regexp.MustCompile(`(?i)\b(\d+(?:\.\d+)?(?:/\d+)?)\s*(tons?|ton)\b`),
+		Pattern:    regexp.MustCompile(`(?i)\b(` + groupedNumber + `|\d+(?:\.\d+)?(?:/\d+)?)\s*(tons?|ton)\b`),
 		UnitType:   Mass,
 		UnitNames:  []string{"tons", "ton"},
 		Confidence: 0.85, // Lower confidence due to potential idiomatic usage
@@ -145,7 +198,7 @@ func (p *UnitPatterns) initializeMassPatterns() {
 func (p *UnitPatterns) initializeVolumePatterns() {
 	// Gallons patterns - capture only number and unit
 	p.VolumePatterns = append(p.VolumePatterns, UnitPattern{
-		Pattern:    regexp.MustCompile(`(?i)\b(\d+(?:\.\d+)?(?:/\d+)?)\s*(gallons?|gal)\b`),
+		Pattern:    regexp.MustCompile(`(?i)\b(` + groupedNumber + `|\d+(?:\.\d+)?(?:/\d+)?)\s*(gallons?|gal)\b`),
 		UnitType:   Volume,
 		UnitNames:  []string{"gallons", "gallon", "gal"},
 		Confidence: 0.9,
@@ -153,7 +206,7 @@ func (p *UnitPatterns) initializeVolumePatterns() {
 
 	// Quarts patterns - capture only number and unit
 	p.VolumePatterns = append(p.VolumePatterns, UnitPattern{
-		Pattern:    regexp.MustCompile(`(?i)\b(\d+(?:\.\d+)?(?:/\d+)?)\s*(quarts?|qt)\b`),
+		Pattern:    regexp.MustCompile(`(?i)\b(` + groupedNumber + `|\d+(?:\.\d+)?(?:/\d+)?)\s*(quarts?|qt)\b`),
 		UnitType:   Volume,
 		UnitNames:  []string{"quarts", "quart", "qt"},
 		Confidence: 0.9,
@@ -161,7 +214,7 @@ func (p *UnitPatterns) initializeVolumePatterns() {
 
 	// Pints patterns - capture only number and unit
 	p.VolumePatterns = append(p.VolumePatterns, UnitPattern{
-		Pattern:    regexp.MustCompile(`(?i)\b(\d+(?:\.\d+)?(?:/\d+)?)\s*(pints?|pt)\b`),
+		Pattern:    regexp.MustCompile(`(?i)\b(` + groupedNumber + `|\d+(?:\.\d+)?(?:/\d+)?)\s*(pints?|pt)\b`),
 		UnitType:   Volume,
 		UnitNames:  []string{"pints", "pint", "pt"},
 		Confidence: 0.9,
@@ -169,7 +222,7 @@ func (p *UnitPatterns) initializeVolumePatterns() {
 
 	// Fluid ounces patterns - capture only number and unit
 	p.VolumePatterns = append(p.VolumePatterns, UnitPattern{
-		Pattern:    regexp.MustCompile(`(?i)\b(\d+(?:\.\d+)?(?:/\d+)?)\s*(fluid\s+ounces?|fl\s*oz|floz)\b`),
+		Pattern:    regexp.MustCompile(`(?i)\b(` + groupedNumber + `|\d+(?:\.\d+)?(?:/\d+)?)\s*(fluid\s+ounces?|fl\s*oz|floz)\b`),
 		UnitType:   Volume,
 		UnitNames:  []string{"fluid ounces", "fluid ounce", "fl oz", "floz"},
 		Confidence: 0.9,
@@ -178,9 +231,9 @@ func (p *UnitPatterns) initializeVolumePatterns() {
 
 // initializeTemperaturePatterns creates regex patterns for temperature units (Fahrenheit)
 func (p *UnitPatterns) initializeTemperaturePatterns() {
-	// Fahrenheit with degree symbol - capture only number and unit
+	// Fahrenheit with degree symbol - capture only number (including any minus sign) and unit
 	p.TemperaturePatterns = append(p.TemperaturePatterns, UnitPattern{
-		Pattern:    regexp.MustCompile(`(?i)\b(\d+(?:\.\d+)?)\s*(°F)\b`),
+		Pattern:    regexp.MustCompile(`(?i)((?:-|−)?\b(?:` + groupedNumber + `|\d+(?:\.\d+)?))\s*(°F)\b`),
 		UnitType:   Temperature,
 		UnitNames:  []string{"°F"},
 		Confidence: 0.95,
@@ -188,7 +241,7 @@ func (p *UnitPatterns) initializeTemperaturePatterns() {
 
 	// Fahrenheit without degree symbol - capture only number and unit
 	p.TemperaturePatterns = append(p.TemperaturePatterns, UnitPattern{
-		Pattern:    regexp.MustCompile(`(?i)\b(\d+(?:\.\d+)?)\s*((?:degrees?\s*)?fahrenheit)\b`),
+		Pattern:    regexp.MustCompile(`(?i)((?:-|−)?\b(?:` + groupedNumber + `|\d+(?:\.\d+)?))\s*((?:degrees?\s*)?fahrenheit)\b`),
 		UnitType:   Temperature,
 		UnitNames:  []string{"fahrenheit", "degrees fahrenheit"},
 		Confidence: 0.9,
@@ -196,32 +249,119 @@ func (p *UnitPatterns) initializeTemperaturePatterns() {
 
 	// F (standalone, context-dependent) - capture only number and unit
 	p.TemperaturePatterns = append(p.TemperaturePatterns, UnitPattern{
-		Pattern:    regexp.MustCompile(`(?i)(?:temperature|temp|heat|cold|warm|hot)\s+(?:of|is|was|reached)\s+(\d+(?:\.\d+)?)\s*(F)\b`),
+		Pattern:    regexp.MustCompile(`(?i)(?:temperature|temp|heat|cold|warm|hot)\s+(?:of|is|was|reached)\s+((?:-|−)?(?:` + groupedNumber + `|\d+(?:\.\d+)?))\s*(F)\b`),
 		UnitType:   Temperature,
 		UnitNames:  []string{"F"},
 		Confidence: 0.8,
 	})
+
+	// Ranges where only the upper value carries the unit (e.g. "20-30°F", "-10 to 5 degrees Fahrenheit")
+	p.TemperatureRangePatterns = append(p.TemperatureRangePatterns, UnitPattern{
+		Pattern:    regexp.MustCompile(`(?i)((?:-|−)?\b\d+(?:\.\d+)?)(\s*(?:-|–|to)\s*)((?:-|−)?\d+(?:\.\d+)?)\s*(°F|(?:degrees?\s*)?fahrenheit)\b`),
+		UnitType:   Temperature,
+		UnitNames:  []string{"°F", "fahrenheit", "degrees fahrenheit"},
+		Confidence: 0.95,
+	})
 }
 
 // initializeAreaPatterns creates regex patterns for area units (square feet, acres)
 func (p *UnitPatterns) initializeAreaPatterns() {
-	// Square feet patterns - capture only number and unit
+	// Square feet patterns - capture only number and unit; "²" isn't a word character, so
+	// "ft²" can't end with \b
 	p.AreaPatterns = append(p.AreaPatterns, UnitPattern{
-		Pattern:    regexp.MustCompile(`(?i)\b(\d+(?:\.\d+)?(?:,\d{3})*)\s*(square\s+feet|sq\s*ft|ft²|ft2)(?:\s|$|[.,;!?])`),
+		Pattern:    regexp.MustCompile(`(?i)\b(` + groupedNumber + `|\d+(?:\.\d+)?)\s*(square\s+feet|sq\.?\s*ft\b|ft2\b|ft²)`),
 		UnitType:   Area,
-		UnitNames:  []string{"square feet", "sq ft", "ft²"},
+		UnitNames:  []string{"square feet", "sq ft", "sqft", "ft²", "ft2"},
 		Confidence: 0.95,
 	})
 
 	// Acres patterns - capture only number and unit
 	p.AreaPatterns = append(p.AreaPatterns, UnitPattern{
-		Pattern:    regexp.MustCompile(`(?i)\b(\d+(?:\.\d+)?(?:,\d{3})*)\s*(acres?|acre)\b`),
+		Pattern:    regexp.MustCompile(`(?i)\b(` + groupedNumber + `|\d+(?:\.\d+)?)\s*(acres?|acre)\b`),
 		UnitType:   Area,
 		UnitNames:  []string{"acres", "acre"},
 		Confidence: 0.9,
 	})
 }
 
+// initializeSpeedPatterns creates regex patterns for speed units (miles per hour)
+func (p *UnitPatterns) initializeSpeedPatterns() {
+	p.SpeedPatterns = append(p.SpeedPatterns, UnitPattern{
+		Pattern:    regexp.MustCompile(`(?i)\b(` + groupedNumber + `|\d+(?:\.\d+)?)\s*(mph|miles\s+(?:per|an)\s+hour)\b`),
+		UnitType:   Speed,
+		UnitNames:  []string{"mph", "miles per hour", "miles an hour"},
+		Confidence: 0.95,
+	})
+}
+
+// initializePressurePatterns creates regex patterns for pressure units (pounds per square inch)
+func (p *UnitPatterns) initializePressurePatterns() {
+	p.PressurePatterns = append(p.PressurePatterns, UnitPattern{
+		Pattern:    regexp.MustCompile(`(?i)\b(` + groupedNumber + `|\d+(?:\.\d+)?)\s*(psi|pounds\s+per\s+square\s+inch)\b`),
+		UnitType:   Pressure,
+		UnitNames:  []string{"psi", "pounds per square inch"},
+		Confidence: 0.95,
+	})
+}
+
+// initializeSpelledQuantityPatterns creates regex patterns for quantities spelled out in words
+// rather than numbers. Only singular units are matched, and units that are more often idiomatic
+// or ambiguous with a spelled-out quantity ("a ton of", "a pint", "an ounce of") are left out.
+func (p *UnitPatterns) initializeSpelledQuantityPatterns() {
+	p.SpelledQuantityPatterns = append(p.SpelledQuantityPatterns, UnitPattern{
+		Pattern:    regexp.MustCompile(`(?i)\b(` + spelledQuantity + `)\s+(foot|inch|yard|mile)\b`),
+		UnitType:   Length,
+		UnitNames:  []string{"foot", "inch", "yard", "mile"},
+		Confidence: 0.7,
+	})
+	p.SpelledQuantityPatterns = append(p.SpelledQuantityPatterns, UnitPattern{
+		Pattern:    regexp.MustCompile(`(?i)\b(` + spelledQuantity + `)\s+(pound)\b`),
+		UnitType:   Mass,
+		UnitNames:  []string{"pound"},
+		Confidence: 0.7,
+	})
+	p.SpelledQuantityPatterns = append(p.SpelledQuantityPatterns, UnitPattern{
+		Pattern:    regexp.MustCompile(`(?i)\b(` + spelledQuantity + `)\s+(gallon)\b`),
+		UnitType:   Volume,
+		UnitNames:  []string{"gallon"},
+		Confidence: 0.7,
+	})
+}
+
+// initializeCookingPatterns creates regex patterns for US cooking measures. The quantity may be a
+// number, a fraction ("1 1/2 cups"), a written number or a spelled-out quantity ("half a cup").
+// A stick is only matched when it's a stick of butter, though only the quantity and "stick" are
+// converted.
+func (p *UnitPatterns) initializeCookingPatterns() {
+	const quantity = `(\d+\s+\d+/\d+|\d+/\d+|\d+(?:\.\d+)?|` + writtenNumber + `|` + spelledQuantity + `)`
+
+	p.CookingPatterns = append(p.CookingPatterns, UnitPattern{
+		Pattern:    regexp.MustCompile(`(?i)\b` + quantity + `\s+(cups?)\b`),
+		UnitType:   Volume,
+		UnitNames:  []string{"cups", "cup"},
+		Confidence: 0.85,
+	})
+	p.CookingPatterns = append(p.CookingPatterns, UnitPattern{
+		Pattern:    regexp.MustCompile(`(?i)\b` + quantity + `\s*(tablespoons?|tbsps?|tbs)\b`),
+		UnitType:   Volume,
+		UnitNames:  []string{"tablespoons", "tablespoon", "tbsps", "tbsp", "tbs"},
+		Confidence: 0.9,
+	})
+	p.CookingPatterns = append(p.CookingPatterns, UnitPattern{
+		Pattern:    regexp.MustCompile(`(?i)\b` + quantity + `\s*(teaspoons?|tsps?)\b`),
+		UnitType:   Volume,
+		UnitNames:  []string{"teaspoons", "teaspoon", "tsps", "tsp"},
+		Confidence: 0.9,
+	})
+	p.CookingPatterns = append(p.CookingPatterns, UnitPattern{
+		Pattern: regexp.MustCompile(`(?i)\b` + quantity + `\s+(sticks?)\s+(?:of\s+)?` +
+			`(?:(?:unsalted|salted|softened|cold|chilled|melted|room[\s-]temperature)\s+)*butter\b`),
+		UnitType:   Mass,
+		UnitNames:  []string{"sticks", "stick"},
+		Confidence: 0.9,
+	})
+}
+
 // initializeExclusionPatterns creates patterns for excluding idiomatic usage
 func (p *UnitPatterns) initializeExclusionPatterns() {
 	// Idiomatic expressions that should NOT be converted
@@ -254,6 +394,19 @@ func (p *UnitPatterns) initializeExclusionPatterns() {
 		// Compound words that aren't measurements
 		`(?i)\b(?:milestone|footprint|yardstick|inchworm|footstep|foothold|footpath)\b`,
 
+		// Idioms with a spelled-out quantity
+		`(?i)(?:put|set)\s+a\s+foot\s+(?:wrong|in|on|down)`,
+		`(?i)a\s+foot\s+in\s+(?:the\s+)?(?:door|grave)`,
+		`(?i)by\s+a\s+mile`,
+		`(?i)a\s+mile\s+(?:a\s+minute|off|away)`,
+		`(?i)(?:give|gave|giving|budge|budged)\s+an\s+inch`,
+		`(?i)within\s+an\s+inch\s+of`,
+		`(?i)a\s+pound\s+of\s+flesh`,
+
+		// Cups that are drinks or trophies rather than measures
+		`(?i)cups?\s+of\s+(?:tea|coffee|cocoa|hot\s+chocolate|joe|espresso|cheer|kindness)`,
+		`(?i)cups?\s+(?:holders?|sizes?|final|tie|match|winners?|run)\b`,
+
 		// Temperature context exclusions
 		`(?i)fahrenheit\s+(?:scale|thermometer)`,
 	}
@@ -272,6 +425,8 @@ func (p *UnitPatterns) GetAllPatterns() map[UnitType][]UnitPattern {
 		Volume:      p.VolumePatterns,
 		Temperature: p.TemperaturePatterns,
 		Area:        p.AreaPatterns,
+		Speed:       p.SpeedPatterns,
+		Pressure:    p.PressurePatterns,
 	}
 }
 
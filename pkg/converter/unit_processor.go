@@ -10,9 +10,13 @@ import (
 
 // UnitProcessor handles unit detection and conversion
 type UnitProcessor struct {
-	detector  UnitDetector
-	converter UnitConverter
-	config    *UnitConfig
+	detector         UnitDetector
+	converter        UnitConverter
+	config           *UnitConfig
+	preferredTargets []preferredTarget // parsed from config.PreferredTargets
+	explain          *explainLog       // records each conversion, when the converter explains its changes
+	counters         *conversionCounters
+	warnings         *warningLog // records conversions next to an idiom, when ambiguity warnings are enabled
 }
 
 // NewUnitProcessor creates a new UnitProcessor with default components
@@ -67,6 +71,52 @@ func (p *UnitProcessor) IsEnabled() bool {
 	return p.config != nil && p.config.Enabled
 }
 
+// SetNormaliseEnabled enables or disables normalising the spacing and symbols of metric units
+// already in the text. It works whether or not imperial units are converted.
+func (p *UnitProcessor) SetNormaliseEnabled(enabled bool) {
+	if p.config != nil {
+		p.config.NormaliseUnits = enabled
+	}
+}
+
+// IsNormaliseEnabled returns whether metric units are normalised
+func (p *UnitProcessor) IsNormaliseEnabled() bool {
+	return p.config != nil && p.config.NormaliseUnits
+}
+
+// SetKeepOriginal controls whether converted units keep the original measurement, with the
+// metric value after it in parentheses: "10 feet (3 metres)"
+func (p *UnitProcessor) SetKeepOriginal(enabled bool) {
+	if p.config != nil {
+		p.config.Preferences.KeepOriginal = enabled
+		p.converter.SetPreferences(p.config.Preferences)
+	}
+}
+
+// IsKeepOriginalEnabled returns whether converted units keep the original measurement
+func (p *UnitProcessor) IsKeepOriginalEnabled() bool {
+	return p.config != nil && p.config.Preferences.KeepOriginal
+}
+
+// SetCookingMeasures controls whether US cooking measures (cups, tablespoons, teaspoons and
+// sticks of butter) are converted along with the other units
+func (p *UnitProcessor) SetCookingMeasures(enabled bool) {
+	if p.config != nil {
+		p.config.CookingMeasures = enabled
+		p.applyConfigToComponents()
+	}
+}
+
+// IsCookingMeasuresEnabled returns whether US cooking measures are converted
+func (p *UnitProcessor) IsCookingMeasuresEnabled() bool {
+	return p.config != nil && p.config.CookingMeasures
+}
+
+// IsActive returns whether ProcessText does anything: converting units, normalising them, or both
+func (p *UnitProcessor) IsActive() bool {
+	return p.IsEnabled() || p.IsNormaliseEnabled()
+}
+
 // GetConfig returns the current configuration
 func (p *UnitProcessor) GetConfig() *UnitConfig {
 	return p.config
@@ -91,6 +141,7 @@ func (p *UnitProcessor) applyConfigToComponents() {
 	if detector, ok := p.detector.(*ContextualUnitDetector); ok {
 		detector.SetMinConfidence(p.config.Detection.MinConfidence)
 		detector.SetMaxNumberDistance(p.config.Detection.MaxNumberDistance)
+		detector.SetCookingMeasuresEnabled(p.config.CookingMeasures)
 	}
 
 	// Apply configuration to converter
@@ -108,17 +159,27 @@ func (p *UnitProcessor) applyConfigToComponents() {
 				converter.SetPrecision(Temperature, p.config.GetPrecisionForUnitType(Temperature))
 			case "area":
 				converter.SetPrecision(Area, p.config.GetPrecisionForUnitType(Area))
+			case "speed":
+				converter.SetPrecision(Speed, p.config.GetPrecisionForUnitType(Speed))
+			case "pressure":
+				converter.SetPrecision(Pressure, p.config.GetPrecisionForUnitType(Pressure))
 			}
 		}
 
 		// Set conversion preferences
 		converter.SetPreferences(p.config.Preferences)
 	}
+
+	// Invalid targets are rejected by ValidateConfig, so any that get here are ignored
+	p.preferredTargets, _ = parsePreferredTargets(p.config.PreferredTargets)
+	if converter, ok := p.converter.(*BasicUnitConverter); ok {
+		converter.setPreferredTargets(p.preferredTargets)
+	}
 }
 
-// ProcessText processes text for unit conversion
+// ProcessText processes text for unit conversion and normalisation
 func (p *UnitProcessor) ProcessText(text string, isCode bool, language string) string {
-	if !p.IsEnabled() {
+	if !p.IsActive() {
 		return text
 	}
 
@@ -128,12 +189,30 @@ func (p *UnitProcessor) ProcessText(text string, isCode bool, language string) s
 	}
 
 	// For regular text, detect and convert all units
-	return p.convertUnitsInText(text)
+	return p.processUnitsInText(text)
+}
+
+// Process implements Processor. Code, identified by the WithLanguage option, only has its
+// comments processed.
+func (p *UnitProcessor) Process(text string, opts ...ProcessOption) string {
+	options := NewProcessOptions(opts...)
+	return p.ProcessText(text, options.Language != "", options.Language)
+}
+
+// processUnitsInText converts imperial units and then normalises metric ones, as enabled
+func (p *UnitProcessor) processUnitsInText(text string) string {
+	if p.IsEnabled() {
+		text = p.convertUnitsInText(text)
+	}
+	if p.IsNormaliseEnabled() {
+		text = p.NormaliseUnits(text)
+	}
+	return text
 }
 
 // ProcessComments processes only comments within code for unit conversion
 func (p *UnitProcessor) ProcessComments(code string, language string) string {
-	if !p.IsEnabled() {
+	if !p.IsActive() {
 		return code
 	}
 
@@ -150,7 +229,7 @@ func (p *UnitProcessor) ProcessComments(code string, language string) string {
 		comment := comments[i]
 
 		// Convert units in the comment content
-		convertedContent := p.convertUnitsInText(comment.Content)
+		convertedContent := p.processUnitsInText(comment.Content)
 
 		// If the original comment had a trailing newline, preserve it
 		originalBlock := code[comment.Start:comment.End]
@@ -220,9 +299,13 @@ func (p *UnitProcessor) extractCommentsFromCode(code string) []CommentBlock {
 		}
 	}
 
-	return comments
+	return dropNestedComments(comments)
 }
 
+// existingConversionRegex matches a parenthesised value straight after a unit, such as the
+// " (3 metres)" in "10 feet (3 metres)"
+var existingConversionRegex = regexp.MustCompile(`^\s*\(\s*[-−]?\d[^()\n]*\)`)
+
 // convertUnitsInText performs the actual unit detection and conversion
 func (p *UnitProcessor) convertUnitsInText(text string) string {
 	// Detect units in the text
@@ -245,6 +328,11 @@ func (p *UnitProcessor) convertUnitsInText(text string) string {
 			continue
 		}
 
+		// Units the user prefers to keep, such as miles for road distances, are left as written
+		if target, ok := findPreferredTarget(p.preferredTargets, match); ok && target == KeepUnit {
+			continue
+		}
+
 		filteredMatches = append(filteredMatches, match)
 	}
 
@@ -254,9 +342,20 @@ func (p *UnitProcessor) convertUnitsInText(text string) string {
 
 	// Process matches in reverse order to maintain positions
 	result := text
+	var explanations []Explanation
+	var warnings []AmbiguityWarning
 	for i := len(filteredMatches) - 1; i >= 0; i-- {
 		match := filteredMatches[i]
 
+		if p.config.Preferences.KeepOriginal {
+			// An existing parenthetical means an earlier run already added the conversion
+			if existingConversionRegex.MatchString(result[match.End:]) {
+				continue
+			}
+			// The conversion stands alone in parentheses, so "6-foot" gives "(1.8 metres)"
+			match.IsCompound = false
+		}
+
 		// Convert the unit
 		conversion, err := p.converter.Convert(match)
 		if err != nil {
@@ -267,18 +366,48 @@ func (p *UnitProcessor) convertUnitsInText(text string) string {
 
 		// Handle compound units specially to preserve hyphen structure
 		var replacement string
-		if match.IsCompound {
+		if p.config.Preferences.KeepOriginal {
+			replacement = result[match.Start:match.End] + " (" + conversion.Formatted + ")"
+		} else if match.IsDimension {
+			// Dimensions are formatted component by component, including a hyphenated unit
+			replacement = conversion.Formatted
+		} else if match.IsCompound && p.config.Preferences.RoundingStrategy != RoundingDefault {
+			// The chosen rounding applies to compound units too
+			value := strings.TrimSpace(strings.TrimSuffix(conversion.Formatted, conversion.MetricUnit))
+			replacement = value + "-" + conversion.MetricUnit
+		} else if match.IsCompound {
 			// For compound units like "9-foot", format as "2.7-metre"
 			replacement = fmt.Sprintf("%.1f-%s", conversion.MetricValue, conversion.MetricUnit)
 		} else {
 			replacement = conversion.Formatted
 		}
 
+		if p.explain != nil {
+			explanations = append(explanations, Explanation{
+				Original:   result[match.Start:match.End],
+				Converted:  replacement,
+				Rule:       "unit: " + match.Unit + "→" + conversion.MetricUnit,
+				Confidence: match.Confidence,
+			})
+		}
+		if p.warnings != nil && hasIdiomaticContext(strings.ToLower(match.Context), match.UnitType) {
+			warnings = append(warnings, AmbiguityWarning{
+				Text:       result[match.Start:match.End],
+				Converted:  replacement,
+				Reason:     "measurement next to an idiom",
+				Context:    warningContext(result, match.Start, match.End),
+				Confidence: match.Confidence,
+			})
+		}
+
 		// Replace the original unit with the converted one
 		before := result[:match.Start]
 		after := result[match.End:]
 		result = before + replacement + after
+		p.counters.add(countUnits, 1)
 	}
+	p.explain.explainReversed(explanations)
+	p.warnings.recordReversed(warnings)
 
 	return result
 }
@@ -0,0 +1,180 @@
+// Package converter provides user-chosen target units for unit conversion
+package converter
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// KeepUnit is the PreferredTargets value that leaves an American unit as written, as for
+// "miles" on UK road signs
+const KeepUnit = "keep"
+
+// kilogramsPerStone is the mass of one stone (14 pounds)
+const kilogramsPerStone = 6.35029318
+
+// preferredTargetUnits maps each American unit, and the other names it's detected under, to
+// the name used in PreferredTargets keys
+var preferredTargetUnits = map[string]string{
+	"feet": "feet", "foot": "feet", "ft": "feet",
+	"inches": "inches", "inch": "inches", "in": "inches",
+	"yards": "yards", "yard": "yards", "yd": "yards",
+	"miles": "miles", "mile": "miles", "mi": "miles",
+	"pounds": "pounds", "pound": "pounds", "lbs": "pounds", "lb": "pounds",
+	"ounces": "ounces", "ounce": "ounces", "oz": "ounces",
+	"tons": "tons", "ton": "tons",
+	"gallons": "gallons", "gallon": "gallons", "gal": "gallons",
+	"quarts": "quarts", "quart": "quarts", "qt": "quarts",
+	"pints": "pints", "pint": "pints", "pt": "pints",
+	"fluid ounces": "fluid ounces", "fluid ounce": "fluid ounces", "fl oz": "fluid ounces", "floz": "fluid ounces",
+	"square feet": "square feet", "sq ft": "square feet", "sqft": "square feet", "ft²": "square feet", "ft2": "square feet",
+	"acres": "acres", "acre": "acres",
+	"fahrenheit": "fahrenheit", "°f": "fahrenheit", "f": "fahrenheit", "degrees fahrenheit": "fahrenheit",
+	"mph": "mph", "miles per hour": "mph", "miles an hour": "mph",
+	"psi": "psi", "pounds per square inch": "psi",
+}
+
+// preferredTargetsByType lists the units each type of measurement can be converted to, besides
+// KeepUnit
+var preferredTargetsByType = map[UnitType][]string{
+	Length:   {"mm", "cm", "metres", "km"},
+	Mass:     {"mg", "g", "kg", "tonnes", "stone"},
+	Volume:   {"ml", "litres"},
+	Area:     {"m²", "hectares"},
+	Pressure: {"kPa", "bar"},
+}
+
+// preferredTargetUnitTypes is the type of measurement of each American unit
+var preferredTargetUnitTypes = map[string]UnitType{
+	"feet": Length, "inches": Length, "yards": Length, "miles": Length,
+	"pounds": Mass, "ounces": Mass, "tons": Mass,
+	"gallons": Volume, "quarts": Volume, "pints": Volume, "fluid ounces": Volume,
+	"square feet": Area, "acres": Area,
+	"fahrenheit": Temperature, "mph": Speed, "psi": Pressure,
+}
+
+// preferredTargetKeyRegex matches a PreferredTargets key: a unit, optionally followed by a lower
+// bound on the value as written, as in "pounds >= 100"
+var preferredTargetKeyRegex = regexp.MustCompile(`^\s*([^<>=]*?)\s*(?:(>=|>)\s*(\d+(?:\.\d+)?))?\s*$`)
+
+// preferredTarget is a parsed PreferredTargets entry
+type preferredTarget struct {
+	unit      string  // American unit, as named in preferredTargetUnits
+	minimum   float64 // lower bound on the value; -Inf when the entry has none
+	inclusive bool    // whether a value equal to minimum matches
+	target    string  // unit to convert to, or KeepUnit
+}
+
+// matches reports whether the entry applies to value
+func (t preferredTarget) matches(value float64) bool {
+	return value > t.minimum || t.inclusive && value == t.minimum
+}
+
+// parsePreferredTargets parses and validates PreferredTargets entries, returning them with the
+// highest lower bound first so the most specific entry for a value is found first
+func parsePreferredTargets(entries map[string]string) ([]preferredTarget, error) {
+	var targets []preferredTarget
+	for key, value := range entries {
+		m := preferredTargetKeyRegex.FindStringSubmatch(key)
+		if m == nil {
+			return nil, fmt.Errorf("invalid preferred target %q: expected a unit, optionally followed by > or >= and a number", key)
+		}
+		unitName, ok := preferredTargetUnits[strings.ToLower(m[1])]
+		if !ok {
+			return nil, fmt.Errorf("invalid preferred target %q: unknown unit %q", key, m[1])
+		}
+
+		target := preferredTarget{unit: unitName, minimum: math.Inf(-1), target: strings.TrimSpace(value)}
+		if m[2] != "" {
+			target.minimum, _ = strconv.ParseFloat(m[3], 64)
+			target.inclusive = m[2] == ">="
+		}
+
+		if !strings.EqualFold(target.target, KeepUnit) {
+			allowed := preferredTargetsByType[preferredTargetUnitTypes[unitName]]
+			i := slices.IndexFunc(allowed, func(name string) bool { return strings.EqualFold(name, target.target) })
+			if i < 0 {
+				if len(allowed) == 0 {
+					return nil, fmt.Errorf("invalid preferred target for %q: %s can only be %q", key, unitName, KeepUnit)
+				}
+				return nil, fmt.Errorf("invalid preferred target %q for %q (valid: %s, %s)", value, key, strings.Join(allowed, ", "), KeepUnit)
+			}
+			target.target = allowed[i]
+		} else {
+			target.target = KeepUnit
+		}
+		targets = append(targets, target)
+	}
+
+	sort.Slice(targets, func(i, j int) bool {
+		if targets[i].minimum != targets[j].minimum {
+			return targets[i].minimum > targets[j].minimum
+		}
+		return targets[i].inclusive && !targets[j].inclusive
+	})
+	for i := 1; i < len(targets); i++ {
+		previous, current := targets[i-1], targets[i]
+		if previous.unit == current.unit && previous.minimum == current.minimum && previous.inclusive == current.inclusive {
+			return nil, fmt.Errorf("more than one preferred target for %s with the same condition", current.unit)
+		}
+	}
+	return targets, nil
+}
+
+// findPreferredTarget returns the unit the user prefers a match to be converted to, if any:
+// that of the entry for its unit with the highest lower bound its value passes. Ranges and
+// dimensions are judged by their largest value.
+func findPreferredTarget(targets []preferredTarget, match UnitMatch) (string, bool) {
+	unitName, ok := preferredTargetUnits[strings.ToLower(match.Unit)]
+	if !ok {
+		return "", false
+	}
+	for _, target := range targets {
+		if target.unit == unitName && target.matches(match.Value) {
+			return target.target, true
+		}
+	}
+	return "", false
+}
+
+// setPreferredTargets sets the units that conversions are made to in place of the automatic
+// choice, as parsed from UnitConfig.PreferredTargets
+func (c *BasicUnitConverter) setPreferredTargets(targets []preferredTarget) {
+	c.preferredTargets = targets
+}
+
+// preferredUnit returns the unit the user prefers match to be converted to, or automatic when
+// they have no preference. A compound unit such as "6-foot" takes the singular "metre".
+func (c *BasicUnitConverter) preferredUnit(match UnitMatch, automatic string) string {
+	target, ok := findPreferredTarget(c.preferredTargets, match)
+	if !ok || target == KeepUnit {
+		return automatic
+	}
+	if target == "metres" && match.IsCompound {
+		return "metre"
+	}
+	return target
+}
+
+// formatStone formats a mass in stone and pounds, the way body weight is given in the UK:
+// "12 stone 3 lb", or "12 stone" when there are no pounds left over
+func (c *BasicUnitConverter) formatStone(kg float64) (float64, string) {
+	stones := kg / kilogramsPerStone
+	whole := math.Floor(stones)
+	pounds := math.Round((stones - whole) * 14)
+	if pounds == 14 {
+		whole++
+		pounds = 0
+	}
+
+	formatted := c.joinValueAndUnit(strconv.FormatFloat(whole, 'f', 0, 64), "stone")
+	if pounds > 0 {
+		formatted += " " + c.joinValueAndUnit(strconv.FormatFloat(pounds, 'f', 0, 64), "lb")
+	}
+	return stones, formatted
+}
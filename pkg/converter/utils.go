@@ -34,6 +34,17 @@ func capitalize(s string) string {
 	return strings.ToUpper(s[:1]) + s[1:]
 }
 
+// matchCase applies the capitalisation of original (all caps or a leading capital) to replacement
+func matchCase(replacement, original string) string {
+	if isAllCaps(original) && len(original) > 1 {
+		return strings.ToUpper(replacement)
+	}
+	if isCapitalized(original) {
+		return capitalize(replacement)
+	}
+	return replacement
+}
+
 // splitPunctuation separates a word from its trailing punctuation
 func splitPunctuation(word string) (string, string) {
 	for i := len(word) - 1; i >= 0; i-- {
@@ -0,0 +1,116 @@
+// Package converter provides a pre-filter that skips lines which can't contain a dictionary word
+package converter
+
+import "strings"
+
+// wordFilter is an Aho-Corasick automaton over the lowercase keys of a dictionary. Every
+// conversion strategy looks up a lowercased substring of a token, so a line whose lowercase
+// form contains no dictionary key can't change and may be skipped. The filter only ever
+// short-circuits the normal conversion; it never decides a replacement.
+type wordFilter struct {
+	matchAll bool       // set when the dictionary has an empty key, which any line could match
+	classes  [256]uint8 // byte -> alphabet index; 0 is for bytes that appear in no key
+	alphabet int        // number of alphabet indexes, including 0
+	next     []int32    // state*alphabet+class -> next state, with failure links resolved
+	match    []bool     // state -> a key ends here or at one of its suffixes
+}
+
+// newWordFilter builds the automaton for the keys of dict
+func newWordFilter(dict map[string]string) *wordFilter {
+	f := &wordFilter{alphabet: 1}
+
+	for key := range dict {
+		if key == "" {
+			f.matchAll = true
+			return f
+		}
+		for i := 0; i < len(key); i++ {
+			if f.classes[key[i]] == 0 {
+				if f.alphabet == 256 {
+					// Too many distinct bytes for the class table; don't filter
+					f.matchAll = true
+					return f
+				}
+				f.classes[key[i]] = uint8(f.alphabet)
+				f.alphabet++
+			}
+		}
+	}
+	// Uppercase ASCII folds onto the lowercase classes so pure ASCII lines needn't be lowercased
+	for b := byte('A'); b <= 'Z'; b++ {
+		f.classes[b] = f.classes[b+'a'-'A']
+	}
+
+	// Build the trie. State 0 is the root; a zero transition means "no child" until the
+	// failure links are resolved below.
+	f.next = make([]int32, f.alphabet)
+	f.match = []bool{false}
+	for key := range dict {
+		state := int32(0)
+		for i := 0; i < len(key); i++ {
+			idx := int(state)*f.alphabet + int(f.classes[key[i]])
+			if f.next[idx] == 0 {
+				f.next = append(f.next, make([]int32, f.alphabet)...)
+				f.match = append(f.match, false)
+				f.next[idx] = int32(len(f.match) - 1)
+			}
+			state = f.next[idx]
+		}
+		f.match[state] = true
+	}
+
+	// Resolve failure links breadth first, turning the trie into a DFA
+	fail := make([]int32, len(f.match))
+	queue := make([]int32, 0, len(f.match))
+	for class := 1; class < f.alphabet; class++ {
+		if child := f.next[class]; child != 0 {
+			queue = append(queue, child)
+		}
+	}
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+		f.match[state] = f.match[state] || f.match[fail[state]]
+
+		for class := 1; class < f.alphabet; class++ {
+			idx := int(state)*f.alphabet + class
+			fallback := f.next[int(fail[state])*f.alphabet+class]
+			if child := f.next[idx]; child != 0 {
+				fail[child] = fallback
+				queue = append(queue, child)
+			} else {
+				f.next[idx] = fallback
+			}
+		}
+	}
+
+	return f
+}
+
+// mayContainWord reports whether line could contain a dictionary word. A false result is
+// definite; a true result means the line needs converting as normal.
+func (f *wordFilter) mayContainWord(line string) bool {
+	if f == nil || f.matchAll {
+		return true
+	}
+
+	for i := 0; i < len(line); i++ {
+		if line[i] >= 0x80 {
+			// Lowercasing non-ASCII text can change its bytes, so scan the lowercased line
+			return f.scan(strings.ToLower(line))
+		}
+	}
+	return f.scan(line)
+}
+
+// scan runs the automaton over text, stopping at the first key found
+func (f *wordFilter) scan(text string) bool {
+	state := int32(0)
+	for i := 0; i < len(text); i++ {
+		state = f.next[int(state)*f.alphabet+int(f.classes[text[i]])]
+		if f.match[state] {
+			return true
+		}
+	}
+	return false
+}
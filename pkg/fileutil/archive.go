@@ -0,0 +1,238 @@
+// Package fileutil provides rewriting of the files inside zip and tar archives
+package fileutil
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ArchiveEntryFunc returns the new content of the archive entry name, or content itself to leave
+// the entry unchanged
+type ArchiveEntryFunc func(name string, content []byte) []byte
+
+// IsArchiveFile checks if a file extension indicates an archive whose entries can be rewritten:
+// .zip, .tar, .tar.gz or .tgz
+func IsArchiveFile(filePath string) bool {
+	return archiveFormat(filePath) != ""
+}
+
+// archiveFormat returns "zip", "tar" or "tar.gz" for an archive path, or "" for anything else
+func archiveFormat(filePath string) string {
+	lower := strings.ToLower(filePath)
+	switch {
+	case strings.HasSuffix(lower, ".zip"):
+		return "zip"
+	case strings.HasSuffix(lower, ".tar"):
+		return "tar"
+	case strings.HasSuffix(lower, ".tar.gz"), strings.HasSuffix(lower, ".tgz"):
+		return "tar.gz"
+	}
+	return ""
+}
+
+// RewriteArchive returns a copy of the archive in data, named filePath to determine its format,
+// with the content of each entry replaced by rewrite. rewrite is only called for regular files
+// that are no larger than maxEntrySize and aren't hidden or in an ignored directory, the same
+// files a directory scan would visit; every other entry is copied verbatim. An archive holding an
+// entry whose path is absolute or leaves the archive root through ".." is rejected.
+func RewriteArchive(filePath string, data []byte, maxEntrySize int64, rewrite ArchiveEntryFunc) ([]byte, error) {
+	switch archiveFormat(filePath) {
+	case "zip":
+		return rewriteZip(data, maxEntrySize, rewrite)
+	case "tar":
+		return rewriteTar(data, false, maxEntrySize, rewrite)
+	case "tar.gz":
+		return rewriteTar(data, true, maxEntrySize, rewrite)
+	}
+	return nil, fmt.Errorf("%s is not a zip or tar archive", filePath)
+}
+
+// checkEntryPath rejects entry names that would escape the archive root if extracted
+func checkEntryPath(name string) error {
+	name = strings.TrimSuffix(name, "/")
+	if name == "" || strings.HasPrefix(name, "/") || strings.Contains(name, "\\") || !filepath.IsLocal(filepath.FromSlash(name)) {
+		return fmt.Errorf("unsafe path in archive: %q", name)
+	}
+	return nil
+}
+
+// isSkippedEntry reports whether a directory scan would skip the entry: hidden files and anything
+// under a hidden or ignored directory
+func isSkippedEntry(name string) bool {
+	parts := strings.Split(path.Clean(name), "/")
+	for i, part := range parts {
+		if strings.HasPrefix(part, ".") {
+			return true
+		}
+		if i < len(parts)-1 {
+			for _, ignored := range ignoredDirs {
+				if strings.ToLower(part) == ignored {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// rewriteZip rewrites the entries of a zip archive. Unchanged entries keep their compressed data
+// and headers byte for byte.
+func rewriteZip(data []byte, maxEntrySize int64, rewrite ArchiveEntryFunc) ([]byte, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("not a valid zip archive: %w", err)
+	}
+	for _, f := range archive.File {
+		if err := checkEntryPath(f.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	if err := writer.SetComment(archive.Comment); err != nil {
+		return nil, err
+	}
+
+	for _, f := range archive.File {
+		if f.Mode().IsRegular() && !isSkippedEntry(f.Name) && f.UncompressedSize64 <= uint64(maxEntrySize) {
+			content, err := readZipEntry(f, maxEntrySize)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s: %w", f.Name, err)
+			}
+			if converted := rewrite(f.Name, content); !bytes.Equal(converted, content) {
+				header := f.FileHeader
+				w, err := writer.CreateHeader(&header)
+				if err != nil {
+					return nil, err
+				}
+				if _, err := w.Write(converted); err != nil {
+					return nil, err
+				}
+				continue
+			}
+		}
+
+		if err := copyZipEntry(writer, f); err != nil {
+			return nil, fmt.Errorf("failed to copy %s: %w", f.Name, err)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// readZipEntry reads an entry's content, refusing entries that inflate beyond maxSize whatever
+// size their header claims
+func readZipEntry(f *zip.File, maxSize int64) ([]byte, error) {
+	r, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	content, err := io.ReadAll(io.LimitReader(r, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(content)) > maxSize {
+		return nil, errors.New("entry is larger than its header claims")
+	}
+	return content, nil
+}
+
+// copyZipEntry copies an entry's compressed data, and its header, to writer unchanged
+func copyZipEntry(writer *zip.Writer, f *zip.File) error {
+	r, err := f.OpenRaw()
+	if err != nil {
+		return err
+	}
+	w, err := writer.CreateRaw(&f.FileHeader)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, r)
+	return err
+}
+
+// rewriteTar rewrites the entries of a tar archive, optionally gzip compressed. Entry headers
+// are kept apart from the size of rewritten entries.
+func rewriteTar(data []byte, gzipped bool, maxEntrySize int64, rewrite ArchiveEntryFunc) ([]byte, error) {
+	var input io.Reader = bytes.NewReader(data)
+	var gzipHeader gzip.Header
+	if gzipped {
+		gz, err := gzip.NewReader(input)
+		if err != nil {
+			return nil, fmt.Errorf("not a valid gzip archive: %w", err)
+		}
+		defer gz.Close()
+		gzipHeader = gz.Header
+		input = gz
+	}
+
+	var buf bytes.Buffer
+	var output io.Writer = &buf
+	var gzipWriter *gzip.Writer
+	if gzipped {
+		gzipWriter = gzip.NewWriter(&buf)
+		gzipWriter.Header = gzipHeader
+		output = gzipWriter
+	}
+
+	reader := tar.NewReader(input)
+	writer := tar.NewWriter(output)
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("not a valid tar archive: %w", err)
+		}
+		if err := checkEntryPath(header.Name); err != nil {
+			return nil, err
+		}
+
+		if header.Typeflag == tar.TypeReg && !isSkippedEntry(header.Name) && header.Size <= maxEntrySize {
+			content, err := io.ReadAll(reader)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s: %w", header.Name, err)
+			}
+			content = rewrite(header.Name, content)
+			header.Size = int64(len(content))
+			if err := writer.WriteHeader(header); err != nil {
+				return nil, err
+			}
+			if _, err := writer.Write(content); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if err := writer.WriteHeader(header); err != nil {
+			return nil, err
+		}
+		if _, err := io.Copy(writer, reader); err != nil {
+			return nil, fmt.Errorf("failed to copy %s: %w", header.Name, err)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	if gzipWriter != nil {
+		if err := gzipWriter.Close(); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,66 @@
+// Package fileutil provides filtering of found files by their extensions
+package fileutil
+
+import (
+	"slices"
+	"strings"
+)
+
+// ExtensionFilter chooses files by extension: only files with one of the Include extensions,
+// when any are given, and none of the Exclude extensions. Extensions are matched against the
+// end of the file name without regard to case, so ".d.ts" and ".tar.gz" work too.
+type ExtensionFilter struct {
+	Include []string
+	Exclude []string
+}
+
+// ParseExtensions splits a comma-separated list of extensions such as ".md,txt" into
+// lower-case extensions that each start with a dot
+func ParseExtensions(list string) []string {
+	var extensions []string
+	for _, ext := range strings.Split(list, ",") {
+		ext = strings.ToLower(strings.TrimSpace(ext))
+		if ext == "" || ext == "." {
+			continue
+		}
+		if !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+		if !slices.Contains(extensions, ext) {
+			extensions = append(extensions, ext)
+		}
+	}
+	return extensions
+}
+
+// IsEmpty reports whether the filter lets every file through
+func (f ExtensionFilter) IsEmpty() bool {
+	return len(f.Include) == 0 && len(f.Exclude) == 0
+}
+
+// Matches reports whether the filter lets the file at path through
+func (f ExtensionFilter) Matches(path string) bool {
+	name := strings.ToLower(path)
+	hasExtension := func(ext string) bool {
+		return strings.HasSuffix(name, ext)
+	}
+	if len(f.Include) > 0 && !slices.ContainsFunc(f.Include, hasExtension) {
+		return false
+	}
+	return !slices.ContainsFunc(f.Exclude, hasExtension)
+}
+
+// FilterFiles returns the files, such as those found by FindTextFiles, that the filter lets
+// through, in the same order
+func FilterFiles(files []FileInfo, filter ExtensionFilter) []FileInfo {
+	if filter.IsEmpty() {
+		return files
+	}
+	var filtered []FileInfo
+	for _, file := range files {
+		if filter.Matches(file.Path) {
+			filtered = append(filtered, file)
+		}
+	}
+	return filtered
+}
@@ -6,7 +6,9 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+	"time"
 	"unicode/utf8"
 )
 
@@ -16,18 +18,39 @@ type FileInfo struct {
 	RelativePath string
 	IsText       bool
 	Size         int64
+	ModTime      time.Time
 }
 
 // IsTextFile determines if a file is likely to be a plain text file
 func IsTextFile(path string) (bool, error) {
+	if isText, known := isTextByExtension(path); known {
+		return isText, nil
+	}
+
+	// For unknown extensions, check file content
+	return isTextFileByContent(path)
+}
+
+// IsTextData determines if data read from a file named name, such as an archive entry, is
+// likely to be plain text, using the same rules as IsTextFile
+func IsTextData(name string, data []byte) bool {
+	if isText, known := isTextByExtension(name); known {
+		return isText
+	}
+	return isTextContent(data[:min(len(data), 512)])
+}
+
+// isTextByExtension classifies a file by its extension, returning known as false when the
+// extension says nothing either way and the content has to be checked
+func isTextByExtension(path string) (isText, known bool) {
 	// Check file extension first for quick filtering
 	ext := strings.ToLower(filepath.Ext(path))
 
 	// Known text file extensions
 	textExtensions := []string{
 		".txt", ".md", ".markdown", ".rst", ".adoc", ".asciidoc",
-		".tex", ".latex", ".org", ".wiki", ".textile",
-		".csv", ".tsv", ".json", ".xml", ".yaml", ".yml",
+		".tex", ".latex", ".org", ".wiki", ".textile", ".rtf",
+		".srt", ".vtt", ".csv", ".tsv", ".json", ".ipynb", ".xml", ".svg", ".yaml", ".yml",
 		".toml", ".ini", ".cfg", ".conf", ".config",
 		".log", ".logs", ".out", ".err",
 		".dockerfile", ".gitignore", ".gitattributes",
@@ -40,28 +63,32 @@ func IsTextFile(path string) (bool, error) {
 		".exe", ".bin", ".dll", ".so", ".dylib", ".a", ".o", ".obj",
 		".jpg", ".jpeg", ".png", ".gif", ".bmp", ".tiff", ".webp", ".ico",
 		".mp3", ".mp4", ".avi", ".mov", ".wmv", ".flv", ".wav", ".ogg",
-		".pdf", ".doc", ".docx", ".xls", ".xlsx", ".ppt", ".pptx",
+		".pdf", ".doc", ".xls", ".xlsx", ".ppt", ".pptx",
 		".zip", ".tar", ".gz", ".bz2", ".xz", ".7z", ".rar",
 		".deb", ".rpm", ".dmg", ".pkg", ".msi",
 		".sqlite", ".db", ".sqlite3",
 	}
 
+	// Documents aren't plain text, but FindOptions.IncludeDocuments can find them
+	if slices.Contains(documentExtensions, ext) {
+		return false, true
+	}
+
 	// Quick exclude for known binary extensions
 	for _, binExt := range binaryExtensions {
 		if ext == binExt {
-			return false, nil
+			return false, true
 		}
 	}
 
 	// Quick include for known text extensions
 	for _, txtExt := range textExtensions {
 		if ext == txtExt {
-			return true, nil
+			return true, true
 		}
 	}
 
-	// For unknown extensions, check file content
-	return isTextFileByContent(path)
+	return false, false
 }
 
 // isTextFileByContent checks if a file is text by examining its content
@@ -81,19 +108,21 @@ func isTextFileByContent(path string) (bool, error) {
 		return false, err
 	}
 
-	// Check if the content is valid UTF-8 and doesn't contain null bytes
-	content := buffer[:n]
+	return isTextContent(buffer[:n]), nil
+}
 
+// isTextContent checks if the start of a file's content looks like text
+func isTextContent(content []byte) bool {
 	// Null bytes are a strong indicator of binary content
 	for _, b := range content {
 		if b == 0 {
-			return false, nil
+			return false
 		}
 	}
 
 	// Check if content is valid UTF-8
 	if !utf8.Valid(content) {
-		return false, nil
+		return false
 	}
 
 	// Check for high ratio of control characters (excluding common ones)
@@ -105,15 +134,121 @@ func isTextFileByContent(path string) (bool, error) {
 	}
 
 	// If more than 10% are control characters, likely binary
-	if float64(controlCount)/float64(len(content)) > 0.1 {
-		return false, nil
+	return controlCount*10 <= len(content)
+}
+
+// ignoredDirs lists common directories that are never processed
+var ignoredDirs = []string{
+	"node_modules", "bower_components",
+	"venv", "__pycache__", ".pytest_cache",
+	"target", "build", "dist", "out", "bin",
+	"vendor",
+	"tmp", "temp",
+}
+
+// versionControlDirs lists version control directories that are never processed, even with
+// IncludeHidden, as writing inside them would corrupt the repository
+var versionControlDirs = []string{".git", ".hg", ".svn"}
+
+// documentExtensions are the documents whose text can be converted although they aren't plain
+// text: Word (.docx) documents
+var documentExtensions = []string{".docx"}
+
+// FindOptions controls which files FindTextFilesWithOptions and FindFilesWithOptions visit
+type FindOptions struct {
+	// IncludeHidden descends into hidden directories and includes hidden files. Version control
+	// directories such as .git are skipped regardless.
+	IncludeHidden bool
+	// IncludeDocuments has FindTextFilesWithOptions also find Word (.docx) documents, whose
+	// text can be converted. They're found with IsText false.
+	IncludeDocuments bool
+}
+
+// findsFile reports whether a text file search with opts keeps the file at path, which is text
+// if isText is set
+func (opts FindOptions) findsFile(path string, isText bool) bool {
+	return isText || (opts.IncludeDocuments && slices.Contains(documentExtensions, strings.ToLower(filepath.Ext(path))))
+}
+
+// SkipsDir reports whether directories named name are skipped when searching, as version
+// control, hidden and common build and dependency directories are
+func (opts FindOptions) SkipsDir(name string) bool {
+	return opts.skipsDir(name, false)
+}
+
+// skipsDir is SkipsDir for a directory that may be the one being searched. That one is always
+// searched, so "." or a hidden directory named explicitly isn't skipped as hidden or ignored,
+// but version control directories never are.
+func (opts FindOptions) skipsDir(name string, isRoot bool) bool {
+	lowerName := strings.ToLower(name)
+	if slices.Contains(versionControlDirs, lowerName) {
+		return true
 	}
+	if isRoot {
+		return false
+	}
+	if strings.HasPrefix(name, ".") && !opts.IncludeHidden {
+		return true
+	}
+	return slices.Contains(ignoredDirs, lowerName)
+}
 
-	return true, nil
+// SkipsFile reports whether files named name are skipped when searching. Hidden files are
+// skipped unless they're included, and a .git file, which points a worktree or submodule at its
+// repository, always is.
+func (opts FindOptions) SkipsFile(name string) bool {
+	return strings.HasPrefix(name, ".") && (!opts.IncludeHidden || name == ".git")
+}
+
+// FindDirs returns rootPath and every directory under it that FindTextFilesWithOptions and
+// FindFilesWithOptions search with opts
+func FindDirs(rootPath string, opts FindOptions) ([]string, error) {
+	var dirs []string
+	err := filepath.WalkDir(rootPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Error accessing %s: %v\n", path, err)
+			return nil
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if opts.skipsDir(d.Name(), path == rootPath) {
+			return filepath.SkipDir
+		}
+		dirs = append(dirs, path)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk directory %s: %w", rootPath, err)
+	}
+	return dirs, nil
 }
 
 // FindTextFiles recursively finds all text files in a directory
 func FindTextFiles(rootPath string) ([]FileInfo, error) {
+	return findFiles(rootPath, true, FindOptions{})
+}
+
+// FindTextFilesWithOptions recursively finds all text files in a directory as FindTextFiles
+// does, with opts applied
+func FindTextFilesWithOptions(rootPath string, opts FindOptions) ([]FileInfo, error) {
+	return findFiles(rootPath, true, opts)
+}
+
+// FindFiles recursively finds all files in a directory regardless of their content, skipping
+// hidden and ignored directories as FindTextFiles does. IsText is not populated.
+func FindFiles(rootPath string) ([]FileInfo, error) {
+	return findFiles(rootPath, false, FindOptions{})
+}
+
+// FindFilesWithOptions recursively finds all files in a directory as FindFiles does, with opts
+// applied
+func FindFilesWithOptions(rootPath string, opts FindOptions) ([]FileInfo, error) {
+	return findFiles(rootPath, false, opts)
+}
+
+// findFiles walks rootPath collecting files, keeping only text files when textOnly is set
+func findFiles(rootPath string, textOnly bool, opts FindOptions) ([]FileInfo, error) {
 	var files []FileInfo
 
 	// Check if the path is a directory
@@ -124,17 +259,21 @@ func FindTextFiles(rootPath string) ([]FileInfo, error) {
 
 	if !info.IsDir() {
 		// Single file
-		isText, err := IsTextFile(rootPath)
-		if err != nil {
-			return nil, fmt.Errorf("failed to check if file is text: %w", err)
+		isText := false
+		if textOnly {
+			isText, err = IsTextFile(rootPath)
+			if err != nil {
+				return nil, fmt.Errorf("failed to check if file is text: %w", err)
+			}
 		}
 
-		if isText {
+		if !textOnly || opts.findsFile(rootPath, isText) {
 			files = append(files, FileInfo{
 				Path:         rootPath,
 				RelativePath: filepath.Base(rootPath),
-				IsText:       true,
+				IsText:       isText,
 				Size:         info.Size(),
+				ModTime:      info.ModTime(),
 			})
 		}
 
@@ -151,64 +290,49 @@ func FindTextFiles(rootPath string) ([]FileInfo, error) {
 
 		// Skip common directories that should be ignored
 		if d.IsDir() {
-			dirName := d.Name()
-
-			// Skip all hidden directories (starting with .)
-			if strings.HasPrefix(dirName, ".") {
+			if opts.skipsDir(d.Name(), path == rootPath) {
 				return filepath.SkipDir
 			}
-
-			// Skip other common directories that should be ignored
-			lowerDirName := strings.ToLower(dirName)
-			ignoredDirs := []string{
-				"node_modules", "bower_components",
-				"venv", "__pycache__", ".pytest_cache",
-				"target", "build", "dist", "out", "bin",
-				"vendor",
-				"tmp", "temp",
-			}
-
-			for _, ignored := range ignoredDirs {
-				if lowerDirName == ignored {
-					return filepath.SkipDir
-				}
-			}
 			return nil
 		}
 
-		// Skip hidden files
-		if strings.HasPrefix(d.Name(), ".") {
+		if opts.SkipsFile(d.Name()) {
 			return nil
 		}
 
 		// Check if it's a text file
-		isText, err := IsTextFile(path)
-		if err != nil {
-			// Log error but continue
-			fmt.Fprintf(os.Stderr, "Warning: Error checking file type for %s: %v\n", path, err)
-			return nil
-		}
-
-		if isText {
-			info, err := d.Info()
+		isText := false
+		if textOnly {
+			isText, err = IsTextFile(path)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Warning: Error getting file info for %s: %v\n", path, err)
+				// Log error but continue
+				fmt.Fprintf(os.Stderr, "Warning: Error checking file type for %s: %v\n", path, err)
 				return nil
 			}
-
-			relPath, err := filepath.Rel(rootPath, path)
-			if err != nil {
-				relPath = path
+			if !opts.findsFile(path, isText) {
+				return nil
 			}
+		}
 
-			files = append(files, FileInfo{
-				Path:         path,
-				RelativePath: relPath,
-				IsText:       true,
-				Size:         info.Size(),
-			})
+		info, err := d.Info()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Error getting file info for %s: %v\n", path, err)
+			return nil
+		}
+
+		relPath, err := filepath.Rel(rootPath, path)
+		if err != nil {
+			relPath = path
 		}
 
+		files = append(files, FileInfo{
+			Path:         path,
+			RelativePath: relPath,
+			IsText:       isText,
+			Size:         info.Size(),
+			ModTime:      info.ModTime(),
+		})
+
 		return nil
 	})
 
@@ -263,6 +387,49 @@ func WriteFileContent(path, content string) error {
 	return nil
 }
 
+// CopyFile copies src to dst verbatim, creating dst's directory if needed and keeping the
+// source file's permissions
+func CopyFile(src, dst string) error {
+	info, err := os.Stat(src)
+	if err != nil {
+		return fmt.Errorf("failed to stat file %s: %w", src, err)
+	}
+
+	content, err := os.ReadFile(src)
+	if err != nil {
+		return fmt.Errorf("failed to read file %s: %w", src, err)
+	}
+
+	dir := filepath.Dir(dst)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+
+	if err := os.WriteFile(dst, content, info.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to write file %s: %w", dst, err)
+	}
+
+	return nil
+}
+
+// ProgressFunc is called by ProcessFiles after each file with the number of files processed so
+// far and the total, so callers can render their own progress indicator
+type ProgressFunc func(done, total int)
+
+// ProcessFiles calls process for each file in order, reporting progress after each one. It stops
+// at the first error returned by process. progress may be nil.
+func ProcessFiles(files []FileInfo, process func(FileInfo) error, progress ProgressFunc) error {
+	for i, file := range files {
+		if err := process(file); err != nil {
+			return err
+		}
+		if progress != nil {
+			progress(i+1, len(files))
+		}
+	}
+	return nil
+}
+
 // GetFileStats returns statistics about a set of files
 func GetFileStats(files []FileInfo) map[string]interface{} {
 	totalFiles := len(files)
@@ -0,0 +1,19 @@
+// Package fileutil provides filtering of found files by when they were modified
+package fileutil
+
+import "time"
+
+// FilterModifiedSince returns the files, such as those found by FindTextFiles, that were last
+// modified after since, in the same order. A zero since lets every file through.
+func FilterModifiedSince(files []FileInfo, since time.Time) []FileInfo {
+	if since.IsZero() {
+		return files
+	}
+	var filtered []FileInfo
+	for _, file := range files {
+		if file.ModTime.After(since) {
+			filtered = append(filtered, file)
+		}
+	}
+	return filtered
+}
@@ -2,6 +2,7 @@ package report
 
 import (
 	"regexp"
+	"sort"
 	"strings"
 	"unicode"
 )
@@ -40,8 +41,11 @@ func (a *Analyser) initUnitPatterns() {
 		`\b\d+(?:\.\d+)?\s*(?:pints?|pt)\b`,
 		`\b\d+(?:\.\d+)?\s*(?:fluid\s+ounces?|fl\s+oz)\b`,
 		`\b\d+(?:\.\d+)?\s*°F\b`,
-		`\b\d+(?:\.\d+)?\s*(?:square\s+feet|sq\s+ft)\b`,
+		`\b\d+(?:\.\d+)?\s*(?:square\s+feet|sq\.?\s*ft|ft2)\b`,
+		`\b\d+(?:\.\d+)?\s*ft²`,
 		`\b\d+(?:\.\d+)?\s*(?:acres?)\b`,
+		`\b\d+(?:\.\d+)?\s*(?:mph|miles\s+(?:per|an)\s+hour)\b`,
+		`\b\d+(?:\.\d+)?\s*(?:psi|pounds\s+per\s+square\s+inch)\b`,
 	}
 
 	for _, pattern := range unitPatterns {
@@ -70,9 +74,75 @@ func (a *Analyser) AnalyseChanges(original, converted string) ChangeStats {
 	// Analyse quote changes
 	a.analyseQuoteChanges(original, converted, &stats)
 
+	stats.ChangeDetails = a.summariseChanges(original, converted, stats)
+
 	return stats
 }
 
+// smartQuoteReplacements maps the characters normalised by smart quote conversion to their replacements
+var smartQuoteReplacements = []struct{ original, converted string }{
+	{"\u201c", "\""},
+	{"\u201d", "\""},
+	{"\u2018", "'"},
+	{"\u2019", "'"},
+	{"\u2013", "-"},
+	{"\u2014", "-"},
+}
+
+// summariseChanges groups the individual changes into distinct substitutions with their counts,
+// keeping spelling, unit and quote changes in separate categories
+func (a *Analyser) summariseChanges(original, converted string, stats ChangeStats) []WordChangeCount {
+	var details []WordChangeCount
+	for _, change := range stats.ChangedWords {
+		details = append(details, WordChangeCount{Original: change.Original, Converted: change.Changed, Count: 1, Category: CategorySpelling})
+	}
+	for _, change := range stats.ChangedUnits {
+		details = append(details, WordChangeCount{Original: change.Original, Converted: change.Changed, Count: 1, Category: CategoryUnit})
+	}
+	for _, quote := range smartQuoteReplacements {
+		if removed := strings.Count(original, quote.original) - strings.Count(converted, quote.original); removed > 0 {
+			details = append(details, WordChangeCount{Original: quote.original, Converted: quote.converted, Count: removed, Category: CategoryQuote})
+		}
+	}
+	return MergeChangeDetails(nil, details)
+}
+
+// categoryOrder is the order categories are listed in when substitutions have the same count
+var categoryOrder = map[ChangeCategory]int{CategorySpelling: 0, CategoryUnit: 1, CategoryQuote: 2}
+
+// MergeChangeDetails combines two substitution lists, summing the counts of identical
+// substitutions and sorting the result by count (highest first)
+func MergeChangeDetails(a, b []WordChangeCount) []WordChangeCount {
+	type key struct {
+		original, converted string
+		category            ChangeCategory
+	}
+	index := make(map[key]int)
+	var merged []WordChangeCount
+	for _, list := range [][]WordChangeCount{a, b} {
+		for _, change := range list {
+			k := key{change.Original, change.Converted, change.Category}
+			if i, ok := index[k]; ok {
+				merged[i].Count += change.Count
+				continue
+			}
+			index[k] = len(merged)
+			merged = append(merged, change)
+		}
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		if merged[i].Count != merged[j].Count {
+			return merged[i].Count > merged[j].Count
+		}
+		if merged[i].Category != merged[j].Category {
+			return categoryOrder[merged[i].Category] < categoryOrder[merged[j].Category]
+		}
+		return merged[i].Original < merged[j].Original
+	})
+	return merged
+}
+
 // countWords counts the number of words in the text
 func (a *Analyser) countWords(text string) int {
 	words := strings.FieldsFunc(text, func(c rune) bool {
@@ -134,11 +204,9 @@ func (a *Analyser) analyseUnitConversions(original, converted string, stats *Cha
 
 // analyseQuoteChanges detects smart quote normalisations
 func (a *Analyser) analyseQuoteChanges(original, converted string, stats *ChangeStats) {
-	smartQuotes := []string{"\u201c", "\u201d", "\u2018", "\u2019", "\u2013", "\u2014"}
-
-	for _, quote := range smartQuotes {
-		originalCount := strings.Count(original, quote)
-		convertedCount := strings.Count(converted, quote)
+	for _, quote := range smartQuoteReplacements {
+		originalCount := strings.Count(original, quote.original)
+		convertedCount := strings.Count(converted, quote.original)
 		if originalCount > convertedCount {
 			stats.QuoteChanges += originalCount - convertedCount
 		}
@@ -173,6 +241,8 @@ func (a *Analyser) findCorrespondingConversion(originalUnit, original, converted
 		regexp.MustCompile(`\b\d+(?:\.\d+)?\s*°C\b`),
 		regexp.MustCompile(`\b\d+(?:\.\d+)?\s*(?:square\s+metres?|square\s+meters?|m²)\b`),
 		regexp.MustCompile(`\b\d+(?:\.\d+)?\s*(?:hectares?|ha)\b`),
+		regexp.MustCompile(`\b\d+(?:\.\d+)?\s*km/h\b`),
+		regexp.MustCompile(`\b\d+(?:\.\d+)?\s*(?:kPa|bar)\b`),
 	}
 
 	// Look in a reasonable range around the original position
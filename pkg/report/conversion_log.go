@@ -0,0 +1,100 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// LogChange is a distinct substitution in a conversion log record
+type LogChange struct {
+	Original  string         `json:"original"`
+	Converted string         `json:"converted"`
+	Count     int            `json:"count"`
+	Category  ChangeCategory `json:"category"`
+}
+
+// LogRecord is one line of a conversion log, describing what happened to a single file
+type LogRecord struct {
+	Time    time.Time `json:"time"`
+	Source  string    `json:"source"` // the tool that processed the file: cli or mcp
+	Path    string    `json:"path"`
+	Written bool      `json:"written"` // whether the converted content was written to disk
+	StatsCounts
+	Changes []LogChange `json:"changes"`
+}
+
+// NewLogRecord builds a log record for the file at path from its change statistics. The path
+// is made absolute so records stay meaningful wherever the tool was run from.
+func NewLogRecord(source, path string, stats ChangeStats, written bool) LogRecord {
+	if absPath, err := filepath.Abs(path); err == nil {
+		path = absPath
+	}
+
+	changes := make([]LogChange, len(stats.ChangeDetails))
+	for i, change := range stats.ChangeDetails {
+		changes[i] = LogChange{
+			Original:  change.Original,
+			Converted: change.Converted,
+			Count:     change.Count,
+			Category:  change.Category,
+		}
+	}
+
+	return LogRecord{
+		Time:        time.Now().UTC(),
+		Source:      source,
+		Path:        path,
+		Written:     written,
+		StatsCounts: NewStatsCounts(stats),
+		Changes:     changes,
+	}
+}
+
+// ConversionLog appends JSON lines records to a log file, giving an audit trail of what was
+// converted and when. It is safe for concurrent use.
+type ConversionLog struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// OpenConversionLog opens path for appending, creating it if needed
+func OpenConversionLog(path string) (*ConversionLog, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open conversion log %s: %w", path, err)
+	}
+	return &ConversionLog{file: file}, nil
+}
+
+// Record appends a record to the log. A nil log discards the record.
+func (l *ConversionLog) Record(record LogRecord) error {
+	if l == nil {
+		return nil
+	}
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode conversion log record: %w", err)
+	}
+	line = append(line, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	// A single write per record keeps lines whole when several processes share the log
+	if _, err := l.file.Write(line); err != nil {
+		return fmt.Errorf("failed to write conversion log: %w", err)
+	}
+	return nil
+}
+
+// Close closes the log file. Closing a nil log does nothing.
+func (l *ConversionLog) Close() error {
+	if l == nil {
+		return nil
+	}
+	return l.file.Close()
+}
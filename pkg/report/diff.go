@@ -0,0 +1,278 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// UnifiedDiff returns a diff of the changes from original to converted. By default it is a
+// line-based unified diff (patch compatible) with a hunk per changed line, or per run of
+// re-wrapped lines, and headers naming filename; with inline it is a character-level diff with
+// ANSI colours. It is "" when nothing changed.
+func UnifiedDiff(original, converted, filename string, inline bool) string {
+	if inline {
+		if original == converted {
+			return ""
+		}
+		dmp := diffmatchpatch.New()
+		return dmp.DiffPrettyText(dmp.DiffMain(original, converted, false))
+	}
+	return LineDiff(original, converted, filename)
+}
+
+// LineDiff creates a simple line-based unified diff showing only lines with actual changes,
+// or "" when there are none
+func LineDiff(original, converted, filename string) string {
+	return AnnotatedLineDiff(original, converted, filename, nil)
+}
+
+// AnnotatedLineDiff is LineDiff with a note, keyed by 1-based line number, appended to the "+"
+// line of each changed line that has one as a trailing "# note" comment. The notes are for
+// reading, so a diff that has any no longer applies as a patch.
+func AnnotatedLineDiff(original, converted, filename string, notes map[int]string) string {
+	var result strings.Builder
+	for _, change := range lineChanges(original, converted) {
+		if result.Len() == 0 {
+			fmt.Fprintf(&result, "--- %s\n", filename+".orig")
+			fmt.Fprintf(&result, "+++ %s\n", filename)
+		}
+		fmt.Fprintf(&result, "@@ -%s +%s @@\n", lineRange(change.originalStart, len(change.original)),
+			lineRange(change.convertedStart, len(change.converted)))
+		for _, line := range change.original {
+			fmt.Fprintf(&result, "-%s\n", line)
+		}
+		for i, line := range change.converted {
+			if note, ok := notes[change.convertedStart+i]; ok {
+				fmt.Fprintf(&result, "+%s  # %s\n", line, note)
+			} else {
+				fmt.Fprintf(&result, "+%s\n", line)
+			}
+		}
+	}
+
+	return result.String()
+}
+
+// lineRange formats the range of a LineDiff hunk of count lines from the 1-based line start, or
+// names the line before it when it's empty, as in "4,0"
+func lineRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start-1)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
+
+// ChangedLine is a line that differs between the original and converted text
+type ChangedLine struct {
+	Number    int // 1-based line number in the converted text
+	Original  string
+	Converted string
+}
+
+// ChangedLines returns the lines of converted that differ from original, numbered as they are
+// in converted, each with the original line it replaced. Original is "" for a line added by
+// re-wrapping a paragraph with SetMaxLineWidth.
+func ChangedLines(original, converted string) []ChangedLine {
+	var changes []ChangedLine
+	for _, change := range lineChanges(original, converted) {
+		for i, line := range change.converted {
+			var originalLine string
+			if i < len(change.original) {
+				originalLine = change.original[i]
+			}
+			changes = append(changes, ChangedLine{Number: change.convertedStart + i, Original: originalLine, Converted: line})
+		}
+	}
+	return changes
+}
+
+// lineChange is a run of original lines, starting at the 1-based line originalStart, that
+// conversion replaced with the converted lines starting at convertedStart
+type lineChange struct {
+	originalStart, convertedStart int
+	original, converted           []string
+}
+
+// lineChanges returns the runs of lines that differ between original and converted. Conversion
+// changes words within lines, so when the line counts match each changed line is its own run,
+// compared by position. Re-wrapping paragraphs can add or remove lines, so otherwise the lines
+// are matched with a line diff, as in PatchDiff.
+func lineChanges(original, converted string) []lineChange {
+	originalLines := strings.Split(original, "\n")
+	convertedLines := strings.Split(converted, "\n")
+
+	var changes []lineChange
+	if len(originalLines) == len(convertedLines) {
+		for i := range originalLines {
+			if originalLines[i] != convertedLines[i] {
+				changes = append(changes, lineChange{i + 1, i + 1, originalLines[i : i+1], convertedLines[i : i+1]})
+			}
+		}
+		return changes
+	}
+
+	dmp := diffmatchpatch.New()
+	originalChars, convertedChars, lineArray := dmp.DiffLinesToChars(original, converted)
+	diffs := dmp.DiffCharsToLines(dmp.DiffMain(originalChars, convertedChars, false), lineArray)
+
+	// originalLine and convertedLine are the 1-based numbers of the next line of each text
+	originalLine, convertedLine := 1, 1
+	inChange := false
+	for _, d := range diffs {
+		var lines []string
+		for _, text := range strings.SplitAfter(d.Text, "\n") {
+			if text != "" {
+				lines = append(lines, strings.TrimSuffix(text, "\n"))
+			}
+		}
+		if d.Type == diffmatchpatch.DiffEqual {
+			originalLine += len(lines)
+			convertedLine += len(lines)
+			inChange = false
+			continue
+		}
+
+		if !inChange {
+			changes = append(changes, lineChange{originalStart: originalLine, convertedStart: convertedLine})
+			inChange = true
+		}
+		change := &changes[len(changes)-1]
+		if d.Type == diffmatchpatch.DiffDelete {
+			change.original = append(change.original, lines...)
+			originalLine += len(lines)
+		} else {
+			change.converted = append(change.converted, lines...)
+			convertedLine += len(lines)
+		}
+	}
+	return changes
+}
+
+// patchContextLines is the number of unchanged lines shown around each change in a patch, as
+// git does by default
+const patchContextLines = 3
+
+// patchLine is a line of a patch: unchanged (' '), removed ('-') or added ('+'). Text keeps its
+// line ending, so the last line of a file without one can be marked as such.
+type patchLine struct {
+	op   byte
+	text string
+}
+
+// FormatPatch returns a single patch covering every file in files that has changes, for review
+// or for "git apply". Files with an error are left out.
+func FormatPatch(files []FileResult) string {
+	var result strings.Builder
+	for _, file := range files {
+		if file.Error == nil && file.HasChanges {
+			result.WriteString(PatchDiff(file.Original, file.Converted, file.FilePath))
+		}
+	}
+	return result.String()
+}
+
+// PatchDiff returns a git-style unified diff of the changes from original to converted, with
+// path prefixed by "a/" and "b/" and three lines of context around each change, so it applies
+// with "git apply" from the directory path is relative to. It is "" when nothing changed.
+func PatchDiff(original, converted, path string) string {
+	if original == converted {
+		return ""
+	}
+
+	dmp := diffmatchpatch.New()
+	originalChars, convertedChars, lineArray := dmp.DiffLinesToChars(original, converted)
+	diffs := dmp.DiffCharsToLines(dmp.DiffMain(originalChars, convertedChars, false), lineArray)
+
+	var lines []patchLine
+	for _, d := range diffs {
+		op := byte(' ')
+		switch d.Type {
+		case diffmatchpatch.DiffDelete:
+			op = '-'
+		case diffmatchpatch.DiffInsert:
+			op = '+'
+		}
+		for _, text := range strings.SplitAfter(d.Text, "\n") {
+			if text != "" {
+				lines = append(lines, patchLine{op, text})
+			}
+		}
+	}
+
+	var result strings.Builder
+	fmt.Fprintf(&result, "diff --git a/%s b/%s\n--- a/%s\n+++ b/%s\n", path, path, path, path)
+
+	// oldLine and newLine count the lines of each version before lines[i]
+	oldLine, newLine := 0, 0
+	for i := 0; i < len(lines); {
+		if lines[i].op == ' ' {
+			oldLine++
+			newLine++
+			i++
+			continue
+		}
+
+		// A hunk starts a few lines before the change and runs on until a gap between changes
+		// is too long to be context for both
+		start := max(0, i-patchContextLines)
+		for _, line := range lines[start:i] {
+			if line.op == ' ' {
+				oldLine--
+				newLine--
+			}
+		}
+		end := i
+		for end < len(lines) {
+			for end < len(lines) && lines[end].op != ' ' {
+				end++
+			}
+			gap := end
+			for gap < len(lines) && lines[gap].op == ' ' {
+				gap++
+			}
+			if gap == len(lines) || gap-end > 2*patchContextLines {
+				end = min(gap, end+patchContextLines)
+				break
+			}
+			end = gap
+		}
+
+		var oldCount, newCount int
+		for _, line := range lines[start:end] {
+			if line.op != '+' {
+				oldCount++
+			}
+			if line.op != '-' {
+				newCount++
+			}
+		}
+		fmt.Fprintf(&result, "@@ -%s +%s @@\n", hunkRange(oldLine, oldCount), hunkRange(newLine, newCount))
+		for _, line := range lines[start:end] {
+			result.WriteByte(line.op)
+			result.WriteString(line.text)
+			if !strings.HasSuffix(line.text, "\n") {
+				result.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+
+		oldLine += oldCount
+		newLine += newCount
+		i = end
+	}
+
+	return result.String()
+}
+
+// hunkRange formats the range of a hunk after before lines of the file, with count lines in it:
+// "4,7", "4" for a single line, or "3,0" for an empty range, which names the line before it
+func hunkRange(before, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", before)
+	case 1:
+		return fmt.Sprintf("%d", before+1)
+	}
+	return fmt.Sprintf("%d,%d", before+1, count)
+}
@@ -0,0 +1,95 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FormatMarkdownReport renders a Markdown summary of the given file results, suitable for
+// pasting into a pull request description. It contains a table of per-file spelling, unit
+// and quote change counts with totals at the bottom, followed by a collapsible diff per file.
+func FormatMarkdownReport(files []FileResult) string {
+	var output strings.Builder
+	var totals ChangeStats
+	var changed, errored []FileResult
+
+	for _, file := range files {
+		if file.Error != nil {
+			errored = append(errored, file)
+			continue
+		}
+		if file.HasChanges {
+			changed = append(changed, file)
+		}
+		totals.SpellingChanges += file.Stats.SpellingChanges
+		totals.UnitConversions += file.Stats.UnitConversions
+		totals.QuoteChanges += file.Stats.QuoteChanges
+	}
+
+	output.WriteString("## M2E Conversion Report\n\n")
+	fmt.Fprintf(&output, "**Files processed:** %d | **Files with changes:** %d", len(files), len(changed))
+	if len(errored) > 0 {
+		fmt.Fprintf(&output, " | **Files with errors:** %d", len(errored))
+	}
+	output.WriteString("\n\n")
+
+	if len(changed) == 0 {
+		output.WriteString("No changes required - all files are already in international English.\n")
+	} else {
+		output.WriteString("| File | Spelling | Units | Quotes |\n")
+		output.WriteString("| --- | ---: | ---: | ---: |\n")
+		for _, file := range changed {
+			fmt.Fprintf(&output, "| `%s` | %d | %d | %d |\n", escapeTableCell(file.FilePath),
+				file.Stats.SpellingChanges, file.Stats.UnitConversions, file.Stats.QuoteChanges)
+		}
+		fmt.Fprintf(&output, "| **Total** | **%d** | **%d** | **%d** |\n",
+			totals.SpellingChanges, totals.UnitConversions, totals.QuoteChanges)
+	}
+
+	if len(errored) > 0 {
+		output.WriteString("\n### Errors\n\n")
+		for _, file := range errored {
+			fmt.Fprintf(&output, "- `%s`: %v\n", file.FilePath, file.Error)
+		}
+	}
+
+	if len(changed) > 0 {
+		output.WriteString("\n### Changes\n")
+		for _, file := range changed {
+			diff := LineDiff(file.Original, file.Converted, file.FilePath)
+			fence := codeFence(diff)
+
+			output.WriteString("\n<details>\n")
+			fmt.Fprintf(&output, "<summary><code>%s</code></summary>\n\n", escapeHTML(file.FilePath))
+			fmt.Fprintf(&output, "%sdiff\n%s%s\n", fence, diff, fence)
+			output.WriteString("\n</details>\n")
+		}
+	}
+
+	return output.String()
+}
+
+// codeFence returns a backtick fence longer than any backtick run in content,
+// so diffs of Markdown files containing code blocks don't close the fence early
+func codeFence(content string) string {
+	longest, run := 0, 0
+	for _, r := range content {
+		if r == '`' {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	return strings.Repeat("`", max(3, longest+1))
+}
+
+// escapeTableCell escapes characters that would break a Markdown table row
+func escapeTableCell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+// escapeHTML escapes characters with special meaning in HTML
+func escapeHTML(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
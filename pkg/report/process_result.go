@@ -0,0 +1,69 @@
+// Package report provides a record of what happened to each file processed in a run
+package report
+
+// FileStatus is what happened to a file when a set of files was processed
+type FileStatus string
+
+const (
+	StatusChanged   FileStatus = "changed"   // the file needs changes, or had them written
+	StatusUnchanged FileStatus = "unchanged" // the file needs no changes
+	StatusSkipped   FileStatus = "skipped"   // the file was deliberately left untouched
+	StatusFailed    FileStatus = "failed"    // the file couldn't be read, converted or written
+)
+
+// FileOutcome records what happened to a single file
+type FileOutcome struct {
+	Path    string
+	Status  FileStatus
+	Stats   ChangeStats
+	Written bool  // whether the converted content was written to disk
+	Err     error // why the file was skipped or failed, e.g. a *MaxChangesError
+}
+
+// ProcessResult collects the outcome of every file processed, in the order they were processed,
+// so that skips and failures can be inspected rather than only read from stderr
+type ProcessResult struct {
+	Files []FileOutcome
+}
+
+// Add records the outcome of a file
+func (r *ProcessResult) Add(outcome FileOutcome) {
+	r.Files = append(r.Files, outcome)
+}
+
+// AddSkipped records a file that was left untouched on purpose, and why
+func (r *ProcessResult) AddSkipped(path string, err error) {
+	r.Add(FileOutcome{Path: path, Status: StatusSkipped, Err: err})
+}
+
+// AddFailed records a file that couldn't be processed, and the error
+func (r *ProcessResult) AddFailed(path string, err error) {
+	r.Add(FileOutcome{Path: path, Status: StatusFailed, Err: err})
+}
+
+// WithStatus returns the outcomes with the given status, in order
+func (r *ProcessResult) WithStatus(status FileStatus) []FileOutcome {
+	var outcomes []FileOutcome
+	for _, outcome := range r.Files {
+		if outcome.Status == status {
+			outcomes = append(outcomes, outcome)
+		}
+	}
+	return outcomes
+}
+
+// Problems returns the outcomes of the files that were skipped or failed, in order
+func (r *ProcessResult) Problems() []FileOutcome {
+	var outcomes []FileOutcome
+	for _, outcome := range r.Files {
+		if outcome.Err != nil {
+			outcomes = append(outcomes, outcome)
+		}
+	}
+	return outcomes
+}
+
+// HasFailures reports whether any file failed
+func (r *ProcessResult) HasFailures() bool {
+	return len(r.WithStatus(StatusFailed)) > 0
+}
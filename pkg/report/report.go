@@ -49,6 +49,49 @@ type ChangeStats struct {
 	QuoteChanges    int
 	ChangedWords    []WordChange
 	ChangedUnits    []UnitChange
+	ChangeDetails   []WordChangeCount // distinct substitutions, most frequent first
+}
+
+// TotalChanges returns the combined number of spelling, unit and quote changes
+func (s ChangeStats) TotalChanges() int {
+	return s.SpellingChanges + s.UnitConversions + s.QuoteChanges
+}
+
+// MaxChangesError reports that converting a file would make more changes than allowed
+type MaxChangesError struct {
+	Changes int
+	Limit   int
+}
+
+func (e *MaxChangesError) Error() string {
+	return fmt.Sprintf("%d changes exceed the limit of %d", e.Changes, e.Limit)
+}
+
+// CheckMaxChanges returns a *MaxChangesError if stats contains more changes than limit,
+// guarding against rewriting unexpectedly large or machine-generated content.
+// A limit of zero or less disables the check.
+func CheckMaxChanges(stats ChangeStats, limit int) error {
+	if limit > 0 && stats.TotalChanges() > limit {
+		return &MaxChangesError{Changes: stats.TotalChanges(), Limit: limit}
+	}
+	return nil
+}
+
+// ChangeCategory identifies the kind of change a substitution belongs to
+type ChangeCategory string
+
+const (
+	CategorySpelling ChangeCategory = "spelling"
+	CategoryUnit     ChangeCategory = "unit"
+	CategoryQuote    ChangeCategory = "quote"
+)
+
+// WordChangeCount records how many times a distinct substitution was made
+type WordChangeCount struct {
+	Original  string
+	Converted string
+	Count     int
+	Category  ChangeCategory
 }
 
 // WordChange represents a single spelling change
@@ -167,6 +210,7 @@ func (r *Reporter) GenerateMultiFileReport(results []FileResult) (string, error)
 		allStats.QuoteChanges += result.Stats.QuoteChanges
 		allStats.ChangedWords = append(allStats.ChangedWords, result.Stats.ChangedWords...)
 		allStats.ChangedUnits = append(allStats.ChangedUnits, result.Stats.ChangedUnits...)
+		allStats.ChangeDetails = MergeChangeDetails(allStats.ChangeDetails, result.Stats.ChangeDetails)
 	}
 
 	r.hasChange = changedFiles > 0
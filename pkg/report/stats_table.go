@@ -0,0 +1,92 @@
+package report
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+)
+
+// StatsCounts are the counts of a file's words and changes, serialised the same way in
+// conversion log records and stats tables
+type StatsCounts struct {
+	Words           int `json:"words"`
+	SpellingChanges int `json:"spellingChanges"`
+	UnitConversions int `json:"unitConversions"`
+	QuoteChanges    int `json:"quoteChanges"`
+}
+
+// NewStatsCounts returns the counts of stats
+func NewStatsCounts(stats ChangeStats) StatsCounts {
+	return StatsCounts{
+		Words:           stats.TotalWords,
+		SpellingChanges: stats.SpellingChanges,
+		UnitConversions: stats.UnitConversions,
+		QuoteChanges:    stats.QuoteChanges,
+	}
+}
+
+// Changes returns the combined number of spelling, unit and quote changes
+func (c StatsCounts) Changes() int {
+	return c.SpellingChanges + c.UnitConversions + c.QuoteChanges
+}
+
+// add adds other's counts to c
+func (c *StatsCounts) add(other StatsCounts) {
+	c.Words += other.Words
+	c.SpellingChanges += other.SpellingChanges
+	c.UnitConversions += other.UnitConversions
+	c.QuoteChanges += other.QuoteChanges
+}
+
+// StatsRow is the counts for one file in a stats table
+type StatsRow struct {
+	Path string `json:"path"`
+	StatsCounts
+}
+
+// StatsTable collects the counts of each file in a run and their total, for writing as CSV or
+// JSON that spreadsheets and scripts can ingest
+type StatsTable struct {
+	Files []StatsRow  `json:"files"`
+	Total StatsCounts `json:"total"`
+}
+
+// Add adds a row for the file at path
+func (t *StatsTable) Add(path string, stats ChangeStats) {
+	counts := NewStatsCounts(stats)
+	t.Files = append(t.Files, StatsRow{Path: path, StatsCounts: counts})
+	t.Total.add(counts)
+}
+
+// WriteCSV writes the table as CSV: a header of file,words,spelling,units,quotes, a row per file
+// and a last row of totals with the file "total"
+func (t *StatsTable) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	row := func(file string, counts StatsCounts) []string {
+		return []string{
+			file,
+			strconv.Itoa(counts.Words),
+			strconv.Itoa(counts.SpellingChanges),
+			strconv.Itoa(counts.UnitConversions),
+			strconv.Itoa(counts.QuoteChanges),
+		}
+	}
+
+	records := [][]string{{"file", "words", "spelling", "units", "quotes"}}
+	for _, file := range t.Files {
+		records = append(records, row(file.Path, file.StatsCounts))
+	}
+	records = append(records, row("total", t.Total))
+	return writer.WriteAll(records)
+}
+
+// WriteJSON writes the table as indented JSON, with the files and their total
+func (t *StatsTable) WriteJSON(w io.Writer) error {
+	if t.Files == nil {
+		t.Files = []StatsRow{} // an empty run lists no files rather than null
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(t)
+}
@@ -0,0 +1,57 @@
+package report
+
+import (
+	"strings"
+
+	"github.com/sammcj/m2e/pkg/converter"
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// TextChange is a run of words that conversion replaced, located in the original text
+type TextChange struct {
+	Start     int // byte offset of Original in the original text
+	End       int // byte offset just past Original
+	Line      int // 1-based line that Original starts on
+	Original  string
+	Converted string
+}
+
+// WordDiff returns an inline diff with colours in which each changed word is shown whole, so
+// "color" → "colour" reads as a word swap rather than "colo[u]r"
+func WordDiff(original, converted string) string {
+	return diffmatchpatch.New().DiffPrettyText(converter.DiffWords(original, converted))
+}
+
+// TextChanges returns the word-level changes from original to converted in order, each with
+// its position in original, so they can be reviewed and applied one at a time with
+// ApplyTextChanges
+func TextChanges(original, converted string) []TextChange {
+	var changes []TextChange
+	pos, line := 0, 1
+	for _, change := range converter.WordChanges(original, converted) {
+		line += strings.Count(original[pos:change.Start], "\n")
+		pos = change.Start
+		changes = append(changes, TextChange{
+			Start:     change.Start,
+			End:       change.End,
+			Line:      line,
+			Original:  change.Original,
+			Converted: change.Replacement,
+		})
+	}
+	return changes
+}
+
+// ApplyTextChanges returns original with the given changes applied. The changes must come
+// from TextChanges for the same original text, in order; any of them can be left out.
+func ApplyTextChanges(original string, changes []TextChange) string {
+	var result strings.Builder
+	pos := 0
+	for _, change := range changes {
+		result.WriteString(original[pos:change.Start])
+		result.WriteString(change.Converted)
+		pos = change.End
+	}
+	result.WriteString(original[pos:])
+	return result.String()
+}
@@ -0,0 +1,118 @@
+package tests
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+func TestAmbiguityWarnings(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+	if conv.IsAmbiguityWarningsEnabled() {
+		t.Fatal("Expected ambiguity warnings to be off by default")
+	}
+	conv.ConvertToBritish("Practice makes perfect.", false)
+	if warnings := conv.TakeAmbiguityWarnings(); len(warnings) != 0 {
+		t.Errorf("Expected no warnings while disabled, got %v", warnings)
+	}
+
+	conv.SetAmbiguityWarningsEnabled(true)
+	tests := []struct {
+		name     string
+		input    string
+		expected []converter.AmbiguityWarning
+	}{
+		{
+			name:  "Low-confidence contextual change",
+			input: "Practice makes perfect.",
+			expected: []converter.AmbiguityWarning{{
+				Text: "Practice", Converted: "Practise", Context: "Practice makes perfect.",
+				Reason: "low-confidence contextual decision (imperative_start pattern for practice)",
+			}},
+		},
+		{
+			name:  "Noun/verb word left as written",
+			input: "licensing practice guidelines",
+			expected: []converter.AmbiguityWarning{{
+				Text: "practice", Context: "licensing practice guidelines",
+				Reason: "unclear whether it's a noun or a verb",
+			}},
+		},
+		{
+			name:  "Measurement next to an idiom",
+			input: "Every inch of the 12 inches board.",
+			expected: []converter.AmbiguityWarning{{
+				Text: "12 inches", Converted: "30.5 cm", Context: "Every inch of the 12 inches board.",
+				Reason: "measurement next to an idiom",
+			}},
+		},
+		{
+			name:  "Confident decisions",
+			input: "I need a license to drive. The wall is 6 feet tall.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conv.ConvertToBritish(tt.input, false)
+			warnings := conv.TakeAmbiguityWarnings()
+			if len(warnings) != len(tt.expected) {
+				t.Fatalf("Expected %d warning(s), got %v", len(tt.expected), warnings)
+			}
+			for i, warning := range warnings {
+				warning.Confidence = 0
+				if warning != tt.expected[i] {
+					t.Errorf("Expected warning %+v, got %+v", tt.expected[i], warning)
+				}
+			}
+		})
+	}
+}
+
+func TestWarningsCLI(t *testing.T) {
+	cliPath := buildTestCLI(t)
+	home := t.TempDir()
+
+	path := filepath.Join(t.TempDir(), "notes.md")
+	if err := os.WriteFile(path, []byte("# Notes\n\nPractice makes perfect.\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := exec.Command(cliPath, "-raw", "-warnings", path)
+	cmd.Env = append(os.Environ(), "HOME="+home)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s%s", err, stdout.String(), stderr.String())
+	}
+	if stdout.String() != "# Notes\n\nPractice makes perfect.\n" {
+		t.Errorf("Expected the converted text alone on stdout, got %q", stdout.String())
+	}
+	expected := "Warning: " + path + `:3: "Practice" left as written: unclear whether it's a noun or a verb`
+	if !strings.HasPrefix(stderr.String(), expected) {
+		t.Errorf("Expected a warning starting %q on stderr, got %q", expected, stderr.String())
+	}
+
+	// Without -warnings nothing is printed
+	cmd = exec.Command(cliPath, "-raw")
+	cmd.Env = append(os.Environ(), "HOME="+home)
+	cmd.Stdin = strings.NewReader("Practice makes perfect.")
+	stdout.Reset()
+	stderr.Reset()
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, stderr.String())
+	}
+	if stderr.Len() != 0 {
+		t.Errorf("Expected no warnings without -warnings, got %q", stderr.String())
+	}
+}
@@ -0,0 +1,308 @@
+package tests
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/fileutil"
+)
+
+// archiveFile is an entry of a test archive
+type archiveFile struct {
+	name    string
+	content string
+}
+
+// buildZip returns a zip archive holding files in order
+func buildZip(t *testing.T, files []archiveFile) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	for _, f := range files {
+		w, err := writer.Create(f.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := io.WriteString(w, f.content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// buildTarGz returns a gzip compressed tar archive holding files in order
+func buildTarGz(t *testing.T, files []archiveFile) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	writer := tar.NewWriter(gz)
+	for _, f := range files {
+		if err := writer.WriteHeader(&tar.Header{Name: f.name, Mode: 0644, Size: int64(len(f.content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := io.WriteString(writer, f.content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// readZip returns the content of each entry of a zip archive
+func readZip(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("Not a valid zip archive: %v", err)
+	}
+	entries := make(map[string]string)
+	for _, f := range archive.File {
+		r, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := io.ReadAll(r)
+		r.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		entries[f.Name] = string(content)
+	}
+	return entries
+}
+
+// readTarGz returns the content of each entry of a gzip compressed tar archive
+func readTarGz(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Not a valid gzip archive: %v", err)
+	}
+	reader := tar.NewReader(gz)
+	entries := make(map[string]string)
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Not a valid tar archive: %v", err)
+		}
+		content, err := io.ReadAll(reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		entries[header.Name] = string(content)
+	}
+	return entries
+}
+
+// upperCase is a stand-in conversion that makes every rewritten entry easy to spot
+func upperCase(name string, content []byte) []byte {
+	return bytes.ToUpper(content)
+}
+
+func TestRewriteArchive(t *testing.T) {
+	binary := "\x00\x01\x02PNG"
+	files := []archiveFile{
+		{"docs/guide.md", "the color"},
+		{"image.png", binary},
+		{".hidden.txt", "the color"},
+		{"node_modules/pkg/readme.txt", "the color"},
+	}
+	expected := map[string]string{
+		"docs/guide.md":               "THE COLOR",
+		"image.png":                   strings.ToUpper(binary),
+		".hidden.txt":                 "the color",
+		"node_modules/pkg/readme.txt": "the color",
+	}
+
+	tests := []struct {
+		name  string
+		path  string
+		build func(*testing.T, []archiveFile) []byte
+		read  func(*testing.T, []byte) map[string]string
+	}{
+		{"Zip", "bundle.zip", buildZip, readZip},
+		{"Tar gz", "bundle.tar.gz", buildTarGz, readTarGz},
+		{"Tgz", "bundle.TGZ", buildTarGz, readTarGz},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !fileutil.IsArchiveFile(tt.path) {
+				t.Fatalf("Expected %s to be recognised as an archive", tt.path)
+			}
+
+			var seen []string
+			rewritten, err := fileutil.RewriteArchive(tt.path, tt.build(t, files), 1024, func(name string, content []byte) []byte {
+				seen = append(seen, name)
+				return upperCase(name, content)
+			})
+			if err != nil {
+				t.Fatalf("RewriteArchive() error: %v", err)
+			}
+
+			// Hidden files and ignored directories are copied without being offered for rewriting
+			if strings.Join(seen, ",") != "docs/guide.md,image.png" {
+				t.Errorf("Expected only visible regular files to be rewritten, got %v", seen)
+			}
+			entries := tt.read(t, rewritten)
+			for name, content := range expected {
+				if entries[name] != content {
+					t.Errorf("Entry %s = %q, expected %q", name, entries[name], content)
+				}
+			}
+		})
+	}
+
+	t.Run("Large entries are copied", func(t *testing.T) {
+		data := buildZip(t, []archiveFile{{"big.txt", strings.Repeat("color ", 100)}})
+		rewritten, err := fileutil.RewriteArchive("big.zip", data, 10, upperCase)
+		if err != nil {
+			t.Fatalf("RewriteArchive() error: %v", err)
+		}
+		if got := readZip(t, rewritten)["big.txt"]; got != strings.Repeat("color ", 100) {
+			t.Errorf("Expected an entry over the size limit to be copied verbatim, got %q", got)
+		}
+	})
+
+	t.Run("Unchanged zip entries keep their data", func(t *testing.T) {
+		data := buildZip(t, []archiveFile{{"a.txt", "colour"}, {"b.txt", "grey"}})
+		rewritten, err := fileutil.RewriteArchive("same.zip", data, 1024, func(name string, content []byte) []byte { return content })
+		if err != nil {
+			t.Fatalf("RewriteArchive() error: %v", err)
+		}
+		if !bytes.Equal(rewritten, data) {
+			t.Error("Expected an archive with nothing to rewrite to be copied byte for byte")
+		}
+	})
+}
+
+func TestRewriteArchiveRejectsPathTraversal(t *testing.T) {
+	for _, name := range []string{"../evil.txt", "docs/../../evil.txt", "/etc/passwd", `..\evil.txt`} {
+		for path, data := range map[string][]byte{
+			"unsafe.zip":    buildZip(t, []archiveFile{{"ok.txt", "fine"}, {name, "the color"}}),
+			"unsafe.tar.gz": buildTarGz(t, []archiveFile{{"ok.txt", "fine"}, {name, "the color"}}),
+		} {
+			_, err := fileutil.RewriteArchive(path, data, 1024, upperCase)
+			if err == nil || !strings.Contains(err.Error(), "unsafe path in archive") {
+				t.Errorf("Expected %s with entry %q to be rejected, got %v", path, name, err)
+			}
+		}
+	}
+}
+
+func TestIsTextData(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected bool
+	}{
+		{"notes.md", "The color.", true},
+		{"image.png", "The color.", false},
+		{"LICENSE", "Plain text without an extension.", true},
+		{"data.unknown", "Plain text.", true},
+		{"data.unknown", "\x00\x01binary", false},
+		{"empty.unknown", "", true},
+	}
+
+	for _, tt := range tests {
+		if result := fileutil.IsTextData(tt.name, []byte(tt.data)); result != tt.expected {
+			t.Errorf("IsTextData(%q, %q) = %v, expected %v", tt.name, tt.data, result, tt.expected)
+		}
+	}
+}
+
+func TestCLIArchive(t *testing.T) {
+	cliPath := buildTestCLI(t)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "docs.zip")
+	config := "# The color\ncolor = \"gray\"\n"
+	if err := os.WriteFile(path, buildZip(t, []archiveFile{
+		{"guide.md", "The color is gray.\n"},
+		{"settings.toml", config},
+		{"notes/british.txt", "The colour is grey.\n"},
+	}), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The default summary lists each entry that needs changes and fails like a directory scan
+	output, err := exec.Command(cliPath, path).CombinedOutput()
+	if exitError, ok := err.(*exec.ExitError); !ok || exitError.ExitCode() != 1 {
+		t.Errorf("Expected exit code 1 when entries need changes, got %v", err)
+	}
+	for _, want := range []string{
+		"Entries requiring changes (2):",
+		filepath.Join(path, "guide.md") + ": 2 spelling change(s) needed",
+		filepath.Join(path, "settings.toml") + ": 1 spelling change(s) needed",
+	} {
+		if !strings.Contains(string(output), want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+
+	output, err = exec.Command(cliPath, "-diff", path).CombinedOutput()
+	if err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+	}
+	if !strings.Contains(string(output), "+The colour is grey.") || strings.Contains(string(output), "british.txt") {
+		t.Errorf("Expected a diff of the changed entries only, got:\n%s", output)
+	}
+
+	copyPath := filepath.Join(dir, "converted.zip")
+	if output, err := exec.Command(cliPath, "-o", copyPath, path).CombinedOutput(); err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+	}
+	converted, err := os.ReadFile(copyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries := readZip(t, converted)
+	if entries["guide.md"] != "The colour is grey.\n" {
+		t.Errorf("Expected guide.md to be converted, got %q", entries["guide.md"])
+	}
+	// Config files only have their comments converted, as they would on disk
+	if entries["settings.toml"] != "# The colour\ncolor = \"gray\"\n" {
+		t.Errorf("Expected only settings.toml's comments to be converted, got %q", entries["settings.toml"])
+	}
+
+	if output, err := exec.Command(cliPath, "-save", path).CombinedOutput(); err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+	} else if !strings.Contains(string(output), "Saved changes to: "+path) {
+		t.Errorf("Expected the archive to be saved, got:\n%s", output)
+	}
+	saved, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := readZip(t, saved)["guide.md"]; got != "The colour is grey.\n" {
+		t.Errorf("Expected the saved archive to be converted, got %q", got)
+	}
+
+	unsafe := filepath.Join(dir, "unsafe.tar.gz")
+	if err := os.WriteFile(unsafe, buildTarGz(t, []archiveFile{{"../evil.txt", "The color."}}), 0644); err != nil {
+		t.Fatal(err)
+	}
+	output, err = exec.Command(cliPath, "-save", unsafe).CombinedOutput()
+	if exitError, ok := err.(*exec.ExitError); !ok || exitError.ExitCode() != 3 || !strings.Contains(string(output), "unsafe path in archive") {
+		t.Errorf("Expected an unsafe archive to be refused, got %v: %s", err, output)
+	}
+}
@@ -0,0 +1,134 @@
+package tests
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+const asciiDocSample = `= The Color Guide
+:toc: left
+:imagesdir: images/color
+:favorite-color: gray
+
+The color of the center is gray.
+See link:https://example.com/color-center.html[the color center] for details.
+
+[source,go]
+----
+// Analyze the color
+color := "gray" // favorite color
+----
+
+image::color-wheel.png[A color wheel]
+`
+
+const asciiDocExpected = `= The Colour Guide
+:toc: left
+:imagesdir: images/color
+:favorite-color: gray
+
+The colour of the centre is grey.
+See link:https://example.com/color-center.html[the colour centre] for details.
+
+[source,go]
+----
+// Analyse the colour
+color := "gray" // favourite colour
+----
+
+image::color-wheel.png[A color wheel]
+`
+
+func TestAsciiDocConversion(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	if result := conv.ConvertAsciiDoc(asciiDocSample, true); result != asciiDocExpected {
+		t.Errorf("ConvertAsciiDoc() =\n%s\nexpected\n%s", result, asciiDocExpected)
+	}
+	if result := conv.ConvertFileContent(asciiDocSample, "guide.adoc", true); result != asciiDocExpected {
+		t.Errorf("Expected .adoc files to be converted as AsciiDoc, got\n%s", result)
+	}
+}
+
+func TestAsciiDocMarkup(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"Monospace", "Set `color` to change the color.\n", "Set `color` to change the colour.\n"},
+		{"Attribute reference", "The {color-name} color.\n", "The {color-name} colour.\n"},
+		{"Unset attribute", ":!color-mode:\n", ":!color-mode:\n"},
+		{"Cross reference text", "See <<color-center,the color center>>.\n", "See <<color-center,the colour centre>>.\n"},
+		{"Anchor", "[[color-center]]\n== The Color Center\n", "[[color-center]]\n== The Colour Centre\n"},
+		{"Bare URL", "Visit https://example.com/color for color.\n", "Visit https://example.com/color for colour.\n"},
+		{"Passthrough", "Keep +color+ and +++<b>color</b>+++ as color.\n", "Keep +color+ and +++<b>color</b>+++ as colour.\n"},
+		{"Other macros", "Press kbd:[Color] for color.\n", "Press kbd:[Color] for colour.\n"},
+		{"Footnote", "Gray.footnote:[The color gray.]\n", "Grey.footnote:[The colour grey.]\n"},
+		{"Literal block", "....\nThe color gray.\n....\n", "....\nThe color gray.\n....\n"},
+		{"Passthrough block", "++++\n<p>color</p>\n++++\n", "++++\n<p>color</p>\n++++\n"},
+		{"Literal paragraph", "Intro color.\n\n  color = gray\n  center\n\nThe color.\n", "Intro colour.\n\n  color = gray\n  center\n\nThe colour.\n"},
+		{"Indented list item", "Items:\n\n * The color\n", "Items:\n\n * The colour\n"},
+		{"Example block", "====\nThe color.\n====\n", "====\nThe colour.\n====\n"},
+		{"Comment block", "////\nThe color.\n////\n", "////\nThe colour.\n////\n"},
+		{"Fenced code", "```python\n# the color\ncolor = 1\n```\n", "```python\n# the colour\ncolor = 1\n```\n"},
+		{"Unclosed delimiter", "----\nThe color.\n", "----\nThe colour.\n"},
+		{"Include directive", "include::color/center.adoc[]\n", "include::color/center.adoc[]\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := conv.ConvertAsciiDoc(tt.input, true); result != tt.expected {
+				t.Errorf("ConvertAsciiDoc(%q) = %q, expected %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAsciiDocIgnoreComments(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	input := "// m2e-ignore-next\nThe color stays.\nThe color changes.\n"
+	expected := "// m2e-ignore-next\nThe color stays.\nThe colour changes.\n"
+	if result := conv.ConvertAsciiDoc(input, true); result != expected {
+		t.Errorf("ConvertAsciiDoc() = %q, expected %q", result, expected)
+	}
+
+	input = "// m2e-ignore-file\nThe color stays.\n"
+	if result := conv.ConvertAsciiDoc(input, true); result != input {
+		t.Errorf("Expected an ignored file to be unchanged, got %q", result)
+	}
+}
+
+func TestCLIAsciiDoc(t *testing.T) {
+	cliPath := buildTestCLI(t)
+
+	doc := filepath.Join(t.TempDir(), "guide.adoc")
+	if err := os.WriteFile(doc, []byte(asciiDocSample), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := exec.Command(cliPath, "-raw", doc).CombinedOutput()
+	if err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+	}
+	if strings.TrimSpace(string(output)) != strings.TrimSpace(asciiDocExpected) {
+		t.Errorf("Unexpected output:\n%s", output)
+	}
+}
@@ -0,0 +1,154 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/cache"
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+func TestCacheGetPut(t *testing.T) {
+	c := cache.New(t.TempDir())
+	key := cache.Key("settings", "content")
+
+	var value string
+	if c.Get(key, &value) {
+		t.Fatal("Expected a miss before anything is stored")
+	}
+	if err := c.Put(key, "converted"); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	if !c.Get(key, &value) || value != "converted" {
+		t.Errorf("Get() = %q, expected the stored value", value)
+	}
+
+	if cache.Key("ab", "c") == cache.Key("a", "bc") {
+		t.Error("Expected different splits of the same bytes to give different keys")
+	}
+}
+
+func TestCacheConcurrentPut(t *testing.T) {
+	c := cache.New(t.TempDir())
+	key := cache.Key("shared")
+
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := c.Put(key, strings.Repeat(fmt.Sprint(i%10), 10000)); err != nil {
+				t.Errorf("Put failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	var value string
+	if !c.Get(key, &value) || len(value) != 10000 || strings.Trim(value, value[:1]) != "" {
+		t.Errorf("Expected one complete entry after concurrent writes, got %d bytes", len(value))
+	}
+}
+
+func TestConverterFingerprint(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	base := conv.Fingerprint()
+	if base == "" || base != conv.Fingerprint() {
+		t.Fatalf("Expected a stable fingerprint, got %q", base)
+	}
+	if conv.Clone().Fingerprint() != base {
+		t.Error("Expected a clone to have the same fingerprint")
+	}
+
+	// Units are enabled by default
+	conv.SetUnitProcessingEnabled(false)
+	if conv.Fingerprint() == base {
+		t.Error("Expected disabling units to change the fingerprint")
+	}
+	conv.SetUnitProcessingEnabled(true)
+
+	conv.SetExcludedWords([]string{"color"})
+	if conv.Fingerprint() == base {
+		t.Error("Expected excluding a word to change the fingerprint")
+	}
+	conv.SetExcludedWords(nil)
+	if conv.Fingerprint() != base {
+		t.Error("Expected restoring the settings to restore the fingerprint")
+	}
+
+	err = conv.RegisterProcessor(converter.ProcessorFunc(func(text string, _ ...converter.ProcessOption) string {
+		return text
+	}), converter.PhasePostSpelling)
+	if err != nil {
+		t.Fatalf("RegisterProcessor failed: %v", err)
+	}
+	if fp := conv.Fingerprint(); fp != "" {
+		t.Errorf("Expected no fingerprint with a custom processor, got %q", fp)
+	}
+}
+
+func TestCLICache(t *testing.T) {
+	cliPath := buildTestCLI(t)
+	home := t.TempDir()
+	file := filepath.Join(t.TempDir(), "doc.txt")
+	if err := os.WriteFile(file, []byte("The color is gray.\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	run := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command(cliPath, append(args, file)...)
+		cmd.Env = append(os.Environ(), "HOME="+home)
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+		}
+		return string(output)
+	}
+
+	if output := run("-raw", "-cache"); output != "The colour is grey.\n" {
+		t.Fatalf("Unexpected output %q", output)
+	}
+	entries, _ := filepath.Glob(filepath.Join(home, ".cache", "m2e", "*", "*.json"))
+	if len(entries) != 1 {
+		t.Fatalf("Expected one cache entry, found %d", len(entries))
+	}
+
+	// Rewrite the entry so a hit is visible in the output
+	data, err := os.ReadFile(entries[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var entry map[string]any
+	if err := json.Unmarshal(data, &entry); err != nil {
+		t.Fatalf("Cache entry is not valid JSON: %v", err)
+	}
+	entry["converted"] = "from the cache\n"
+	data, _ = json.Marshal(entry)
+	if err := os.WriteFile(entries[0], data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if output := run("-raw", "-cache"); output != "from the cache\n" {
+		t.Errorf("Expected the cached conversion, got %q", output)
+	}
+	if output := run("-raw", "-cache", "-no-cache"); output != "The colour is grey.\n" {
+		t.Errorf("Expected -no-cache to convert the file, got %q", output)
+	}
+	if output := run("-raw", "-cache", "-spelling=ize"); output != "The colour is grey.\n" {
+		t.Errorf("Expected different settings to miss the cache, got %q", output)
+	}
+	if output := run("-raw"); output != "The colour is grey.\n" {
+		t.Errorf("Expected no caching without -cache, got %q", output)
+	}
+}
@@ -0,0 +1,87 @@
+package tests
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestCLICompletion(t *testing.T) {
+	cliPath := buildTestCLI(t)
+
+	tests := []struct {
+		shell    string
+		contains []string
+		excludes []string
+	}{
+		{
+			shell: "bash",
+			contains: []string{"complete -o filenames -F _m2e m2e", "-units", "-stdin-filename", "compgen -f",
+				"-spelling)\n            COMPREPLY=( $(compgen -W \"ise ize oxford\" -- \"$cur\") )",
+				"-stats-format)\n            COMPREPLY=( $(compgen -W \"human csv json\" -- \"$cur\") )"},
+		},
+		{
+			shell: "zsh",
+			contains: []string{"#compdef m2e", "'-units[", "-o[", ":_files'", "'*:file or directory:_files'",
+				":dashes:(flatten typographic)'", ":preset:(code docs strict)'", "-write-patch["},
+			excludes: []string{":spelling:_files", ":only-words:_files", ":md-elements:_files"},
+		},
+		{
+			shell: "fish",
+			contains: []string{"complete -c m2e -o units", "complete -c m2e -o o -r -F", "complete -c m2e -o width -x",
+				"complete -c m2e -o report -x -a 'md'", "complete -c m2e -o log -r -F", "complete -c m2e -o output-dir -r -F"},
+			excludes: []string{"-o spelling -r -F", "-o only-words -r -F", "-o stats-format -r -F"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.shell, func(t *testing.T) {
+			output, err := exec.Command(cliPath, "-completion", tt.shell).Output()
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			script := string(output)
+			for _, want := range tt.contains {
+				if !strings.Contains(script, want) {
+					t.Errorf("Expected %s completion to contain %q, got:\n%s", tt.shell, want, script)
+				}
+			}
+			for _, unwanted := range tt.excludes {
+				if strings.Contains(script, unwanted) {
+					t.Errorf("Expected %s completion not to contain %q", tt.shell, unwanted)
+				}
+			}
+			if strings.Contains(script, "-completion") || strings.Contains(script, "-o completion") {
+				t.Errorf("Expected the hidden -completion flag to be excluded from the %s script", tt.shell)
+			}
+
+			// Validate the script syntax when the shell is available
+			if _, err := exec.LookPath(tt.shell); err == nil {
+				cmd := exec.Command(tt.shell, "-n")
+				cmd.Stdin = strings.NewReader(script)
+				if out, err := cmd.CombinedOutput(); err != nil {
+					t.Errorf("Generated %s script is not valid: %v\n%s", tt.shell, err, out)
+				}
+			}
+		})
+	}
+
+	t.Run("unsupported shell", func(t *testing.T) {
+		cmd := exec.Command(cliPath, "-completion", "powershell")
+		output, err := cmd.CombinedOutput()
+		if err == nil {
+			t.Fatalf("Expected an error for an unsupported shell, got output: %s", output)
+		}
+		if !strings.Contains(string(output), "unsupported shell") {
+			t.Errorf("Expected unsupported shell error, got: %s", output)
+		}
+	})
+
+	t.Run("hidden from help", func(t *testing.T) {
+		output, _ := exec.Command(cliPath, "-help").CombinedOutput()
+		if strings.Contains(string(output), "-completion") {
+			t.Errorf("Expected -completion to be hidden from help output")
+		}
+	})
+}
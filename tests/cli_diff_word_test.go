@@ -0,0 +1,68 @@
+package tests
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCLIDiffWord(t *testing.T) {
+	cliPath := buildTestCLI(t)
+
+	t.Run("Whole words are swapped", func(t *testing.T) {
+		cmd := exec.Command(cliPath, "-diff-word")
+		cmd.Stdin = strings.NewReader("The color of the center isn't gray.\n")
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+		}
+
+		for _, swap := range []string{
+			"\x1b[31mcolor\x1b[0m\x1b[32mcolour\x1b[0m",
+			"\x1b[31mcenter\x1b[0m\x1b[32mcentre\x1b[0m",
+			"\x1b[31mgray\x1b[0m\x1b[32mgrey\x1b[0m",
+		} {
+			if !strings.Contains(string(output), swap) {
+				t.Errorf("Expected whole-word swap %q in %q", swap, output)
+			}
+		}
+		if !strings.Contains(string(output), " of the ") || !strings.Contains(string(output), "isn't") {
+			t.Errorf("Expected unchanged text to be kept, got %q", output)
+		}
+	})
+
+	t.Run("No changes prints nothing", func(t *testing.T) {
+		cmd := exec.Command(cliPath, "-diff-word")
+		cmd.Stdin = strings.NewReader("Nothing to see here.\n")
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+		}
+		if len(output) != 0 {
+			t.Errorf("Expected no output, got %q", output)
+		}
+	})
+
+	t.Run("Directory labels each file", func(t *testing.T) {
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, "doc.txt"), []byte("The color.\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		output, err := exec.Command(cliPath, "-diff-word", dir).CombinedOutput()
+		if err != nil {
+			t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+		}
+		if !strings.Contains(string(output), "=== doc.txt ===\nThe \x1b[31mcolor\x1b[0m\x1b[32mcolour\x1b[0m.") {
+			t.Errorf("Expected a labelled word diff, got %q", output)
+		}
+	})
+
+	t.Run("Cannot be combined with -diff-inline", func(t *testing.T) {
+		output, err := exec.Command(cliPath, "-diff-inline", "-diff-word", "color").CombinedOutput()
+		if err == nil || !strings.Contains(string(output), "Only one output mode") {
+			t.Errorf("Expected an output mode error, got %v: %q", err, output)
+		}
+	})
+}
@@ -0,0 +1,53 @@
+package tests
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCLIDoctor(t *testing.T) {
+	cliPath := buildTestCLI(t)
+	home := t.TempDir()
+
+	doctor := func() (string, error) {
+		cmd := exec.Command(cliPath, "doctor")
+		cmd.Env = append(os.Environ(), "HOME="+home)
+		output, err := cmd.CombinedOutput()
+		return string(output), err
+	}
+
+	output, err := doctor()
+	if err != nil {
+		t.Fatalf("doctor failed: %v\n%s", err, output)
+	}
+	for _, want := range []string{
+		"American to British entries loaded",
+		"unit_config.json (not present, using defaults)",
+		"Contextual words:",
+		`-> "The colour of the organisation's centre"`,
+		"All checks passed",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected doctor output to contain %q, got:\n%s", want, output)
+		}
+	}
+
+	// A broken unit config is reported and fails the check
+	configPath := filepath.Join(home, ".config", "m2e", "unit_config.json")
+	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(configPath, []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	output, err = doctor()
+	if err == nil {
+		t.Errorf("Expected doctor to fail with an invalid unit config, got:\n%s", output)
+	}
+	if !strings.Contains(output, "invalid:") {
+		t.Errorf("Expected invalid unit config to be reported, got:\n%s", output)
+	}
+}
@@ -0,0 +1,82 @@
+package tests
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCLIExitCodes(t *testing.T) {
+	cliPath := buildTestCLI(t)
+
+	dir := t.TempDir()
+	american := filepath.Join(dir, "american.txt")
+	british := filepath.Join(dir, "british.txt")
+	if err := os.WriteFile(american, []byte("The color is gray.\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(british, []byte("The colour is grey.\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	badDict := filepath.Join(dir, "bad.json")
+	if err := os.WriteFile(badDict, []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		env      []string
+		stdin    string
+		expected int
+	}{
+		{"No changes", []string{"-exit-on-change", british}, nil, "", 0},
+		{"Changes detected", []string{"-exit-on-change", american}, nil, "", 1},
+		{"Directory changes", []string{dir}, nil, "", 1},
+		{"Conflicting output modes", []string{"-diff", "-raw", american}, nil, "", 2},
+		{"Invalid flag value", []string{"-format", "yaml", american}, nil, "", 2},
+		{"Unknown flag", []string{"-nope", american}, nil, "", 2},
+		{"Unknown flag with a value", []string{"-nope=1", american}, nil, "", 2},
+		{"Negative number with =", []string{"-max-changes=-1", american}, nil, "", 2},
+		{"Number flag with a word", []string{"-max-changes=many", american}, nil, "", 2},
+		{"Two-dash boolean flag", []string{"--exit-on-change", american}, nil, "", 1},
+		{"Unwritable output file", []string{"-o", filepath.Join(dir, "missing", "out.txt"), american}, nil, "", 3},
+		{"Invalid dictionary", []string{"-raw"}, []string{"M2E_DICT_PATH=" + badDict, "HOME=" + t.TempDir()}, "The color.", 4},
+		{"Help", []string{"-help"}, nil, "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := exec.Command(cliPath, tt.args...)
+			cmd.Env = append(os.Environ(), tt.env...)
+			cmd.Stdin = strings.NewReader(tt.stdin)
+			output, err := cmd.CombinedOutput()
+
+			code := 0
+			var exitError *exec.ExitError
+			if errors.As(err, &exitError) {
+				code = exitError.ExitCode()
+			} else if err != nil {
+				t.Fatalf("Failed to run CLI: %v", err)
+			}
+			if code != tt.expected {
+				t.Errorf("Expected exit code %d, got %d\nOutput: %s", tt.expected, code, output)
+			}
+		})
+	}
+}
+
+func TestCLIHelpListsExitCodes(t *testing.T) {
+	cliPath := buildTestCLI(t)
+
+	output, _ := exec.Command(cliPath, "-help").CombinedOutput()
+	for _, want := range []string{"Exit Codes:", "2  Usage error", "3  I/O error", "4  Config error"} {
+		if !strings.Contains(string(output), want) {
+			t.Errorf("Expected usage to contain %q, got:\n%s", want, output)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package tests
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCLIFailFast(t *testing.T) {
+	cliPath := buildTestCLI(t)
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"a_clean.txt":  "Nothing to change here.\n",
+		"b_first.txt":  "The color is gray.\n",
+		"c_second.txt": "The center.\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expectExit1 := func(t *testing.T, err error, output []byte) {
+		t.Helper()
+		var exitError *exec.ExitError
+		if !errors.As(err, &exitError) || exitError.ExitCode() != 1 {
+			t.Fatalf("Expected exit code 1, got %v\nOutput: %s", err, output)
+		}
+	}
+
+	t.Run("Stops at the first file with changes", func(t *testing.T) {
+		output, err := exec.Command(cliPath, "-exit-on-change", "-fail-fast", dir).CombinedOutput()
+		expectExit1(t, err, output)
+		if !strings.Contains(string(output), "File requires changes: b_first.txt") {
+			t.Errorf("Expected the first changed file to be reported, got %q", output)
+		}
+		if strings.Contains(string(output), "c_second.txt") {
+			t.Errorf("Expected the walk to stop before c_second.txt, got %q", output)
+		}
+	})
+
+	t.Run("Diff of only the first file", func(t *testing.T) {
+		output, err := exec.Command(cliPath, "-diff", "-exit-on-change", "-fail-fast", dir).CombinedOutput()
+		expectExit1(t, err, output)
+		if !strings.Contains(string(output), "=== b_first.txt ===") || !strings.Contains(string(output), "colour") {
+			t.Errorf("Expected a diff of b_first.txt, got %q", output)
+		}
+		if strings.Contains(string(output), "centre") {
+			t.Errorf("Expected no diff of later files, got %q", output)
+		}
+	})
+
+	t.Run("Clean directory exits 0", func(t *testing.T) {
+		clean := t.TempDir()
+		if err := os.WriteFile(filepath.Join(clean, "doc.txt"), []byte("All fine.\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if output, err := exec.Command(cliPath, "-exit-on-change", "-fail-fast", clean).CombinedOutput(); err != nil {
+			t.Errorf("Expected success, got %v\nOutput: %s", err, output)
+		}
+	})
+
+	t.Run("Requires -exit-on-change", func(t *testing.T) {
+		output, err := exec.Command(cliPath, "-fail-fast", dir).CombinedOutput()
+		if err == nil || !strings.Contains(string(output), "-fail-fast requires -exit-on-change") {
+			t.Errorf("Expected a usage error, got %v: %q", err, output)
+		}
+	})
+
+	t.Run("Cannot be combined with -save", func(t *testing.T) {
+		output, err := exec.Command(cliPath, "-save", "-exit-on-change", "-fail-fast", dir).CombinedOutput()
+		if err == nil || !strings.Contains(string(output), "cannot be used with -save") {
+			t.Errorf("Expected a usage error, got %v: %q", err, output)
+		}
+		content, _ := os.ReadFile(filepath.Join(dir, "b_first.txt"))
+		if string(content) != files["b_first.txt"] {
+			t.Errorf("Expected no files to be saved, got %q", content)
+		}
+	})
+}
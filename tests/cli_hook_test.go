@@ -0,0 +1,88 @@
+package tests
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCLIInstallHook(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	cliPath := buildTestCLI(t)
+
+	repo := t.TempDir()
+	git := func(args ...string) (string, error) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		output, err := cmd.CombinedOutput()
+		return string(output), err
+	}
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"config", "user.email", "test@example.com"},
+		{"config", "user.name", "Test"},
+	} {
+		if output, err := git(args...); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, output)
+		}
+	}
+
+	install := func(args ...string) (string, error) {
+		cmd := exec.Command(cliPath, append([]string{"install-hook"}, args...)...)
+		cmd.Dir = repo
+		output, err := cmd.CombinedOutput()
+		return string(output), err
+	}
+
+	if output, err := install(); err != nil {
+		t.Fatalf("install-hook failed: %v\n%s", err, output)
+	}
+	hookPath := filepath.Join(repo, ".git", "hooks", "pre-commit")
+	info, err := os.Stat(hookPath)
+	if err != nil {
+		t.Fatalf("Expected hook to be written: %v", err)
+	}
+	if info.Mode().Perm()&0111 == 0 {
+		t.Errorf("Expected hook to be executable, got mode %v", info.Mode())
+	}
+
+	if output, err := install(); err == nil || !strings.Contains(output, "use -force") {
+		t.Errorf("Expected existing hook not to be overwritten, got %v: %q", err, output)
+	}
+	if output, err := install("-force"); err != nil {
+		t.Errorf("Expected -force to overwrite the hook, got %v: %q", err, output)
+	}
+
+	// Only the staged version of text files is checked
+	files := map[string]string{
+		"american.md": "The color is gray.",
+		"british.md":  "The colour is grey.",
+		"image.png":   "\x89PNG\r\n\x1a\n\x00\x00\x00color",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(repo, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	if output, err := git("add", "."); err != nil {
+		t.Fatalf("git add failed: %v\n%s", err, output)
+	}
+	if err := os.WriteFile(filepath.Join(repo, "british.md"), []byte("Unstaged color change."), 0644); err != nil {
+		t.Fatalf("Failed to modify british.md: %v", err)
+	}
+
+	output, err := git("commit", "-q", "-m", "test")
+	if err == nil {
+		t.Fatalf("Expected the hook to block the commit\nOutput: %s", output)
+	}
+	if !strings.Contains(output, "need conversion") || !strings.Contains(output, "  american.md") {
+		t.Errorf("Expected american.md to be reported, got %q", output)
+	}
+	if strings.Contains(output, "british.md") || strings.Contains(output, "image.png") {
+		t.Errorf("Expected only staged text files with changes to be reported, got %q", output)
+	}
+}
@@ -0,0 +1,107 @@
+package tests
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/report"
+)
+
+// readConversionLog parses each line of a conversion log
+func readConversionLog(t *testing.T, path string) []report.LogRecord {
+	t.Helper()
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Failed to open log: %v", err)
+	}
+	defer file.Close()
+
+	var records []report.LogRecord
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var record report.LogRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("Log line %q is not valid JSON: %v", scanner.Text(), err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestCLIConversionLog(t *testing.T) {
+	cliPath := buildTestCLI(t)
+
+	dir := t.TempDir()
+	changed := filepath.Join(dir, "changed.txt")
+	unchanged := filepath.Join(dir, "unchanged.txt")
+	logPath := filepath.Join(dir, "m2e.log")
+	if err := os.WriteFile(changed, []byte("The color of the color center."), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(unchanged, []byte("Nothing to see here."), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := exec.Command(cliPath, "-save", "-log", logPath, changed, unchanged).CombinedOutput()
+	if err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+	}
+
+	records := readConversionLog(t, logPath)
+	if len(records) != 2 {
+		t.Fatalf("Expected a record per file, got %d: %+v", len(records), records)
+	}
+
+	byPath := map[string]report.LogRecord{}
+	for _, record := range records {
+		if !filepath.IsAbs(record.Path) {
+			t.Errorf("Expected an absolute path, got %q", record.Path)
+		}
+		if record.Source != "cli" || record.Time.IsZero() {
+			t.Errorf("Expected a timestamped cli record, got %+v", record)
+		}
+		byPath[filepath.Base(record.Path)] = record
+	}
+
+	record := byPath["changed.txt"]
+	if !record.Written || record.SpellingChanges != 3 {
+		t.Errorf("Expected a written file with 3 spelling changes, got %+v", record)
+	}
+	found := false
+	for _, change := range record.Changes {
+		if change.Original == "color" && change.Converted == "colour" && change.Count == 2 {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected color -> colour twice in the changes, got %+v", record.Changes)
+	}
+
+	if record := byPath["unchanged.txt"]; record.Written || len(record.Changes) != 0 {
+		t.Errorf("Expected an unwritten file with no changes, got %+v", record)
+	}
+
+	// A second run appends rather than truncating
+	if output, err := exec.Command(cliPath, "-save", "-log="+logPath, unchanged).CombinedOutput(); err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+	}
+	if records := readConversionLog(t, logPath); len(records) != 3 {
+		t.Errorf("Expected the log to be appended to, got %d records", len(records))
+	}
+
+	// An unwritable log path fails before any file is converted
+	if err := os.WriteFile(changed, []byte("The color."), 0644); err != nil {
+		t.Fatal(err)
+	}
+	output, err = exec.Command(cliPath, "-save", "-log", filepath.Join(dir, "missing", "m2e.log"), changed).CombinedOutput()
+	if err == nil {
+		t.Errorf("Expected an error for an unwritable log, got output %q", output)
+	}
+	if content, _ := os.ReadFile(changed); string(content) != "The color." {
+		t.Errorf("Expected file to be left untouched, got %q", content)
+	}
+}
@@ -0,0 +1,117 @@
+package tests
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCLIMaxChanges(t *testing.T) {
+	cliPath := buildTestCLI(t)
+
+	dir := t.TempDir()
+	small := filepath.Join(dir, "small.txt")
+	dump := filepath.Join(dir, "dump.txt")
+	smallContent := "The color is nice."
+	dumpContent := strings.Repeat("color flavor gray center\n", 10)
+	for path, content := range map[string]string{small: smallContent, dump: dumpContent} {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", path, err)
+		}
+	}
+
+	t.Run("Single file over the limit is left untouched", func(t *testing.T) {
+		output, err := exec.Command(cliPath, "-save", "-max-changes", "5", dump).CombinedOutput()
+		if err == nil {
+			t.Errorf("Expected an error exit status, got none\nOutput: %s", output)
+		}
+		if !strings.Contains(string(output), "dump.txt") || !strings.Contains(string(output), "exceed the limit of 5") {
+			t.Errorf("Expected the file that tripped the limit to be reported, got %q", output)
+		}
+		if content, _ := os.ReadFile(dump); string(content) != dumpContent {
+			t.Errorf("Expected file to be left untouched, got %q", content)
+		}
+	})
+
+	t.Run("Other files are still saved", func(t *testing.T) {
+		output, err := exec.Command(cliPath, "-save", "-max-changes", "5", small, dump).CombinedOutput()
+		if err == nil {
+			t.Errorf("Expected an error exit status, got none\nOutput: %s", output)
+		}
+		if !strings.Contains(string(output), "Skipping "+dump) {
+			t.Errorf("Expected skipped file to be reported, got %q", output)
+		}
+		if content, _ := os.ReadFile(small); string(content) != "The colour is nice." {
+			t.Errorf("Expected file under the limit to be saved, got %q", content)
+		}
+		if content, _ := os.ReadFile(dump); string(content) != dumpContent {
+			t.Errorf("Expected file over the limit to be left untouched, got %q", content)
+		}
+	})
+
+	t.Run("Limit given as -max-changes=N", func(t *testing.T) {
+		output, err := exec.Command(cliPath, "-save", "-max-changes=5", dump).CombinedOutput()
+		if err == nil || !strings.Contains(string(output), "exceed the limit of 5") {
+			t.Errorf("Expected -max-changes=5 to apply the limit, got %v %q", err, output)
+		}
+		if content, _ := os.ReadFile(dump); string(content) != dumpContent {
+			t.Errorf("Expected file to be left untouched, got %q", content)
+		}
+	})
+
+	t.Run("Report mode lists files over the limit", func(t *testing.T) {
+		output, err := exec.Command(cliPath, "-max-changes", "5", dir).CombinedOutput()
+		if exitErr, ok := err.(*exec.ExitError); !ok || exitErr.ExitCode() != 1 {
+			t.Errorf("Expected exit code 1, got %v\nOutput: %s", err, output)
+		}
+		for _, want := range []string{"Files requiring changes (1)", "dump.txt: 40 spelling change(s) needed", "1 file(s) exceed -max-changes: dump.txt"} {
+			if !strings.Contains(string(output), want) {
+				t.Errorf("Expected output to contain %q, got %q", want, output)
+			}
+		}
+		if content, _ := os.ReadFile(dump); string(content) != dumpContent {
+			t.Errorf("Expected file to be left untouched, got %q", content)
+		}
+	})
+}
+
+func TestCLIVerboseListsProblems(t *testing.T) {
+	cliPath := buildTestCLI(t)
+
+	for _, multiple := range []bool{false, true} {
+		dir := t.TempDir()
+		files := map[string]string{
+			"small.txt":   "The color is nice.",
+			"dump.txt":    strings.Repeat("color flavor gray center\n", 10),
+			"broken.docx": "not a Word document",
+		}
+		var paths []string
+		for name, content := range files {
+			if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			paths = append(paths, filepath.Join(dir, name))
+		}
+
+		args := []string{"-verbose", "-save", "-max-changes", "5", dir}
+		if multiple {
+			args = append(args[:len(args)-1], paths...)
+		}
+		cmd := exec.Command(cliPath, args...)
+		cmd.Env = append(os.Environ(), "HOME="+t.TempDir())
+		var stderr strings.Builder
+		cmd.Stderr = &stderr
+		_ = cmd.Run()
+
+		for _, want := range []string{"skipped " + filepath.Join(dir, "dump.txt") + ": 40 changes exceed the limit of 5", "failed " + filepath.Join(dir, "broken.docx") + ": "} {
+			if !strings.Contains(stderr.String(), want) {
+				t.Errorf("Expected stderr with multiple=%v to contain %q, got %q", multiple, want, stderr.String())
+			}
+		}
+		if strings.Contains(stderr.String(), " "+filepath.Join(dir, "small.txt")+": ") {
+			t.Errorf("Expected small.txt not to be listed as a problem, got %q", stderr.String())
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package tests
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCLIOutputDir(t *testing.T) {
+	cliPath := buildTestCLI(t)
+
+	createTree := func(t *testing.T) string {
+		t.Helper()
+		dir := t.TempDir()
+		files := map[string]string{
+			"readme.md":         "The color is gray.",
+			"docs/guide.txt":    "Center the dialog.",
+			"docs/unchanged.md": "Nothing to convert here.",
+			"images/logo.png":   "\x89PNG\r\n\x1a\n\x00\x00\x00binary",
+		}
+		for relPath, content := range files {
+			fullPath := filepath.Join(dir, relPath)
+			if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+				t.Fatalf("Failed to create directory: %v", err)
+			}
+			if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
+				t.Fatalf("Failed to write %s: %v", relPath, err)
+			}
+		}
+		return dir
+	}
+
+	readFile := func(t *testing.T, path string) string {
+		t.Helper()
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", path, err)
+		}
+		return string(content)
+	}
+
+	t.Run("Mirrors converted files and skips non-text files", func(t *testing.T) {
+		input := createTree(t)
+		output := filepath.Join(t.TempDir(), "out")
+
+		out, err := exec.Command(cliPath, "-output-dir", output, input).CombinedOutput()
+		if err != nil {
+			t.Fatalf("CLI failed: %v\nOutput: %s", err, out)
+		}
+
+		if got := readFile(t, filepath.Join(output, "readme.md")); got != "The colour is grey." {
+			t.Errorf("Unexpected converted content: %q", got)
+		}
+		if got := readFile(t, filepath.Join(output, "docs", "guide.txt")); got != "Centre the dialogue." {
+			t.Errorf("Unexpected converted content: %q", got)
+		}
+		if got := readFile(t, filepath.Join(output, "docs", "unchanged.md")); got != "Nothing to convert here." {
+			t.Errorf("Expected unchanged file to be copied, got %q", got)
+		}
+		if _, err := os.Stat(filepath.Join(output, "images", "logo.png")); !os.IsNotExist(err) {
+			t.Errorf("Expected non-text file to be skipped without -copy-all, got %v", err)
+		}
+		if got := readFile(t, filepath.Join(input, "readme.md")); got != "The color is gray." {
+			t.Errorf("Expected original to be untouched, got %q", got)
+		}
+		if !strings.Contains(string(out), "2 converted, 1 copied unchanged, 1 non-text file(s) skipped") {
+			t.Errorf("Unexpected summary: %q", out)
+		}
+	})
+
+	t.Run("Copies non-text files with -copy-all", func(t *testing.T) {
+		input := createTree(t)
+		output := filepath.Join(t.TempDir(), "out")
+
+		out, err := exec.Command(cliPath, "-output-dir", output, "-copy-all", input).CombinedOutput()
+		if err != nil {
+			t.Fatalf("CLI failed: %v\nOutput: %s", err, out)
+		}
+		if got := readFile(t, filepath.Join(output, "images", "logo.png")); got != "\x89PNG\r\n\x1a\n\x00\x00\x00binary" {
+			t.Errorf("Expected non-text file to be copied verbatim, got %q", got)
+		}
+	})
+
+	t.Run("Rejects invalid combinations", func(t *testing.T) {
+		input := createTree(t)
+		tests := []struct {
+			args     []string
+			expected string
+		}{
+			{[]string{"-output-dir", t.TempDir(), "-save", input}, "-output-dir cannot be used with"},
+			{[]string{"-output-dir", t.TempDir(), filepath.Join(input, "readme.md")}, "requires a directory input"},
+			{[]string{"-output-dir", input, input}, "must differ from the input directory"},
+			{[]string{"-copy-all", input}, "-copy-all requires -output-dir"},
+		}
+		for _, tt := range tests {
+			out, err := exec.Command(cliPath, tt.args...).CombinedOutput()
+			if err == nil {
+				t.Errorf("Expected %v to fail, got output %q", tt.args, out)
+			}
+			if !strings.Contains(string(out), tt.expected) {
+				t.Errorf("Expected %v to report %q, got %q", tt.args, tt.expected, out)
+			}
+		}
+	})
+}
@@ -0,0 +1,50 @@
+package tests
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCLIPreset(t *testing.T) {
+	cliPath := buildTestCLI(t)
+
+	goFile := filepath.Join(t.TempDir(), "main.go")
+	if err := os.WriteFile(goFile, []byte("// The color is 10 feet\nvar color = 1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		stdin    string
+		expected string
+	}{
+		{"docs converts all text with units", []string{"-preset", "docs", goFile}, "", "// The colour is 3 metres\nvar colour = 1\n"},
+		{"docs with units turned off", []string{"-preset=docs", "-units=false", goFile}, "", "// The colour is 10 feet\nvar colour = 1\n"},
+		{"code converts only comments", []string{"-preset", "code", goFile}, "", "// The colour is 10 feet\nvar color = 1\n"},
+		{"code overridden by -all-text", []string{"-preset", "code", "-all-text", goFile}, "", "// The colour is 10 feet\nvar colour = 1\n"},
+		{"strict leaves contextual words and quotes", []string{"-preset", "strict"}, "The color of the license—see “below”", "The colour of the license—see “below”"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := exec.Command(cliPath, append([]string{"-raw"}, tt.args...)...)
+			cmd.Stdin = strings.NewReader(tt.stdin)
+			output, err := cmd.CombinedOutput()
+			if err != nil {
+				t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+			}
+			if strings.TrimSpace(string(output)) != strings.TrimSpace(tt.expected) {
+				t.Errorf("Expected %q, got %q", tt.expected, output)
+			}
+		})
+	}
+
+	err := exec.Command(cliPath, "-preset", "unknown", goFile).Run()
+	if exitErr, ok := err.(*exec.ExitError); !ok || exitErr.ExitCode() != 2 {
+		t.Errorf("Expected a usage error for an unknown preset, got %v", err)
+	}
+}
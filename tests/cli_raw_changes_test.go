@@ -0,0 +1,72 @@
+package tests
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCLIRawChanges(t *testing.T) {
+	cliPath := buildTestCLI(t)
+
+	dir := t.TempDir()
+	doc := filepath.Join(dir, "doc.txt")
+	content := "First line.\nThe color is gray.\nNothing here.\nThe center.\n"
+	if err := os.WriteFile(doc, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Single file", func(t *testing.T) {
+		output, err := exec.Command(cliPath, "-raw-changes", doc).CombinedOutput()
+		if err != nil {
+			t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+		}
+		expected := "2:The colour is grey.\n4:The centre.\n"
+		if string(output) != expected {
+			t.Errorf("Expected %q, got %q", expected, output)
+		}
+	})
+
+	t.Run("Stdin", func(t *testing.T) {
+		cmd := exec.Command(cliPath, "-raw-changes")
+		cmd.Stdin = strings.NewReader(content)
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+		}
+		if string(output) != "2:The colour is grey.\n4:The centre.\n" {
+			t.Errorf("Unexpected output %q", output)
+		}
+	})
+
+	t.Run("No changes prints nothing", func(t *testing.T) {
+		cmd := exec.Command(cliPath, "-raw-changes")
+		cmd.Stdin = strings.NewReader("Nothing to see here.\n")
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+		}
+		if len(output) != 0 {
+			t.Errorf("Expected no output, got %q", output)
+		}
+	})
+
+	t.Run("Directory labels each file", func(t *testing.T) {
+		output, err := exec.Command(cliPath, "-raw-changes", dir).CombinedOutput()
+		if err != nil {
+			t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+		}
+		if !strings.Contains(string(output), "=== doc.txt ===\n2:The colour is grey.\n4:The centre.\n") {
+			t.Errorf("Expected the file's changed lines under its name, got %q", output)
+		}
+	})
+
+	t.Run("Cannot be combined with -raw", func(t *testing.T) {
+		output, err := exec.Command(cliPath, "-raw", "-raw-changes", doc).CombinedOutput()
+		if err == nil || !strings.Contains(string(output), "Only one output mode") {
+			t.Errorf("Expected an output mode error, got %v: %q", err, output)
+		}
+	})
+}
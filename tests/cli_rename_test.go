@@ -0,0 +1,99 @@
+package tests
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCLIRenameOnly(t *testing.T) {
+	cliPath := buildTestCLI(t)
+
+	createAssets := func(t *testing.T) string {
+		t.Helper()
+		dir := t.TempDir()
+		files := map[string]string{
+			"color-chart.png":    "\x89PNG\r\n\x1a\n\x00\x00\x00binary",
+			"notes/color.txt":    "The color is gray.",
+			"gray.txt":           "American gray",
+			"grey.txt":           "Already British",
+			"unchanged-name.txt": "The color is gray.",
+		}
+		for relPath, content := range files {
+			fullPath := filepath.Join(dir, relPath)
+			if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+				t.Fatalf("Failed to create directory: %v", err)
+			}
+			if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
+				t.Fatalf("Failed to write %s: %v", relPath, err)
+			}
+		}
+		return dir
+	}
+
+	t.Run("Lists renames without -save", func(t *testing.T) {
+		dir := createAssets(t)
+		output, _ := exec.Command(cliPath, "-rename-only", dir).CombinedOutput()
+		outputStr := string(output)
+
+		for _, want := range []string{"color-chart.png → colour-chart.png", "color.txt → colour.txt"} {
+			if !strings.Contains(outputStr, want) {
+				t.Errorf("Expected output to contain %q, got %q", want, outputStr)
+			}
+		}
+		if _, err := os.Stat(filepath.Join(dir, "color-chart.png")); err != nil {
+			t.Errorf("Expected file not to be renamed without -save: %v", err)
+		}
+	})
+
+	t.Run("Renames without converting contents and skips collisions", func(t *testing.T) {
+		dir := createAssets(t)
+		output, err := exec.Command(cliPath, "-rename-only", "-save", dir).CombinedOutput()
+		outputStr := string(output)
+		if err == nil {
+			t.Errorf("Expected an error exit status for the collision, got none\nOutput: %s", outputStr)
+		}
+
+		if _, err := os.Stat(filepath.Join(dir, "colour-chart.png")); err != nil {
+			t.Errorf("Expected binary asset to be renamed: %v", err)
+		}
+
+		content, err := os.ReadFile(filepath.Join(dir, "notes", "colour.txt"))
+		if err != nil {
+			t.Fatalf("Expected nested file to be renamed: %v", err)
+		}
+		if string(content) != "The color is gray." {
+			t.Errorf("Expected contents to be untouched, got %q", content)
+		}
+
+		// gray.txt would overwrite the existing grey.txt
+		if !strings.Contains(outputStr, "gray.txt → grey.txt: target already exists") {
+			t.Errorf("Expected collision to be reported, got %q", outputStr)
+		}
+		grey, err := os.ReadFile(filepath.Join(dir, "grey.txt"))
+		if err != nil || string(grey) != "Already British" {
+			t.Errorf("Expected existing grey.txt to be preserved, got %q (%v)", grey, err)
+		}
+		if _, err := os.Stat(filepath.Join(dir, "gray.txt")); err != nil {
+			t.Errorf("Expected colliding file to be left in place: %v", err)
+		}
+
+		unchanged, _ := os.ReadFile(filepath.Join(dir, "unchanged-name.txt"))
+		if string(unchanged) != "The color is gray." {
+			t.Errorf("Expected contents to be untouched, got %q", unchanged)
+		}
+	})
+
+	t.Run("Rejects output mode flags", func(t *testing.T) {
+		dir := createAssets(t)
+		output, err := exec.Command(cliPath, "-rename-only", "-diff", dir).CombinedOutput()
+		if err == nil {
+			t.Errorf("Expected error combining -rename-only with -diff")
+		}
+		if !strings.Contains(string(output), "-rename-only can only be combined with -save") {
+			t.Errorf("Unexpected output: %q", output)
+		}
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -266,3 +267,88 @@ func TestCLILegacyCompatibility(t *testing.T) {
 		}
 	})
 }
+
+func TestCLIMarkdownReport(t *testing.T) {
+	cliPath := buildTestCLI(t)
+
+	tempDir := t.TempDir()
+	docsDir := filepath.Join(tempDir, "docs")
+	if err := os.MkdirAll(docsDir, 0755); err != nil {
+		t.Fatalf("Failed to create docs dir: %v", err)
+	}
+	files := map[string]string{
+		filepath.Join(docsDir, "a.md"): "The color is gray.\n",
+		filepath.Join(docsDir, "b.md"): "I love flavor.\n",
+		filepath.Join(docsDir, "c.md"): "Already British colour.\n",
+	}
+	for path, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", path, err)
+		}
+	}
+
+	t.Run("Directory", func(t *testing.T) {
+		output, err := exec.Command(cliPath, "-report=md", docsDir).Output()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		result := string(output)
+		for _, want := range []string{"| File | Spelling | Units | Quotes |", "a.md` | 2 | 0 | 0 |", "b.md` | 1 | 0 | 0 |", "| **Total** | **3** |", "<details>"} {
+			if !strings.Contains(result, want) {
+				t.Errorf("Expected report to contain %q, got:\n%s", want, result)
+			}
+		}
+		if strings.Contains(result, "Processing:") {
+			t.Error("Expected report output to contain only the Markdown report")
+		}
+	})
+
+	t.Run("Multiple files", func(t *testing.T) {
+		output, err := exec.Command(cliPath, "-report", "md", filepath.Join(docsDir, "a.md"), filepath.Join(docsDir, "b.md")).Output()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !strings.Contains(string(output), "**Files processed:** 2 | **Files with changes:** 2") {
+			t.Errorf("Expected both files in the report, got:\n%s", output)
+		}
+	})
+
+	t.Run("Single file to output file", func(t *testing.T) {
+		reportPath := filepath.Join(tempDir, "report.md")
+		if output, err := exec.Command(cliPath, "-report=md", "-o", reportPath, filepath.Join(docsDir, "a.md")).CombinedOutput(); err != nil {
+			t.Fatalf("Unexpected error: %v\n%s", err, output)
+		}
+		content, err := os.ReadFile(reportPath)
+		if err != nil {
+			t.Fatalf("Failed to read report: %v", err)
+		}
+		if !strings.Contains(string(content), "+The colour is grey.") {
+			t.Errorf("Expected diff in report file, got:\n%s", content)
+		}
+		if original, _ := os.ReadFile(filepath.Join(docsDir, "a.md")); string(original) != files[filepath.Join(docsDir, "a.md")] {
+			t.Error("Expected the input file to be left unchanged")
+		}
+	})
+
+	t.Run("Stdin", func(t *testing.T) {
+		cmd := exec.Command(cliPath, "-report=md")
+		cmd.Stdin = strings.NewReader("I love color.")
+		output, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !strings.Contains(string(output), "| `stdin` | 1 | 0 | 0 |") {
+			t.Errorf("Expected stdin row in report, got:\n%s", output)
+		}
+	})
+
+	t.Run("Unsupported format", func(t *testing.T) {
+		output, err := exec.Command(cliPath, "-report=html", filepath.Join(docsDir, "a.md")).CombinedOutput()
+		if err == nil {
+			t.Fatalf("Expected an error for an unsupported format, got:\n%s", output)
+		}
+		if !strings.Contains(string(output), "unsupported report format") {
+			t.Errorf("Expected unsupported format error, got:\n%s", output)
+		}
+	})
+}
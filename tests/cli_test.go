@@ -1,6 +1,7 @@
 package tests
 
 import (
+	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
@@ -120,3 +121,128 @@ func TestCLIUnitConversion(t *testing.T) {
 		})
 	}
 }
+
+func TestCLISkipFrontMatter(t *testing.T) {
+	cliPath := buildTestCLI(t)
+
+	post := filepath.Join(t.TempDir(), "post.md")
+	content := "---\ntitle: The color\ncolor: gray\n---\nThe color is gray.\n"
+	if err := os.WriteFile(post, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{
+			name:     "Front matter values are converted by default",
+			args:     []string{"-raw", post},
+			expected: "---\ntitle: The colour\ncolor: grey\n---\nThe colour is grey.\n",
+		},
+		{
+			name:     "Front matter is left untouched with -skip-frontmatter",
+			args:     []string{"-raw", "-skip-frontmatter", post},
+			expected: "---\ntitle: The color\ncolor: gray\n---\nThe colour is grey.\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := exec.Command(cliPath, tt.args...).CombinedOutput()
+			if err != nil {
+				t.Fatalf("Unexpected error: %v\nOutput: %s", err, output)
+			}
+			if !strings.Contains(string(output), tt.expected) {
+				t.Errorf("Expected output to contain %q, got %q", tt.expected, output)
+			}
+		})
+	}
+}
+
+func TestCLINoContextual(t *testing.T) {
+	cliPath := buildTestCLI(t)
+	text := "I need a license to practice the color."
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{
+			name:     "Contextual words are converted by default",
+			args:     []string{"-raw", text},
+			expected: "I need a licence to practise the colour.",
+		},
+		{
+			name:     "Contextual words are left as written with -no-contextual",
+			args:     []string{"-raw", "-no-contextual", text},
+			expected: "I need a license to practice the colour.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := exec.Command(cliPath, tt.args...).CombinedOutput()
+			if err != nil {
+				t.Fatalf("Unexpected error: %v\nOutput: %s", err, output)
+			}
+			if !strings.Contains(string(output), tt.expected) {
+				t.Errorf("Expected output to contain %q, got %q", tt.expected, output)
+			}
+		})
+	}
+}
+
+func TestCLIStatsDetail(t *testing.T) {
+	cliPath := buildTestCLI(t)
+
+	input := "The color and the other color are gray."
+
+	tests := []struct {
+		name        string
+		args        []string
+		contains    []string
+		notContains []string
+	}{
+		{
+			name:        "Stats without detail",
+			args:        []string{"-stats"},
+			contains:    []string{"Spelling changes needed:** 3"},
+			notContains: []string{"Top Changes"},
+		},
+		{
+			name:     "Stats with detail lists the most frequent substitutions",
+			args:     []string{"-stats", "-stats-detail", "1"},
+			contains: []string{"Top Changes", "2 × color → colour (spelling)", "... and 1 more"},
+		},
+		{
+			name:     "Stats detail accepts the -flag=value form",
+			args:     []string{"-stats", "-stats-detail=5"},
+			contains: []string{"2 × color → colour (spelling)", "1 × gray → grey (spelling)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := exec.Command(cliPath, tt.args...)
+			cmd.Stdin = strings.NewReader(input)
+			output, err := cmd.CombinedOutput()
+			if err != nil {
+				t.Fatalf("Unexpected error: %v\nOutput: %s", err, output)
+			}
+
+			for _, want := range tt.contains {
+				if !strings.Contains(string(output), want) {
+					t.Errorf("Expected output to contain %q, got %q", want, output)
+				}
+			}
+			for _, unwanted := range tt.notContains {
+				if strings.Contains(string(output), unwanted) {
+					t.Errorf("Expected output not to contain %q, got %q", unwanted, output)
+				}
+			}
+		})
+	}
+}
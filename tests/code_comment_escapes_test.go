@@ -0,0 +1,56 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+func TestCodeCommentEscapesAndQuotes(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		comment  string
+		expected string
+	}{
+		{"Escaped newline in a string", `// color = "\n"`, `// colour = "\n"`},
+		{"Escape sequences", `// color is \t then \u00e9 then \\`, `// colour is \t then \u00e9 then \\`},
+		{"Regular expression", `// The regex \bcolor\b matches the color`, `// The regex \bcolor\b matches the colour`},
+		{"Double-quoted string", `// Set the color to "gray" or "color"`, `// Set the colour to "gray" or "color"`},
+		{"Escaped quote in a string", `// The color is "say \"organize\"" here`, `// The colour is "say \"organize\"" here`},
+		{"Single-quoted string", `# The color of 'organize' is set`, `# The colour of 'organize' is set`},
+		{"Backticks", "// The color of `analyze` is set", "// The colour of `analyze` is set"},
+		{"Apostrophes", `// Don't change the color's favor`, `// Don't change the colour's favour`},
+		{"Block comment", `/* color = "\n" and 'favor' */`, `/* colour = "\n" and 'favor' */`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := "```go\n" + tt.comment + "\nvar color = \"\\n\"\n```\n"
+			expected := "```go\n" + tt.expected + "\nvar color = \"\\n\"\n```\n"
+			if result := conv.ConvertFileContent(input, "guide.md", false); result != expected {
+				t.Errorf("ConvertFileContent(%q) = %q, expected %q", input, result, expected)
+			}
+		})
+	}
+}
+
+func TestCodeCommentDocstringsStillConverted(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	// The quotes around a docstring aren't a quoted string
+	input := "```python\ndef paint():\n    \"\"\"Pick a color.\"\"\"\n```\n"
+	expected := "```python\ndef paint():\n    \"\"\"Pick a colour.\"\"\"\n```\n"
+	if result := conv.ConvertFileContent(input, "guide.md", false); result != expected {
+		t.Errorf("ConvertFileContent(%q) = %q, expected %q", input, result, expected)
+	}
+}
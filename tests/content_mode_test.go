@@ -0,0 +1,112 @@
+package tests
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+func TestConvertFileContentContentMode(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	script := "# Set the color\ncolor = gray\n"
+	goCode := "// The color is set here\nvar color = \"gray\"\n"
+
+	tests := []struct {
+		name     string
+		mode     converter.ContentMode
+		content  string
+		filePath string
+		expected string
+	}{
+		{"Auto converts a .txt in full", converter.ContentModeAuto, script, "setup.txt", "# Set the colour\ncolour = grey\n"},
+		{"Comments only for a .txt", converter.ContentModeCommentsOnly, script, "setup.txt", "# Set the colour\ncolor = gray\n"},
+		{"Comments only without a filename", converter.ContentModeCommentsOnly, script, "", "# Set the colour\ncolor = gray\n"},
+		{"Auto converts only comments in code", converter.ContentModeAuto, goCode, "main.go", "// The colour is set here\nvar color = \"gray\"\n"},
+		{"All text for code", converter.ContentModeAllText, goCode, "main.go", "// The colour is set here\nvar colour = \"grey\"\n"},
+		{"All text for a config file", converter.ContentModeAllText, "color = \"gray\" # color\n", "app.toml", "colour = \"grey\" # colour\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conv.SetContentMode(tt.mode)
+			defer conv.SetContentMode(converter.ContentModeAuto)
+
+			if result := conv.ConvertFileContent(tt.content, tt.filePath, true); result != tt.expected {
+				t.Errorf("ConvertFileContent(%q) = %q, expected %q", tt.filePath, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCLIContentModeFlags(t *testing.T) {
+	cliPath := buildTestCLI(t)
+
+	dir := t.TempDir()
+	script := filepath.Join(dir, "setup.txt")
+	config := filepath.Join(dir, "app.toml")
+	if err := os.WriteFile(script, []byte("# Set the color\ncolor = gray\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(config, []byte("color = \"gray\" # color\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Only comments in a .txt", func(t *testing.T) {
+		output, err := exec.Command(cliPath, "-raw", "-only-comments", script).CombinedOutput()
+		if err != nil {
+			t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+		}
+		if string(output) != "# Set the colour\ncolor = gray\n" {
+			t.Errorf("Expected only the comment to change, got %q", output)
+		}
+	})
+
+	t.Run("All text in a config file", func(t *testing.T) {
+		output, err := exec.Command(cliPath, "-raw", "-all-text", config).CombinedOutput()
+		if err != nil {
+			t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+		}
+		if string(output) != "colour = \"grey\" # colour\n" {
+			t.Errorf("Expected the whole file to change, got %q", output)
+		}
+	})
+
+	t.Run("Only comments from stdin", func(t *testing.T) {
+		cmd := exec.Command(cliPath, "-raw", "-only-comments")
+		cmd.Stdin = strings.NewReader("# Set the color\ncolor = gray\n")
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+		}
+		if string(output) != "# Set the colour\ncolor = gray\n" {
+			t.Errorf("Expected only the comment to change, got %q", output)
+		}
+	})
+
+	t.Run("All text overrides -stdin-filename", func(t *testing.T) {
+		cmd := exec.Command(cliPath, "-raw", "-all-text", "-stdin-filename", "main.go")
+		cmd.Stdin = strings.NewReader("var color = 1 // color\n")
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+		}
+		if string(output) != "var colour = 1 // colour\n" {
+			t.Errorf("Expected the whole input to change, got %q", output)
+		}
+	})
+
+	t.Run("Flags are mutually exclusive", func(t *testing.T) {
+		output, err := exec.Command(cliPath, "-raw", "-only-comments", "-all-text", script).CombinedOutput()
+		if err == nil || !strings.Contains(string(output), "cannot be used together") {
+			t.Errorf("Expected an error for conflicting flags, got %v: %q", err, output)
+		}
+	})
+}
@@ -0,0 +1,123 @@
+package tests
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+func TestContextualReErEndings(t *testing.T) {
+	// A fresh home directory so the default contextual configuration is used
+	t.Setenv("HOME", t.TempDir())
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"Meter as a unit", "Run 100 meters, then another meter.", "Run 100 metres, then another metre."},
+		{"Meter in verse", "The poem is written in iambic meter.", "The poem is written in iambic metre."},
+		{"Gas meter", "Read the gas meter every month.", "Read the gas meter every month."},
+		{"Meter reading", "The meter reading was high.", "The meter reading was high."},
+		{"Plural devices", "Smart meters are being installed.", "Smart meters are being installed."},
+		{"Device and unit", "The parking meter is 5 meters away.", "The parking meter is 5 metres away."},
+		{"Caliber of a gun", "A .22 caliber rifle.", "A .22 calibre rifle."},
+		{"Caliber as quality", "Engineers of the highest caliber.", "Engineers of the highest calibre."},
+		{"Plural calibers", "Rifles of several calibers.", "Rifles of several calibres."},
+		{"Watch movement name", "It runs on the Caliber 321 movement.", "It runs on the Caliber 321 movement."},
+		{"Fiber as a material", "Dietary fiber and carbon fiber.", "Dietary fibre and carbon fibre."},
+		{"Plural fibers", "Optical fibers carry the signal.", "Optical fibres carry the signal."},
+		{"Capitalised fiber", "Fiber broadband is available.", "Fibre broadband is available."},
+		{"React Fiber", "React Fiber rewrote the reconciler.", "React Fiber rewrote the reconciler."},
+		{"Fiber scheduler", "Each Ruby fiber yields to the fiber scheduler.", "Each Ruby fiber yields to the fiber scheduler."},
+		{"Threads and fibers", "Compare threads and fibers.", "Compare threads and fibers."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := conv.ConvertToBritish(tt.input, false); result != tt.expected {
+				t.Errorf("ConvertToBritish(%q) = %q, expected %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestContextualReErEndingsOwnedByDetector(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	// The dictionary leaves every form the contextual layer owns to it
+	supported := conv.GetContextualWordDetector().SupportedWords()
+	for _, word := range []string{"meter", "meters", "caliber", "calibers", "fiber", "fibers"} {
+		if !slices.Contains(supported, word) {
+			t.Errorf("Expected %q to be handled by the contextual detector, got %v", word, supported)
+		}
+	}
+
+	// With contextual detection off, the dictionary doesn't convert them either
+	conv.SetContextualWordDetectionEnabled(false)
+	input := "The gas meter is 5 meters from the fiber cable."
+	if result := conv.ConvertToBritish(input, false); result != input {
+		t.Errorf("Expected contextual words to be left alone without contextual detection, got %q", result)
+	}
+}
+
+// writeContextualWordConfig writes config as the contextual word configuration under home, as
+// an earlier version of m2e would have saved it
+func writeContextualWordConfig(t *testing.T, home string, config *converter.ContextualWordConfig) {
+	t.Helper()
+	data, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	configDir := filepath.Join(home, ".config", "m2e")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(configDir, "contextual_word_config.json"), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestContextualReErEndingsWithExistingConfig(t *testing.T) {
+	// A config saved before the -re/-er words were added, with one of its words turned off
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	config := converter.GetDefaultContextualWordConfig()
+	for _, word := range []string{"meter", "meters", "caliber", "calibers", "fiber", "fibers"} {
+		config.RemoveCustomWord(word)
+	}
+	config.DisableWord("license")
+	writeContextualWordConfig(t, home, config)
+
+	loaded, err := converter.LoadContextualWordConfig()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if wordConfig, ok := loaded.WordConfigs["fiber"]; !ok || !wordConfig.Enabled {
+		t.Errorf("Expected the default fiber entry to be merged in, got %+v", loaded.WordConfigs["fiber"])
+	}
+	if loaded.WordConfigs["license"].Enabled {
+		t.Error("Expected the file's disabled license entry to win over the default")
+	}
+
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+	input := "Carbon fiber, a .22 caliber rifle and 100 meters."
+	if result := conv.ConvertToBritish(input, false); result != "Carbon fibre, a .22 calibre rifle and 100 metres." {
+		t.Errorf("ConvertToBritish(%q) = %q", input, result)
+	}
+}
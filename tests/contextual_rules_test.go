@@ -0,0 +1,113 @@
+package tests
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+func TestContextualRules(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	rules := conv.ContextualRules()
+	byWord := make(map[string]converter.ContextualRule)
+	for i, rule := range rules {
+		if i > 0 && rules[i-1].Word >= rule.Word {
+			t.Errorf("Expected rules sorted by word, got %q before %q", rules[i-1].Word, rule.Word)
+		}
+		byWord[rule.Word] = rule
+	}
+
+	license, ok := byWord["license"]
+	if !ok {
+		t.Fatalf("Expected a rule for license, got %v", rules)
+	}
+	if license.Noun != "licence" || license.Verb != "license" {
+		t.Errorf("Expected license to map to licence/license, got %q/%q", license.Noun, license.Verb)
+	}
+	found := false
+	for i, pattern := range license.Patterns {
+		if i > 0 && license.Patterns[i-1].Confidence < pattern.Confidence {
+			t.Errorf("Expected patterns sorted by confidence, got %v", license.Patterns)
+		}
+		if pattern.Name == "determiner_noun" {
+			found = pattern.WordType == "noun" && pattern.Replacement == "licence" && pattern.Confidence > 0
+		}
+	}
+	if !found {
+		t.Errorf("Expected a determiner_noun pattern converting to licence, got %v", license.Patterns)
+	}
+
+	principle, ok := byWord["principle"]
+	if !ok || len(principle.SemanticVariants) == 0 {
+		t.Fatalf("Expected semantic variants for principle, got %+v", principle)
+	}
+	for _, variant := range principle.SemanticVariants {
+		if variant.Replacement != "principal" {
+			t.Errorf("Expected principle's semantic variants to convert to principal, got %+v", variant)
+		}
+	}
+
+	// Words whose patterns aren't applied aren't listed
+	if _, ok := byWord["check"]; ok {
+		t.Error("Expected check, which isn't configured, not to be listed")
+	}
+}
+
+func TestFormatContextualRules(t *testing.T) {
+	rules := []converter.ContextualRule{
+		{
+			Word: "license",
+			Noun: "licence",
+			Verb: "license",
+			Patterns: []converter.ContextualRulePattern{
+				{Name: "infinitive", WordType: "verb", Replacement: "license", Confidence: 0.98},
+				{Name: "determiner_noun", WordType: "noun", Replacement: "licence", Confidence: 0.8},
+			},
+		},
+		{
+			Word: "principal",
+			SemanticVariants: []converter.ContextualRulePattern{
+				{Name: `(?i)design\s+(principals?)\b`, Replacement: "principle", Confidence: 0.99},
+			},
+		},
+	}
+	expected := `license
+  noun: licence
+  verb: license
+  patterns:
+    0.98  verb      infinitive → license
+    0.80  noun      determiner_noun → licence
+
+principal
+  semantic variants:
+    0.99  (?i)design\s+(principals?)\b → principle
+`
+	if result := converter.FormatContextualRules(rules); result != expected {
+		t.Errorf("FormatContextualRules() =\n%s\nexpected\n%s", result, expected)
+	}
+}
+
+func TestCLIListContextual(t *testing.T) {
+	cliPath := buildTestCLI(t)
+
+	output, err := exec.Command(cliPath, "-list-contextual").CombinedOutput()
+	if err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+	}
+	for _, want := range []string{"license\n  noun: licence\n  verb: license\n", "noun      determiner_noun → licence", "practice\n", "semantic variants:"} {
+		if !strings.Contains(string(output), want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+
+	again, _ := exec.Command(cliPath, "-list-contextual").CombinedOutput()
+	if string(again) != string(output) {
+		t.Error("Expected the listing to be the same every time")
+	}
+}
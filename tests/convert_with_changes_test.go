@@ -0,0 +1,122 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+func TestConvertWithChangesPositions(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	text := "The color of the gray center"
+	converted, changes := conv.ConvertWithChanges(text, converter.Options{})
+	if converted != "The colour of the grey centre" {
+		t.Fatalf("converted = %q", converted)
+	}
+
+	want := []converter.Change{
+		{Start: 4, End: 9, Original: "color", Replacement: "colour", Category: converter.ChangeSpelling},
+		{Start: 17, End: 21, Original: "gray", Replacement: "grey", Category: converter.ChangeSpelling},
+		{Start: 22, End: 28, Original: "center", Replacement: "centre", Category: converter.ChangeSpelling},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("got %d changes, want %d: %+v", len(changes), len(want), changes)
+	}
+	for i, change := range changes {
+		if change != want[i] {
+			t.Errorf("change %d = %+v, want %+v", i, change, want[i])
+		}
+		if text[change.Start:change.End] != change.Original {
+			t.Errorf("change %d: text[%d:%d] = %q, want %q", i, change.Start, change.End, text[change.Start:change.End], change.Original)
+		}
+	}
+}
+
+func TestConvertWithChangesCategories(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+	conv.SetUnitProcessingEnabled(true)
+
+	tests := []struct {
+		name     string
+		text     string
+		quotes   bool
+		original string
+		category converter.ChangeCategory
+	}{
+		{"unit", "The fence is 10 feet tall.", false, "10 feet", converter.ChangeUnit},
+		{"contextual", "You need a license to drive.", false, "license", converter.ChangeContextual},
+		{"smart quotes", "He said “hello” today.", true, "“", converter.ChangePunctuation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			converted, changes := conv.ConvertWithChanges(tt.text, converter.Options{NormaliseSmartQuotes: tt.quotes})
+			for _, change := range changes {
+				if change.Original != tt.original {
+					continue
+				}
+				if change.Category != tt.category {
+					t.Errorf("category of %q = %q, want %q", change.Original, change.Category, tt.category)
+				}
+				if tt.text[change.Start:change.End] != change.Original {
+					t.Errorf("text[%d:%d] = %q, want %q", change.Start, change.End, tt.text[change.Start:change.End], change.Original)
+				}
+				return
+			}
+			t.Errorf("no change of %q in %+v (converted %q)", tt.original, changes, converted)
+		})
+	}
+}
+
+func TestConvertWithChangesRebuildsConvertedText(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+	conv.SetUnitProcessingEnabled(true)
+
+	text := "We analyzed the color.\nThe box weighs 5 pounds and the road is 3 miles long."
+	converted, changes := conv.ConvertWithChanges(text, converter.Options{NormaliseSmartQuotes: true})
+
+	rebuilt, pos := "", 0
+	for _, change := range changes {
+		rebuilt += text[pos:change.Start] + change.Replacement
+		pos = change.End
+	}
+	rebuilt += text[pos:]
+	if rebuilt != converted {
+		t.Errorf("applying changes gives %q, want %q", rebuilt, converted)
+	}
+}
+
+func TestConvertWithChangesKeepsExplanations(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	conv.SetExplainEnabled(true)
+	defer conv.SetExplainEnabled(false)
+	conv.ConvertToBritish("The color", false)
+	conv.ConvertWithChanges("The center", converter.Options{})
+
+	if !conv.IsExplainEnabled() {
+		t.Error("explain was turned off")
+	}
+	if explanations := conv.TakeExplanations(); len(explanations) != 2 {
+		t.Errorf("got %d explanations, want 2: %+v", len(explanations), explanations)
+	}
+
+	conv.SetExplainEnabled(false)
+	conv.ConvertWithChanges("The center", converter.Options{})
+	if conv.IsExplainEnabled() {
+		t.Error("explain was left on")
+	}
+}
@@ -0,0 +1,70 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+func TestConvertWord(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	tests := []struct {
+		word     string
+		british  string
+		changed  bool
+		category string
+	}{
+		{"color", "colour", true, "spelling"},
+		{"Color", "Colour", true, "spelling"},
+		{"COLOR", "COLOUR", true, "spelling"},
+		{"color's", "colour's", true, "spelling"},
+		{"organize", "organise", true, "spelling"},
+		{"license", "licence", true, "contextual"},
+		{"License", "Licence", true, "contextual"},
+		{"LICENSES", "LICENCES", true, "contextual"},
+		{"practice", "practice", false, ""},
+		{"principal", "principal", false, ""},
+		{"colour", "colour", false, ""},
+		{"table", "table", false, ""},
+		{"", "", false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			british, changed, category := conv.ConvertWord(tt.word)
+			if british != tt.british || changed != tt.changed || category != tt.category {
+				t.Errorf("ConvertWord(%q) = (%q, %t, %q), expected (%q, %t, %q)",
+					tt.word, british, changed, category, tt.british, tt.changed, tt.category)
+			}
+		})
+	}
+}
+
+func TestConvertWordSettings(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	conv.SetSpellingVariant(converter.SpellingOxfordIZE)
+	if british, _, _ := conv.ConvertWord("organize"); british != "organize" {
+		t.Errorf("Expected Oxford spelling to keep organize, got %q", british)
+	}
+
+	conv.SetContextualWordDetectionEnabled(false)
+	if british, changed, _ := conv.ConvertWord("license"); changed {
+		t.Errorf("Expected contextual words to be kept with detection off, got %q", british)
+	}
+	conv.SetContextualWordDetectionEnabled(true)
+
+	conv.SetExcludedWords([]string{"color", "license"})
+	for _, word := range []string{"color", "license"} {
+		if british, changed, _ := conv.ConvertWord(word); changed {
+			t.Errorf("Expected excluded word %q to be kept, got %q", word, british)
+		}
+	}
+}
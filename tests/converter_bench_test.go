@@ -129,3 +129,58 @@ func BenchmarkConvertNoChanges(b *testing.B) {
 		conv.ConvertToBritish(britishText, false)
 	}
 }
+
+// noChangeLargeText is a large document with nothing to convert, the common case for files that
+// are already in British English.
+var noChangeLargeText = strings.Repeat(`The programme was launched in the capital after months of careful planning and debate.
+Engineers reviewed the plans, checked every figure, and signed off on the final report.
+Visitors can book tickets online or buy them at the door on the day of the event.
+`, 1000)
+
+// newDictionaryBenchConverter returns a converter with unit and contextual word detection
+// turned off, so benchmarks measure the dictionary stage.
+func newDictionaryBenchConverter(b *testing.B) *converter.Converter {
+	b.Helper()
+	conv, err := converter.NewConverter()
+	if err != nil {
+		b.Fatal(err)
+	}
+	conv.SetUnitProcessingEnabled(false)
+	conv.GetContextualWordDetector().SetEnabled(false)
+	return conv
+}
+
+// BenchmarkConvertNoChanges_Large benchmarks the dictionary stage on a large document with no
+// convertible words, which the dictionary pre-filter skips without tokenising.
+func BenchmarkConvertNoChanges_Large(b *testing.B) {
+	conv := newDictionaryBenchConverter(b)
+	b.SetBytes(int64(len(noChangeLargeText)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		conv.ConvertToBritishSimple(noChangeLargeText, false)
+	}
+}
+
+// BenchmarkConvertSparseChanges_Large benchmarks the dictionary stage on a large document where
+// one line in a hundred needs converting, so most lines are skipped by the pre-filter.
+func BenchmarkConvertSparseChanges_Large(b *testing.B) {
+	conv := newDictionaryBenchConverter(b)
+	text := strings.Repeat(strings.Repeat("Engineers reviewed the plans, checked every figure, and signed off on the final report.\n", 99)+
+		"The color of the center was gray.\n", 30)
+	b.SetBytes(int64(len(text)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		conv.ConvertToBritishSimple(text, false)
+	}
+}
+
+// BenchmarkConvertDenseChanges_Medium benchmarks the dictionary stage on text where every line
+// needs converting, showing the pre-filter's overhead when it can't skip anything.
+func BenchmarkConvertDenseChanges_Medium(b *testing.B) {
+	conv := newDictionaryBenchConverter(b)
+	b.SetBytes(int64(len(mediumText)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		conv.ConvertToBritishSimple(mediumText, false)
+	}
+}
@@ -0,0 +1,106 @@
+package tests
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+func TestCountOnlyMatchesConversion(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+	conv.SetCountersEnabled(true)
+	counter, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+	counter.SetCountOnly(true)
+
+	inputs := map[string]string{
+		"Prose":            "The color of the center. Organize the catalog!\n",
+		"URLs and quotes":  "See https://example.com/color and the 'flavor' of \"gray\".\n",
+		"Proper nouns":     "The Department of Labor has a favorite color.\n",
+		"Markdown":         "# Color guide\n\n- Pick a color\n- `color` stays\n\n```go\n// color\nvar color = 1\n```\n",
+		"Parallel path":    strings.Repeat("The color and the flavor.\nNothing here.\n", 400),
+		"Nothing to count": "The colour of the centre.\n",
+	}
+	for name, input := range inputs {
+		t.Run(name, func(t *testing.T) {
+			conv.TakeCounters()
+			conv.ConvertFileContent(input, "doc.md", true)
+			expected := conv.TakeCounters()
+
+			counter.ConvertFileContent(input, "doc.md", true)
+			counts := counter.TakeCounters()
+			if counts.Changes() != expected.Changes() || counts.DictionaryHits != expected.DictionaryHits {
+				t.Errorf("Expected count-only to count %+v, got %+v", expected, counts)
+			}
+		})
+	}
+
+	// The dictionary stage leaves the text as it is
+	if result := counter.ConvertToBritish("The color.", false); result != "The color." {
+		t.Errorf("Expected count-only conversion to leave dictionary words, got %q", result)
+	}
+	if counts := counter.TakeCounters(); counts.DictionaryHits != 1 {
+		t.Errorf("Expected 1 dictionary change counted, got %+v", counts)
+	}
+}
+
+func TestCountOnlyCLI(t *testing.T) {
+	cliPath := buildTestCLI(t)
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.md":     "The color and the center.\n",
+		"b.txt":    "Nothing to change.\n",
+		"sub/c.md": "The flavor.\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cmd := exec.Command(cliPath, "-count-only", dir)
+	cmd.Env = append(os.Environ(), "HOME="+t.TempDir())
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, out)
+	}
+	expected := filepath.Join(dir, "a.md") + ": 2 change(s) (2 spelling, 0 unit)\n" +
+		filepath.Join(dir, "sub", "c.md") + ": 1 change(s) (1 spelling, 0 unit)\n" +
+		"Total: 3 change(s) (3 spelling, 0 unit) in 2 of 3 file(s)\n"
+	if string(out) != expected {
+		t.Errorf("Expected\n%s\ngot\n%s", expected, out)
+	}
+	for name, content := range files {
+		if got, _ := os.ReadFile(filepath.Join(dir, name)); string(got) != content {
+			t.Errorf("Expected %s to be left alone, got %q", name, got)
+		}
+	}
+
+	cmd = exec.Command(cliPath, "-count-only", "-exit-on-change", filepath.Join(dir, "a.md"))
+	cmd.Env = append(os.Environ(), "HOME="+t.TempDir())
+	err = cmd.Run()
+	if exitErr, ok := err.(*exec.ExitError); !ok || exitErr.ExitCode() != 1 {
+		t.Errorf("Expected exit code 1 with -exit-on-change, got %v", err)
+	}
+
+	cmd = exec.Command(cliPath, "-count-only", "-diff", dir)
+	err = cmd.Run()
+	if exitErr, ok := err.(*exec.ExitError); !ok || exitErr.ExitCode() != 2 {
+		t.Errorf("Expected -count-only -diff to fail with a usage error, got %v", err)
+	}
+}
@@ -0,0 +1,143 @@
+package tests
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+func TestConvertCSVColumns(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	input := "id,color,description,price\n" +
+		"101,gray,\"A gray, colorful \"\"favorite\"\" mug\",12.50\n" +
+		"102,color,Neutral color,8\n" +
+		"103,gray,\"Two lines:\ncolor and flavor\",3\n"
+
+	tests := []struct {
+		name     string
+		columns  []string
+		expected string
+	}{
+		{
+			name:    "By header name",
+			columns: []string{"description"},
+			expected: "id,color,description,price\n" +
+				"101,gray,\"A grey, colourful \"\"favourite\"\" mug\",12.50\n" +
+				"102,color,Neutral colour,8\n" +
+				"103,gray,\"Two lines:\ncolour and flavour\",3\n",
+		},
+		{
+			name:    "By number and case-insensitive name",
+			columns: []string{"2", "DESCRIPTION"},
+			expected: "id,color,description,price\n" +
+				"101,grey,\"A grey, colourful \"\"favourite\"\" mug\",12.50\n" +
+				"102,colour,Neutral colour,8\n" +
+				"103,grey,\"Two lines:\ncolour and flavour\",3\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conv.SetCSVColumns(tt.columns)
+			result, err := conv.ConvertCSV(input, "export.csv", true)
+			if err != nil {
+				t.Fatalf("ConvertCSV failed: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("ConvertCSV() =\n%s\nexpected\n%s", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestConvertCSVQuoting(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+	conv.SetCSVColumns([]string{"notes"})
+
+	// Unchanged cells keep their quoting, including quotes that aren't needed
+	input := "\"id\",\"notes\"\r\n\"7\",\"plain text\"\r\n8,color\r\n9,\"color\"\r\n10,\"Say \"\"hi\"\", color\""
+	expected := "\"id\",\"notes\"\r\n\"7\",\"plain text\"\r\n8,colour\r\n9,\"colour\"\r\n10,\"Say \"\"hi\"\", colour\""
+	result, err := conv.ConvertCSV(input, "notes.csv", false)
+	if err != nil {
+		t.Fatalf("ConvertCSV failed: %v", err)
+	}
+	if result != expected {
+		t.Errorf("ConvertCSV() = %q, expected %q", result, expected)
+	}
+}
+
+func TestConvertTSV(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+	conv.SetCSVColumns([]string{"name"})
+
+	input := "id\tname\n1\tcolor, gray\n"
+	result := conv.ConvertFileContent(input, "data.tsv", false)
+	if result != "id\tname\n1\tcolour, grey\n" {
+		t.Errorf("ConvertFileContent() = %q", result)
+	}
+}
+
+func TestConvertCSVErrors(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		columns []string
+		input   string
+	}{
+		{"Unknown column", []string{"summary"}, "id,description\n1,color\n"},
+		{"Column number out of range", []string{"3"}, "id,description\n1,color\n"},
+		{"Invalid CSV", []string{"description"}, "id,description\n1,\"color\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conv.SetCSVColumns(tt.columns)
+			result, err := conv.ConvertCSV(tt.input, "data.csv", false)
+			if err == nil {
+				t.Error("Expected an error")
+			}
+			if result != tt.input {
+				t.Errorf("Expected the input unchanged, got %q", result)
+			}
+			if result := conv.ConvertFileContent(tt.input, "data.csv", false); result != tt.input {
+				t.Errorf("Expected ConvertFileContent to leave the input unchanged, got %q", result)
+			}
+		})
+	}
+}
+
+func TestCLICSVColumns(t *testing.T) {
+	cliPath := buildTestCLI(t)
+
+	input := "id,color,description\n1,gray,A colorful mug\n"
+	cmd := exec.Command(cliPath, "-raw", "-csv-columns", "description")
+	cmd.Stdin = strings.NewReader(input)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+	}
+	if string(output) != "id,color,description\n1,gray,A colourful mug\n" {
+		t.Errorf("Expected only the description column to convert, got %q", output)
+	}
+
+	cmd = exec.Command(cliPath, "-csv-columns", "description", "-all-text", "text")
+	if output, err := cmd.CombinedOutput(); err == nil {
+		t.Errorf("Expected -csv-columns with -all-text to fail, got %q", output)
+	}
+}
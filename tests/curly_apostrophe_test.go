@@ -0,0 +1,36 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+func TestCurlyApostrophes(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"The color’s hue", "The colour’s hue"},
+		{"The Color’s hue", "The Colour’s hue"},
+		{"It’s gray, don’t worry.", "It’s grey, don’t worry."},
+		{"Mary’s favorite", "Mary’s favourite"},
+		{"She said ‘color’, not ‘colour’.", "She said ‘colour’, not ‘colour’."},
+		{"The colors’ names", "The colours’ names"},
+		{"The color's hue", "The colour's hue"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			// Smart quotes are left alone, so the curly apostrophes reach the dictionary
+			if result := conv.ConvertToBritish(tt.input, false); result != tt.expected {
+				t.Errorf("ConvertToBritish(%q) = %q, expected %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
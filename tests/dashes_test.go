@@ -0,0 +1,81 @@
+package tests
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+func TestDashModes(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	input := "From 1990–1995 the “color” faded—slowly."
+	if result := conv.ConvertToBritish(input, true); result != `From 1990-1995 the "colour" faded-slowly.` {
+		t.Errorf("Expected dashes to be flattened by default, got %q", result)
+	}
+
+	conv.SetDashMode(converter.DashModeTypographic)
+	if conv.GetDashMode() != converter.DashModeTypographic {
+		t.Fatal("Expected the typographic dash mode to be set")
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"En-dash range survives", "From 1990–1995.", "From 1990–1995."},
+		{"Em-dash survives", "The color—gray—faded.", "The colour—grey—faded."},
+		{"Quotes still normalised", "The “flavor” of it.", `The "flavour" of it.`},
+		{"Spaced hyphen between numbers", "Pages 10 - 20 of the catalog.", "Pages 10–20 of the catalogue."},
+		{"Spaced hyphen between words kept", "The color - and more.", "The colour - and more."},
+		{"Unspaced hyphen kept", "Call 555-1234.", "Call 555-1234."},
+		{"Inline code kept", "Run `seq 1 - 5` from 1990 - 1995.", "Run `seq 1 - 5` from 1990–1995."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := conv.ConvertToBritish(tt.input, true); result != tt.expected {
+				t.Errorf("ConvertToBritish(%q) = %q, expected %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+
+	// Fenced code is only recognised when the whole document is processed at once
+	code := "From 1990 - 1995.\n\n```\nx = 10 - 2\n```\n"
+	if result := conv.ProcessCodeAware(code, true); result != "From 1990–1995.\n\n```\nx = 10 - 2\n```\n" {
+		t.Errorf("Expected code blocks to be kept, got %q", result)
+	}
+	if result := conv.ConvertFileContent(code, "notes.md", true); result != "From 1990–1995.\n\n```\nx = 10 - 2\n```\n" {
+		t.Errorf("Expected code blocks in files to be kept, got %q", result)
+	}
+
+	if _, err := converter.ParseDashMode("fancy"); err == nil {
+		t.Error("Expected an unknown dash mode to be rejected")
+	}
+}
+
+func TestCLIDashes(t *testing.T) {
+	cliPath := buildTestCLI(t)
+
+	cmd := exec.Command(cliPath, "-raw", "-dashes=typographic")
+	cmd.Stdin = strings.NewReader("From 1990–1995 and 2000 - 2005.")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+	}
+	if strings.TrimSpace(string(output)) != "From 1990–1995 and 2000–2005." {
+		t.Errorf("Expected en-dash ranges, got %q", output)
+	}
+
+	cmd = exec.Command(cliPath, "-raw", "-dashes", "bogus")
+	cmd.Stdin = strings.NewReader("text")
+	if err := cmd.Run(); err == nil {
+		t.Error("Expected an unknown dash mode to fail")
+	}
+}
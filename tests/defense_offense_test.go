@@ -0,0 +1,66 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+func TestDefenseOffenseConversion(t *testing.T) {
+	// A fresh home directory so the default contextual configuration is used
+	t.Setenv("HOME", t.TempDir())
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+	conv.SetProperNounConversionEnabled(true)
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"Compound", "The defense mechanism kicked in.", "The defence mechanism kicked in."},
+		{"Sport", "He plays defense, and she plays offense.", "He plays defence, and she plays offence."},
+		{"Legal", "The defense rested after the second offense was read.", "The defence rested after the second offence was read."},
+		{"Sentence start", "Defense is the best form of attack.", "Defence is the best form of attack."},
+		{"Upper case", "NO OFFENSE INTENDED", "NO OFFENCE INTENDED"},
+		{"Adjectives unchanged", "A defensive line and an offensive remark.", "A defensive line and an offensive remark."},
+		{"Plurals", "Their defenses held against minor offenses.", "Their defences held against minor offences."},
+		{"Department of Defense", "The Department of Defense issued a statement.", "The Department of Defense issued a statement."},
+		{"Secretary of Defense", "The Secretary of Defense spoke about missile defense.", "The Secretary of Defense spoke about missile defence."},
+		{"Defense Department", "The Defense Department and the defense budget.", "The Defense Department and the defence budget."},
+		{"Agency name", "A report from the Defense Intelligence Agency.", "A report from the Defense Intelligence Agency."},
+		{"Lower-case department", "The company's department of defense was small.", "The company's department of defence was small."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := conv.ConvertToBritish(tt.input, false); result != tt.expected {
+				t.Errorf("ConvertToBritish(%q) = %q, expected %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDefenseOffenseWithExistingConfig(t *testing.T) {
+	// A config saved before defense and offense were added keeps "Department of Defense"
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	config := converter.GetDefaultContextualWordConfig()
+	config.RemoveCustomWord("defense")
+	config.RemoveCustomWord("offense")
+	writeContextualWordConfig(t, home, config)
+
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+	conv.SetProperNounConversionEnabled(true)
+
+	input := "The Department of Defense spoke about missile defense and a minor offense."
+	expected := "The Department of Defense spoke about missile defence and a minor offence."
+	if result := conv.ConvertToBritish(input, false); result != expected {
+		t.Errorf("ConvertToBritish(%q) = %q, expected %q", input, result, expected)
+	}
+}
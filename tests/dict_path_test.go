@@ -0,0 +1,114 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+func TestDictPathFileReplacesEmbeddedDictionary(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dictFile := filepath.Join(t.TempDir(), "spellings.json")
+	if err := os.WriteFile(dictFile, []byte(`{"color": "colour", "gizmo": "gadget"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(converter.DictPathEnv, dictFile)
+
+	dicts, err := converter.LoadDictionaries()
+	if err != nil {
+		t.Fatalf("LoadDictionaries() failed: %v", err)
+	}
+	if dicts.AmericanToBritish["gizmo"] != "gadget" {
+		t.Errorf("Expected the override's entries, got %q", dicts.AmericanToBritish["gizmo"])
+	}
+	if _, ok := dicts.AmericanToBritish["center"]; ok {
+		t.Error("Expected a file to replace the embedded dictionary, but center is still present")
+	}
+
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+	if result := conv.ConvertToBritish("The color gizmo in the center.", false); result != "The colour gadget in the center." {
+		t.Errorf("Unexpected conversion %q", result)
+	}
+}
+
+func TestDictPathDirectory(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir := t.TempDir()
+	files := map[string]string{
+		"a_extra.json":          `{"gizmo": "gadget", "center": "middle"}`,
+		"b_extra.json":          `{"gizmo": "widget"}`,
+		"american_phrases.json": `{"on the weekend": "at the weekend"}`,
+		"notes.txt":             `not a dictionary`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv(converter.DictPathEnv, dir)
+
+	dicts, err := converter.LoadDictionaries()
+	if err != nil {
+		t.Fatalf("LoadDictionaries() failed: %v", err)
+	}
+	// Without an american_spellings.json the embedded spellings are augmented
+	if dicts.AmericanToBritish["color"] != "colour" {
+		t.Error("Expected the embedded dictionary to be kept")
+	}
+	if got := dicts.AmericanToBritish["center"]; got != "middle" {
+		t.Errorf("Expected extra files to override embedded entries, got %q", got)
+	}
+	if got := dicts.AmericanToBritish["gizmo"]; got != "widget" {
+		t.Errorf("Expected later files to win, got %q", got)
+	}
+
+	phrases, err := converter.LoadDefaultPhrases()
+	if err != nil {
+		t.Fatalf("LoadDefaultPhrases() failed: %v", err)
+	}
+	if len(phrases) != 1 || phrases["on the weekend"] != "at the weekend" {
+		t.Errorf("Expected american_phrases.json to replace the embedded phrases, got %v", phrases)
+	}
+}
+
+func TestDictPathValidation(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	tests := []struct {
+		name    string
+		content string
+		errText string
+	}{
+		{"Not JSON", `{"color": `, "must be a JSON object"},
+		{"Array", `["color", "colour"]`, "must be a JSON object"},
+		{"Non-string value", `{"color": 1}`, `entry "color"`},
+		{"Empty value", `{"color": ""}`, `entry "color"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dictFile := filepath.Join(t.TempDir(), "spellings.json")
+			if err := os.WriteFile(dictFile, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			t.Setenv(converter.DictPathEnv, dictFile)
+
+			if _, err := converter.NewConverter(); err == nil || !strings.Contains(err.Error(), tt.errText) {
+				t.Errorf("Expected an error containing %q, got %v", tt.errText, err)
+			}
+		})
+	}
+
+	t.Run("Missing path", func(t *testing.T) {
+		t.Setenv(converter.DictPathEnv, filepath.Join(t.TempDir(), "missing.json"))
+		if _, err := converter.LoadDictionaries(); err == nil || !strings.Contains(err.Error(), converter.DictPathEnv) {
+			t.Errorf("Expected an error naming %s, got %v", converter.DictPathEnv, err)
+		}
+	})
+}
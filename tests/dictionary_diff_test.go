@@ -0,0 +1,89 @@
+package tests
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+func TestDiffDictionaries(t *testing.T) {
+	oldDict := map[string]string{"color": "colour", "gray": "grey", "center": "centre"}
+	newDict := map[string]string{"color": "colour", "gray": "gray", "flavor": "flavour", "favor": "color"}
+
+	diff := converter.DiffDictionaries(oldDict, newDict)
+
+	expectedAdded := []converter.DictionaryEntry{{American: "favor", British: "color"}, {American: "flavor", British: "flavour"}}
+	if !reflect.DeepEqual(diff.Added, expectedAdded) {
+		t.Errorf("Added = %v, expected %v", diff.Added, expectedAdded)
+	}
+	expectedRemoved := []converter.DictionaryEntry{{American: "center", British: "centre"}}
+	if !reflect.DeepEqual(diff.Removed, expectedRemoved) {
+		t.Errorf("Removed = %v, expected %v", diff.Removed, expectedRemoved)
+	}
+	expectedChanged := []converter.DictionaryChange{{American: "gray", Old: "grey", New: "gray"}}
+	if !reflect.DeepEqual(diff.Changed, expectedChanged) {
+		t.Errorf("Changed = %v, expected %v", diff.Changed, expectedChanged)
+	}
+
+	if len(diff.Warnings) != 2 || diff.Warnings[0].American != "favor" || diff.Warnings[1].Reason != "maps to itself" {
+		t.Errorf("Expected warnings for favor and gray, got %v", diff.Warnings)
+	}
+
+	if !converter.DiffDictionaries(oldDict, oldDict).IsEmpty() {
+		t.Error("Expected no differences between a dictionary and itself")
+	}
+}
+
+func TestCLIDictDiff(t *testing.T) {
+	cliPath := buildTestCLI(t)
+
+	dir := t.TempDir()
+	oldPath := filepath.Join(dir, "old.json")
+	newPath := filepath.Join(dir, "new.json")
+	if err := os.WriteFile(oldPath, []byte(`{"color": "colour", "center": "centre"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(newPath, []byte(`{"color": "colour", "flavor": "flavour"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := exec.Command(cliPath, "dict-diff", oldPath, newPath).CombinedOutput()
+	if err != nil {
+		t.Fatalf("dict-diff failed: %v\n%s", err, output)
+	}
+	for _, want := range []string{"+ flavor → flavour", "- center → centre", "1 added, 1 removed, 0 changed"} {
+		if !strings.Contains(string(output), want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+
+	output, err = exec.Command(cliPath, "dict-diff", "-json", oldPath, newPath).Output()
+	if err != nil {
+		t.Fatalf("dict-diff -json failed: %v", err)
+	}
+	var diff converter.DictionaryDiff
+	if err := json.Unmarshal(output, &diff); err != nil {
+		t.Fatalf("Expected JSON output, got %q: %v", output, err)
+	}
+	if len(diff.Added) != 1 || len(diff.Removed) != 1 || len(diff.Changed) != 0 {
+		t.Errorf("Unexpected JSON differences: %+v", diff)
+	}
+
+	if err := exec.Command(cliPath, "dict-diff", "-exit-on-change", oldPath, newPath).Run(); err == nil {
+		t.Error("Expected -exit-on-change to fail when the dictionaries differ")
+	}
+	if output, err := exec.Command(cliPath, "dict-diff", "-exit-on-change", oldPath, oldPath).CombinedOutput(); err != nil {
+		t.Errorf("Expected identical dictionaries to succeed, got %v\n%s", err, output)
+	}
+
+	err = exec.Command(cliPath, "dict-diff", oldPath).Run()
+	if exitErr, ok := err.(*exec.ExitError); !ok || exitErr.ExitCode() != 2 {
+		t.Errorf("Expected a usage error with one file, got %v", err)
+	}
+}
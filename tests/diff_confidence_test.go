@@ -0,0 +1,93 @@
+package tests
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+	"github.com/sammcj/m2e/pkg/report"
+)
+
+func TestConvertWithChangesConfidence(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+	conv.SetUnitProcessingEnabled(true)
+
+	_, changes := conv.ConvertWithChanges("You need a license for the color 10 feet away.", converter.Options{})
+	confidence := make(map[string]float64)
+	for _, change := range changes {
+		confidence[change.Original] = change.Confidence
+	}
+
+	if c := confidence["license"]; c <= 0 || c > 1 {
+		t.Errorf("Expected the contextual change to carry its confidence, got %v", c)
+	}
+	if c := confidence["10 feet"]; c <= 0 || c > 1 {
+		t.Errorf("Expected the unit change to carry its confidence, got %v", c)
+	}
+	if c, ok := confidence["color"]; !ok || c != 0 {
+		t.Errorf("Expected the dictionary change to have no confidence, got %v (found %v)", c, ok)
+	}
+}
+
+func TestAnnotatedLineDiff(t *testing.T) {
+	original := "The color.\nA license.\n"
+	converted := "The colour.\nA licence.\n"
+
+	expected := "--- notes.txt.orig\n+++ notes.txt\n" +
+		"@@ -1,1 +1,1 @@\n-The color.\n+The colour.\n" +
+		"@@ -2,1 +2,1 @@\n-A license.\n+A licence.  # confidence: \"licence\" 0.90\n"
+	if diff := report.AnnotatedLineDiff(original, converted, "notes.txt", map[int]string{2: `confidence: "licence" 0.90`}); diff != expected {
+		t.Errorf("AnnotatedLineDiff() = %q, expected %q", diff, expected)
+	}
+
+	if diff := report.AnnotatedLineDiff(original, converted, "notes.txt", nil); diff != report.LineDiff(original, converted, "notes.txt") {
+		t.Errorf("Expected no notes to give the plain line diff, got %q", diff)
+	}
+}
+
+func TestDiffConfidenceCLI(t *testing.T) {
+	cliPath := buildTestCLI(t)
+	input := "I need a license.\nThe color is gray.\n"
+
+	cmd := exec.Command(cliPath, "-diff", "-diff-confidence")
+	cmd.Stdin = strings.NewReader(input)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+	}
+
+	lines := strings.Split(string(output), "\n")
+	var licence, colour string
+	for _, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "+I need"):
+			licence = line
+		case strings.HasPrefix(line, "+The colour"):
+			colour = line
+		}
+	}
+	if !strings.HasPrefix(licence, `+I need a licence.  # confidence: "licence" 0.`) {
+		t.Errorf("Expected the contextual change to be annotated, got %q\nOutput: %s", licence, output)
+	}
+	if colour != "+The colour is grey." {
+		t.Errorf("Expected dictionary changes to be left unannotated, got %q", colour)
+	}
+
+	// Plain -diff is unchanged
+	cmd = exec.Command(cliPath, "-diff")
+	cmd.Stdin = strings.NewReader(input)
+	if output, err := cmd.CombinedOutput(); err != nil || strings.Contains(string(output), "confidence") {
+		t.Errorf("Expected -diff alone not to annotate, got %q (%v)", output, err)
+	}
+
+	// It only annotates -diff
+	cmd = exec.Command(cliPath, "-raw", "-diff-confidence")
+	cmd.Stdin = strings.NewReader(input)
+	if output, err := cmd.CombinedOutput(); err == nil || !strings.Contains(string(output), "-diff-confidence requires -diff") {
+		t.Errorf("Expected -diff-confidence without -diff to be rejected, got %q (%v)", output, err)
+	}
+}
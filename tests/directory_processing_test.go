@@ -1,6 +1,7 @@
 package tests
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
@@ -420,3 +421,46 @@ func TestFindTextFilesIgnoresHiddenDirectories(t *testing.T) {
 		}
 	}
 }
+
+func TestProcessFilesReportsProgress(t *testing.T) {
+	files := []fileutil.FileInfo{{Path: "a.txt"}, {Path: "b.txt"}, {Path: "c.txt"}}
+
+	var processed []string
+	var progress [][2]int
+	err := fileutil.ProcessFiles(files, func(file fileutil.FileInfo) error {
+		processed = append(processed, file.Path)
+		return nil
+	}, func(done, total int) {
+		progress = append(progress, [2]int{done, total})
+	})
+	if err != nil {
+		t.Fatalf("ProcessFiles failed: %v", err)
+	}
+
+	if strings.Join(processed, ",") != "a.txt,b.txt,c.txt" {
+		t.Errorf("Expected files to be processed in order, got %v", processed)
+	}
+	expected := [][2]int{{1, 3}, {2, 3}, {3, 3}}
+	if len(progress) != len(expected) {
+		t.Fatalf("Expected %d progress calls, got %v", len(expected), progress)
+	}
+	for i := range expected {
+		if progress[i] != expected[i] {
+			t.Errorf("Progress call %d: expected %v, got %v", i, expected[i], progress[i])
+		}
+	}
+
+	// A nil ProgressFunc is allowed and the first error stops processing
+	stop := errors.New("stop")
+	calls := 0
+	err = fileutil.ProcessFiles(files, func(file fileutil.FileInfo) error {
+		calls++
+		if file.Path == "b.txt" {
+			return stop
+		}
+		return nil
+	}, nil)
+	if !errors.Is(err, stop) || calls != 2 {
+		t.Errorf("Expected processing to stop at the first error, got %v after %d call(s)", err, calls)
+	}
+}
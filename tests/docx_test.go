@@ -0,0 +1,255 @@
+package tests
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+	"github.com/sammcj/m2e/pkg/fileutil"
+)
+
+const docxContentTypes = `<?xml version="1.0" encoding="UTF-8"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/></Types>`
+
+const docxStyles = `<w:styles><w:style w:styleId="ColorHeading"><w:name w:val="Color Heading"/></w:style></w:styles>`
+
+// buildDocx returns a Word package with the given parts, plus content types and styles
+func buildDocx(t *testing.T, parts map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	names := []string{"[Content_Types].xml", "word/styles.xml"}
+	contents := map[string]string{"[Content_Types].xml": docxContentTypes, "word/styles.xml": docxStyles}
+	for name, content := range parts {
+		names = append(names, name)
+		contents[name] = content
+	}
+	for _, name := range names {
+		w, err := writer.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := io.WriteString(w, contents[name]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// readDocxParts returns the content of each part of a Word package
+func readDocxParts(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("Converted document isn't a valid zip archive: %v", err)
+	}
+	parts := make(map[string]string)
+	for _, f := range archive.File {
+		r, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := io.ReadAll(r)
+		r.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		parts[f.Name] = string(content)
+	}
+	return parts
+}
+
+func TestDocxConversion(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	document := `<w:document><w:body>` +
+		`<w:p><w:pPr><w:pStyle w:val="ColorHeading"/></w:pPr><w:r><w:t>The color guide</w:t></w:r></w:p>` +
+		// A word split across runs with different formatting
+		`<w:p><w:r><w:rPr><w:b/></w:rPr><w:t>Col</w:t></w:r><w:r><w:t xml:space="preserve">or the center &amp; gray</w:t></w:r><w:r><w:tab/><w:t>areas.</w:t></w:r></w:p>` +
+		// Field instructions and hyperlink targets aren't text
+		`<w:p><w:r><w:instrText xml:space="preserve"> HYPERLINK "https://example.com/color" </w:instrText></w:r><w:r><w:t>Nothing to change.</w:t></w:r></w:p>` +
+		`</w:body></w:document>`
+	expectedDocument := `<w:document><w:body>` +
+		`<w:p><w:pPr><w:pStyle w:val="ColorHeading"/></w:pPr><w:r><w:t>The colour guide</w:t></w:r></w:p>` +
+		`<w:p><w:r><w:rPr><w:b/></w:rPr><w:t>Col</w:t></w:r><w:r><w:t xml:space="preserve">our the centre &amp; grey</w:t></w:r><w:r><w:tab/><w:t>areas.</w:t></w:r></w:p>` +
+		`<w:p><w:r><w:instrText xml:space="preserve"> HYPERLINK "https://example.com/color" </w:instrText></w:r><w:r><w:t>Nothing to change.</w:t></w:r></w:p>` +
+		`</w:body></w:document>`
+	footer := `<w:ftr><w:p><w:r><w:t>Page color</w:t></w:r></w:p></w:ftr>`
+	relationships := `<Relationships><Relationship Id="rId1" Target="footer1.xml"/></Relationships>`
+
+	data := buildDocx(t, map[string]string{
+		"word/document.xml":            document,
+		"word/footer1.xml":             footer,
+		"word/_rels/document.xml.rels": relationships,
+	})
+	converted, err := conv.ConvertDocx(data, false)
+	if err != nil {
+		t.Fatalf("ConvertDocx() error: %v", err)
+	}
+
+	parts := readDocxParts(t, converted)
+	if parts["word/document.xml"] != expectedDocument {
+		t.Errorf("Unexpected document.xml:\n%s\nexpected\n%s", parts["word/document.xml"], expectedDocument)
+	}
+	if parts["word/footer1.xml"] != `<w:ftr><w:p><w:r><w:t>Page colour</w:t></w:r></w:p></w:ftr>` {
+		t.Errorf("Expected the footer to be converted, got %s", parts["word/footer1.xml"])
+	}
+	for name, content := range map[string]string{
+		"[Content_Types].xml":          docxContentTypes,
+		"word/styles.xml":              docxStyles,
+		"word/_rels/document.xml.rels": relationships,
+	} {
+		if parts[name] != content {
+			t.Errorf("Expected %s to be untouched, got %s", name, parts[name])
+		}
+	}
+
+	text, err := conv.ExtractDocxText(converted)
+	if err != nil {
+		t.Fatalf("ExtractDocxText() error: %v", err)
+	}
+	if !strings.Contains(text, "Colour the centre & grey\tareas.\n") {
+		t.Errorf("Unexpected document text: %q", text)
+	}
+}
+
+func TestDocxUnchangedPackage(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	data := buildDocx(t, map[string]string{"word/document.xml": `<w:document><w:body><w:p><w:r><w:t>All fine.</w:t></w:r></w:p></w:body></w:document>`})
+	converted, err := conv.ConvertDocx(data, false)
+	if err != nil {
+		t.Fatalf("ConvertDocx() error: %v", err)
+	}
+	if !bytes.Equal(converted, data) {
+		t.Error("Expected a document with nothing to convert to be rewritten byte for byte")
+	}
+}
+
+func TestDocxRejectsInvalidPackages(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	var notWord bytes.Buffer
+	writer := zip.NewWriter(&notWord)
+	if _, err := writer.Create("readme.txt"); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, data := range map[string][]byte{
+		"Not a zip":      []byte("The color is gray."),
+		"Not a document": notWord.Bytes(),
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := conv.ConvertDocx(data, false); err == nil || !strings.Contains(err.Error(), "not a valid Office Open XML package") {
+				t.Errorf("Expected an invalid package error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestCLIDocx(t *testing.T) {
+	cliPath := buildTestCLI(t)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "report.docx")
+	data := buildDocx(t, map[string]string{"word/document.xml": `<w:document><w:body><w:p><w:r><w:t>The color is gray.</w:t></w:r></w:p></w:body></w:document>`})
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := exec.Command(cliPath, "-diff", path).CombinedOutput()
+	if err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+	}
+	if !strings.Contains(string(output), "+The colour is grey.") {
+		t.Errorf("Expected a diff of the document text, got:\n%s", output)
+	}
+
+	if output, err := exec.Command(cliPath, "-save", path).CombinedOutput(); err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+	}
+	saved, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := readDocxParts(t, saved)["word/document.xml"]; !strings.Contains(got, "<w:t>The colour is grey.</w:t>") {
+		t.Errorf("Expected the saved document to be converted, got %s", got)
+	}
+
+	invalid := filepath.Join(dir, "broken.docx")
+	if err := os.WriteFile(invalid, []byte("The color."), 0644); err != nil {
+		t.Fatal(err)
+	}
+	output, err = exec.Command(cliPath, "-save", invalid).CombinedOutput()
+	var exitError *exec.ExitError
+	if !errors.As(err, &exitError) || exitError.ExitCode() != 3 || !strings.Contains(string(output), "not a valid Office Open XML package") {
+		t.Errorf("Expected an I/O error for an invalid document, got %v: %s", err, output)
+	}
+	if content, _ := os.ReadFile(invalid); string(content) != "The color." {
+		t.Errorf("Expected the invalid document to be left alone, got %q", content)
+	}
+}
+
+func TestCLIDocxDirectory(t *testing.T) {
+	cliPath := buildTestCLI(t)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "docs", "report.docx")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	data := buildDocx(t, map[string]string{"word/document.xml": `<w:document><w:body><w:p><w:r><w:t>The color is gray.</w:t></w:r></w:p></w:body></w:document>`})
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only a search that asks for documents finds them
+	if files, err := fileutil.FindTextFiles(dir); err != nil || len(files) != 0 {
+		t.Errorf("Expected a text file search to skip the document, got %v, %v", files, err)
+	}
+	files, err := fileutil.FindTextFilesWithOptions(dir, fileutil.FindOptions{IncludeDocuments: true})
+	if err != nil || len(files) != 1 || files[0].Path != path || files[0].IsText {
+		t.Errorf("Expected IncludeDocuments to find the document, got %v, %v", files, err)
+	}
+
+	output, err := exec.Command(cliPath, "-diff", dir).CombinedOutput()
+	if err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+	}
+	if !strings.Contains(string(output), "Found 1 text file(s)") || !strings.Contains(string(output), "+The colour is grey.") {
+		t.Errorf("Expected the directory run to diff the document text, got:\n%s", output)
+	}
+
+	if output, err := exec.Command(cliPath, "-save", dir).CombinedOutput(); err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+	}
+	saved, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := readDocxParts(t, saved)["word/document.xml"]; !strings.Contains(got, "<w:t>The colour is grey.</w:t>") {
+		t.Errorf("Expected the saved document to be converted, got %s", got)
+	}
+}
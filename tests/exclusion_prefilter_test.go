@@ -0,0 +1,107 @@
+package tests
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+// exclusionSample mixes license/practice text that hits the exclusion patterns
+// with text that only mentions the contextual words in passing.
+const exclusionSample = `The MIT license is included in the LICENSE.md file at the root of the repo.
+Doctors need a license to practice medicine, and the practice manager keeps the records.
+She will check the software license agreement before the license plate is renewed.
+var licenseKey = loadKey(); practice := "daily" // the advice was to practise daily
+Read the short story in the story book, then tell the story to the class.
+The hard disk drive filled up, so the computer program couldn't save the draft document.
+You tire easily when the meter reads 40 square meter and you must curb your enthusiasm.
+See https://example.com/license/terms and /usr/share/doc/license/ for the full text.
+Their practice of giving free advice was welcome; the license holder agreed to license it.
+`
+
+// perPatternExcluded is the original IsExcluded implementation, running every
+// exclusion pattern in turn.
+func perPatternExcluded(patterns []*regexp.Regexp, text string) bool {
+	for _, pattern := range patterns {
+		if pattern.MatchString(text) {
+			return true
+		}
+	}
+	return false
+}
+
+// exclusionContexts returns the ±50 character windows around each word, as
+// the contextual detector checks them.
+func exclusionContexts(text string) []string {
+	var contexts []string
+	for _, loc := range regexp.MustCompile(`\w+`).FindAllStringIndex(text, -1) {
+		start := max(0, loc[0]-50)
+		end := min(len(text), loc[1]+50)
+		contexts = append(contexts, text[start:end])
+	}
+	return contexts
+}
+
+func TestIsExcludedMatchesPerPatternLoop(t *testing.T) {
+	patterns := converter.NewContextualWordPatterns()
+
+	extra := []string{
+		"", "license", "LICENSE.TXT", "The ſtory book", "bed time ſtory",
+		"a hard diſk drive", "We MUST CURB spending", "12.5 meter", "licence file",
+	}
+	contexts := append(exclusionContexts(exclusionSample), extra...)
+	contexts = append(contexts, strings.Split(exclusionSample, "\n")...)
+
+	for _, context := range contexts {
+		want := perPatternExcluded(patterns.ExclusionPatterns, context)
+		if got := patterns.IsExcluded(context); got != want {
+			t.Errorf("IsExcluded(%q) = %v, per-pattern loop gives %v", context, got, want)
+		}
+	}
+}
+
+func TestIsExcludedSeesAppendedPatterns(t *testing.T) {
+	patterns := converter.NewContextualWordPatterns()
+	text := "a bespoke licence register"
+
+	if patterns.IsExcluded(text) {
+		t.Fatalf("IsExcluded(%q) = true before adding a pattern", text)
+	}
+
+	patterns.ExclusionPatterns = append(patterns.ExclusionPatterns, regexp.MustCompile(`(?i)bespoke\s+licence`))
+	if !patterns.IsExcluded(text) {
+		t.Errorf("IsExcluded(%q) = false after appending a matching pattern", text)
+	}
+
+	patterns.ExclusionPatterns = nil
+	if patterns.IsExcluded("MIT license") {
+		t.Error("IsExcluded should be false once ExclusionPatterns is cleared")
+	}
+}
+
+// BenchmarkIsExcluded_PerPattern benchmarks the per-pattern loop over every
+// context window of a large document (the behaviour before the prefilter).
+func BenchmarkIsExcluded_PerPattern(b *testing.B) {
+	patterns := converter.NewContextualWordPatterns()
+	contexts := exclusionContexts(strings.Repeat(exclusionSample, 10))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, context := range contexts {
+			perPatternExcluded(patterns.ExclusionPatterns, context)
+		}
+	}
+}
+
+// BenchmarkIsExcluded benchmarks IsExcluded over the same context windows.
+func BenchmarkIsExcluded(b *testing.B) {
+	patterns := converter.NewContextualWordPatterns()
+	contexts := exclusionContexts(strings.Repeat(exclusionSample, 10))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, context := range contexts {
+			patterns.IsExcluded(context)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package tests
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+func TestExplainChanges(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	// Nothing is recorded until explaining is enabled
+	conv.ConvertToBritish("The color.", false)
+	if explanations := conv.TakeExplanations(); explanations != nil {
+		t.Errorf("Expected no explanations while disabled, got %v", explanations)
+	}
+
+	conv.SetExplainEnabled(true)
+	if !conv.IsExplainEnabled() {
+		t.Error("Expected explaining to be enabled")
+	}
+	conv.SetUnitProcessingEnabled(true)
+	conv.SetPhraseProcessingEnabled(true)
+
+	input := "I need a license. See you on the weekend; the color “gray” is 6 feet away."
+	expected := "I need a licence. See you at the weekend; the colour \"grey\" is 1.8 metres away."
+	if result := conv.ConvertToBritish(input, true); result != expected {
+		t.Fatalf("ConvertToBritish() = %q, expected %q", result, expected)
+	}
+
+	want := []converter.Explanation{
+		{Original: "“", Converted: "\"", Rule: "smart quotes"},
+		{Original: "”", Converted: "\"", Rule: "smart quotes"},
+		{Original: "on the weekend", Converted: "at the weekend", Rule: "phrase"},
+		{Original: "license", Converted: "licence", Rule: "contextual: determiner_noun pattern for license"},
+		{Original: "color", Converted: "colour", Rule: "dictionary"},
+		{Original: "gray", Converted: "grey", Rule: "dictionary"},
+		{Original: "6 feet", Converted: "1.8 metres", Rule: "unit: feet→metres"},
+	}
+	got := conv.TakeExplanations()
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d explanations, got %d: %v", len(want), len(got), got)
+	}
+	for i := range want {
+		// Only contextual words and units come with the detector's confidence
+		detected := strings.HasPrefix(want[i].Rule, "contextual") || strings.HasPrefix(want[i].Rule, "unit")
+		if detected != (got[i].Confidence > 0 && got[i].Confidence <= 1) {
+			t.Errorf("Explanation %d has confidence %v", i, got[i].Confidence)
+		}
+		got[i].Confidence = 0
+		if got[i] != want[i] {
+			t.Errorf("Explanation %d = %+v, expected %+v", i, got[i], want[i])
+		}
+	}
+
+	if explanations := conv.TakeExplanations(); len(explanations) != 0 {
+		t.Errorf("Expected explanations to be cleared once taken, got %v", explanations)
+	}
+
+	t.Run("Code comments", func(t *testing.T) {
+		conv.ConvertFileContent("// The color center\ncolor := 1\n", "main.go", false)
+		got := conv.TakeExplanations()
+		if len(got) != 2 || got[0].Original != "color" || got[1].Original != "center" {
+			t.Errorf("Expected only the comment's words to be explained, got %v", got)
+		}
+	})
+
+	t.Run("Disabled again", func(t *testing.T) {
+		conv.SetExplainEnabled(false)
+		conv.ConvertToBritish("The color.", false)
+		if explanations := conv.TakeExplanations(); explanations != nil {
+			t.Errorf("Expected no explanations once disabled, got %v", explanations)
+		}
+	})
+}
+
+func TestFormatExplanation(t *testing.T) {
+	tests := []struct {
+		explanation converter.Explanation
+		expected    string
+	}{
+		{converter.Explanation{Original: "color", Converted: "colour", Rule: "dictionary"}, "color → colour (dictionary)"},
+		{converter.Explanation{Original: "—", Converted: "-", Rule: "smart quotes"}, "\"—\" → \"-\" (smart quotes)"},
+	}
+
+	for _, tt := range tests {
+		if result := converter.FormatExplanation(tt.explanation); result != tt.expected {
+			t.Errorf("FormatExplanation(%+v) = %q, expected %q", tt.explanation, result, tt.expected)
+		}
+	}
+}
+
+func TestCLIExplain(t *testing.T) {
+	cliPath := buildTestCLI(t)
+
+	cmd := exec.Command(cliPath, "-explain", "-units")
+	cmd.Stdin = strings.NewReader("The license holder walked 3 miles past the color center.")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+	}
+	for _, want := range []string{
+		"license → licence (contextual: compound_noun pattern for license)",
+		"color → colour (dictionary)",
+		"center → centre (dictionary)",
+		"3 miles → 4.8 km (unit: miles→km)",
+	} {
+		if !strings.Contains(string(output), want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+
+	cmd = exec.Command(cliPath, "-explain")
+	cmd.Stdin = strings.NewReader("Nothing to change.")
+	if output, err := cmd.CombinedOutput(); err != nil || strings.TrimSpace(string(output)) != "No changes" {
+		t.Errorf("Expected \"No changes\", got %q (%v)", output, err)
+	}
+
+	output, err = exec.Command(cliPath, "-explain", "-raw", "The color.").CombinedOutput()
+	if err == nil || !strings.Contains(string(output), "Only one output mode") {
+		t.Errorf("Expected -explain to be an output mode, got %v: %q", err, output)
+	}
+}
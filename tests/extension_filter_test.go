@@ -0,0 +1,115 @@
+package tests
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/fileutil"
+)
+
+func TestParseExtensions(t *testing.T) {
+	got := fileutil.ParseExtensions(" .MD, txt,,.go,.md ,.")
+	expected := []string{".md", ".txt", ".go"}
+	if !slices.Equal(got, expected) {
+		t.Errorf("ParseExtensions() = %v, expected %v", got, expected)
+	}
+}
+
+func TestExtensionFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   fileutil.ExtensionFilter
+		path     string
+		expected bool
+	}{
+		{"Empty filter", fileutil.ExtensionFilter{}, "main.go", true},
+		{"Included", fileutil.ExtensionFilter{Include: []string{".md", ".go"}}, "docs/README.MD", true},
+		{"Not included", fileutil.ExtensionFilter{Include: []string{".md"}}, "main.go", false},
+		{"Excluded", fileutil.ExtensionFilter{Exclude: []string{".txt"}}, "notes.txt", false},
+		{"Exclude wins", fileutil.ExtensionFilter{Include: []string{".ts"}, Exclude: []string{".d.ts"}}, "types.d.ts", false},
+		{"Multi-part extension", fileutil.ExtensionFilter{Include: []string{".d.ts"}}, "types.d.ts", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Matches(tt.path); got != tt.expected {
+				t.Errorf("Matches(%q) = %v, expected %v", tt.path, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFilterFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.md", "b.txt", "c.go", ".hidden.md"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("color\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files, err := fileutil.FindTextFiles(dir)
+	if err != nil {
+		t.Fatalf("FindTextFiles failed: %v", err)
+	}
+	filtered := fileutil.FilterFiles(files, fileutil.ExtensionFilter{Include: []string{".md", ".txt"}, Exclude: []string{".txt"}})
+
+	var names []string
+	for _, file := range filtered {
+		names = append(names, file.RelativePath)
+	}
+	// Hidden files are still skipped by FindTextFiles
+	if !slices.Equal(names, []string{"a.md"}) {
+		t.Errorf("Expected only a.md, got %v", names)
+	}
+}
+
+func TestExtensionFilterCLI(t *testing.T) {
+	cliPath := buildTestCLI(t)
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.md":         "The color.\n",
+		"docs/b.txt":   "The color.\n",
+		"c.go":         "// The color\npackage main\n",
+		"notes.txt":    "The color.\n",
+		"single.rst":   "The color.\n",
+		"vendor/d.md":  "The color.\n",
+		"scripts/e.sh": "# The color\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	out, err := exec.Command(cliPath, "-save", "-ext", ".md,.txt", "-ext=go", "-ext-exclude=.txt", dir).CombinedOutput()
+	if err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, out)
+	}
+
+	converted := map[string]bool{"a.md": true, "c.go": true}
+	for name, original := range files {
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if changed := string(content) != original; changed != converted[name] {
+			t.Errorf("%s: expected converted=%v, got content %q\nOutput: %s", name, converted[name], content, out)
+		}
+	}
+
+	// A file named directly isn't filtered
+	out, err = exec.Command(cliPath, "-save", "-ext", ".md", filepath.Join(dir, "single.rst")).CombinedOutput()
+	if err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, out)
+	}
+	if content, _ := os.ReadFile(filepath.Join(dir, "single.rst")); string(content) != "The colour.\n" {
+		t.Errorf("Expected a file given directly to be converted, got %q", content)
+	}
+}
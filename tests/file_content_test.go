@@ -0,0 +1,246 @@
+package tests
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+func TestConvertFileContent(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	goCode := "// The color is set here\nvar color = \"gray\"\n"
+
+	tests := []struct {
+		name     string
+		filePath string
+		input    string
+		expected string
+	}{
+		{
+			name:     "Go file converts only comments",
+			filePath: "main.go",
+			input:    goCode,
+			expected: "// The colour is set here\nvar color = \"gray\"\n",
+		},
+		{
+			name:     "Markdown file converts prose",
+			filePath: "README.md",
+			input:    "The color is gray.",
+			expected: "The colour is grey.",
+		},
+		{
+			name:     "Extension matching is case insensitive",
+			filePath: "NOTES.TXT",
+			input:    "The color is gray.",
+			expected: "The colour is grey.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := conv.ConvertFileContent(tt.input, tt.filePath, true)
+			if result != tt.expected {
+				t.Errorf("ConvertFileContent(%q) = %q, expected %q", tt.filePath, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestConvertFileContent_ConfigComments(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		filePath string
+		input    string
+		expected string
+	}{
+		{
+			name:     "INI semicolon and hash comments",
+			filePath: "settings.ini",
+			input:    "; color setting\n[theme]\ncolor = gray ; the default color\n# center the window\nurl = http://example.com/#color\n",
+			expected: "; colour setting\n[theme]\ncolor = gray ; the default colour\n# centre the window\nurl = http://example.com/#color\n",
+		},
+		{
+			name:     "TOML string values are not converted",
+			filePath: "config.toml",
+			input:    "# color palette\ncolor = \"gray\" # favorite color\nhex = \"#color\"\nnote = 'labor; # not a comment'\n",
+			expected: "# colour palette\ncolor = \"gray\" # favourite colour\nhex = \"#color\"\nnote = 'labor; # not a comment'\n",
+		},
+		{
+			name:     "TOML multi-line strings are skipped",
+			filePath: "config.toml",
+			input:    "text = \"\"\"\nThe color # gray\n\"\"\" # color note\n",
+			expected: "text = \"\"\"\nThe color # gray\n\"\"\" # colour note\n",
+		},
+		{
+			name:     "Semicolons are not comments in TOML",
+			filePath: "config.toml",
+			input:    "key = 1 ; color\n",
+			expected: "key = 1 ; color\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := conv.ConvertFileContent(tt.input, tt.filePath, true)
+			if result != tt.expected {
+				t.Errorf("ConvertFileContent(%q) = %q, expected %q", tt.filePath, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsConfigFile(t *testing.T) {
+	tests := map[string]bool{
+		"settings.ini": true,
+		"Cargo.toml":   true,
+		"setup.cfg":    true,
+		"config.json":  false,
+		"README.md":    false,
+	}
+
+	for path, expected := range tests {
+		if got := converter.IsConfigFile(path); got != expected {
+			t.Errorf("IsConfigFile(%q) = %v, expected %v", path, got, expected)
+		}
+	}
+}
+
+func TestIsPlainTextFile(t *testing.T) {
+	tests := map[string]bool{
+		"doc.md":     true,
+		"notes.txt":  true,
+		"paper.tex":  true,
+		"main.go":    false,
+		"config.yml": false,
+		"Makefile":   false,
+	}
+
+	for path, expected := range tests {
+		if got := converter.IsPlainTextFile(path); got != expected {
+			t.Errorf("IsPlainTextFile(%q) = %v, expected %v", path, got, expected)
+		}
+	}
+}
+
+func TestCLIStdinFilename(t *testing.T) {
+	cliPath := buildTestCLI(t)
+
+	goCode := "// The color is set here\nvar color = \"gray\"\n"
+
+	tests := []struct {
+		name        string
+		args        []string
+		contains    []string
+		notContains []string
+	}{
+		{
+			name:        "Go filename converts only comments",
+			args:        []string{"-raw", "-stdin-filename", "main.go"},
+			contains:    []string{"// The colour is set here", `var color = "gray"`},
+			notContains: []string{`"grey"`},
+		},
+		{
+			name:     "Without the flag stdin is treated as plain text",
+			args:     []string{"-raw"},
+			contains: []string{"// The colour is set here", `var colour = "grey"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := exec.Command(cliPath, tt.args...)
+			cmd.Stdin = strings.NewReader(goCode)
+			output, err := cmd.CombinedOutput()
+			if err != nil {
+				t.Fatalf("Unexpected error: %v\nOutput: %s", err, output)
+			}
+
+			outputStr := string(output)
+			for _, want := range tt.contains {
+				if !strings.Contains(outputStr, want) {
+					t.Errorf("Expected output to contain %q, got %q", want, outputStr)
+				}
+			}
+			for _, unwanted := range tt.notContains {
+				if strings.Contains(outputStr, unwanted) {
+					t.Errorf("Expected output not to contain %q, got %q", unwanted, outputStr)
+				}
+			}
+		})
+	}
+}
+
+func TestCLIFileRouting(t *testing.T) {
+	cliPath := buildTestCLI(t)
+	dir := t.TempDir()
+
+	tests := []struct {
+		name     string
+		file     string
+		content  string
+		expected string
+		warning  string
+	}{
+		{
+			name:     "Code file converts only comments",
+			file:     "main.go",
+			content:  "// The color is set here\nvar color = \"gray\"\n",
+			expected: "// The colour is set here\nvar color = \"gray\"\n",
+		},
+		{
+			name:     "Unknown extension converts only comments",
+			file:     "script.xyz",
+			content:  "# The color\ncolor = gray\n",
+			expected: "# The colour\ncolor = gray\n",
+		},
+		{
+			name:     "Markdown keeps fenced code",
+			file:     "doc.md",
+			content:  "The color.\n\n````\n```\nvar color = \"gray\"\n````\n",
+			expected: "The colour.\n\n````\n```\nvar color = \"gray\"\n````\n",
+		},
+		{
+			name:     "Invalid notebook is left unchanged with a warning",
+			file:     "broken.ipynb",
+			content:  `{"cells": [{"source": "The color"`,
+			expected: `{"cells": [{"source": "The color"`,
+			warning:  "broken.ipynb: left unchanged",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, tt.file)
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			cmd := exec.Command(cliPath, "-raw", path)
+			cmd.Env = append(os.Environ(), "HOME="+t.TempDir())
+			var stdout, stderr bytes.Buffer
+			cmd.Stdout, cmd.Stderr = &stdout, &stderr
+			if err := cmd.Run(); err != nil {
+				t.Fatalf("CLI failed: %v\nOutput: %s%s", err, stdout.String(), stderr.String())
+			}
+			if stdout.String() != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, stdout.String())
+			}
+			if tt.warning != "" && !strings.Contains(stderr.String(), tt.warning) {
+				t.Errorf("Expected a warning containing %q, got %q", tt.warning, stderr.String())
+			}
+		})
+	}
+}
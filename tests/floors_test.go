@@ -0,0 +1,109 @@
+package tests
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+func TestFloorNumbering(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+	if conv.IsFloorNumberingEnabled() {
+		t.Fatal("Expected floor numbering to be off by default")
+	}
+	if result := conv.ConvertToBritish("The office is on the second floor.", false); result != "The office is on the second floor." {
+		t.Errorf("Expected floors to be left alone by default, got %q", result)
+	}
+
+	conv.SetFloorNumberingEnabled(true)
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"First floor", "Reception is on the first floor.", "Reception is on the ground floor."},
+		{"Second floor", "The office is on the second floor.", "The office is on the first floor."},
+		{"Capitalised", "Second Floor, 12 Main Street", "First Floor, 12 Main Street"},
+		{"Floor as a name", "1st Floor lobby", "Ground Floor lobby"},
+		{"All caps", "FIRST FLOOR", "GROUND FLOOR"},
+		{"Numeric", "Take the lift to the 3rd floor.", "Take the lift to the 2nd floor."},
+		{"Numeric teens", "the 12th floor and the 22nd floor", "the 11th floor and the 21st floor"},
+		{"Hyphenated", "a second-floor flat", "a first-floor flat"},
+		{"List", "The second and third floors are closed.", "The first and second floors are closed."},
+		{"Comma list", "on the 2nd, 3rd or 4th floor", "on the 1st, 2nd or 3rd floor"},
+		{"Compound ordinal", "the twenty-first floor", "the twenty-first floor"},
+		{"Ground floor", "The ground floor is open.", "The ground floor is open."},
+		{"Not a floor", "The first step is the second floorplan.", "The first step is the second floorplan."},
+		{"Inline code", "Set `floor = \"second floor\"` first.", "Set `floor = \"second floor\"` first."},
+		{"Spelling still converted", "The color of the first floor.", "The colour of the ground floor."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := conv.ConvertToBritish(tt.input, false); result != tt.expected {
+				t.Errorf("ConvertToBritish(%q) = %q, expected %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+
+	// Code only has its comments converted
+	code := "// Meet on the second floor\nfloor := \"second floor\"\n"
+	expected := "// Meet on the first floor\nfloor := \"second floor\"\n"
+	if result := conv.ConvertFileContent(code, "main.go", false); result != expected {
+		t.Errorf("Expected only the comment to be renumbered, got %q", result)
+	}
+}
+
+func TestFloorNumberingCLI(t *testing.T) {
+	cliPath := buildTestCLI(t)
+
+	cmd := exec.Command(cliPath, "-raw", "-floors")
+	cmd.Stdin = strings.NewReader("The lab is on the first floor, above the gray lobby.")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+	}
+	if expected := "The lab is on the ground floor, above the grey lobby."; strings.TrimSpace(string(output)) != expected {
+		t.Errorf("Expected %q, got %q", expected, output)
+	}
+}
+
+func TestFloorNumberingNotIdempotent(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+	conv.SetFloorNumberingEnabled(true)
+
+	// The documented exception to idempotency: each pass renumbers the floors again
+	once := conv.ConvertToBritish("The office is on the third floor.", false)
+	if twice := conv.ConvertToBritish(once, false); twice != "The office is on the first floor." {
+		t.Errorf("Expected a second pass to renumber the floors again, got %q", twice)
+	}
+}
+
+func TestFloorNumberingRefusedWithWatchSave(t *testing.T) {
+	cliPath := buildTestCLI(t)
+	path := filepath.Join(t.TempDir(), "notes.md")
+	if err := os.WriteFile(path, []byte("The second floor.\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := exec.Command(cliPath, "-watch", "-save", "-floors", path).CombinedOutput()
+	if exitErr, ok := err.(*exec.ExitError); !ok || exitErr.ExitCode() != 2 {
+		t.Fatalf("Expected a usage error, got %v\nOutput: %s", err, output)
+	}
+	if !strings.Contains(string(output), "-floors") {
+		t.Errorf("Expected the error to name -floors, got %q", output)
+	}
+	if content, _ := os.ReadFile(path); string(content) != "The second floor.\n" {
+		t.Errorf("Expected the file to be left untouched, got %q", content)
+	}
+}
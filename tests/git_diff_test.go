@@ -0,0 +1,96 @@
+package tests
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCLIGitDiffPatch(t *testing.T) {
+	cliPath := buildTestCLI(t)
+
+	dir := t.TempDir()
+	content := "The color was set.\nThe new color is gray.\nThe center holds.\n"
+	if err := os.WriteFile(filepath.Join(dir, "notes.md"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	patch := "diff --git a/notes.md b/notes.md\n--- a/notes.md\n+++ b/notes.md\n@@ -1,0 +2 @@\n+The new color is gray.\n"
+	if err := os.WriteFile(filepath.Join(dir, "change.patch"), []byte(patch), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	run := func(args ...string) (string, error) {
+		cmd := exec.Command(cliPath, args...)
+		cmd.Dir = dir
+		output, err := cmd.CombinedOutput()
+		return string(output), err
+	}
+
+	output, err := run("-git-diff", "-patch", "change.patch", "-raw-changes")
+	if err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+	}
+	if output != "notes.md:2:The new colour is grey.\n" {
+		t.Errorf("Expected only the added line to be converted, got %q", output)
+	}
+
+	if _, err := run("-git-diff", "-patch=change.patch", "-exit-on-change"); err == nil {
+		t.Error("Expected -exit-on-change to fail when added lines need conversion")
+	}
+
+	if output, err := run("-git-diff", "-patch", "change.patch", "-save"); err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+	}
+	saved, err := os.ReadFile(filepath.Join(dir, "notes.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "The color was set.\nThe new colour is grey.\nThe center holds.\n"; string(saved) != expected {
+		t.Errorf("Expected pre-existing lines to be left alone, got %q", saved)
+	}
+
+	if _, err := run("-patch", "change.patch"); err == nil {
+		t.Error("Expected -patch without -git-diff to fail")
+	}
+}
+
+func TestCLIGitDiff(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	cliPath := buildTestCLI(t)
+
+	repo := t.TempDir()
+	git := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, output)
+		}
+	}
+	write := func(content string) {
+		if err := os.WriteFile(filepath.Join(repo, "README.md"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	git("init", "-q")
+	git("config", "user.email", "test@example.com")
+	git("config", "user.name", "Test")
+	write("An old color.\n")
+	git("add", ".")
+	git("commit", "-q", "-m", "initial")
+	write("An old color.\nA new flavor.\n")
+
+	cmd := exec.Command(cliPath, "-git-diff", "-diff")
+	cmd.Dir = repo
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+	}
+	if !strings.Contains(string(output), "+A new flavour.") || strings.Contains(string(output), "colour") {
+		t.Errorf("Expected a diff of the added line only, got %q", output)
+	}
+}
@@ -0,0 +1,135 @@
+package tests
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+// TestIdempotency converts a corpus covering every stage of the pipeline, then converts the
+// result again, and checks the second pass changes nothing under each combination of options.
+// Converting already British text must be a no-op, so running m2e over a file twice, or over a
+// file someone has partly converted by hand, is always safe.
+func TestIdempotency(t *testing.T) {
+	data, err := os.ReadFile("testdata/idempotency_corpus.md")
+	if err != nil {
+		t.Fatalf("Failed to read corpus: %v", err)
+	}
+	corpus := string(data)
+
+	// Floor numbering is left out: it's the documented exception, as "first floor" reads the
+	// same before and after it's renumbered (see TestFloorNumberingNotIdempotent)
+	configs := []struct {
+		name  string
+		setup func(*converter.Converter)
+	}{
+		{"Default", func(*converter.Converter) {}},
+		{"Units", func(c *converter.Converter) {
+			c.SetUnitProcessingEnabled(true)
+		}},
+		{"Units normalised", func(c *converter.Converter) {
+			c.SetUnitProcessingEnabled(true)
+			c.SetUnitNormalisationEnabled(true)
+		}},
+		{"Units keeping originals", func(c *converter.Converter) {
+			c.SetUnitProcessingEnabled(true)
+			c.SetUnitKeepOriginal(true)
+		}},
+		{"Phrases", func(c *converter.Converter) {
+			c.SetPhraseProcessingEnabled(true)
+		}},
+		{"Oxford spelling", func(c *converter.Converter) {
+			variant, err := converter.ParseSpellingVariant("ize")
+			if err != nil {
+				t.Fatal(err)
+			}
+			c.SetSpellingVariant(variant)
+		}},
+	}
+
+	modes := []struct {
+		name    string
+		convert func(c *converter.Converter, text string, normaliseSmartQuotes bool) string
+	}{
+		{"Markdown file", func(c *converter.Converter, text string, nsq bool) string {
+			return c.ConvertFileContent(text, "corpus.md", nsq)
+		}},
+		{"Text file", func(c *converter.Converter, text string, nsq bool) string {
+			return c.ConvertFileContent(text, "corpus.txt", nsq)
+		}},
+		{"Text", func(c *converter.Converter, text string, nsq bool) string {
+			return c.ConvertToBritish(text, nsq)
+		}},
+	}
+
+	for _, config := range configs {
+		for _, mode := range modes {
+			for _, nsq := range []bool{true, false} {
+				name := config.name + "/" + mode.name
+				if !nsq {
+					name += "/Keeping smart quotes"
+				}
+				t.Run(name, func(t *testing.T) {
+					conv, err := converter.NewConverter()
+					if err != nil {
+						t.Fatalf("Failed to create converter: %v", err)
+					}
+					config.setup(conv)
+
+					once := mode.convert(conv, corpus, nsq)
+					if once == corpus {
+						t.Fatal("Expected the corpus to be converted")
+					}
+					twice := mode.convert(conv, once, nsq)
+					if twice == once {
+						return
+					}
+					onceLines, twiceLines := strings.Split(once, "\n"), strings.Split(twice, "\n")
+					for i := range min(len(onceLines), len(twiceLines)) {
+						if onceLines[i] != twiceLines[i] {
+							t.Errorf("Line %d changed on the second pass:\n  first:  %s\n  second: %s", i+1, onceLines[i], twiceLines[i])
+						}
+					}
+					if len(onceLines) != len(twiceLines) {
+						t.Errorf("Second pass changed the line count from %d to %d", len(onceLines), len(twiceLines))
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestContextualPatternsStopAtConvertedWord(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	// The modal verb governs "practise", not the noun after it, whether or not "practise" has
+	// already been converted
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{
+			"You must practice every day because practice makes perfect.",
+			"You must practise every day because practice makes perfect.",
+		},
+		{
+			"You must practise every day because practice makes perfect.",
+			"You must practise every day because practice makes perfect.",
+		},
+		{
+			"You should really practice more.",
+			"You should really practise more.",
+		},
+	}
+
+	for _, tt := range tests {
+		if result := conv.ConvertToBritish(tt.input, false); result != tt.expected {
+			t.Errorf("ConvertToBritish(%q) = %q, expected %q", tt.input, result, tt.expected)
+		}
+	}
+}
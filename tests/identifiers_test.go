@@ -0,0 +1,69 @@
+package tests
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+func TestIdentifierPreservation(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	tests := []struct {
+		name               string
+		input              string
+		expected           string
+		convertIdentifiers bool
+	}{
+		{"camelCase kept", "Call initializeColor() to set it.", "Call initializeColor() to set it.", false},
+		{"snake_case kept", "Set color_value first.", "Set color_value first.", false},
+		{"PascalCase kept", "We use ColorPicker here.", "We use ColorPicker here.", false},
+		{"Constant kept", "Read COLOR_VALUE from the env.", "Read COLOR_VALUE from the env.", false},
+		{"Plain word converted", "Pick a color.", "Pick a colour.", false},
+		{"Capitalised word converted", "Color matters.", "Colour matters.", false},
+		{"camelCase converted", "Call initializeColor() to set it.", "Call initialiseColour() to set it.", true},
+		{"snake_case converted", "Set color_value first.", "Set colour_value first.", true},
+		{"PascalCase converted", "We use ColorPicker here.", "We use ColourPicker here.", true},
+		{"Constant converted", "Read COLOR_VALUE from the env.", "Read COLOUR_VALUE from the env.", true},
+		{"Plain word still converted", "Pick a color.", "Pick a colour.", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conv.SetIdentifierConversionEnabled(tt.convertIdentifiers)
+			if result := conv.ConvertToBritish(tt.input, false); result != tt.expected {
+				t.Errorf("ConvertToBritish(%q) = %q, expected %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPreserveIdentifiersCLI(t *testing.T) {
+	cliPath := buildTestCLI(t)
+	input := "Call initializeColor() with color_value to set the color."
+
+	cmd := exec.Command(cliPath, "-raw")
+	cmd.Stdin = strings.NewReader(input)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+	}
+	if strings.TrimSpace(string(output)) != "Call initializeColor() with color_value to set the colour." {
+		t.Errorf("Expected identifiers to be kept by default, got %q", output)
+	}
+
+	cmd = exec.Command(cliPath, "-raw", "-preserve-identifiers=false")
+	cmd.Stdin = strings.NewReader(input)
+	output, err = cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+	}
+	if strings.TrimSpace(string(output)) != "Call initialiseColour() with colour_value to set the colour." {
+		t.Errorf("Expected identifiers to convert with -preserve-identifiers=false, got %q", output)
+	}
+}
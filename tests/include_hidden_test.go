@@ -0,0 +1,129 @@
+package tests
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/fileutil"
+)
+
+// writeHiddenTree writes a directory with hidden directories and files, each containing
+// "The color.\n", and returns its path
+func writeHiddenTree(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	for _, name := range []string{
+		"README.md",
+		".env.md",
+		".github/CONTRIBUTING.md",
+		".github/ISSUE_TEMPLATE/bug.md",
+		".git/COMMIT_EDITMSG",
+		".git/description",
+		"sub/.git",
+		"sub/notes.md",
+		"node_modules/pkg/README.md",
+	} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("The color.\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+// relativeNames returns the slash-separated relative paths of files, sorted
+func relativeNames(files []fileutil.FileInfo) []string {
+	var names []string
+	for _, file := range files {
+		names = append(names, filepath.ToSlash(file.RelativePath))
+	}
+	slices.Sort(names)
+	return names
+}
+
+func TestFindTextFilesIncludeHidden(t *testing.T) {
+	dir := writeHiddenTree(t)
+
+	files, err := fileutil.FindTextFiles(dir)
+	if err != nil {
+		t.Fatalf("FindTextFiles failed: %v", err)
+	}
+	if names := relativeNames(files); !slices.Equal(names, []string{"README.md", "sub/notes.md"}) {
+		t.Errorf("Expected hidden files to be skipped by default, got %v", names)
+	}
+
+	files, err = fileutil.FindTextFilesWithOptions(dir, fileutil.FindOptions{IncludeHidden: true})
+	if err != nil {
+		t.Fatalf("FindTextFilesWithOptions failed: %v", err)
+	}
+	expected := []string{".env.md", ".github/CONTRIBUTING.md", ".github/ISSUE_TEMPLATE/bug.md", "README.md", "sub/notes.md"}
+	if names := relativeNames(files); !slices.Equal(names, expected) {
+		t.Errorf("Expected hidden files but not .git or ignored directories, got %v", names)
+	}
+
+	files, err = fileutil.FindFilesWithOptions(dir, fileutil.FindOptions{IncludeHidden: true})
+	if err != nil {
+		t.Fatalf("FindFilesWithOptions failed: %v", err)
+	}
+	if names := relativeNames(files); !slices.Equal(names, expected) {
+		t.Errorf("Expected FindFilesWithOptions to skip .git too, got %v", names)
+	}
+}
+
+func TestFindTextFilesHiddenRoot(t *testing.T) {
+	dir := writeHiddenTree(t)
+
+	// A hidden directory named directly is searched, but .git never is
+	files, err := fileutil.FindTextFiles(filepath.Join(dir, ".github"))
+	if err != nil {
+		t.Fatalf("FindTextFiles failed: %v", err)
+	}
+	if names := relativeNames(files); !slices.Equal(names, []string{"CONTRIBUTING.md", "ISSUE_TEMPLATE/bug.md"}) {
+		t.Errorf("Expected the files in a hidden root, got %v", names)
+	}
+
+	files, err = fileutil.FindTextFilesWithOptions(filepath.Join(dir, ".git"), fileutil.FindOptions{IncludeHidden: true})
+	if err != nil {
+		t.Fatalf("FindTextFilesWithOptions failed: %v", err)
+	}
+	if len(files) != 0 {
+		t.Errorf("Expected .git to be skipped even when named directly, got %v", relativeNames(files))
+	}
+}
+
+func TestIncludeHiddenCLI(t *testing.T) {
+	cliPath := buildTestCLI(t)
+	dir := writeHiddenTree(t)
+
+	out, err := exec.Command(cliPath, "-save", dir).CombinedOutput()
+	if err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, out)
+	}
+	if content, _ := os.ReadFile(filepath.Join(dir, ".github", "CONTRIBUTING.md")); string(content) != "The color.\n" {
+		t.Errorf("Expected hidden directories to be skipped by default, got %q", content)
+	}
+
+	out, err = exec.Command(cliPath, "-save", "-include-hidden", dir).CombinedOutput()
+	if err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, out)
+	}
+	converted := map[string]bool{
+		"README.md": true, ".env.md": true, ".github/CONTRIBUTING.md": true,
+		".github/ISSUE_TEMPLATE/bug.md": true, "sub/notes.md": true,
+	}
+	for _, name := range []string{".github/CONTRIBUTING.md", ".github/ISSUE_TEMPLATE/bug.md", ".env.md", ".git/COMMIT_EDITMSG", ".git/description", "sub/.git", "node_modules/pkg/README.md"} {
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if changed := string(content) != "The color.\n"; changed != converted[name] {
+			t.Errorf("%s: expected converted=%v, got %q\nOutput: %s", name, converted[name], content, out)
+		}
+	}
+}
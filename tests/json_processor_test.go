@@ -0,0 +1,146 @@
+package tests
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+func TestConvertJSONValues(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "Values converted, keys untouched",
+			input:    `{"color": "Pick a color", "count": 3, "enabled": true, "optional": null}`,
+			expected: `{"color": "Pick a colour", "count": 3, "enabled": true, "optional": null}`,
+		},
+		{
+			name:     "Nested objects and arrays keep formatting",
+			input:    "{\n  \"menu\": {\n    \"items\": [\"Favorite\", \"Gray theme\"]\n  }\n}\n",
+			expected: "{\n  \"menu\": {\n    \"items\": [\"Favourite\", \"Grey theme\"]\n  }\n}\n",
+		},
+		{
+			name:     "Escapes round-trip",
+			input:    `{"msg": "Café \"color\"\nsettings\/center"}`,
+			expected: `{"msg": "Café \"colour\"\nsettings\/center"}`,
+		},
+		{
+			name:     "Unicode escapes round-trip",
+			input:    `{"msg": "caf\u00e9 color \ud83c\udfa8"}`,
+			expected: `{"msg": "caf\u00e9 colour \ud83c\udfa8"}`,
+		},
+		{
+			name:     "Unchanged strings keep their escaping",
+			input:    `{"msg": "Café au lait"}`,
+			expected: `{"msg": "Café au lait"}`,
+		},
+		{
+			name:     "Top-level array",
+			input:    `["color", "flavor"]`,
+			expected: `["colour", "flavour"]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := conv.ConvertJSONValues(tt.input, true)
+			if err != nil {
+				t.Fatalf("ConvertJSONValues failed: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("ConvertJSONValues(%q) = %q, expected %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+
+	t.Run("Invalid JSON is returned unchanged", func(t *testing.T) {
+		input := `{"color": "gray"`
+		result, err := conv.ConvertJSONValues(input, true)
+		if err == nil {
+			t.Errorf("Expected an error for invalid JSON")
+		}
+		if result != input {
+			t.Errorf("Expected invalid JSON to be unchanged, got %q", result)
+		}
+	})
+
+	t.Run("Key pattern limits converted values", func(t *testing.T) {
+		if err := conv.SetJSONKeyPattern(`^(label|hints)$`); err != nil {
+			t.Fatalf("SetJSONKeyPattern failed: %v", err)
+		}
+		defer func() { _ = conv.SetJSONKeyPattern("") }()
+
+		input := `{"label": "Color", "id": "color", "hints": ["gray", "center"]}`
+		expected := `{"label": "Colour", "id": "color", "hints": ["grey", "centre"]}`
+		result, err := conv.ConvertJSONValues(input, true)
+		if err != nil {
+			t.Fatalf("ConvertJSONValues failed: %v", err)
+		}
+		if result != expected {
+			t.Errorf("ConvertJSONValues(%q) = %q, expected %q", input, result, expected)
+		}
+
+		if err := conv.SetJSONKeyPattern("("); err == nil {
+			t.Errorf("Expected an error for an invalid key pattern")
+		}
+	})
+
+	t.Run("ConvertFileContent routes JSON files when enabled", func(t *testing.T) {
+		input := `{"color": "color"}`
+		if result := conv.ConvertFileContent(input, "en.json", true); result != input {
+			t.Errorf("Expected JSON to be left alone by default, got %q", result)
+		}
+
+		conv.SetJSONValuesOnly(true)
+		defer conv.SetJSONValuesOnly(false)
+		if result := conv.ConvertFileContent(input, "en.json", true); result != `{"color": "colour"}` {
+			t.Errorf("Expected only the value to be converted, got %q", result)
+		}
+	})
+}
+
+func TestCLIFormatJSON(t *testing.T) {
+	cliPath := buildTestCLI(t)
+
+	dir := t.TempDir()
+	jsonPath := filepath.Join(dir, "en.json")
+	if err := os.WriteFile(jsonPath, []byte(`{"color": "Favorite color", "size": 12}`), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	output, err := exec.Command(cliPath, "-format=json", "-save", jsonPath).CombinedOutput()
+	if err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+	}
+	content, _ := os.ReadFile(jsonPath)
+	if string(content) != `{"color": "Favourite colour", "size": 12}` {
+		t.Errorf("Unexpected converted JSON: %q", content)
+	}
+
+	cmd := exec.Command(cliPath, "-format", "json", "-json-keys", "^title$", "-raw")
+	cmd.Stdin = strings.NewReader(`{"title": "Color", "color": "gray"}`)
+	output, err = cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+	}
+	if !strings.Contains(string(output), `{"title": "Colour", "color": "gray"}`) {
+		t.Errorf("Expected only the title value to be converted, got %q", output)
+	}
+
+	output, err = exec.Command(cliPath, "-format=yaml", jsonPath).CombinedOutput()
+	if err == nil || !strings.Contains(string(output), "unsupported format") {
+		t.Errorf("Expected an unsupported format error, got %v: %q", err, output)
+	}
+}
@@ -0,0 +1,93 @@
+package tests
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+func TestDetectLineEnding(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected converter.LineEnding
+	}{
+		{"one line", converter.LineEndingNone},
+		{"a\nb\n", converter.LineEndingLF},
+		{"a\r\nb\r\n", converter.LineEndingCRLF},
+		{"a\rb\r", converter.LineEndingCR},
+		{"a\r\nb\n", converter.LineEndingMixed},
+	}
+
+	for _, tt := range tests {
+		if result := converter.DetectLineEnding(tt.input); result != tt.expected {
+			t.Errorf("DetectLineEnding(%q) = %v, expected %v", tt.input, result, tt.expected)
+		}
+	}
+
+	if result := converter.PreserveLineEndings("a\r\nb\r\n", "c\nd\r\n"); result != "c\r\nd\r\n" {
+		t.Errorf("Expected CRLF to be restored, got %q", result)
+	}
+	if result := converter.PreserveLineEndings("a\r\nb\n", "c\nd\n"); result != "c\nd\n" {
+		t.Errorf("Expected mixed line endings to be left alone, got %q", result)
+	}
+}
+
+func TestLineEndingsPreserved(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		filePath string
+		input    string
+		expected string
+	}{
+		{"Markdown CRLF", "notes.md", "# The color\r\n\r\nThe gray  \r\nUnchanged \t\r\n", "# The colour\r\n\r\nThe grey  \r\nUnchanged \t\r\n"},
+		{"Text CR", "notes.txt", "The color.\rThe gray  \rEnd\r", "The colour.\rThe grey  \rEnd\r"},
+		{"Go line comments CRLF", "main.go", "// The color  \r\nx := 1 // gray\r\n", "// The colour  \r\nx := 1 // grey\r\n"},
+		{"Go comment mentioning an issue", "main.go", "x := 1 // see #1 color\r\ny := 2\r\n", "x := 1 // see #1 colour\r\ny := 2\r\n"},
+		{"Go block comment CRLF", "main.go", "/* The color\r\n * gray\r\n */  \r\nx := 1\r\n", "/* The colour\r\n * grey\r\n */  \r\nx := 1\r\n"},
+		{"Python trailing whitespace", "main.py", "x = 1  # gray  \r\ny = 2\t\r\n", "x = 1  # grey  \r\ny = 2\t\r\n"},
+		{"YAML comments CRLF", "config.yaml", "# The color  \r\nkey: gray # gray\r\n", "# The colour  \r\nkey: gray # grey\r\n"},
+		{"TOML comments CRLF", "config.toml", "# The color  \r\nkey = 1\r\n", "# The colour  \r\nkey = 1\r\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := conv.ConvertFileContent(tt.input, tt.filePath, true); result != tt.expected {
+				t.Errorf("ConvertFileContent(%q) = %q, expected %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCLILineEndingsPreserved(t *testing.T) {
+	cliPath := buildTestCLI(t)
+
+	dir := t.TempDir()
+	files := map[string][2]string{
+		"notes.md": {"The color.  \r\nThe gray\r\n\r\nEnd\r\n", "The colour.  \r\nThe grey\r\n\r\nEnd\r\n"},
+		"main.go":  {"// The color  \r\nx := 1 // gray\r\n", "// The colour  \r\nx := 1 // grey\r\n"},
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content[0]), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if output, err := exec.Command(cliPath, "-save", path).CombinedOutput(); err != nil {
+			t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+		}
+		saved, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(saved) != content[1] {
+			t.Errorf("Expected %s to keep its CRLF line endings, got %q", name, saved)
+		}
+	}
+}
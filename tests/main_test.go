@@ -0,0 +1,45 @@
+package tests
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// testCLI is the CLI built once for the tests that run it, in a directory removed by TestMain
+var testCLI struct {
+	once sync.Once
+	dir  string
+	path string
+	err  error
+}
+
+func TestMain(m *testing.M) {
+	code := m.Run()
+	if testCLI.dir != "" {
+		_ = os.RemoveAll(testCLI.dir)
+	}
+	os.Exit(code)
+}
+
+// buildTestCLI returns the path to the CLI, building it into a temporary directory the first
+// time it's called so every test shares one build
+func buildTestCLI(t *testing.T) string {
+	t.Helper()
+	testCLI.once.Do(func() {
+		if testCLI.dir, testCLI.err = os.MkdirTemp("", "m2e-test"); testCLI.err != nil {
+			return
+		}
+		testCLI.path = filepath.Join(testCLI.dir, "m2e-test")
+		if output, err := exec.Command("go", "build", "-o", testCLI.path, "../cmd/m2e").CombinedOutput(); err != nil {
+			testCLI.err = fmt.Errorf("%w\n%s", err, output)
+		}
+	})
+	if testCLI.err != nil {
+		t.Fatalf("Failed to build CLI: %v", testCLI.err)
+	}
+	return testCLI.path
+}
@@ -0,0 +1,173 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+func TestMarkdownNestedFencedCodeBlocks(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	// A Markdown example inside a Markdown doc: the inner ``` fences are part of the outer
+	// ```` block, so the color after them is still code
+	input := "# Writing docs\n\n" +
+		"Pick a color for the center panel.\n\n" +
+		"````markdown\n" +
+		"Some text.\n\n" +
+		"```go\n" +
+		"var color = \"gray\"\n" +
+		"```\n\n" +
+		"var center = color\n" +
+		"````\n\n" +
+		"The color is used in the center.\n"
+	expected := "# Writing docs\n\n" +
+		"Pick a colour for the centre panel.\n\n" +
+		"````markdown\n" +
+		"Some text.\n\n" +
+		"```go\n" +
+		"var color = \"gray\"\n" +
+		"```\n\n" +
+		"var center = color\n" +
+		"````\n\n" +
+		"The colour is used in the centre.\n"
+
+	if result := conv.ProcessCodeAware(input, true); result != expected {
+		t.Errorf("ProcessCodeAware() =\n%s\nexpected\n%s", result, expected)
+	}
+	if result := conv.ConvertFileContent(input, "guide.md", true); result != expected {
+		t.Errorf("ConvertFileContent() =\n%s\nexpected\n%s", result, expected)
+	}
+}
+
+func TestMarkdownFencedCodeBlocks(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "Tilde fence containing backtick fences",
+			input:    "The color.\n\n~~~\n```\nvar color\n```\n~~~\n\nThe color.",
+			expected: "The colour.\n\n~~~\n```\nvar color\n```\n~~~\n\nThe colour.",
+		},
+		{
+			name:     "Closing fence may be longer than the opening one",
+			input:    "```\nvar color\n`````\nThe color.",
+			expected: "```\nvar color\n`````\nThe colour.",
+		},
+		{
+			name:     "Fence with an info string keeps it",
+			input:    "```go title=\"main.go\"\nvar color // the color\n```\nThe color.",
+			expected: "```go title=\"main.go\"\nvar color // the colour\n```\nThe colour.",
+		},
+		{
+			name:     "Indented fence inside a list item",
+			input:    "- Set the color:\n\n    ```sh\n    export COLOR=gray\n    ```\n\n- The color is set.",
+			expected: "- Set the colour:\n\n    ```sh\n    export COLOR=gray\n    ```\n\n- The colour is set.",
+		},
+		{
+			name:     "Unclosed fence is treated as text",
+			input:    "The color.\n\n```\nThe color.",
+			expected: "The colour.\n\n```\nThe colour.",
+		},
+		{
+			name:     "Empty fenced block",
+			input:    "```\n```\nThe color.",
+			expected: "```\n```\nThe colour.",
+		},
+		{
+			name:     "CRLF line endings",
+			input:    "The color.\r\n\r\n```\r\nvar color\r\n```\r\nThe color.\r\n",
+			expected: "The colour.\r\n\r\n```\r\nvar color\r\n```\r\nThe colour.\r\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := conv.ProcessCodeAware(tt.input, true); result != tt.expected {
+				t.Errorf("ProcessCodeAware(%q) = %q, expected %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMarkdownIndentedCodeBlocks(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "Four space indented block",
+			input:    "Set the color:\n\n    var color = \"gray\" // the color\n    fmt.Println(color)\n\nThe color is set.",
+			expected: "Set the colour:\n\n    var color = \"gray\" // the colour\n    fmt.Println(color)\n\nThe colour is set.",
+		},
+		{
+			name:     "Tab indented block with a blank line inside",
+			input:    "The color:\n\n\tcolor := 1\n\n\tcenter := 2\nThe color.",
+			expected: "The colour:\n\n\tcolor := 1\n\n\tcenter := 2\nThe colour.",
+		},
+		{
+			name:     "Indented line continuing a paragraph is text",
+			input:    "The color\n    of the center.",
+			expected: "The colour\n    of the centre.",
+		},
+		{
+			name:     "Indented paragraph in a list item is text",
+			input:    "- The color:\n\n    The center of the list item.\n\nThe color.",
+			expected: "- The colour:\n\n    The centre of the list item.\n\nThe colour.",
+		},
+		{
+			name:     "Indented text at the start is text",
+			input:    "    The color of the center.",
+			expected: "    The colour of the centre.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := conv.ProcessCodeAware(tt.input, true); result != tt.expected {
+				t.Errorf("ProcessCodeAware(%q) = %q, expected %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDetectCodeBlocksNestedFences(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	input := "Text.\n\n````md\n```\ninner\n```\n````\n"
+	var code []converter.CodeBlock
+	for _, block := range conv.DetectCodeBlocks(input) {
+		if block.IsCode {
+			code = append(code, block)
+		}
+	}
+
+	if len(code) != 1 {
+		t.Fatalf("Expected one code block, got %+v", code)
+	}
+	if code[0].Language != "md" || code[0].Content != "```\ninner\n```" {
+		t.Errorf("Unexpected code block %+v", code[0])
+	}
+	if got := input[code[0].Start:code[0].End]; got != "````md\n```\ninner\n```\n````" {
+		t.Errorf("Expected the block to span both fences, got %q", got)
+	}
+}
@@ -0,0 +1,143 @@
+package tests
+
+import (
+	"os/exec"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+const markdownElementsInput = `# The color guide
+
+The color of the center.
+
+> A quote about color.
+
+- A list item about color.
+
+| Name | Color |
+| ---- | ----- |
+| red  | color |
+
+See [the color chart](https://example.com/color) for the center.
+`
+
+func TestParseMarkdownElements(t *testing.T) {
+	elements, err := converter.ParseMarkdownElements(" Headings,paragraphs,,headings ")
+	if err != nil {
+		t.Fatalf("ParseMarkdownElements failed: %v", err)
+	}
+	if expected := []converter.MarkdownElement{converter.MarkdownHeadings, converter.MarkdownParagraphs}; !slices.Equal(elements, expected) {
+		t.Errorf("ParseMarkdownElements() = %v, expected %v", elements, expected)
+	}
+
+	for _, list := range []string{"headings,footnotes", ","} {
+		if _, err := converter.ParseMarkdownElements(list); err == nil {
+			t.Errorf("Expected an error for %q", list)
+		}
+	}
+}
+
+func TestMarkdownElements(t *testing.T) {
+	tests := []struct {
+		name     string
+		elements string
+		expected string
+	}{
+		{
+			name:     "Headings only",
+			elements: "headings",
+			expected: strings.Replace(markdownElementsInput, "# The color guide", "# The colour guide", 1),
+		},
+		{
+			name:     "Paragraphs but not link text",
+			elements: "paragraphs",
+			expected: strings.NewReplacer(
+				"The color of the center.", "The colour of the centre.",
+				"for the center.", "for the centre.",
+			).Replace(markdownElementsInput),
+		},
+		{
+			name:     "Blockquotes, lists and tables",
+			elements: "blockquotes,lists,tables",
+			expected: strings.NewReplacer(
+				"about color.", "about colour.",
+				"| Color |", "| Colour |",
+				"| color |", "| colour |",
+			).Replace(markdownElementsInput),
+		},
+		{
+			name:     "Link text only",
+			elements: "links",
+			expected: strings.Replace(markdownElementsInput, "[the color chart]", "[the colour chart]", 1),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conv, err := converter.NewConverter()
+			if err != nil {
+				t.Fatalf("Failed to create converter: %v", err)
+			}
+			elements, err := converter.ParseMarkdownElements(tt.elements)
+			if err != nil {
+				t.Fatalf("ParseMarkdownElements failed: %v", err)
+			}
+			conv.SetMarkdownElements(elements)
+
+			if result := conv.ConvertFileContent(markdownElementsInput, "guide.md", false); result != tt.expected {
+				t.Errorf("Unexpected result:\n%s\nexpected:\n%s", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMarkdownElementsDefault(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	expected := conv.ConvertFileContent(markdownElementsInput, "guide.md", false)
+	if strings.Count(expected, "colour") != 6 {
+		t.Fatalf("Expected every element to be converted by default, got:\n%s", expected)
+	}
+	if result := conv.FilterMarkdownElements(markdownElementsInput, expected); result != expected {
+		t.Errorf("Expected no filtering without elements, got:\n%s", result)
+	}
+}
+
+func TestMarkdownElementsFrontMatter(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+	conv.SetMarkdownElements([]converter.MarkdownElement{converter.MarkdownHeadings})
+
+	input := "---\ntitle: The color\n---\n# A color\n\nThe color.\n"
+	expected := "---\ntitle: The colour\n---\n# A colour\n\nThe color.\n"
+	if result := conv.ConvertFileContent(input, "post.md", false); result != expected {
+		t.Errorf("Expected %q, got %q", expected, result)
+	}
+}
+
+func TestMarkdownElementsCLI(t *testing.T) {
+	cliPath := buildTestCLI(t)
+
+	cmd := exec.Command(cliPath, "-raw", "-md-elements=headings,paragraphs")
+	cmd.Stdin = strings.NewReader("# The color\n\nThe color.\n\n> The color.\n")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+	}
+	if expected := "# The colour\n\nThe colour.\n\n> The color."; strings.TrimSpace(string(output)) != expected {
+		t.Errorf("Expected %q, got %q", expected, output)
+	}
+
+	output, err = exec.Command(cliPath, "-md-elements", "footnotes", "color").CombinedOutput()
+	if err == nil || !strings.Contains(string(output), `unknown Markdown element "footnotes"`) {
+		t.Errorf("Expected an unknown element to be rejected, got %v: %s", err, output)
+	}
+}
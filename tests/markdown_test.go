@@ -1,6 +1,7 @@
 package tests
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/sammcj/m2e/pkg/converter"
@@ -300,3 +301,113 @@ func TestMarkdownWithPlainURLs(t *testing.T) {
 		})
 	}
 }
+
+// jekyllPost is a Jekyll-style post with YAML front matter followed by a Markdown body
+const jekyllPost = `---
+layout: post
+title: "The color of the center"
+color: gray
+tags:
+  - color
+  - organization
+summary: |
+  We analyze the color
+  of the center.
+---
+The color of the center is gray.
+`
+
+func TestMarkdownFrontMatter(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	expected := `---
+layout: post
+title: "The colour of the centre"
+color: grey
+tags:
+  - colour
+  - organisation
+summary: |
+  We analyse the colour
+  of the centre.
+---
+The colour of the centre is grey.
+`
+
+	t.Run("ConvertToBritish converts only values", func(t *testing.T) {
+		if result := conv.ConvertToBritish(jekyllPost, true); result != expected {
+			t.Errorf("ConvertToBritish() = %q, expected %q", result, expected)
+		}
+	})
+
+	t.Run("ConvertFileContent converts only values", func(t *testing.T) {
+		if result := conv.ConvertFileContent(jekyllPost, "_posts/2024-01-01-post.md", true); result != expected {
+			t.Errorf("ConvertFileContent() = %q, expected %q", result, expected)
+		}
+	})
+
+	t.Run("Thematic break is not front matter", func(t *testing.T) {
+		input := "---\nThe color is gray.\n---\n"
+		expected := "---\nThe colour is grey.\n---\n"
+		if result := conv.ConvertToBritish(input, true); result != expected {
+			t.Errorf("ConvertToBritish(%q) = %q, expected %q", input, result, expected)
+		}
+	})
+}
+
+func TestMarkdownSkipFrontMatter(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+	conv.SetSkipFrontMatter(true)
+
+	frontMatter := jekyllPost[:strings.Index(jekyllPost, "---\nThe")+len("---\n")]
+	expected := frontMatter + "The colour of the centre is grey.\n"
+
+	if result := conv.ConvertToBritish(jekyllPost, true); result != expected {
+		t.Errorf("ConvertToBritish() = %q, expected %q", result, expected)
+	}
+	if result := conv.ConvertFileContent(jekyllPost, "post.md", true); result != expected {
+		t.Errorf("ConvertFileContent() = %q, expected %q", result, expected)
+	}
+}
+
+func TestMarkdownInlineCode(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	input := "Set the `color` option to `gray` for the center panel."
+
+	t.Run("Inline code is preserved by default", func(t *testing.T) {
+		expected := "Set the `color` option to `gray` for the centre panel."
+		if result := conv.ConvertToBritish(input, true); result != expected {
+			t.Errorf("ConvertToBritish(%q) = %q, expected %q", input, result, expected)
+		}
+	})
+
+	t.Run("Inline code is converted when enabled", func(t *testing.T) {
+		conv.SetConvertInlineCode(true)
+		defer conv.SetConvertInlineCode(false)
+
+		expected := "Set the `colour` option to `grey` for the centre panel."
+		if result := conv.ConvertToBritish(input, true); result != expected {
+			t.Errorf("ConvertToBritish(%q) = %q, expected %q", input, result, expected)
+		}
+	})
+
+	t.Run("Fenced code blocks are still preserved when enabled", func(t *testing.T) {
+		conv.SetConvertInlineCode(true)
+		defer conv.SetConvertInlineCode(false)
+
+		code := "```go\nvar color = \"gray\"\n```"
+		if result := conv.ProcessCodeAware(code, true); result != code {
+			t.Errorf("ProcessCodeAware(%q) = %q, expected it unchanged", code, result)
+		}
+	})
+}
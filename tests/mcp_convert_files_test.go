@@ -0,0 +1,189 @@
+package tests
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// mcpStdioClient talks JSON-RPC to an MCP server over its stdin and stdout
+type mcpStdioClient struct {
+	stdin  io.WriteCloser
+	stdout *bufio.Scanner
+	nextID int
+}
+
+// startTestMCPServer builds the MCP server and starts it in stdio mode, initialised and ready
+// for tool calls. The server is stopped when the test ends.
+func startTestMCPServer(t *testing.T) *mcpStdioClient {
+	t.Helper()
+	serverPath := filepath.Join(t.TempDir(), "m2e-mcp")
+	if output, err := exec.Command("go", "build", "-o", serverPath, "../cmd/m2e-mcp").CombinedOutput(); err != nil {
+		t.Fatalf("Failed to build MCP server: %v\n%s", err, output)
+	}
+
+	server := exec.Command(serverPath)
+	server.Env = append(os.Environ(), "MCP_TRANSPORT=stdio", "HOME="+t.TempDir())
+	stdin, err := server.StdinPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout, err := server.StdoutPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := server.Start(); err != nil {
+		t.Fatalf("Failed to start MCP server: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = stdin.Close()
+		_ = server.Wait()
+	})
+
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 1024*1024), 16*1024*1024)
+	client := &mcpStdioClient{stdin: stdin, stdout: scanner}
+	client.call(t, "initialize", map[string]any{
+		"protocolVersion": "2025-03-26",
+		"capabilities":    map[string]any{},
+		"clientInfo":      map[string]any{"name": "m2e-test", "version": "1.0.0"},
+	})
+	client.send(t, map[string]any{"jsonrpc": "2.0", "method": "notifications/initialized"})
+	return client
+}
+
+func (c *mcpStdioClient) send(t *testing.T, message map[string]any) {
+	t.Helper()
+	data, err := json.Marshal(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fmt.Fprintf(c.stdin, "%s\n", data); err != nil {
+		t.Fatalf("Failed to write to MCP server: %v", err)
+	}
+}
+
+// call sends a request and returns the result of its response
+func (c *mcpStdioClient) call(t *testing.T, method string, params map[string]any) json.RawMessage {
+	t.Helper()
+	c.nextID++
+	c.send(t, map[string]any{"jsonrpc": "2.0", "id": c.nextID, "method": method, "params": params})
+
+	for c.stdout.Scan() {
+		var response struct {
+			ID     int             `json:"id"`
+			Result json.RawMessage `json:"result"`
+			Error  *struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if err := json.Unmarshal(c.stdout.Bytes(), &response); err != nil || response.ID != c.nextID {
+			continue // notifications and log lines
+		}
+		if response.Error != nil {
+			t.Fatalf("%s failed: %s", method, response.Error.Message)
+		}
+		return response.Result
+	}
+	t.Fatalf("MCP server closed without answering %s: %v", method, c.stdout.Err())
+	return nil
+}
+
+// callTool calls a tool and returns the text of its result and whether it is an error
+func (c *mcpStdioClient) callTool(t *testing.T, name string, arguments map[string]any) (string, bool) {
+	t.Helper()
+	var result struct {
+		Content []struct {
+			Text string `json:"text"`
+		} `json:"content"`
+		IsError bool `json:"isError"`
+	}
+	if err := json.Unmarshal(c.call(t, "tools/call", map[string]any{"name": name, "arguments": arguments}), &result); err != nil {
+		t.Fatalf("Failed to decode %s result: %v", name, err)
+	}
+	if len(result.Content) == 0 {
+		t.Fatalf("%s returned no content", name)
+	}
+	return result.Content[0].Text, result.IsError
+}
+
+func TestMCPConvertFiles(t *testing.T) {
+	client := startTestMCPServer(t)
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"notes.md": "The color and flavor.\n",
+		"main.go":  "// The color\nvar color = 1\n",
+		"done.txt": "Already in British English.\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	missing := filepath.Join(dir, "missing.md")
+
+	text, isError := client.callTool(t, "convert_files", map[string]any{
+		"file_paths": []string{filepath.Join(dir, "notes.md"), missing, filepath.Join(dir, "main.go"), filepath.Join(dir, "done.txt")},
+	})
+	if isError {
+		t.Fatalf("Expected the batch to succeed despite a missing file, got error %q", text)
+	}
+
+	var batch struct {
+		Files []struct {
+			Path    string `json:"path"`
+			Status  string `json:"status"`
+			Changes int    `json:"changes"`
+			Error   string `json:"error"`
+		} `json:"files"`
+		Converted int `json:"converted"`
+		Unchanged int `json:"unchanged"`
+		Failed    int `json:"failed"`
+	}
+	if err := json.Unmarshal([]byte(text), &batch); err != nil {
+		t.Fatalf("Expected a JSON result, got %q: %v", text, err)
+	}
+
+	if len(batch.Files) != 4 || batch.Converted != 2 || batch.Unchanged != 1 || batch.Failed != 1 {
+		t.Fatalf("Expected 2 converted, 1 unchanged and 1 failed of 4 files, got %s", text)
+	}
+	expected := []struct {
+		name    string
+		status  string
+		changes int
+	}{
+		{"notes.md", "converted", 2},
+		{"missing.md", "error", 0},
+		{"main.go", "converted", 1},
+		{"done.txt", "unchanged", 0},
+	}
+	for i, want := range expected {
+		got := batch.Files[i]
+		if filepath.Base(got.Path) != want.name || got.Status != want.status || got.Changes != want.changes {
+			t.Errorf("File %d: expected %s %s with %d changes, got %+v", i, want.name, want.status, want.changes, got)
+		}
+	}
+	if !strings.Contains(batch.Files[1].Error, "does not exist") {
+		t.Errorf("Expected the missing file's error to be reported, got %q", batch.Files[1].Error)
+	}
+
+	for name, want := range map[string]string{
+		"notes.md": "The colour and flavour.\n",
+		"main.go":  "// The colour\nvar color = 1\n",
+	} {
+		if content, _ := os.ReadFile(filepath.Join(dir, name)); string(content) != want {
+			t.Errorf("Expected %s to be saved as %q, got %q", name, want, content)
+		}
+	}
+
+	if text, isError := client.callTool(t, "convert_files", map[string]any{"file_paths": []string{}}); !isError {
+		t.Errorf("Expected an empty file list to be an error, got %q", text)
+	}
+}
@@ -0,0 +1,72 @@
+package tests
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+func TestMarkdownProcessingDisabled(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+	if !conv.IsMarkdownProcessingEnabled() {
+		t.Fatal("Expected Markdown processing to be on by default")
+	}
+
+	tests := []struct {
+		name      string
+		input     string
+		markdown  string // result with Markdown processing; empty to skip the check
+		plainText string // result without it
+	}{
+		{
+			name:      "Stray backticks",
+			input:     "He typed ` to start, then the color and the flavor, then ` again.",
+			markdown:  "He typed ` to start, then the color and the flavor, then ` again.",
+			plainText: "He typed ` to start, then the colour and the flavour, then ` again.",
+		},
+		{
+			name:      "Fences",
+			input:     "The color:\n\n```\nvar color\n```\n",
+			markdown:  "The colour:\n\n```\nvar color\n```\n",
+			plainText: "The colour:\n\n```\nvar colour\n```\n",
+		},
+		{
+			// Emphasised words are converted with the sentence around them
+			name:      "Asterisks",
+			input:     "Please *license* the software.",
+			plainText: "Please *license* the software.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conv.SetMarkdownProcessingEnabled(true)
+			if result := conv.ConvertFileContent(tt.input, "notes.txt", false); tt.markdown != "" && result != tt.markdown {
+				t.Errorf("With Markdown processing, expected %q, got %q", tt.markdown, result)
+			}
+			conv.SetMarkdownProcessingEnabled(false)
+			if result := conv.ConvertFileContent(tt.input, "notes.txt", false); result != tt.plainText {
+				t.Errorf("Without Markdown processing, expected %q, got %q", tt.plainText, result)
+			}
+		})
+	}
+}
+
+func TestNoMarkdownCLI(t *testing.T) {
+	cliPath := buildTestCLI(t)
+
+	cmd := exec.Command(cliPath, "-raw", "-no-markdown")
+	cmd.Stdin = strings.NewReader("Use ` for the color, it's the favorite `key.")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+	}
+	if expected := "Use ` for the colour, it's the favourite `key."; strings.TrimSpace(string(output)) != expected {
+		t.Errorf("Expected %q, got %q", expected, output)
+	}
+}
@@ -0,0 +1,207 @@
+package tests
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+// testNotebook is a small nbformat 4 notebook laid out the way Jupyter writes it
+const testNotebook = `{
+ "cells": [
+  {
+   "cell_type": "markdown",
+   "metadata": {},
+   "source": [
+    "# Color analysis\n",
+    "\n",
+    "We analyze the favorite colors."
+   ]
+  },
+  {
+   "cell_type": "code",
+   "execution_count": 1,
+   "metadata": {
+    "tags": ["color"]
+   },
+   "outputs": [
+    {
+     "name": "stdout",
+     "output_type": "stream",
+     "text": [
+      "The color is gray\n"
+     ]
+    }
+   ],
+   "source": [
+    "# Initialize the color\n",
+    "color = \"gray\"\n",
+    "print(f\"The color is {color}\")"
+   ]
+  },
+  {
+   "cell_type": "raw",
+   "metadata": {},
+   "source": "The color stays gray"
+  }
+ ],
+ "metadata": {
+  "kernelspec": {
+   "display_name": "Python 3",
+   "language": "python",
+   "name": "python3"
+  }
+ },
+ "nbformat": 4,
+ "nbformat_minor": 5
+}
+`
+
+// notebookCells decodes the cells of a notebook, failing the test if it isn't valid JSON
+func notebookCells(t *testing.T, notebook string) ([]map[string]json.RawMessage, map[string]json.RawMessage) {
+	t.Helper()
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(notebook), &doc); err != nil {
+		t.Fatalf("Converted notebook is not valid JSON: %v", err)
+	}
+	var cells []map[string]json.RawMessage
+	if err := json.Unmarshal(doc["cells"], &cells); err != nil {
+		t.Fatalf("Failed to decode cells: %v", err)
+	}
+	return cells, doc
+}
+
+func TestConvertNotebook(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	result, err := conv.ConvertNotebook(testNotebook, false)
+	if err != nil {
+		t.Fatalf("ConvertNotebook failed: %v", err)
+	}
+
+	cells, doc := notebookCells(t, result)
+	originalCells, originalDoc := notebookCells(t, testNotebook)
+	if len(cells) != 3 {
+		t.Fatalf("Expected 3 cells, got %d", len(cells))
+	}
+
+	t.Run("Markdown cell converted", func(t *testing.T) {
+		var source []string
+		if err := json.Unmarshal(cells[0]["source"], &source); err != nil {
+			t.Fatalf("Failed to decode source: %v", err)
+		}
+		expected := []string{"# Colour analysis\n", "\n", "We analyse the favourite colours."}
+		if strings.Join(source, "|") != strings.Join(expected, "|") {
+			t.Errorf("Markdown source = %q, want %q", source, expected)
+		}
+	})
+
+	t.Run("Code cell only has its comment converted", func(t *testing.T) {
+		var source []string
+		if err := json.Unmarshal(cells[1]["source"], &source); err != nil {
+			t.Fatalf("Failed to decode source: %v", err)
+		}
+		expected := []string{"# Initialise the colour\n", "color = \"gray\"\n", "print(f\"The color is {color}\")"}
+		if strings.Join(source, "|") != strings.Join(expected, "|") {
+			t.Errorf("Code source = %q, want %q", source, expected)
+		}
+	})
+
+	t.Run("Outputs and metadata byte-identical", func(t *testing.T) {
+		for i := range cells {
+			for _, key := range []string{"outputs", "metadata", "execution_count"} {
+				if string(cells[i][key]) != string(originalCells[i][key]) {
+					t.Errorf("Cell %d %s changed:\n%s\nwant:\n%s", i, key, cells[i][key], originalCells[i][key])
+				}
+			}
+		}
+		for _, key := range []string{"metadata", "nbformat", "nbformat_minor"} {
+			if string(doc[key]) != string(originalDoc[key]) {
+				t.Errorf("Notebook %s changed: %s, want %s", key, doc[key], originalDoc[key])
+			}
+		}
+	})
+
+	t.Run("Raw cell untouched", func(t *testing.T) {
+		if string(cells[2]["source"]) != `"The color stays gray"` {
+			t.Errorf("Raw cell source changed: %s", cells[2]["source"])
+		}
+	})
+
+	t.Run("Only source lines change", func(t *testing.T) {
+		expected := strings.NewReplacer(
+			`"# Color analysis\n"`, `"# Colour analysis\n"`,
+			`"We analyze the favorite colors."`, `"We analyse the favourite colours."`,
+			`"# Initialize the color\n"`, `"# Initialise the colour\n"`,
+		).Replace(testNotebook)
+		if result != expected {
+			t.Errorf("Unexpected notebook:\n%s\nwant:\n%s", result, expected)
+		}
+	})
+}
+
+func TestConvertNotebookSourceForms(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "Source as a single string",
+			input:    `{"cells": [{"cell_type": "markdown", "metadata": {}, "source": "Pick a color & check a > b"}], "nbformat": 4, "nbformat_minor": 2}`,
+			expected: `{"cells": [{"cell_type": "markdown", "metadata": {}, "source": "Pick a colour & check a > b"}], "nbformat": 4, "nbformat_minor": 2}`,
+		},
+		{
+			name:     "Unchanged notebook round-trips",
+			input:    `{"cells": [{"cell_type": "markdown", "metadata": {}, "source": ["Nothing to change\n", "here"]}], "nbformat": 4, "nbformat_minor": 5}`,
+			expected: `{"cells": [{"cell_type": "markdown", "metadata": {}, "source": ["Nothing to change\n", "here"]}], "nbformat": 4, "nbformat_minor": 5}`,
+		},
+		{
+			name:     "Notebook without cells",
+			input:    `{"metadata": {}, "nbformat": 4, "nbformat_minor": 5}`,
+			expected: `{"metadata": {}, "nbformat": 4, "nbformat_minor": 5}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := conv.ConvertNotebook(tt.input, false)
+			if err != nil {
+				t.Fatalf("ConvertNotebook failed: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("ConvertNotebook() = %s, want %s", result, tt.expected)
+			}
+		})
+	}
+
+	t.Run("Invalid JSON left unchanged", func(t *testing.T) {
+		input := `{"cells": [{"cell_type": "markdown", "source": "color"`
+		if _, err := conv.ConvertNotebook(input, false); err == nil {
+			t.Error("Expected an error for invalid JSON")
+		}
+		if result := conv.ConvertFileContent(input, "analysis.ipynb", false); result != input {
+			t.Errorf("ConvertFileContent changed invalid notebook: %s", result)
+		}
+	})
+
+	t.Run("ConvertFileContent routes notebooks", func(t *testing.T) {
+		result := conv.ConvertFileContent(testNotebook, "analysis.ipynb", false)
+		if !strings.Contains(result, `"# Initialise the colour\n"`) || !strings.Contains(result, `"color = \"gray\"\n"`) {
+			t.Errorf("ConvertFileContent did not convert the notebook cells:\n%s", result)
+		}
+		if !strings.Contains(result, `"The color is gray\n"`) {
+			t.Errorf("ConvertFileContent changed the notebook outputs:\n%s", result)
+		}
+	})
+}
@@ -0,0 +1,96 @@
+package tests
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+func TestPhraseProcessor(t *testing.T) {
+	processor := converter.NewPhraseProcessor(map[string]string{
+		"on the weekend":    "at the weekend",
+		"different than":    "different from",
+		"fill out":          "fill in",
+		"fill out the form": "fill in the form",
+	})
+
+	if result := processor.ProcessText("See you on the weekend."); result != "See you on the weekend." {
+		t.Errorf("Expected no changes while disabled, got %q", result)
+	}
+	processor.SetEnabled(true)
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"Lowercase", "See you on the weekend.", "See you at the weekend."},
+		{"Capitalised", "On the weekend we rest.", "At the weekend we rest."},
+		{"All caps", "OPEN ON THE WEEKEND", "OPEN AT THE WEEKEND"},
+		{"Split across lines", "This is different\nthan that.", "This is different from that."},
+		{"Longest phrase wins", "Please fill out the form.", "Please fill in the form."},
+		{"Whole words only", "Indifferent thanks and a common the weekender.", "Indifferent thanks and a common the weekender."},
+		{"Skips URLs", "Read https://example.com/on the weekend", "Read https://example.com/on the weekend"},
+		{"Skips inline code", "Run `job --on the weekend` on the weekend", "Run `job --on the weekend` at the weekend"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := processor.ProcessText(tt.input); result != tt.expected {
+				t.Errorf("ProcessText(%q) = %q, expected %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestConverterPhrases(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	input := "The color is different than I expected on the weekend."
+	if result := conv.ConvertToBritish(input, true); result != "The colour is different than I expected on the weekend." {
+		t.Errorf("Expected phrases to be left alone by default, got %q", result)
+	}
+
+	conv.SetPhraseProcessingEnabled(true)
+	if result := conv.ConvertToBritish(input, true); result != "The colour is different from I expected at the weekend." {
+		t.Errorf("Unexpected phrase conversion: %q", result)
+	}
+
+	// Code is preserved
+	code := "See you on the weekend.\n\n```\nprint(\"on the weekend\")\n```\n\nOr `on the weekend`."
+	expected := "See you at the weekend.\n\n```\nprint(\"on the weekend\")\n```\n\nOr `on the weekend`."
+	if result := conv.ProcessCodeAware(code, true); result != expected {
+		t.Errorf("Expected code to be preserved, got %q", result)
+	}
+
+	// Only comments are converted in source files
+	goCode := "// Runs on the weekend\nvar s = \"on the weekend\"\n"
+	expectedGo := "// Runs at the weekend\nvar s = \"on the weekend\"\n"
+	if result := conv.ConvertFileContent(goCode, ".go", true); result != expectedGo {
+		t.Errorf("Expected only the comment to change, got %q", result)
+	}
+
+	// The processor can be extended at runtime
+	conv.GetPhraseProcessor().AddRules(map[string]string{"stand in line": "queue"})
+	if result := conv.ConvertToBritish("We had to stand in line.", true); result != "We had to queue." {
+		t.Errorf("Expected added rule to apply, got %q", result)
+	}
+}
+
+func TestCLIPhrases(t *testing.T) {
+	cliPath := buildTestCLI(t)
+
+	cmd := exec.Command(cliPath, "-phrases", "-raw")
+	cmd.Stdin = strings.NewReader("The shop is closed on the weekend.")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+	}
+	if !strings.Contains(string(output), "The shop is closed at the weekend.") {
+		t.Errorf("Expected phrase to be converted, got %q", output)
+	}
+}
@@ -0,0 +1,94 @@
+package tests
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+func TestEffectiveConfig(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	config := converter.GetDefaultUnitConfig()
+	config.SpellingVariant = "ize"
+	config.ExcludedWords = []string{"color"}
+	if err := conv.ApplyConfig(config); err != nil {
+		t.Fatalf("ApplyConfig failed: %v", err)
+	}
+	conv.SetSpellingVariant(converter.SpellingOxfordIZE)
+	conv.SetUnitKeepOriginal(true)
+
+	effective := conv.EffectiveConfig()
+	if effective.SpellingVariant != "oxford" {
+		t.Errorf("Expected the spelling variant set last, got %q", effective.SpellingVariant)
+	}
+	if !slices.Equal(effective.ExcludedWords, []string{"color"}) {
+		t.Errorf("Expected the excluded words, got %v", effective.ExcludedWords)
+	}
+	if !effective.Preferences.KeepOriginal {
+		t.Error("Expected unit settings changed after ApplyConfig to be included")
+	}
+
+	// The result is a copy that can be applied as is
+	effective.ExcludedWords[0] = "flavor"
+	if !slices.Equal(conv.GetExcludedWords(), []string{"color"}) {
+		t.Errorf("Expected EffectiveConfig to return a copy, got %v", conv.GetExcludedWords())
+	}
+	if err := converter.ValidateConfig(effective); err != nil {
+		t.Errorf("Expected a valid config, got %v", err)
+	}
+}
+
+func TestPrintConfigCLI(t *testing.T) {
+	cliPath := buildTestCLI(t)
+	home := t.TempDir()
+	project := t.TempDir()
+	if err := os.WriteFile(filepath.Join(project, ".m2e.json"), []byte(`{"spellingVariant": "oxford", "excludedWords": ["color"]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(project, "docs"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	printConfig := func(args ...string) *converter.UnitConfig {
+		t.Helper()
+		cmd := exec.Command(cliPath, append([]string{"-print-config"}, args...)...)
+		cmd.Env = append(os.Environ(), "HOME="+home)
+		output, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("CLI %v failed: %v\nOutput: %s", args, err, output)
+		}
+		config := converter.GetDefaultUnitConfig()
+		if err := json.Unmarshal(output, config); err != nil {
+			t.Fatalf("Expected JSON, got %v\n%s", err, output)
+		}
+		return config
+	}
+
+	config := printConfig()
+	if config.Enabled || config.SpellingVariant != "ise" || len(config.ExcludedWords) != 0 {
+		t.Errorf("Expected the defaults with units off, got enabled=%t spelling=%q excluded=%v",
+			config.Enabled, config.SpellingVariant, config.ExcludedWords)
+	}
+
+	config = printConfig("-units", "-units-keep-original", filepath.Join(project, "docs"))
+	if !config.Enabled || !config.Preferences.KeepOriginal {
+		t.Error("Expected command-line flags to be applied")
+	}
+	if config.SpellingVariant != "oxford" || !slices.Equal(config.ExcludedWords, []string{"color"}) {
+		t.Errorf("Expected the project config to be applied, got spelling=%q excluded=%v", config.SpellingVariant, config.ExcludedWords)
+	}
+
+	// Flags win over the project config
+	if config = printConfig("-spelling", "ize", project); config.SpellingVariant != "ize" {
+		t.Errorf("Expected -spelling to override the project config, got %q", config.SpellingVariant)
+	}
+}
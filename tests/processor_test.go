@@ -0,0 +1,124 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+// recordingProcessor records the text it sees and applies a replacement
+type recordingProcessor struct {
+	seen     []string
+	old, new string
+}
+
+func (r *recordingProcessor) Process(text string, opts ...converter.ProcessOption) string {
+	r.seen = append(r.seen, text)
+	return strings.ReplaceAll(text, r.old, r.new)
+}
+
+func TestRegisterProcessorPhases(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+	conv.SetUnitProcessingEnabled(true)
+
+	pre := &recordingProcessor{old: "synergize", new: "work together"}
+	post := &recordingProcessor{old: "colour", new: "hue"}
+	postUnits := &recordingProcessor{old: "km", new: "kilometres"}
+	for _, registration := range []struct {
+		p     converter.Processor
+		phase converter.Phase
+	}{{pre, converter.PhasePreSpelling}, {post, converter.PhasePostSpelling}, {postUnits, converter.PhasePostUnits}} {
+		if err := conv.RegisterProcessor(registration.p, registration.phase); err != nil {
+			t.Fatalf("RegisterProcessor(%s) failed: %v", registration.phase, err)
+		}
+	}
+
+	input := "We synergize on color after 5 miles."
+	expected := "We work together on hue after 8 kilometres."
+	if result := conv.ConvertToBritish(input, false); result != expected {
+		t.Errorf("ConvertToBritish() = %q, expected %q", result, expected)
+	}
+
+	// Each phase sees the text as the previous steps left it
+	if len(pre.seen) == 0 || pre.seen[0] != input {
+		t.Errorf("Expected the pre-spelling processor to see the original text, got %q", pre.seen)
+	}
+	if len(post.seen) == 0 || !strings.Contains(post.seen[0], "colour") || !strings.Contains(post.seen[0], "5 miles") {
+		t.Errorf("Expected the post-spelling processor to see converted spelling but no units, got %q", post.seen)
+	}
+	if len(postUnits.seen) == 0 || !strings.Contains(postUnits.seen[0], "8 km") {
+		t.Errorf("Expected the post-units processor to see converted units, got %q", postUnits.seen)
+	}
+}
+
+func TestRegisterProcessorSkipsCode(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	jargon := converter.ProcessorFunc(func(text string, opts ...converter.ProcessOption) string {
+		return strings.ReplaceAll(text, "leverage", "use")
+	})
+	if err := conv.RegisterProcessor(jargon, converter.PhasePostSpelling); err != nil {
+		t.Fatalf("RegisterProcessor failed: %v", err)
+	}
+
+	input := "We leverage `leverage()` here.\n\n```go\nleverage() // leverage the color\n```\n"
+	expected := "We use `leverage()` here.\n\n```go\nleverage() // use the colour\n```\n"
+	if result := conv.ProcessCodeAware(input, false); result != expected {
+		t.Errorf("ProcessCodeAware() = %q, expected %q", result, expected)
+	}
+
+	// Clones have their own processors
+	clone := conv.Clone()
+	if err := clone.RegisterProcessor(jargon, converter.PhasePreSpelling); err != nil {
+		t.Fatalf("RegisterProcessor failed: %v", err)
+	}
+	if len(conv.Processors(converter.PhasePreSpelling)) != 0 || len(clone.Processors(converter.PhasePostSpelling)) != 1 {
+		t.Error("Expected a clone to copy the original's processors without sharing new ones")
+	}
+}
+
+func TestRegisterProcessorValidation(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	if err := conv.RegisterProcessor(nil, converter.PhasePreSpelling); err == nil {
+		t.Error("Expected an error for a nil processor")
+	}
+	if err := conv.RegisterProcessor(&recordingProcessor{}, converter.Phase(42)); err == nil {
+		t.Error("Expected an error for an unknown phase")
+	}
+}
+
+func TestBuiltInProcessors(t *testing.T) {
+	upper := converter.WithConvert(strings.ToUpper)
+
+	markdown := converter.NewMarkdownProcessor()
+	if result := markdown.Process("see [the docs](http://example.com/docs)", upper); result != "SEE [THE DOCS](http://example.com/docs)" {
+		t.Errorf("MarkdownProcessor.Process() = %q", result)
+	}
+
+	ignore := converter.NewCommentIgnoreProcessor()
+	colour := converter.WithConvert(func(text string) string { return strings.ReplaceAll(text, "color", "colour") })
+	input := "color\n// m2e-ignore-next\ncolor\ncolor"
+	if result := ignore.Process(input, colour); result != "colour\n// m2e-ignore-next\ncolor\ncolour" {
+		t.Errorf("CommentIgnoreProcessor.Process() = %q", result)
+	}
+
+	units := converter.NewUnitProcessorWithConfig(converter.GetDefaultUnitConfig())
+	if result := units.Process("The trail is 5 miles long."); result != "The trail is 8 km long." {
+		t.Errorf("UnitProcessor.Process() = %q", result)
+	}
+	code := "x := 5 // 5 miles\ny := \"5 miles\""
+	if result := units.Process(code, converter.WithLanguage("go")); result != "x := 5 // 8 km\ny := \"5 miles\"" {
+		t.Errorf("UnitProcessor.Process() with a language = %q", result)
+	}
+}
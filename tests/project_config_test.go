@@ -0,0 +1,144 @@
+package tests
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFindProjectConfig(t *testing.T) {
+	root := t.TempDir()
+	configPath := filepath.Join(root, "project", converter.ProjectConfigFileName)
+	writeTestFile(t, configPath, `{}`)
+
+	if got := converter.FindProjectConfig(filepath.Join(root, "project", "docs", "guides")); got != configPath {
+		t.Errorf("Expected config from parent directory %s, got %q", configPath, got)
+	}
+	if got := converter.FindProjectConfig(filepath.Join(root, "project")); got != configPath {
+		t.Errorf("Expected config in the directory itself %s, got %q", configPath, got)
+	}
+	if got := converter.FindProjectConfig(filepath.Join(root, "other")); got != "" && strings.HasPrefix(got, root) {
+		t.Errorf("Expected no config for a sibling directory, got %q", got)
+	}
+}
+
+func TestLoadProjectConfigKeepsUnsetFields(t *testing.T) {
+	path := filepath.Join(t.TempDir(), converter.ProjectConfigFileName)
+	writeTestFile(t, path, `{"spellingVariant": "oxford", "excludedWords": ["color"], "precision": {"length": 2}}`)
+
+	base := converter.GetDefaultUnitConfig()
+	base.Enabled = false
+	config, err := converter.LoadProjectConfig(path, base)
+	if err != nil {
+		t.Fatalf("LoadProjectConfig failed: %v", err)
+	}
+
+	if config.Enabled {
+		t.Error("Expected enabled to be inherited from the base config")
+	}
+	if config.SpellingVariant != "oxford" || len(config.ExcludedWords) != 1 {
+		t.Errorf("Expected spelling variant and excluded words from the file, got %q and %v", config.SpellingVariant, config.ExcludedWords)
+	}
+	if config.Precision["length"] != 2 || config.Precision["mass"] != 1 {
+		t.Errorf("Expected precision to be merged over the base, got %v", config.Precision)
+	}
+	if config.Detection.MinConfidence != base.Detection.MinConfidence {
+		t.Errorf("Expected detection settings to be inherited, got %+v", config.Detection)
+	}
+	if base.Precision["length"] != 1 || base.SpellingVariant != "" {
+		t.Error("Expected the base config not to be modified")
+	}
+
+	writeTestFile(t, path, `{"spellingVariant": "americanize"}`)
+	if _, err := converter.LoadProjectConfig(path, base); err == nil {
+		t.Error("Expected an invalid spelling variant to be rejected")
+	}
+}
+
+func TestConverterForFileUsesNearestProjectConfig(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, filepath.Join(root, "app", converter.ProjectConfigFileName), `{"spellingVariant": "ize", "excludedWords": ["Color"]}`)
+
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+	conv.EnableProjectConfig(nil)
+
+	input := "I realize the color of the center."
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{filepath.Join(root, "app", "docs", "readme.txt"), "I realize the color of the centre."},
+		{filepath.Join(root, "web", "readme.txt"), "I realise the colour of the centre."},
+	}
+	for _, tt := range tests {
+		fileConv, err := conv.ForFile(tt.path)
+		if err != nil {
+			t.Fatalf("ForFile(%s) failed: %v", tt.path, err)
+		}
+		if got := fileConv.ConvertToBritish(input, true); got != tt.expected {
+			t.Errorf("ForFile(%s): got %q, expected %q", tt.path, got, tt.expected)
+		}
+	}
+
+	// The project settings don't leak into the base converter
+	if got := conv.ConvertToBritish(input, true); got != "I realise the colour of the centre." {
+		t.Errorf("Expected base converter to be unchanged, got %q", got)
+	}
+}
+
+func TestCLIProjectConfig(t *testing.T) {
+	cliPath := buildTestCLI(t)
+	root := t.TempDir()
+	input := "I realize the color is 6 feet from the center.\n"
+
+	writeTestFile(t, filepath.Join(root, "units", converter.ProjectConfigFileName), `{"enabled": true, "spellingVariant": "ize"}`)
+	writeTestFile(t, filepath.Join(root, "units", "guide", "doc.txt"), input)
+	writeTestFile(t, filepath.Join(root, "plain", "doc.txt"), input)
+
+	cmd := exec.Command(cliPath, "-save", root)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("CLI failed: %v\n%s", err, output)
+	}
+
+	read := func(rel string) string {
+		data, err := os.ReadFile(filepath.Join(root, rel))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+	if got := read("units/guide/doc.txt"); got != "I realize the colour is 1.8 metres from the centre.\n" {
+		t.Errorf("Expected project config to enable units and -ize, got %q", got)
+	}
+	if got := read("plain/doc.txt"); got != "I realise the colour is 6 feet from the centre.\n" {
+		t.Errorf("Expected defaults outside the project, got %q", got)
+	}
+
+	// Command-line flags take precedence over the project config
+	writeTestFile(t, filepath.Join(root, "units", "guide", "doc.txt"), input)
+	cmd = exec.Command(cliPath, "-spelling", "ise", "-raw", filepath.Join(root, "units", "guide", "doc.txt"))
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("CLI failed: %v\n%s", err, output)
+	}
+	if !strings.Contains(string(output), "I realise the colour is 1.8 metres") {
+		t.Errorf("Expected -spelling to override the project config, got %q", output)
+	}
+}
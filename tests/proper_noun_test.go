@@ -0,0 +1,75 @@
+package tests
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+func TestProperNounGuard(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"Name with connector", "He works at the Department of Labor.", "He works at the Department of Labor."},
+		{"Name at sentence start", "Department of Labor figures were late.", "Department of Labor figures were late."},
+		{"Multi-word name", "Ask the World Health Organization about it.", "Ask the World Health Organization about it."},
+		{"Name starting a sentence", "World Health Organization staff traveled.", "World Health Organization staff travelled."},
+		{"Lowercase word", "Years of hard labor.", "Years of hard labour."},
+		{"Capitalised at sentence start", "Labor is hard. Color matters.", "Labour is hard. Colour matters."},
+		{"Sentence start before a verb", "They Americanize everything.", "They Americanise everything."},
+		{"Single capitalised word", "We spoke to Honor about the color.", "We spoke to Honour about the colour."},
+		{"Punctuation ends the name", "See Labor, Color and more.", "See Labour, Colour and more."},
+		{"Markdown heading", "# The Color Guide", "# The Colour Guide"},
+		{"AsciiDoc heading", "== The Color Center", "== The Colour Centre"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := conv.ConvertToBritish(tt.input, false); result != tt.expected {
+				t.Errorf("ConvertToBritish(%q) = %q, expected %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+
+	conv.SetProperNounConversionEnabled(true)
+	if !conv.IsProperNounConversionEnabled() {
+		t.Error("Expected proper noun conversion to be enabled")
+	}
+	if result := conv.ConvertToBritish("He works at the Department of Labor.", false); result != "He works at the Department of Labour." {
+		t.Errorf("Expected names to convert when enabled, got %q", result)
+	}
+}
+
+func TestCLIConvertProperNouns(t *testing.T) {
+	cliPath := buildTestCLI(t)
+
+	input := "The Department of Labor studied hard labor."
+	cmd := exec.Command(cliPath, "-raw")
+	cmd.Stdin = strings.NewReader(input)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+	}
+	if strings.TrimSpace(string(output)) != "The Department of Labor studied hard labour." {
+		t.Errorf("Expected the name to be kept, got %q", output)
+	}
+
+	cmd = exec.Command(cliPath, "-raw", "-convert-proper-nouns")
+	cmd.Stdin = strings.NewReader(input)
+	output, err = cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+	}
+	if strings.TrimSpace(string(output)) != "The Department of Labour studied hard labour." {
+		t.Errorf("Expected the name to convert with -convert-proper-nouns, got %q", output)
+	}
+}
@@ -0,0 +1,84 @@
+package tests
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+func TestQuotePunctuation(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+	conv.SetQuotePunctuationEnabled(true)
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"Comma moved", `They called it "simple," which it wasn't.`, `They called it "simple", which it wasn't.`},
+		{"Full stop moved", `The plan was labelled "good enough."`, `The plan was labelled "good enough".`},
+		{"Curly quotes", "We call these “smart quotes,” mostly.", "We call these “smart quotes”, mostly."},
+		{"Converted alongside spelling", `It's the "best color," apparently.`, `It's the "best colour", apparently.`},
+		{"Full sentence kept", `He wrote "This is a complete sentence that we quote in full."`, `He wrote "This is a complete sentence that we quote in full."`},
+		{"Capitalised quote kept", `She named it "Project Blue," then left.`, `She named it "Project Blue," then left.`},
+		{"Speech kept", `He said "yes." Then he left.`, `He said "yes." Then he left.`},
+		{"Introduced by a comma kept", `He replied, "maybe."`, `He replied, "maybe."`},
+		{"Question mark kept", `Is it really "finished?"`, `Is it really "finished?"`},
+		{"Long phrase kept", `It was "a very long phrase of many words," so kept.`, `It was "a very long phrase of many words," so kept.`},
+		{"Already British", `They called it "simple", which it wasn't.`, `They called it "simple", which it wasn't.`},
+		{"Inline code kept", "Run `echo \"hi,\" now` to test.", "Run `echo \"hi,\" now` to test."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := conv.ConvertToBritish(tt.input, false); result != tt.expected {
+				t.Errorf("ConvertToBritish(%q) = %q, expected %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestQuotePunctuationSkipsCode(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+	conv.SetQuotePunctuationEnabled(true)
+
+	input := "They called it \"simple,\" sadly.\n\n```go\nfmt.Println(\"it is \" + \"simple,\" + x)\n```\n"
+	expected := "They called it \"simple\", sadly.\n\n```go\nfmt.Println(\"it is \" + \"simple,\" + x)\n```\n"
+	if result := conv.ConvertToBritish(input, false); result != expected {
+		t.Errorf("Expected code blocks to be left alone, got %q", result)
+	}
+}
+
+func TestQuotePunctuationDisabled(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	input := `They called it "simple," which it wasn't.`
+	if result := conv.ConvertToBritish(input, false); result != input {
+		t.Errorf("Expected punctuation to stay inside quotes by default, got %q", result)
+	}
+}
+
+func TestQuotePunctuationCLI(t *testing.T) {
+	cliPath := buildTestCLI(t)
+
+	cmd := exec.Command(cliPath, "-raw", "-quote-punctuation")
+	cmd.Stdin = strings.NewReader(`They called it "simple," which it wasn't.`)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+	}
+	if strings.TrimSpace(string(output)) != `They called it "simple", which it wasn't.` {
+		t.Errorf("Expected the comma to move outside the quote, got %q", output)
+	}
+}
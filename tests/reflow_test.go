@@ -0,0 +1,163 @@
+package tests
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+func TestReflow(t *testing.T) {
+	tests := []struct {
+		name      string
+		original  string
+		converted string
+		expected  string
+	}{
+		{
+			name:      "Changed paragraph re-wrapped",
+			original:  "The color of the center\nis gray.",
+			converted: "The colour of the centre\nis grey.",
+			expected:  "The colour of the\ncentre is grey.",
+		},
+		{
+			name:      "Unchanged paragraph kept",
+			original:  "A paragraph that is\nwrapped by hand.\n\nThe color.",
+			converted: "A paragraph that is\nwrapped by hand.\n\nThe colour.",
+			expected:  "A paragraph that is\nwrapped by hand.\n\nThe colour.",
+		},
+		{
+			name:      "Fenced code kept",
+			original:  "```\nvar color = \"a very long line of code that is wider than the width\"\n```\n",
+			converted: "```\nvar color = \"a very long line of code that is wider than the width\"\n```\n",
+			expected:  "```\nvar color = \"a very long line of code that is wider than the width\"\n```\n",
+		},
+		{
+			name:      "List items get a hanging indent",
+			original:  "- The color of the center is gray\n- Another item",
+			converted: "- The colour of the centre is grey\n- Another item",
+			expected:  "- The colour of the\n  centre is grey\n- Another item",
+		},
+		{
+			name:      "Numbered list item",
+			original:  "1. Organize the color of the center",
+			converted: "1. Organise the colour of the centre",
+			expected:  "1. Organise the\n   colour of the\n   centre",
+		},
+		{
+			name:      "Heading kept",
+			original:  "# The color of the center is gray and long\n",
+			converted: "# The colour of the centre is grey and long\n",
+			expected:  "# The colour of the centre is grey and long\n",
+		},
+		{
+			name:      "Blank lines kept",
+			original:  "Color one.\n\n\nColor two.\n",
+			converted: "Colour one.\n\n\nColour two.\n",
+			expected:  "Colour one.\n\n\nColour two.\n",
+		},
+		{
+			name:      "Hard line breaks kept",
+			original:  "The color of the center  \nis gray.",
+			converted: "The colour of the centre  \nis grey.",
+			expected:  "The colour of the centre  \nis grey.",
+		},
+		{
+			name:      "Marker-like word kept off the start of a line",
+			original:  "The color of the center - gray",
+			converted: "The colour of the centre - grey",
+			expected:  "The colour of the\ncentre - grey",
+		},
+		{
+			name:      "CRLF line endings kept",
+			original:  "The color of the center\r\nis gray.\r\n",
+			converted: "The colour of the centre\r\nis grey.\r\n",
+			expected:  "The colour of the\r\ncentre is grey.\r\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := converter.Reflow(tt.original, tt.converted, 20); result != tt.expected {
+				t.Errorf("Reflow() = %q, expected %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestReflowFile(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	input := "The color of the center\nis gray.\n"
+	if result := conv.ConvertFileContent(input, "notes.md", false); result != "The colour of the centre\nis grey.\n" {
+		t.Errorf("Expected no re-wrapping by default, got %q", result)
+	}
+
+	conv.SetMaxLineWidth(20)
+	if result := conv.ConvertFileContent(input, "notes.md", false); result != "The colour of the\ncentre is grey.\n" {
+		t.Errorf("Expected the Markdown paragraph to be re-wrapped, got %q", result)
+	}
+
+	code := "// The color of the center\n// is gray.\nfunc main() {}\n"
+	if result := conv.ConvertFileContent(code, "main.go", false); result != "// The colour of the centre\n// is grey.\nfunc main() {}\n" {
+		t.Errorf("Expected code not to be re-wrapped, got %q", result)
+	}
+}
+
+func TestMaxLineWidthCLI(t *testing.T) {
+	cliPath := buildTestCLI(t)
+	input := "The color of the center\nis gray.\n\n```\nx = \"a long line of code that stays as it is\"\n```\n"
+
+	for _, flag := range []string{"-max-line-width", "-width"} {
+		cmd := exec.Command(cliPath, "-raw", flag, "20")
+		cmd.Stdin = strings.NewReader(input)
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+		}
+		expected := "The colour of the\ncentre is grey.\n\n```\nx = \"a long line of code that stays as it is\"\n```\n"
+		if string(output) != expected {
+			t.Errorf("%s: expected %q, got %q", flag, expected, output)
+		}
+	}
+
+	cmd := exec.Command(cliPath, "-raw", "-max-line-width", "wide")
+	cmd.Stdin = strings.NewReader(input)
+	if err := cmd.Run(); err == nil {
+		t.Error("Expected an invalid width to be rejected")
+	}
+}
+
+func TestMaxLineWidthCLIChangedLines(t *testing.T) {
+	cliPath := buildTestCLI(t)
+	path := filepath.Join(t.TempDir(), "notes.txt")
+	if err := os.WriteFile(path, []byte("Intro.\n\nThe color of the center is gray.\n\nThe flavor.\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Re-wrapping adds a line, so the lines after it are numbered as they are once converted
+	tests := []struct {
+		mode     string
+		expected string
+	}{
+		{"-raw-changes", "3:The colour of the\n4:centre is grey.\n6:The flavour.\n"},
+		{"-diff", "--- " + path + ".orig\n+++ " + path + "\n" +
+			"@@ -3,1 +3,2 @@\n-The color of the center is gray.\n+The colour of the\n+centre is grey.\n" +
+			"@@ -5,1 +6,1 @@\n-The flavor.\n+The flavour.\n"},
+	}
+	for _, tt := range tests {
+		output, err := exec.Command(cliPath, tt.mode, "-max-line-width", "20", path).CombinedOutput()
+		if err != nil {
+			t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+		}
+		if string(output) != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.mode, tt.expected, output)
+		}
+	}
+}
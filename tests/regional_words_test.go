@@ -0,0 +1,97 @@
+package tests
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+func TestRegionalWords(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+	conv.SetRegionalWordsEnabled(true)
+	if !conv.IsRegionalWordsEnabled() {
+		t.Fatal("Expected regional words to be enabled")
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"Season capitalised", "We moved in the Fall.", "We moved in the Autumn."},
+		{"Season", "The leaves turn in the fall, then drop.", "The leaves turn in the autumn, then drop."},
+		{"Season with a year", "It opened in fall of 2021.", "It opened in autumn of 2021."},
+		{"Season before a noun", "The fall semester starts soon.", "The autumn semester starts soon."},
+		{"Season after a determiner", "We'll ship it next fall.", "We'll ship it next autumn."},
+		{"Seasons listed", "Spring and fall are mild.", "Spring and autumn are mild."},
+		{"Verb kept", "Don't fall down the stairs.", "Don't fall down the stairs."},
+		{"Verb with a subject kept", "Prices fall when demand drops.", "Prices fall when demand drops."},
+		{"Noun for a drop kept", "The fall of Rome was slow.", "The fall of Rome was slow."},
+		{"Holiday", "She is on vacation this week.", "She is on holiday this week."},
+		{"Holidays", "Our summer vacations were short.", "Our summer holidays were short."},
+		{"Holiday before a noun", "They bought a vacation home.", "They bought a holiday home."},
+		{"Vacation as a verb kept", "They vacation in Maine.", "They vacation in Maine."},
+		{"Weekday range", "Open Monday through Friday.", "Open Monday to Friday."},
+		{"Month range", "It runs January through March.", "It runs January to March."},
+		{"Through kept", "Walk through the door.", "Walk through the door."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := conv.ConvertToBritish(tt.input, false); result != tt.expected {
+				t.Errorf("ConvertToBritish(%q) = %q, expected %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRegionalWordsDisabled(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	input := "On vacation in the fall, Monday through Friday."
+	if result := conv.ConvertToBritish(input, false); result != input {
+		t.Errorf("Expected regional words to be kept by default, got %q", result)
+	}
+
+	conv.SetRegionalWordsEnabled(true)
+	conv.SetRegionalWordsEnabled(false)
+	if conv.IsRegionalWordsEnabled() {
+		t.Error("Expected regional words to be disabled")
+	}
+	if result := conv.ConvertToBritish(input, false); result != input {
+		t.Errorf("Expected regional words to be kept once disabled again, got %q", result)
+	}
+}
+
+func TestRegionalWordsCLI(t *testing.T) {
+	cliPath := buildTestCLI(t)
+	input := "Closed in the fall, open Monday through Friday."
+
+	cmd := exec.Command(cliPath, "-raw")
+	cmd.Stdin = strings.NewReader(input)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+	}
+	if strings.TrimSpace(string(output)) != input {
+		t.Errorf("Expected regional words to be kept, got %q", output)
+	}
+
+	cmd = exec.Command(cliPath, "-raw", "-regional")
+	cmd.Stdin = strings.NewReader(input)
+	output, err = cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+	}
+	if strings.TrimSpace(string(output)) != "Closed in the autumn, open Monday to Friday." {
+		t.Errorf("Expected regional words to convert with -regional, got %q", output)
+	}
+}
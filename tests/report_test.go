@@ -1,6 +1,7 @@
 package tests
 
 import (
+	"errors"
 	"strings"
 	"testing"
 
@@ -253,6 +254,91 @@ func TestAnalyser_QuoteChanges(t *testing.T) {
 	}
 }
 
+func TestAnalyser_ChangeDetails(t *testing.T) {
+	americanWords := map[string]string{
+		"color": "colour",
+		"humor": "humour",
+	}
+
+	analyser := report.NewAnalyser(americanWords)
+
+	original := "Color, color and humor \u201cmatter\u201d in a 12 feet room."
+	converted := "Colour, colour and humour \"matter\" in a 4 metres room."
+	stats := analyser.AnalyseChanges(original, converted)
+
+	expected := []report.WordChangeCount{
+		{Original: "\u201c", Converted: "\"", Count: 1, Category: report.CategoryQuote},
+		{Original: "\u201d", Converted: "\"", Count: 1, Category: report.CategoryQuote},
+	}
+	find := func(original string, category report.ChangeCategory) *report.WordChangeCount {
+		for i := range stats.ChangeDetails {
+			if stats.ChangeDetails[i].Original == original && stats.ChangeDetails[i].Category == category {
+				return &stats.ChangeDetails[i]
+			}
+		}
+		return nil
+	}
+
+	if change := find("color", report.CategorySpelling); change == nil || change.Converted != "colour" || change.Count != 1 {
+		t.Errorf("Expected color → colour once, got %+v", change)
+	}
+	if change := find("humor", report.CategorySpelling); change == nil || change.Count != 1 {
+		t.Errorf("Expected humor → humour once, got %+v", change)
+	}
+	if change := find("12 feet", report.CategoryUnit); change == nil || change.Converted != "4 metres" {
+		t.Errorf("Expected unit change to be categorised separately, got %+v", change)
+	}
+	for _, want := range expected {
+		if change := find(want.Original, want.Category); change == nil || *change != want {
+			t.Errorf("Expected quote change %+v, got %+v", want, change)
+		}
+	}
+
+	// Spelling changes sort before quote changes with the same count
+	if stats.ChangeDetails[0].Category != report.CategorySpelling {
+		t.Errorf("Expected spelling changes first, got %+v", stats.ChangeDetails[0])
+	}
+}
+
+func TestMergeChangeDetails(t *testing.T) {
+	a := []report.WordChangeCount{{Original: "color", Converted: "colour", Count: 2, Category: report.CategorySpelling}}
+	b := []report.WordChangeCount{
+		{Original: "humor", Converted: "humour", Count: 1, Category: report.CategorySpelling},
+		{Original: "color", Converted: "colour", Count: 2, Category: report.CategorySpelling},
+	}
+
+	merged := report.MergeChangeDetails(a, b)
+	if len(merged) != 2 {
+		t.Fatalf("Expected 2 distinct substitutions, got %+v", merged)
+	}
+	if merged[0].Original != "color" || merged[0].Count != 4 {
+		t.Errorf("Expected color with count 4 first, got %+v", merged[0])
+	}
+}
+
+func TestCheckMaxChanges(t *testing.T) {
+	stats := report.ChangeStats{SpellingChanges: 3, UnitConversions: 1, QuoteChanges: 2}
+
+	if stats.TotalChanges() != 6 {
+		t.Errorf("Expected 6 total changes, got %d", stats.TotalChanges())
+	}
+	if err := report.CheckMaxChanges(stats, 0); err != nil {
+		t.Errorf("Expected a limit of 0 to disable the check, got %v", err)
+	}
+	if err := report.CheckMaxChanges(stats, 6); err != nil {
+		t.Errorf("Expected no error at the limit, got %v", err)
+	}
+
+	err := report.CheckMaxChanges(stats, 5)
+	var limitErr *report.MaxChangesError
+	if !errors.As(err, &limitErr) {
+		t.Fatalf("Expected a MaxChangesError, got %v", err)
+	}
+	if limitErr.Changes != 6 || limitErr.Limit != 5 {
+		t.Errorf("Unexpected error details: %+v", limitErr)
+	}
+}
+
 func TestUnitTypeDetection(t *testing.T) {
 	analyser := report.NewAnalyser(map[string]string{})
 
@@ -282,3 +368,131 @@ func TestUnitTypeDetection(t *testing.T) {
 		}
 	}
 }
+
+func TestFormatMarkdownReport(t *testing.T) {
+	files := []report.FileResult{
+		{
+			FilePath:   "docs/readme.md",
+			Original:   "The color is gray.\nUnchanged line.",
+			Converted:  "The colour is grey.\nUnchanged line.",
+			Stats:      report.ChangeStats{SpellingChanges: 2, QuoteChanges: 1},
+			HasChanges: true,
+		},
+		{
+			FilePath:   "notes|draft.txt",
+			Original:   "It is 5 miles away.",
+			Converted:  "It is 8 kilometres away.",
+			Stats:      report.ChangeStats{SpellingChanges: 1, UnitConversions: 1},
+			HasChanges: true,
+		},
+		{
+			FilePath:  "already.md",
+			Original:  "The colour is grey.",
+			Converted: "The colour is grey.",
+		},
+		{
+			FilePath: "missing.md",
+			Error:    errors.New("file not found"),
+		},
+	}
+
+	result := report.FormatMarkdownReport(files)
+
+	expectedContents := []string{
+		"**Files processed:** 4 | **Files with changes:** 2 | **Files with errors:** 1",
+		"| File | Spelling | Units | Quotes |",
+		"| `docs/readme.md` | 2 | 0 | 1 |",
+		"| `notes\\|draft.txt` | 1 | 1 | 0 |",
+		"| **Total** | **3** | **1** | **1** |",
+		"- `missing.md`: file not found",
+		"<details>",
+		"<summary><code>docs/readme.md</code></summary>",
+		"```diff",
+		"-The color is gray.",
+		"+The colour is grey.",
+		"</details>",
+	}
+	for _, expected := range expectedContents {
+		if !strings.Contains(result, expected) {
+			t.Errorf("Expected report to contain %q, got:\n%s", expected, result)
+		}
+	}
+
+	if strings.Contains(result, "`already.md`") {
+		t.Error("Expected unchanged files to be left out of the table")
+	}
+	if strings.Contains(result, "Unchanged line.") {
+		t.Error("Expected unchanged lines to be left out of the diff")
+	}
+
+	// The totals row must come after every file row
+	if strings.Index(result, "**Total**") < strings.Index(result, "`notes") {
+		t.Error("Expected the totals row at the bottom of the table")
+	}
+}
+
+func TestFormatMarkdownReport_NoChanges(t *testing.T) {
+	result := report.FormatMarkdownReport([]report.FileResult{
+		{FilePath: "a.md", Original: "colour", Converted: "colour"},
+	})
+
+	if !strings.Contains(result, "No changes required") {
+		t.Errorf("Expected no changes message, got:\n%s", result)
+	}
+	if strings.Contains(result, "<details>") {
+		t.Error("Expected no diff sections when nothing changed")
+	}
+}
+
+func TestFormatMarkdownReport_FenceLongerThanContent(t *testing.T) {
+	result := report.FormatMarkdownReport([]report.FileResult{
+		{
+			FilePath:   "guide.md",
+			Original:   "Set ```color``` here",
+			Converted:  "Set ```colour``` here",
+			HasChanges: true,
+		},
+	})
+
+	if !strings.Contains(result, "````diff") {
+		t.Errorf("Expected a fence longer than the backtick runs in the diff, got:\n%s", result)
+	}
+}
+
+func TestProcessResult(t *testing.T) {
+	var result report.ProcessResult
+	limitErr := report.CheckMaxChanges(report.ChangeStats{SpellingChanges: 5}, 2)
+	readErr := errors.New("file too large")
+
+	result.Add(report.FileOutcome{Path: "a.md", Status: report.StatusChanged, Stats: report.ChangeStats{SpellingChanges: 1}, Written: true})
+	result.AddSkipped("b.md", limitErr)
+	result.Add(report.FileOutcome{Path: "c.md", Status: report.StatusUnchanged})
+	result.AddFailed("d.md", readErr)
+
+	if len(result.Files) != 4 {
+		t.Fatalf("Expected 4 outcomes, got %d", len(result.Files))
+	}
+	if changed := result.WithStatus(report.StatusChanged); len(changed) != 1 || changed[0].Path != "a.md" || !changed[0].Written {
+		t.Errorf("Expected a.md to be the only changed file, got %+v", changed)
+	}
+
+	problems := result.Problems()
+	if len(problems) != 2 || problems[0].Path != "b.md" || problems[1].Path != "d.md" {
+		t.Fatalf("Expected b.md and d.md as problems in order, got %+v", problems)
+	}
+	var maxErr *report.MaxChangesError
+	if problems[0].Status != report.StatusSkipped || !errors.As(problems[0].Err, &maxErr) {
+		t.Errorf("Expected b.md to be skipped for exceeding the change limit, got %+v", problems[0])
+	}
+	if problems[1].Status != report.StatusFailed || !errors.Is(problems[1].Err, readErr) {
+		t.Errorf("Expected d.md to have failed with its read error, got %+v", problems[1])
+	}
+	if !result.HasFailures() {
+		t.Error("Expected HasFailures() to be true")
+	}
+
+	var empty report.ProcessResult
+	if empty.HasFailures() || len(empty.Problems()) != 0 {
+		t.Error("Expected an empty result to have no problems")
+	}
+}
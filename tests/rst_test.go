@@ -0,0 +1,139 @@
+package tests
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+const rstSample = `The Color Guide
+===============
+
+The color of the center is gray. See :func:` + "`color_center`" + ` and ` + "`the color center <https://example.com/color>`_" + `.
+
+.. code-block:: python
+   :caption: color.py
+
+   # Analyze the color
+   color = "gray"  # favorite color
+
+.. note:: The color may vary.
+
+   Check the center first.
+
+.. image:: images/color-wheel.png
+   :alt: color wheel
+
+.. _color-center: https://example.com/color-center
+
+Example::
+
+    color = gray
+    center()
+
+The color again.
+`
+
+const rstExpected = `The Colour Guide
+================
+
+The colour of the centre is grey. See :func:` + "`color_center`" + ` and ` + "`the colour centre <https://example.com/color>`_" + `.
+
+.. code-block:: python
+   :caption: color.py
+
+   # Analyse the colour
+   color = "gray"  # favourite colour
+
+.. note:: The colour may vary.
+
+   Check the centre first.
+
+.. image:: images/color-wheel.png
+   :alt: color wheel
+
+.. _color-center: https://example.com/color-center
+
+Example::
+
+    color = gray
+    center()
+
+The colour again.
+`
+
+func TestRSTConversion(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	if result := conv.ConvertRST(rstSample, true); result != rstExpected {
+		t.Errorf("ConvertRST() =\n%s\nexpected\n%s", result, rstExpected)
+	}
+	if result := conv.ConvertFileContent(rstSample, "guide.rst", true); result != rstExpected {
+		t.Errorf("Expected .rst files to be converted as reStructuredText, got\n%s", result)
+	}
+}
+
+func TestRSTMarkup(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"Inline literal", "Set ``color`` to change the color.\n", "Set ``color`` to change the colour.\n"},
+		{"Interpreted text", "The `color` role and `color center`_.\n", "The `color` role and `color center`_.\n"},
+		{"Reference", "See color_ for the color.\n", "See color_ for the colour.\n"},
+		{"Substitution reference", "The |color| color.\n", "The |color| colour.\n"},
+		{"Field list", ":color-mode: The color\n", ":color-mode: The colour\n"},
+		{"Comment", ".. The color comment\n", ".. The colour comment\n"},
+		{"Footnote", ".. [1] The color gray.\n", ".. [1] The colour grey.\n"},
+		{"Substitution definition", ".. |logo| image:: color.png\n", ".. |logo| image:: color.png\n"},
+		{"Raw directive", ".. raw:: html\n\n   <p>color</p>\n\nThe color.\n", ".. raw:: html\n\n   <p>color</p>\n\nThe colour.\n"},
+		{"Toctree", ".. toctree::\n   :maxdepth: 2\n\n   color\n   center\n", ".. toctree::\n   :maxdepth: 2\n\n   color\n   center\n"},
+		{"Domain directive", ".. py:function:: color(center)\n\n   Return the color.\n", ".. py:function:: color(center)\n\n   Return the colour.\n"},
+		{"Expanded literal block", "Example:\n\n::\n\n   color = gray\n\nThe color.\n", "Example:\n\n::\n\n   color = gray\n\nThe colour.\n"},
+		{"Doctest", ">>> color = 'gray'\n>>> color\n", ">>> color = 'gray'\n>>> color\n"},
+		{"Grid table", "+-------+\n| color |\n+-------+\n", "+-------+\n| color |\n+-------+\n"},
+		{"Simple table", "=====  =====\ncolor  gray\n=====  =====\n\nThe color.\n", "=====  =====\ncolor  gray\n=====  =====\n\nThe colour.\n"},
+		{"Overlined title", "=====\nColor\n=====\n", "======\nColour\n======\n"},
+		{"Long underline kept", "Color\n==========\n", "Colour\n==========\n"},
+		{"Transition", "The color.\n\n----------\n\nThe gray.\n", "The colour.\n\n----------\n\nThe grey.\n"},
+		{"Block quote", "Intro.\n\n   The color.\n", "Intro.\n\n   The colour.\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := conv.ConvertRST(tt.input, true); result != tt.expected {
+				t.Errorf("ConvertRST(%q) = %q, expected %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCLIRST(t *testing.T) {
+	cliPath := buildTestCLI(t)
+
+	doc := filepath.Join(t.TempDir(), "guide.rst")
+	if err := os.WriteFile(doc, []byte(rstSample), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := exec.Command(cliPath, "-raw", doc).CombinedOutput()
+	if err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+	}
+	if strings.TrimSpace(string(output)) != strings.TrimSpace(rstExpected) {
+		t.Errorf("Unexpected output:\n%s", output)
+	}
+}
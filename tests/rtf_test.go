@@ -0,0 +1,86 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+func TestRTFConversion(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "Plain paragraph",
+			input:    `{\rtf1\ansi\deff0 {\fonttbl{\f0 Times;}}\f0\fs24 The color of the center.\par}`,
+			expected: `{\rtf1\ansi\deff0 {\fonttbl{\f0 Times;}}\f0\fs24 The colour of the centre.\par}`,
+		},
+		{
+			name:     "Bold and italic runs",
+			input:    `{\rtf1\ansi\deff0\fs24 I love {\b color} and {\i flavor} in my {\b\i organization}.\par}`,
+			expected: `{\rtf1\ansi\deff0\fs24 I love {\b colour} and {\i flavour} in my {\b\i organisation}.\par}`,
+		},
+		{
+			name:     "Control words that look like words are preserved",
+			input:    "{\\rtf1\\ansi{\\colortbl;\\red255\\green0\\blue0;}\\cf1\\fs24 The color\\par\n}",
+			expected: "{\\rtf1\\ansi{\\colortbl;\\red255\\green0\\blue0;}\\cf1\\fs24 The colour\\par\n}",
+		},
+		{
+			name:     "Ignorable destinations and metadata are not converted",
+			input:    `{\rtf1{\info{\title color}}{\*\generator color;}\fs24 color\par}`,
+			expected: `{\rtf1{\info{\title color}}{\*\generator color;}\fs24 colour\par}`,
+		},
+		{
+			name:     "Escaped characters and unicode fallbacks are preserved",
+			input:    `{\rtf1\fs24 caf\'e9 \u8220?color\u8221? \{gray\}\par}`,
+			expected: `{\rtf1\fs24 caf\'e9 \u8220?colour\u8221? \{grey\}\par}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := conv.ConvertToBritish(tt.input, true)
+			if result != tt.expected {
+				t.Errorf("ConvertToBritish() =\n%s\nexpected\n%s", result, tt.expected)
+			}
+
+			// Routing by filename must give the same result
+			if fileResult := conv.ConvertFileContent(tt.input, "document.rtf", true); fileResult != tt.expected {
+				t.Errorf("ConvertFileContent() =\n%s\nexpected\n%s", fileResult, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRTFControlWordsSurvive(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	input := `{\rtf1\ansi\ansicpg1252\cocoartf2639{\fonttbl\f0\fswiss\fcharset0 Helvetica;}` + "\n" +
+		`\pard\tx566\pardirnatural\partightenfactor0` + "\n" +
+		`\f0\fs24 \cf0 The {\b analyze} step is {\i optimized}.\par}`
+	result := conv.ConvertToBritish(input, true)
+
+	controls := []string{`\fs24`, `\cf0`, `\pard`, `\tx566`, `\pardirnatural`, `\partightenfactor0`, `\cocoartf2639`, `\fcharset0`, `\ansicpg1252`}
+	for _, control := range controls {
+		if !strings.Contains(result, control) {
+			t.Errorf("Expected control word %q to survive conversion, got:\n%s", control, result)
+		}
+	}
+
+	for _, want := range []string{`{\b analyse}`, `{\i optimised}`} {
+		if !strings.Contains(result, want) {
+			t.Errorf("Expected %q in converted RTF, got:\n%s", want, result)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestServerChangeLineAndColumn(t *testing.T) {
+	baseURL, _ := startTestServer(t)
+
+	text := "café\nthe naïve color"
+	body, _ := json.Marshal(map[string]any{"text": text})
+	resp, err := http.Post(baseURL+"/convert", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Text    string `json:"text"`
+		Changes []struct {
+			Position int    `json:"position"`
+			Line     int    `json:"line"`
+			Column   int    `json:"column"`
+			Original string `json:"original"`
+		} `json:"changes"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if result.Text != "café\nthe naïve colour" || len(result.Changes) != 1 {
+		t.Fatalf("Expected one change to \"colour\", got %+v", result)
+	}
+
+	// "é" and "ï" are two bytes each: the position is a byte offset, the column counts characters
+	change := result.Changes[0]
+	if change.Original != "color" || change.Position != 17 || text[change.Position:change.Position+5] != "color" {
+		t.Errorf("Expected \"color\" at byte offset 17, got %+v", change)
+	}
+	if change.Line != 2 || change.Column != 11 {
+		t.Errorf("Expected \"color\" at line 2, column 11, got line %d, column %d", change.Line, change.Column)
+	}
+}
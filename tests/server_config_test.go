@@ -0,0 +1,137 @@
+package tests
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// configRequest sends a request with body to the server's /config endpoint, with token as a
+// bearer token unless it's empty, returning the status and body of the response
+func configRequest(t *testing.T, baseURL, method, body, token string) (int, string) {
+	t.Helper()
+	req, err := http.NewRequest(method, baseURL+"/config", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(data)
+}
+
+func TestServerConfigEndpoint(t *testing.T) {
+	home := t.TempDir()
+	baseURL, _ := startTestServer(t, "HOME="+home, "API_CONFIG_TOKEN=secret", "CORS_ORIGIN=http://localhost:3000")
+	configPath := filepath.Join(home, ".config", "m2e", "unit_config.json")
+
+	code, body := configRequest(t, baseURL, http.MethodGet, "", "")
+	if code != http.StatusOK || !strings.Contains(body, `"enabledUnitTypes"`) {
+		t.Fatalf("Expected the default config, got %d %q", code, body)
+	}
+
+	update := `{"enabledUnitTypes": ["length"], "spellingVariant": "oxford"}`
+	if code, body := configRequest(t, baseURL, http.MethodPut, update, ""); code != http.StatusUnauthorized {
+		t.Errorf("Expected 401 without the token, got %d %q", code, body)
+	}
+	if code, body := configRequest(t, baseURL, http.MethodPut, update, "wrong"); code != http.StatusUnauthorized {
+		t.Errorf("Expected 401 with the wrong token, got %d %q", code, body)
+	}
+	if _, err := os.Stat(configPath); !os.IsNotExist(err) {
+		t.Fatalf("Expected unauthorised requests not to write the config, got %v", err)
+	}
+
+	code, body = configRequest(t, baseURL, http.MethodPut, update, "secret")
+	if code != http.StatusOK || !strings.Contains(body, `"spellingVariant":"oxford"`) {
+		t.Fatalf("Expected the saved config, got %d %q", code, body)
+	}
+	if saved, err := os.ReadFile(configPath); err != nil || !strings.Contains(string(saved), "oxford") {
+		t.Errorf("Expected the config to be saved to %s, got %q, %v", configPath, saved, err)
+	}
+	code, body = configRequest(t, baseURL, http.MethodGet, "", "")
+	if code != http.StatusOK || !strings.Contains(body, `"spellingVariant":"oxford"`) || !strings.Contains(body, `"enabledUnitTypes":["length"]`) {
+		t.Errorf("Expected GET to return the saved config, got %d %q", code, body)
+	}
+
+	tests := []struct {
+		name         string
+		method       string
+		body         string
+		expectedCode int
+	}{
+		{"Invalid preferred target", http.MethodPut, `{"preferredTargets": {"feet": "furlongs"}}`, http.StatusBadRequest},
+		{"Invalid spelling", http.MethodPut, `{"spellingVariant": "american"}`, http.StatusBadRequest},
+		{"Invalid JSON", http.MethodPut, `{"enabledUnitTypes": `, http.StatusBadRequest},
+		{"Oversized", http.MethodPut, `{"excludePatterns": ["` + strings.Repeat("x", 2<<20) + `"]}`, http.StatusRequestEntityTooLarge},
+		{"POST", http.MethodPost, update, http.StatusMethodNotAllowed},
+		{"DELETE", http.MethodDelete, "", http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code, body := configRequest(t, baseURL, tt.method, tt.body, "secret"); code != tt.expectedCode {
+				t.Errorf("Expected %d, got %d %q", tt.expectedCode, code, body)
+			}
+		})
+	}
+
+	// Rejected updates leave the saved config alone
+	if saved, _ := os.ReadFile(configPath); !strings.Contains(string(saved), "oxford") {
+		t.Errorf("Expected the saved config to be kept, got %q", saved)
+	}
+}
+
+func TestServerConfigUpdatesDisabled(t *testing.T) {
+	home := t.TempDir()
+	baseURL, _ := startTestServer(t, "HOME="+home)
+
+	if code, body := configRequest(t, baseURL, http.MethodGet, "", ""); code != http.StatusOK {
+		t.Errorf("Expected GET to work without a token, got %d %q", code, body)
+	}
+	code, body := configRequest(t, baseURL, http.MethodPut, `{"enabledUnitTypes": ["length"]}`, "secret")
+	if code != http.StatusForbidden || !strings.Contains(body, "API_CONFIG_TOKEN") {
+		t.Errorf("Expected 403 without API_CONFIG_TOKEN, got %d %q", code, body)
+	}
+	if _, err := os.Stat(filepath.Join(home, ".config", "m2e", "unit_config.json")); !os.IsNotExist(err) {
+		t.Errorf("Expected no config to be written, got %v", err)
+	}
+
+	req, _ := http.NewRequest(http.MethodOptions, baseURL+"/config", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	resp.Body.Close()
+	if methods := resp.Header.Get("Access-Control-Allow-Methods"); strings.Contains(methods, "PUT") {
+		t.Errorf("Expected PUT not to be allowed by CORS, got %q", methods)
+	}
+
+	// A token is refused alongside the default wildcard origin
+	server := exec.Command(buildTestServer(t))
+	server.Env = append(os.Environ(), "API_PORT=0", "HOME="+t.TempDir(), "API_CONFIG_TOKEN=secret", "CORS_ORIGIN=")
+	done := make(chan error, 1)
+	if err := server.Start(); err != nil {
+		t.Fatalf("Failed to start server: %v", err)
+	}
+	go func() { done <- server.Wait() }()
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Error("Expected the server to refuse API_CONFIG_TOKEN with CORS_ORIGIN=*")
+		}
+	case <-time.After(10 * time.Second):
+		_ = server.Process.Kill()
+		t.Error("Expected the server to exit when API_CONFIG_TOKEN is set with CORS_ORIGIN=*")
+	}
+}
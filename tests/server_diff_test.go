@@ -0,0 +1,159 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sammcj/m2e/pkg/report"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	original := "The color.\nUnchanged.\nThe center."
+	converted := "The colour.\nUnchanged.\nThe centre."
+
+	expected := "--- notes.txt.orig\n+++ notes.txt\n" +
+		"@@ -1,1 +1,1 @@\n-The color.\n+The colour.\n" +
+		"@@ -3,1 +3,1 @@\n-The center.\n+The centre.\n"
+	if diff := report.UnifiedDiff(original, converted, "notes.txt", false); diff != expected {
+		t.Errorf("UnifiedDiff() = %q, expected %q", diff, expected)
+	}
+
+	if diff := report.UnifiedDiff(original, original, "notes.txt", false); diff != "" {
+		t.Errorf("Expected no diff for unchanged text, got %q", diff)
+	}
+	if diff := report.UnifiedDiff(original, original, "notes.txt", true); diff != "" {
+		t.Errorf("Expected no inline diff for unchanged text, got %q", diff)
+	}
+	if diff := report.UnifiedDiff(original, converted, "notes.txt", true); !strings.Contains(diff, "u") || strings.Contains(diff, "@@") {
+		t.Errorf("Expected a character-level inline diff, got %q", diff)
+	}
+}
+
+func TestChangedLines(t *testing.T) {
+	original := "The color.\nUnchanged.\nThe center."
+	converted := "The colour.\nUnchanged.\nThe centre."
+
+	expected := []report.ChangedLine{
+		{Number: 1, Original: "The color.", Converted: "The colour."},
+		{Number: 3, Original: "The center.", Converted: "The centre."},
+	}
+	if lines := report.ChangedLines(original, converted); !slices.Equal(lines, expected) {
+		t.Errorf("ChangedLines() = %+v, expected %+v", lines, expected)
+	}
+	if lines := report.ChangedLines(original, original); lines != nil {
+		t.Errorf("Expected no changed lines for unchanged text, got %+v", lines)
+	}
+
+	// Re-wrapping changes the number of lines, so lines are matched with a line diff
+	rewrapped := "The colour.\nUnchanged.\nThe\ncentre."
+	expected = []report.ChangedLine{
+		{Number: 1, Original: "The color.", Converted: "The colour."},
+		{Number: 3, Original: "The center.", Converted: "The"},
+		{Number: 4, Original: "", Converted: "centre."},
+	}
+	if lines := report.ChangedLines(original, rewrapped); !slices.Equal(lines, expected) {
+		t.Errorf("ChangedLines() = %+v, expected %+v", lines, expected)
+	}
+}
+
+// buildTestServer builds the API server, returning the path to its binary
+func buildTestServer(t *testing.T) string {
+	t.Helper()
+	serverPath := filepath.Join(t.TempDir(), "m2e-server")
+	if output, err := exec.Command("go", "build", "-o", serverPath, "../cmd/m2e-server").CombinedOutput(); err != nil {
+		t.Fatalf("Failed to build server: %v\n%s", err, output)
+	}
+	return serverPath
+}
+
+// startTestServer builds and starts the API server on a free port with extra environment
+// variables, returning its /api/v1 URL once it answers health checks, and its process. The
+// server is stopped when the test ends.
+func startTestServer(t *testing.T, env ...string) (string, *exec.Cmd) {
+	t.Helper()
+	serverPath := buildTestServer(t)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to find a free port: %v", err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	server := exec.Command(serverPath)
+	server.Env = append(os.Environ(), fmt.Sprintf("API_PORT=%d", port), "HOME="+t.TempDir())
+	server.Env = append(server.Env, env...)
+	if err := server.Start(); err != nil {
+		t.Fatalf("Failed to start server: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = server.Process.Kill()
+		_ = server.Wait()
+	})
+
+	baseURL := fmt.Sprintf("http://127.0.0.1:%d/api/v1", port)
+	for start := time.Now(); ; time.Sleep(50 * time.Millisecond) {
+		if resp, err := http.Get(baseURL + "/health"); err == nil {
+			resp.Body.Close()
+			break
+		}
+		if time.Since(start) > 10*time.Second {
+			t.Fatal("Server didn't start")
+		}
+	}
+	return baseURL, server
+}
+
+func TestServerDiffEndpoint(t *testing.T) {
+	baseURL, _ := startTestServer(t)
+
+	post := func(path, accept string) (*http.Response, string) {
+		body, _ := json.Marshal(map[string]any{"text": "The color is gray.\nFine.", "filename": "notes.txt"})
+		req, _ := http.NewRequest(http.MethodPost, baseURL+path, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(resp.Body)
+		return resp, string(data)
+	}
+
+	expected := "--- notes.txt.orig\n+++ notes.txt\n@@ -1,1 +1,1 @@\n-The color is gray.\n+The colour is grey.\n"
+
+	_, body := post("/diff", "")
+	var result struct {
+		Diff string `json:"diff"`
+	}
+	if err := json.Unmarshal([]byte(body), &result); err != nil {
+		t.Fatalf("Expected a JSON response, got %q: %v", body, err)
+	}
+	if result.Diff != expected {
+		t.Errorf("Expected diff %q, got %q", expected, result.Diff)
+	}
+
+	resp, body := post("/diff", "text/x-diff")
+	if !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/x-diff") || body != expected {
+		t.Errorf("Expected a text/x-diff response, got %q: %q", resp.Header.Get("Content-Type"), body)
+	}
+
+	resp, body = post("/diff?inline=true", "text/x-diff")
+	if resp.StatusCode != http.StatusOK || strings.Contains(body, "@@") {
+		t.Errorf("Expected an inline diff, got %d: %q", resp.StatusCode, body)
+	}
+}
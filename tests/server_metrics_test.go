@@ -0,0 +1,49 @@
+package tests
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestServerReadyAndMetrics(t *testing.T) {
+	baseURL, _ := startTestServer(t)
+
+	get := func(url string) (int, string) {
+		resp, err := http.Get(url)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(data)
+	}
+
+	if code, body := get(baseURL + "/ready"); code != http.StatusOK || body != "READY" {
+		t.Errorf("Expected /ready to answer 200 READY, got %d %q", code, body)
+	}
+
+	resp, err := http.Post(baseURL+"/convert", "application/json", strings.NewReader(`{"text": "The color is gray."}`))
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	code, metrics := get(strings.TrimSuffix(baseURL, "/api/v1") + "/metrics")
+	if code != http.StatusOK {
+		t.Fatalf("Expected /metrics to answer 200, got %d", code)
+	}
+	for _, expected := range []string{
+		`m2e_http_requests_total{path="/api/v1/convert",code="200"} 1`,
+		`m2e_http_requests_total{path="/api/v1/ready",code="200"} 1`,
+		"# TYPE m2e_conversion_duration_seconds histogram",
+		`m2e_conversion_duration_seconds_bucket{le="+Inf"} 1`,
+		"m2e_conversion_duration_seconds_count 1",
+		"m2e_changes_applied_total 2",
+	} {
+		if !strings.Contains(metrics, expected) {
+			t.Errorf("Expected metrics to contain %q, got:\n%s", expected, metrics)
+		}
+	}
+}
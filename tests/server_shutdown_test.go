@@ -0,0 +1,45 @@
+package tests
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestServerGracefulShutdown(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("SIGTERM can't be sent on Windows")
+	}
+	_, server := startTestServer(t, "API_SHUTDOWN_TIMEOUT=5s")
+
+	if err := server.Process.Signal(syscall.SIGTERM); err != nil {
+		t.Fatalf("Failed to signal server: %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- server.Wait() }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Expected the server to exit cleanly on SIGTERM, got %v", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("Server didn't shut down")
+	}
+}
+
+func TestServerInvalidTimeout(t *testing.T) {
+	server := exec.Command(buildTestServer(t))
+	server.Env = append(os.Environ(), "API_PORT=0", "HOME="+t.TempDir(), "API_WRITE_TIMEOUT=soon")
+	output, err := server.CombinedOutput()
+	if err == nil {
+		t.Fatalf("Expected an invalid timeout to stop the server, got output %q", output)
+	}
+	if !strings.Contains(string(output), "API_WRITE_TIMEOUT") {
+		t.Errorf("Expected the error to name the variable, got %q", output)
+	}
+}
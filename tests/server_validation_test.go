@@ -0,0 +1,48 @@
+package tests
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestServerRequestValidation(t *testing.T) {
+	baseURL, _ := startTestServer(t, "API_MAX_REQUEST_BYTES=1024")
+
+	post := func(path string, body []byte) (int, string) {
+		resp, err := http.Post(baseURL+path, "application/json", bytes.NewReader(body))
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(data)
+	}
+
+	tests := []struct {
+		name         string
+		body         []byte
+		expectedCode int
+		expectedBody string
+	}{
+		{"Valid", []byte(`{"text": "The color."}`), http.StatusOK, "colour"},
+		{"Empty text", []byte(`{"text": ""}`), http.StatusOK, `"text":""`},
+		{"Oversized", []byte(`{"text": "` + strings.Repeat("color ", 200) + `"}`), http.StatusRequestEntityTooLarge, "1024 byte limit"},
+		{"Invalid UTF-8", []byte("{\"text\": \"The color \xff\xfe.\"}"), http.StatusBadRequest, "valid UTF-8"},
+		{"Missing text", []byte(`{"convert_units": true}`), http.StatusBadRequest, `must include "text"`},
+		{"Invalid JSON", []byte(`{"text": `), http.StatusBadRequest, "Error decoding request body"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, path := range []string{"/convert", "/diff"} {
+				code, body := post(path, tt.body)
+				if code != tt.expectedCode || (path == "/convert" && !strings.Contains(body, tt.expectedBody)) {
+					t.Errorf("%s: expected %d containing %q, got %d %q", path, tt.expectedCode, tt.expectedBody, code, body)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package tests
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/sammcj/m2e/pkg/fileutil"
+)
+
+// writeDatedFiles writes files under dir, each with its content set to "The color.\n" and its
+// modification time set to the given time
+func writeDatedFiles(t *testing.T, dir string, files map[string]time.Time) {
+	t.Helper()
+	for name, modTime := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("The color.\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestFilterModifiedSince(t *testing.T) {
+	dir := t.TempDir()
+	writeDatedFiles(t, dir, map[string]time.Time{
+		"old.md":      time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		"new.md":      time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+		"docs/new.md": time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+	})
+
+	files, err := fileutil.FindTextFiles(dir)
+	if err != nil {
+		t.Fatalf("FindTextFiles failed: %v", err)
+	}
+	if len(files) != 3 || files[0].ModTime.IsZero() {
+		t.Fatalf("Expected three files with modification times, got %+v", files)
+	}
+
+	var names []string
+	for _, file := range fileutil.FilterModifiedSince(files, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		names = append(names, filepath.ToSlash(file.RelativePath))
+	}
+	slices.Sort(names)
+	if !slices.Equal(names, []string{"docs/new.md", "new.md"}) {
+		t.Errorf("Expected only the new files, got %v", names)
+	}
+
+	if got := fileutil.FilterModifiedSince(files, time.Time{}); len(got) != 3 {
+		t.Errorf("Expected a zero time to keep every file, got %d", len(got))
+	}
+}
+
+func TestSinceDateCLI(t *testing.T) {
+	cliPath := buildTestCLI(t)
+	dir := t.TempDir()
+	writeDatedFiles(t, dir, map[string]time.Time{
+		"old.md": time.Date(2020, 1, 1, 0, 0, 0, 0, time.Local),
+		"new.md": time.Now(),
+	})
+
+	out, err := exec.Command(cliPath, "-save", "-since", "2024-01-01", dir).CombinedOutput()
+	if err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, out)
+	}
+	for name, expected := range map[string]string{"old.md": "The color.\n", "new.md": "The colour.\n"} {
+		if content, _ := os.ReadFile(filepath.Join(dir, name)); string(content) != expected {
+			t.Errorf("%s: expected %q, got %q", name, expected, content)
+		}
+	}
+
+	// A file named directly isn't filtered
+	out, err = exec.Command(cliPath, "-save", "-since=2024-01-01", filepath.Join(dir, "old.md")).CombinedOutput()
+	if err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, out)
+	}
+	if content, _ := os.ReadFile(filepath.Join(dir, "old.md")); string(content) != "The colour.\n" {
+		t.Errorf("Expected a file given directly to be converted, got %q", content)
+	}
+}
+
+func TestSinceGitCLI(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	cliPath := buildTestCLI(t)
+
+	repo := t.TempDir()
+	git := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, output)
+		}
+	}
+	write := func(name, content string) {
+		path := filepath.Join(repo, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	git("init", "-q")
+	git("config", "user.email", "test@example.com")
+	git("config", "user.name", "Test")
+	write("docs/untouched.md", "The color.\n")
+	write("docs/committed.md", "The color.\n")
+	write("docs/edited.md", "The color.\n")
+	git("add", ".")
+	git("commit", "-q", "-m", "initial")
+	write("docs/committed.md", "The color and flavor.\n")
+	git("commit", "-q", "-am", "second")
+	write("docs/edited.md", "The color and center.\n")
+	write("docs/untracked.md", "The color.\n")
+
+	// Run from the repository root on a subdirectory, so paths are matched across the prefix
+	cmd := exec.Command(cliPath, "-save", "-since", "HEAD~1", "docs")
+	cmd.Dir = repo
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, out)
+	}
+
+	expected := map[string]string{
+		"docs/untouched.md": "The color.\n",
+		"docs/committed.md": "The colour and flavour.\n",
+		"docs/edited.md":    "The colour and centre.\n",
+		"docs/untracked.md": "The colour.\n",
+	}
+	for name, want := range expected {
+		if content, _ := os.ReadFile(filepath.Join(repo, name)); string(content) != want {
+			t.Errorf("%s: expected %q, got %q", name, want, content)
+		}
+	}
+
+	cmd = exec.Command(cliPath, "-since", "no-such-revision", "docs")
+	cmd.Dir = repo
+	if out, err := cmd.CombinedOutput(); err == nil {
+		t.Errorf("Expected an unknown revision to fail, got %s", out)
+	}
+}
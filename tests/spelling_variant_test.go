@@ -0,0 +1,112 @@
+package tests
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+func TestSpellingVariant(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	input := "We organize and analyze the colorized data about color in the organization."
+	tests := []struct {
+		variant  converter.SpellingVariant
+		expected string
+	}{
+		{converter.SpellingISE, "We organise and analyse the colourised data about colour in the organisation."},
+		{converter.SpellingIZE, "We organize and analyze the colourized data about colour in the organization."},
+		{converter.SpellingOxfordIZE, "We organize and analyse the colourized data about colour in the organization."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.variant.String(), func(t *testing.T) {
+			conv.SetSpellingVariant(tt.variant)
+			defer conv.SetSpellingVariant(converter.SpellingISE)
+
+			if result := conv.ConvertToBritish(input, true); result != tt.expected {
+				t.Errorf("ConvertToBritish(%q) = %q, expected %q", input, result, tt.expected)
+			}
+		})
+	}
+
+	t.Run("Kept -ize words are not suggested", func(t *testing.T) {
+		conv.SetSpellingVariant(converter.SpellingOxfordIZE)
+		defer conv.SetSpellingVariant(converter.SpellingISE)
+
+		if suggestions := conv.SuggestAmericanisms("organize operationalize"); len(suggestions) != 0 {
+			t.Errorf("Expected no -ize suggestions with Oxford spelling, got %+v", suggestions)
+		}
+	})
+}
+
+func TestApplySpellingVariant(t *testing.T) {
+	dict := map[string]string{
+		"organize":    "organise",
+		"analyzing":   "analysing",
+		"colorize":    "colourise",
+		"parallelizm": "parallelism",
+		"color":       "colour",
+	}
+
+	resolved := converter.ApplySpellingVariant(dict, converter.SpellingOxfordIZE)
+	expected := map[string]string{
+		"analyzing":   "analysing",
+		"colorize":    "colourize",
+		"parallelizm": "parallelism",
+		"color":       "colour",
+	}
+	if len(resolved) != len(expected) {
+		t.Errorf("Expected %d entries, got %v", len(expected), resolved)
+	}
+	for american, british := range expected {
+		if resolved[american] != british {
+			t.Errorf("%s: expected %q, got %q", american, british, resolved[american])
+		}
+	}
+
+	if dict["organize"] != "organise" {
+		t.Errorf("Expected the input dictionary to be left unchanged")
+	}
+}
+
+func TestParseSpellingVariant(t *testing.T) {
+	for name, expected := range map[string]converter.SpellingVariant{
+		"ise":    converter.SpellingISE,
+		"IZE":    converter.SpellingIZE,
+		"oxford": converter.SpellingOxfordIZE,
+	} {
+		variant, err := converter.ParseSpellingVariant(name)
+		if err != nil || variant != expected {
+			t.Errorf("ParseSpellingVariant(%q) = %v, %v; expected %v", name, variant, err, expected)
+		}
+	}
+
+	if _, err := converter.ParseSpellingVariant("american"); err == nil {
+		t.Errorf("Expected an error for an unknown variant")
+	}
+}
+
+func TestCLISpellingVariant(t *testing.T) {
+	cliPath := buildTestCLI(t)
+
+	cmd := exec.Command(cliPath, "-spelling=ize", "-raw")
+	cmd.Stdin = strings.NewReader("Organize the color palette.")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+	}
+	if !strings.Contains(string(output), "Organize the colour palette.") {
+		t.Errorf("Expected -ize to be kept, got %q", output)
+	}
+
+	output, err = exec.Command(cliPath, "-spelling", "canadian", "color").CombinedOutput()
+	if err == nil || !strings.Contains(string(output), "unknown spelling variant") {
+		t.Errorf("Expected an unknown variant error, got %v: %q", err, output)
+	}
+}
@@ -0,0 +1,131 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/report"
+)
+
+func TestStatsTable(t *testing.T) {
+	var table report.StatsTable
+	table.Add("a.md", report.ChangeStats{TotalWords: 10, SpellingChanges: 2, UnitConversions: 1})
+	table.Add("b, c.md", report.ChangeStats{TotalWords: 4, QuoteChanges: 3})
+
+	var csvOut bytes.Buffer
+	if err := table.WriteCSV(&csvOut); err != nil {
+		t.Fatalf("WriteCSV failed: %v", err)
+	}
+	expected := "file,words,spelling,units,quotes\n" +
+		"a.md,10,2,1,0\n" +
+		"\"b, c.md\",4,0,0,3\n" +
+		"total,14,2,1,3\n"
+	if csvOut.String() != expected {
+		t.Errorf("Expected CSV\n%s\ngot\n%s", expected, csvOut.String())
+	}
+	if changes := table.Total.Changes(); changes != 6 {
+		t.Errorf("Expected 6 changes in total, got %d", changes)
+	}
+
+	var jsonOut bytes.Buffer
+	if err := table.WriteJSON(&jsonOut); err != nil {
+		t.Fatalf("WriteJSON failed: %v", err)
+	}
+	var decoded report.StatsTable
+	if err := json.Unmarshal(jsonOut.Bytes(), &decoded); err != nil {
+		t.Fatalf("Failed to decode JSON %s: %v", jsonOut.String(), err)
+	}
+	if len(decoded.Files) != 2 || decoded.Files[1].Path != "b, c.md" || decoded.Files[1].QuoteChanges != 3 {
+		t.Errorf("Expected the JSON to list both files, got %+v", decoded.Files)
+	}
+	if decoded.Total != table.Total {
+		t.Errorf("Expected the JSON total %+v, got %+v", table.Total, decoded.Total)
+	}
+
+	// An empty run lists no files rather than null
+	jsonOut.Reset()
+	if err := (&report.StatsTable{}).WriteJSON(&jsonOut); err != nil {
+		t.Fatalf("WriteJSON failed: %v", err)
+	}
+	if !strings.Contains(jsonOut.String(), `"files": []`) {
+		t.Errorf("Expected an empty file list, got %s", jsonOut.String())
+	}
+}
+
+func TestStatsFormatCLI(t *testing.T) {
+	cliPath := buildTestCLI(t)
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.md":     "The color and the center.\n",
+		"b.txt":    "Nothing to change.\n",
+		"sub/c.md": "The flavor.\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cmd := exec.Command(cliPath, "-stats-format=csv", dir)
+	cmd.Env = append(os.Environ(), "HOME="+t.TempDir())
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, out)
+	}
+	expected := "file,words,spelling,units,quotes\n" +
+		filepath.Join(dir, "a.md") + ",5,2,0,0\n" +
+		filepath.Join(dir, "b.txt") + ",3,0,0,0\n" +
+		filepath.Join(dir, "sub", "c.md") + ",2,1,0,0\n" +
+		"total,10,3,0,0\n"
+	if string(out) != expected {
+		t.Errorf("Expected\n%s\ngot\n%s", expected, out)
+	}
+	for name, content := range files {
+		if got, _ := os.ReadFile(filepath.Join(dir, name)); string(got) != content {
+			t.Errorf("Expected %s to be left alone, got %q", name, got)
+		}
+	}
+
+	cmd = exec.Command(cliPath, "-stats-format", "json", "-stats", filepath.Join(dir, "a.md"))
+	cmd.Env = append(os.Environ(), "HOME="+t.TempDir())
+	out, err = cmd.Output()
+	if err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, out)
+	}
+	var table report.StatsTable
+	if err := json.Unmarshal(out, &table); err != nil {
+		t.Fatalf("Failed to decode JSON %s: %v", out, err)
+	}
+	if len(table.Files) != 1 || table.Files[0].Path != filepath.Join(dir, "a.md") || table.Total.SpellingChanges != 2 {
+		t.Errorf("Expected one file with 2 spelling changes, got %+v", table)
+	}
+
+	cmd = exec.Command(cliPath, "-stats-format=csv", "-exit-on-change", filepath.Join(dir, "a.md"))
+	cmd.Env = append(os.Environ(), "HOME="+t.TempDir())
+	err = cmd.Run()
+	if exitErr, ok := err.(*exec.ExitError); !ok || exitErr.ExitCode() != 1 {
+		t.Errorf("Expected exit code 1 with -exit-on-change, got %v", err)
+	}
+
+	cmd = exec.Command(cliPath, "-stats-format=xml", dir)
+	err = cmd.Run()
+	if exitErr, ok := err.(*exec.ExitError); !ok || exitErr.ExitCode() != 2 {
+		t.Errorf("Expected an unsupported format to fail with a usage error, got %v", err)
+	}
+
+	cmd = exec.Command(cliPath, "-stats-format=csv", "-diff", dir)
+	err = cmd.Run()
+	if exitErr, ok := err.(*exec.ExitError); !ok || exitErr.ExitCode() != 2 {
+		t.Errorf("Expected -stats-format -diff to fail with a usage error, got %v", err)
+	}
+}
@@ -0,0 +1,97 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+func TestConvertSubtitles(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		filePath     string
+		input        string
+		expected     string
+		warningLines []int
+	}{
+		{
+			name:     "SRT dialogue converted, cue numbers and timings preserved",
+			filePath: "episode.srt",
+			input:    "1\n00:00:01,000 --> 00:00:03,500\nWhat color is the center?\n\n2\n00:00:04,000 --> 00:00:06,000\nI realize it's gray.\nMy favorite.\n",
+			expected: "1\n00:00:01,000 --> 00:00:03,500\nWhat colour is the centre?\n\n2\n00:00:04,000 --> 00:00:06,000\nI realise it's grey.\nMy favourite.\n",
+		},
+		{
+			name:     "SRT formatting tags and CRLF preserved",
+			filePath: "episode.SRT",
+			input:    "1\r\n00:00:01,000 --> 00:00:03,500\r\n{\\an8}<font color=\"red\">The color</font> of my <i>neighbor</i>\r\n",
+			expected: "1\r\n00:00:01,000 --> 00:00:03,500\r\n{\\an8}<font color=\"red\">The colour</font> of my <i>neighbour</i>\r\n",
+		},
+		{
+			name:         "SRT malformed timestamp left untouched and reported",
+			filePath:     "episode.srt",
+			input:        "1\n00:00:01,000 -> 00:00:03,500\nThe color\n\n2\n00:00:04,000 --> 00:00:06,000\nThe center\n",
+			expected:     "1\n00:00:01,000 -> 00:00:03,500\nThe colour\n\n2\n00:00:04,000 --> 00:00:06,000\nThe centre\n",
+			warningLines: []int{2},
+		},
+		{
+			name:         "SRT block without timing line left untouched",
+			filePath:     "episode.srt",
+			input:        "1\n00:00:01,000 --> 00:00:03,500\nThe color\n\nStray color text\n",
+			expected:     "1\n00:00:01,000 --> 00:00:03,500\nThe colour\n\nStray color text\n",
+			warningLines: []int{5},
+		},
+		{
+			name:     "VTT header, notes, identifiers and settings preserved",
+			filePath: "episode.vtt",
+			input:    "WEBVTT - color captions\nKind: captions\n\nNOTE the color of this note\n\ncolor-cue\n00:01.000 --> 00:03.000 align:start\n<v Narrator>The color center\n\n01:00:05.000 --> 01:00:07.000\nFavorite\n",
+			expected: "WEBVTT - color captions\nKind: captions\n\nNOTE the color of this note\n\ncolor-cue\n00:01.000 --> 00:03.000 align:start\n<v Narrator>The colour centre\n\n01:00:05.000 --> 01:00:07.000\nFavourite\n",
+		},
+		{
+			name:         "VTT malformed timestamp left untouched and reported",
+			filePath:     "episode.vtt",
+			input:        "WEBVTT\n\n00:61.000 --> 00:62.000\nThe color\n",
+			expected:     "WEBVTT\n\n00:61.000 --> 00:62.000\nThe colour\n",
+			warningLines: []int{3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, warnings := conv.ConvertSubtitles(tt.input, tt.filePath, true)
+			if result != tt.expected {
+				t.Errorf("ConvertSubtitles() = %q, expected %q", result, tt.expected)
+			}
+			if len(warnings) != len(tt.warningLines) {
+				t.Fatalf("Expected %d warning(s), got %v", len(tt.warningLines), warnings)
+			}
+			for i, line := range tt.warningLines {
+				if warnings[i].Line != line {
+					t.Errorf("Expected warning on line %d, got %v", line, warnings[i])
+				}
+			}
+		})
+	}
+}
+
+func TestConvertFileContentRoutesSubtitles(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	input := "1\n00:00:01,000 --> 00:00:03,500\nThe color\n"
+	result := conv.ConvertFileContent(input, "clip.srt", true)
+	if !strings.Contains(result, "00:00:01,000 --> 00:00:03,500\nThe colour") {
+		t.Errorf("Expected SRT dialogue to be converted with timing preserved, got %q", result)
+	}
+
+	if !converter.IsSubtitleFile("captions.VTT") || converter.IsSubtitleFile("notes.txt") {
+		t.Error("IsSubtitleFile misclassified a file")
+	}
+}
@@ -0,0 +1,90 @@
+package tests
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+func TestSuggestAmericanisms(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	input := "We operationalize the plan.\nOperationalize it again and reanalyze the favorful results."
+	suggestions := conv.SuggestAmericanisms(input)
+
+	expected := []converter.Suggestion{
+		{Word: "operationalize", Suggested: "operationalise", Rule: "-ize → -ise", Line: 1, Count: 2},
+		{Word: "reanalyze", Suggested: "reanalyse", Rule: "-yze → -yse", Line: 2, Count: 1},
+		{Word: "favorful", Suggested: "favourful", Rule: "-or → -our", Line: 2, Count: 1},
+	}
+	if len(suggestions) != len(expected) {
+		t.Fatalf("Expected %d suggestions, got %+v", len(expected), suggestions)
+	}
+	for i, want := range expected {
+		if suggestions[i] != want {
+			t.Errorf("Suggestion %d = %+v, expected %+v", i, suggestions[i], want)
+		}
+	}
+
+	if result := conv.ConvertToBritish(input, true); !strings.Contains(result, "operationalize") {
+		t.Errorf("Expected suggestions not to affect conversion, got %q", result)
+	}
+}
+
+func TestSuggestAmericanisms_SkipsKnownAndBritishWords(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	// Dictionary words, agent nouns and words spelt the same in British English
+	input := "The color, the organize step, the actor, doctor, error, floor, major, razor, size, prize and seize."
+	if suggestions := conv.SuggestAmericanisms(input); len(suggestions) != 0 {
+		t.Errorf("Expected no suggestions, got %+v", suggestions)
+	}
+}
+
+func TestSuggestAmericanisms_PreservesCase(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	suggestions := conv.SuggestAmericanisms("Operationalize and OPERATIONALIZATION")
+	if len(suggestions) != 2 {
+		t.Fatalf("Expected 2 suggestions, got %+v", suggestions)
+	}
+	if suggestions[0].Suggested != "Operationalise" || suggestions[1].Suggested != "OPERATIONALISATION" {
+		t.Errorf("Unexpected case handling: %+v", suggestions)
+	}
+}
+
+func TestCLISuggest(t *testing.T) {
+	cliPath := buildTestCLI(t)
+
+	cmd := exec.Command(cliPath, "-suggest")
+	cmd.Stdin = strings.NewReader("The color of the plan.\nWe operationalize it.")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v\nOutput: %s", err, output)
+	}
+
+	outputStr := string(output)
+	if !strings.Contains(outputStr, "stdin:2: operationalize → operationalise (-ize → -ise)") {
+		t.Errorf("Expected suggestion in output, got %q", outputStr)
+	}
+	if strings.Contains(outputStr, "colour") {
+		t.Errorf("Expected -suggest not to convert text, got %q", outputStr)
+	}
+
+	cmd = exec.Command(cliPath, "-suggest", "-exit-on-change")
+	cmd.Stdin = strings.NewReader("We operationalize it.")
+	if err := cmd.Run(); err == nil {
+		t.Error("Expected exit status 1 with -exit-on-change when suggestions are found")
+	}
+}
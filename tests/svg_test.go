@@ -0,0 +1,62 @@
+package tests
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+func TestSVGConversion(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	original, err := os.ReadFile(filepath.Join("testdata", "legend.svg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	converted := conv.ConvertFileContent(string(original), "legend.svg", true)
+
+	// Only the text labels, title and description change
+	expected := strings.NewReplacer(
+		"<title>Color Legend</title>", "<title>Colour Legend</title>",
+		"center color &amp; gray areas", "centre colour &amp; grey areas",
+		`class="color-label">Color Legend</text>`, `class="color-label">Colour Legend</text>`,
+		`<tspan font-weight="bold">gray</tspan>`, `<tspan font-weight="bold">grey</tspan>`,
+		`<tspan dy="1.2em">optimized</tspan>`, `<tspan dy="1.2em">optimised</tspan>`,
+		"]]> center</text>", "]]> centre</text>",
+	).Replace(string(original))
+	if converted != expected {
+		t.Errorf("Unexpected SVG conversion.\nExpected:\n%s\nGot:\n%s", expected, converted)
+	}
+
+	if again := conv.ConvertFileContent(converted, "legend.svg", true); again != converted {
+		t.Errorf("Expected converting the SVG again to change nothing, got:\n%s", again)
+	}
+}
+
+func TestSVGCLI(t *testing.T) {
+	cliPath := buildTestCLI(t)
+
+	path := filepath.Join(t.TempDir(), "diagram.svg")
+	svg := `<svg xmlns="http://www.w3.org/2000/svg"><text id="color" fill="gray">Color Legend</text></svg>` + "\n"
+	if err := os.WriteFile(path, []byte(svg), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := exec.Command(cliPath, "-save", path)
+	cmd.Env = append(os.Environ(), "HOME="+t.TempDir())
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+	}
+	expected := `<svg xmlns="http://www.w3.org/2000/svg"><text id="color" fill="gray">Colour Legend</text></svg>` + "\n"
+	if got, _ := os.ReadFile(path); string(got) != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+}
@@ -0,0 +1,81 @@
+package tests
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/report"
+)
+
+func TestTextChanges(t *testing.T) {
+	original := "The color\nof the gray center.\n"
+	converted := "The colour\nof the grey centre.\n"
+
+	changes := report.TextChanges(original, converted)
+	expected := []report.TextChange{
+		{Start: 4, End: 9, Line: 1, Original: "color", Converted: "colour"},
+		{Start: 17, End: 21, Line: 2, Original: "gray", Converted: "grey"},
+		{Start: 22, End: 28, Line: 2, Original: "center", Converted: "centre"},
+	}
+	if !reflect.DeepEqual(changes, expected) {
+		t.Fatalf("TextChanges() = %+v, expected %+v", changes, expected)
+	}
+
+	if result := report.ApplyTextChanges(original, changes); result != converted {
+		t.Errorf("Applying every change = %q, expected %q", result, converted)
+	}
+	if result := report.ApplyTextChanges(original, []report.TextChange{changes[0], changes[2]}); result != "The colour\nof the gray centre.\n" {
+		t.Errorf("Applying a subset of changes = %q", result)
+	}
+	if result := report.ApplyTextChanges(original, nil); result != original {
+		t.Errorf("Applying no changes = %q, expected the original", result)
+	}
+}
+
+func TestCLIInteractiveNeedsTerminal(t *testing.T) {
+	cliPath := buildTestCLI(t)
+
+	path := filepath.Join(t.TempDir(), "notes.md")
+	if err := os.WriteFile(path, []byte("The color\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := exec.Command(cliPath, "-interactive", path)
+	cmd.Stdin = strings.NewReader("y\n")
+	output, err := cmd.CombinedOutput()
+	if exitErr, ok := err.(*exec.ExitError); !ok || exitErr.ExitCode() != 2 {
+		t.Fatalf("Expected a usage error without a terminal, got %v\n%s", err, output)
+	}
+	if !strings.Contains(string(output), "terminal") {
+		t.Errorf("Expected the error to mention the terminal, got %s", output)
+	}
+
+	saved, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(saved) != "The color\n" {
+		t.Errorf("Expected the file to be left untouched, got %q", saved)
+	}
+
+	// /dev/null is a character device but not a terminal
+	devNull, err := os.Open(os.DevNull)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer devNull.Close()
+	cmd = exec.Command(cliPath, "-interactive", path)
+	cmd.Stdin = devNull
+	output, err = cmd.CombinedOutput()
+	if exitErr, ok := err.(*exec.ExitError); !ok || exitErr.ExitCode() != 2 {
+		t.Errorf("Expected a usage error with stdin from %s, got %v\n%s", os.DevNull, err, output)
+	}
+
+	if err := exec.Command(cliPath, "-interactive", "-diff", path).Run(); err == nil {
+		t.Error("Expected -interactive with -diff to fail")
+	}
+}
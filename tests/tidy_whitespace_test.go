@@ -0,0 +1,86 @@
+package tests
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+// newAnnotationStrippingConverter returns a converter that converts units, keeping the
+// original, and drops "(approx.)" afterwards, leaving a double space where it was
+func newAnnotationStrippingConverter(t *testing.T) *converter.Converter {
+	t.Helper()
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+	conv.SetUnitProcessingEnabled(true)
+	conv.SetUnitKeepOriginal(true)
+	err = conv.RegisterProcessor(converter.ProcessorFunc(func(text string, _ ...converter.ProcessOption) string {
+		return strings.ReplaceAll(text, "(approx.)", "")
+	}), converter.PhasePostUnits)
+	if err != nil {
+		t.Fatalf("RegisterProcessor failed: %v", err)
+	}
+	return conv
+}
+
+func TestTidyWhitespace(t *testing.T) {
+	conv := newAnnotationStrippingConverter(t)
+	conv.SetTidyWhitespaceEnabled(true)
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"Introduced run folded", "The board is 10 feet (approx.) wide.", "The board is 10 feet (3 metres) wide."},
+		{"Introduced run folded without units", "The color is (approx.) gray.", "The colour is grey."},
+		{"Existing run kept", "The board is 10 feet  wide.", "The board is 10 feet (3 metres)  wide."},
+		{"Unchanged text kept", "Name    Value\ncolor   red", "Name    Value\ncolour   red"},
+		{
+			"Code alignment kept",
+			"The color (approx.) here.\n\n```go\nx   := 1 // the color\nfoo := 2\n```\n",
+			"The colour here.\n\n```go\nx   := 1 // the colour\nfoo := 2\n```\n",
+		},
+		{
+			"Table alignment kept",
+			"| Name  | Color (approx.) |\n| ----- | ----- |\n| a     | gray  |\n",
+			"| Name  | Colour |\n| ----- | ----- |\n| a     | grey  |\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := conv.ConvertToBritish(tt.input, false); result != tt.expected {
+				t.Errorf("ConvertToBritish(%q) = %q, expected %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTidyWhitespaceDisabled(t *testing.T) {
+	conv := newAnnotationStrippingConverter(t)
+
+	input := "The board is 10 feet (approx.) wide."
+	if result := conv.ConvertToBritish(input, false); result != "The board is 10 feet (3 metres)  wide." {
+		t.Errorf("Expected introduced spaces to be kept by default, got %q", result)
+	}
+}
+
+func TestTidyWhitespaceCLI(t *testing.T) {
+	cliPath := buildTestCLI(t)
+
+	// "on the weekend" → "at the weekend" leaves the spacing around the phrase as it was
+	cmd := exec.Command(cliPath, "-raw", "-tidy-whitespace", "-phrases")
+	cmd.Stdin = strings.NewReader("See you on the weekend.  The color is gray.")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+	}
+	if expected := "See you at the weekend.  The colour is grey."; strings.TrimSpace(string(output)) != expected {
+		t.Errorf("Expected %q, got %q", expected, output)
+	}
+}
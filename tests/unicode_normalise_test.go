@@ -0,0 +1,102 @@
+package tests
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+const (
+	entreeComposed   = "entr\u00e9e"  // é as one code point
+	entreeDecomposed = "entre\u0301e" // e followed by a combining acute accent
+)
+
+// newEntreeConverter returns a converter whose dictionary also maps "entrée" to "main course"
+func newEntreeConverter(t *testing.T) *converter.Converter {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "food.json"), []byte(`{"`+entreeComposed+`": "main course"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(converter.DictPathEnv, dir)
+
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+	return conv
+}
+
+func TestUnicodeNormalisation(t *testing.T) {
+	conv := newEntreeConverter(t)
+
+	// Without normalisation only the composed form matches, and text is left byte for byte
+	if result := conv.ConvertToBritish("The "+entreeComposed+" was gray.", false); result != "The main course was grey." {
+		t.Errorf("Expected the composed form to match, got %q", result)
+	}
+	decomposed := "The " + entreeDecomposed + " was gray."
+	if result := conv.ConvertToBritish(decomposed, false); result != "The "+entreeDecomposed+" was grey." {
+		t.Errorf("Expected the decomposed form to be kept as is, got %q", result)
+	}
+
+	conv.SetUnicodeNormalisationEnabled(true)
+	if !conv.IsUnicodeNormalisationEnabled() {
+		t.Error("Expected Unicode normalisation to be enabled")
+	}
+	for name, input := range map[string]string{
+		"Composed":   "The " + entreeComposed + " was gray.",
+		"Decomposed": decomposed,
+	} {
+		t.Run(name, func(t *testing.T) {
+			if result := conv.ConvertToBritish(input, false); result != "The main course was grey." {
+				t.Errorf("ConvertToBritish(%q) = %q, expected both forms to convert", input, result)
+			}
+		})
+	}
+
+	t.Run("Unchanged prose is composed", func(t *testing.T) {
+		if result := conv.ConvertToBritish("Cafe\u0301 society.", false); result != "Caf\u00e9 society." {
+			t.Errorf("Expected NFC output, got %q", result)
+		}
+	})
+
+	t.Run("Code keeps its bytes", func(t *testing.T) {
+		input := "A cafe\u0301 color.\n\n```go\ns := \"cafe\u0301\"\n```\n\nUse `cafe\u0301` here.\n"
+		expected := "A caf\u00e9 colour.\n\n```go\ns := \"cafe\u0301\"\n```\n\nUse `cafe\u0301` here.\n"
+		if result := conv.ConvertFileContent(input, "menu.md", false); result != expected {
+			t.Errorf("ConvertFileContent() = %q, expected %q", result, expected)
+		}
+
+		code := "// A cafe\u0301 color\nname := \"cafe\u0301\"\n"
+		expectedCode := "// A caf\u00e9 colour\nname := \"cafe\u0301\"\n"
+		if result := conv.ConvertFileContent(code, "menu.go", false); result != expectedCode {
+			t.Errorf("ConvertFileContent() = %q, expected %q", result, expectedCode)
+		}
+	})
+}
+
+func TestCLINormaliseUnicode(t *testing.T) {
+	cliPath := buildTestCLI(t)
+
+	input := "A cafe\u0301 color."
+	cmd := exec.Command(cliPath, "-raw", "-normalise-unicode")
+	cmd.Stdin = strings.NewReader(input)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+	}
+	if strings.TrimSpace(string(output)) != "A caf\u00e9 colour." {
+		t.Errorf("Expected composed output, got %q", output)
+	}
+
+	cmd = exec.Command(cliPath, "-raw")
+	cmd.Stdin = strings.NewReader(input)
+	if output, err := cmd.CombinedOutput(); err != nil || strings.TrimSpace(string(output)) != "A cafe\u0301 colour." {
+		t.Errorf("Expected the decomposed form to be kept without the flag, got %q (%v)", output, err)
+	}
+}
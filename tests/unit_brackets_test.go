@@ -0,0 +1,38 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+func TestUnitsInBrackets(t *testing.T) {
+	processor := converter.NewUnitProcessorWithConfig(converter.GetDefaultUnitConfig())
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"Parentheses", "The shelf (12 ft) is long.", "The shelf (3.7 metres) is long."},
+		{"Square brackets", "A bag [5 lb] of flour.", "A bag [2.3 kg] of flour."},
+		{"Braces", "A bag {5 lb} of flour.", "A bag {2.3 kg} of flour."},
+		{"No space", "The shelf (12ft) is long.", "The shelf (3.7 metres) is long."},
+		{"Leading bracket only", "The shelf (12 ft is long.", "The shelf (3.7 metres is long."},
+		{"Trailing bracket only", "The shelf is 12 ft) long.", "The shelf is 3.7 metres) long."},
+		{"Nested in words", "The shelf (about 12 ft wide) is long.", "The shelf (about 3.7 metres wide) is long."},
+		{"Nested brackets", "The shelf ([12 ft]) is long.", "The shelf ([3.7 metres]) is long."},
+		{"Adjacent groups", "Sizes (12 ft)(5 lb) listed.", "Sizes (3.7 metres)(2.3 kg) listed."},
+		{"Temperature", "It was hot (90°F) today.", "It was hot (32°C) today."},
+		{"Date in brackets left alone", "Meet [2024-01-12 in the lobby].", "Meet [2024-01-12 in the lobby]."},
+		{"Date in braces left alone", "Meet {2024-01-12 in the lobby}.", "Meet {2024-01-12 in the lobby}."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := processor.ProcessText(tt.input, false, ""); result != tt.expected {
+				t.Errorf("ProcessText(%q) = %q, expected %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
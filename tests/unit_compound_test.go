@@ -0,0 +1,35 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+func TestUnitHyphenatedCompounds(t *testing.T) {
+	processor := converter.NewUnitProcessorWithConfig(converter.GetDefaultUnitConfig())
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"Chain", "He is a 6-foot-tall man.", "He is a 1.8-metre-tall man."},
+		{"Decimal chain", "A 6.5-foot-tall door.", "A 2.0-metre-tall door."},
+		{"Written number", "A twenty-foot wall.", "A 6.1-metre wall."},
+		{"Hyphenated written number", "A twenty-five-foot boat.", "A 7.6-metre boat."},
+		{"Teen written number chain", "A fifteen-foot-long pole.", "A 4.6-metre-long pole."},
+		{"Inch chain", "A 10-inch-wide board.", "A 25.4-cm-wide board."},
+		{"Written inch compound", "A forty-two-inch TV.", "A 106.7-cm TV."},
+		{"Hyphenated number before a spaced unit", "It is twenty-five feet away.", "It is 7.6 metres away."},
+		{"Not a length", "The 2-in-1 device.", "The 2-in-1 device."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := processor.ProcessText(tt.input, false, ""); result != tt.expected {
+				t.Errorf("ProcessText(%q) = %q, expected %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
@@ -30,6 +30,8 @@ func TestGetDefaultUnitConfig(t *testing.T) {
 		converter.Volume,
 		converter.Temperature,
 		converter.Area,
+		converter.Speed,
+		converter.Pressure,
 	}
 
 	if len(config.EnabledUnitTypes) != len(expectedUnitTypes) {
@@ -216,6 +218,56 @@ func TestValidateConfig(t *testing.T) {
 			expectError: true,
 			errorMsg:    "invalid temperature format",
 		},
+		{
+			name: "invalid rounding strategy",
+			config: func() *converter.UnitConfig {
+				config := converter.GetDefaultUnitConfig()
+				config.Preferences.RoundingStrategy = "ceiling"
+				return config
+			}(),
+			expectError: true,
+			errorMsg:    "invalid rounding strategy",
+		},
+		{
+			name: "invalid number format",
+			config: func() *converter.UnitConfig {
+				config := converter.GetDefaultUnitConfig()
+				config.Preferences.NumberFormat = "grouped-dot"
+				return config
+			}(),
+			expectError: true,
+			errorMsg:    "invalid number format",
+		},
+		{
+			name: "valid number format",
+			config: func() *converter.UnitConfig {
+				config := converter.GetDefaultUnitConfig()
+				config.Preferences.NumberFormat = converter.NumberFormatGroupedSpace
+				return config
+			}(),
+			expectError: false,
+		},
+		{
+			name: "invalid significant figures",
+			config: func() *converter.UnitConfig {
+				config := converter.GetDefaultUnitConfig()
+				config.Preferences.RoundingStrategy = converter.RoundingSignificant
+				config.Preferences.SignificantFigures = 20
+				return config
+			}(),
+			expectError: true,
+			errorMsg:    "significantFigures must be between 1 and 15",
+		},
+		{
+			name: "valid significant rounding",
+			config: func() *converter.UnitConfig {
+				config := converter.GetDefaultUnitConfig()
+				config.Preferences.RoundingStrategy = converter.RoundingSignificant
+				config.Preferences.SignificantFigures = 3
+				return config
+			}(),
+			expectError: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -1075,6 +1127,43 @@ func TestUnitProcessor_ConfigurationIntegration(t *testing.T) {
 			t.Error("Length units should be converted")
 		}
 	})
+
+	t.Run("processor applies rounding strategy", func(t *testing.T) {
+		config := converter.GetDefaultUnitConfig()
+		data := `{"preferences": {"roundingStrategy": "significant", "significantFigures": 2}}`
+		if err := json.Unmarshal([]byte(data), config); err != nil {
+			t.Fatalf("Failed to unmarshal config: %v", err)
+		}
+		if err := converter.ValidateConfig(config); err != nil {
+			t.Fatalf("Expected config to be valid, got %v", err)
+		}
+
+		processor := converter.NewUnitProcessorWithConfig(config)
+
+		// Compound units follow the strategy too
+		result := processor.ProcessText("Preheat the oven to 350°F and fit a 6-foot shelf.", false, "")
+		expected := "Preheat the oven to 180°C and fit a 1.8-metre shelf."
+		if result != expected {
+			t.Errorf("Expected %q, got %q", expected, result)
+		}
+	})
+
+	t.Run("processor applies number format", func(t *testing.T) {
+		config := converter.GetDefaultUnitConfig()
+		if err := json.Unmarshal([]byte(`{"preferences": {"numberFormat": "grouped-comma"}}`), config); err != nil {
+			t.Fatalf("Failed to unmarshal config: %v", err)
+		}
+		if err := converter.ValidateConfig(config); err != nil {
+			t.Fatalf("Expected config to be valid, got %v", err)
+		}
+
+		processor := converter.NewUnitProcessorWithConfig(config)
+		result := processor.ProcessText("The tank holds 10000 gallons on a 1 acre plot.", false, "")
+		expected := "The tank holds 37,854.1 litres on a 4,046.9 m² plot."
+		if result != expected {
+			t.Errorf("Expected %q, got %q", expected, result)
+		}
+	})
 }
 
 func TestUnitProcessor_ConfigurationReload(t *testing.T) {
@@ -0,0 +1,96 @@
+package tests
+
+import (
+	"encoding/json"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+func TestUnitCookingMeasures(t *testing.T) {
+	config := converter.GetDefaultUnitConfig()
+	config.CookingMeasures = true
+	processor := converter.NewUnitProcessorWithConfig(config)
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"Cups of flour", "Add 2 cups of flour.", "Add 473.2 ml of flour."},
+		{"Mixed fraction", "Stir in 1 1/2 cups sugar.", "Stir in 354.9 ml sugar."},
+		{"Tablespoon", "Add 1 tablespoon of oil.", "Add 14.8 ml of oil."},
+		{"Abbreviated teaspoons", "Season with 2 tsp salt.", "Season with 9.9 ml salt."},
+		{"Tablespoons abbreviated without a space", "Whisk in 3tbsp milk.", "Whisk in 44.4 ml milk."},
+		{"Written number", "Use two cups of water.", "Use 473.2 ml of water."},
+		{"Spelled quantity", "Pour in half a cup of cream.", "Pour in 120 ml of cream."},
+		{"A cup of flour", "Sift a cup of flour.", "Sift 240 ml of flour."},
+		{"Sticks of butter", "Melt 2 sticks of unsalted butter.", "Melt 226.8 g of unsalted butter."},
+		{"Stick without butter", "Cut 2 sticks of celery.", "Cut 2 sticks of celery."},
+		{"Cup of coffee", "I had a cup of coffee.", "I had a cup of coffee."},
+		{"Cups of tea", "She drinks 3 cups of tea a day.", "She drinks 3 cups of tea a day."},
+		{"Not my cup of tea", "Opera is not my cup of tea.", "Opera is not my cup of tea."},
+		{"A cup alone", "He won a cup at the fair.", "He won a cup at the fair."},
+		{"Cup final", "Tickets for a cup final.", "Tickets for a cup final."},
+		{"Idiom nearby", "Add 2 cups of flour, then have a cup of tea.", "Add 473.2 ml of flour, then have a cup of tea."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := processor.ProcessText(tt.input, false, ""); result != tt.expected {
+				t.Errorf("ProcessText(%q) = %q, expected %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestUnitCookingMeasuresDisabledByDefault(t *testing.T) {
+	processor := converter.NewUnitProcessorWithConfig(converter.GetDefaultUnitConfig())
+
+	input := "Add 2 cups of flour and 1 tbsp of oil to 1 pint of milk."
+	if result := processor.ProcessText(input, false, ""); result != "Add 2 cups of flour and 1 tbsp of oil to 473.2 ml of milk." {
+		t.Errorf("Expected cooking measures to be left alone by default, got %q", result)
+	}
+}
+
+func TestUnitCookingMeasuresConfigJSON(t *testing.T) {
+	config := converter.GetDefaultUnitConfig()
+	if err := json.Unmarshal([]byte(`{"cookingMeasures": true}`), config); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if !config.CookingMeasures || !config.Clone().CookingMeasures {
+		t.Fatal("Expected cookingMeasures to be decoded and cloned")
+	}
+
+	processor := converter.NewUnitProcessorWithConfig(config)
+	if result := processor.ProcessText("Add 2 cups of flour.", false, ""); result != "Add 473.2 ml of flour." {
+		t.Errorf("Expected the config to enable cooking measures, got %q", result)
+	}
+}
+
+func TestUnitCookingMeasuresCLI(t *testing.T) {
+	cliPath := buildTestCLI(t)
+	input := "Add 2 cups of flour to a cup of coffee."
+
+	tests := []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{"-raw", "-units"}, input},
+		{[]string{"-raw", "-units", "-units-cooking"}, "Add 473.2 ml of flour to a cup of coffee."},
+	}
+
+	for _, tt := range tests {
+		cmd := exec.Command(cliPath, tt.args...)
+		cmd.Stdin = strings.NewReader(input)
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("CLI %v failed: %v\nOutput: %s", tt.args, err, output)
+		}
+		if result := strings.TrimSpace(string(output)); result != tt.expected {
+			t.Errorf("CLI %v: expected %q, got %q", tt.args, tt.expected, result)
+		}
+	}
+}
@@ -346,6 +346,93 @@ func TestUnitConversion_Formatting(t *testing.T) {
 	}
 }
 
+// TestUnitConversion_RoundingStrategies tests each rounding strategy against the default
+func TestUnitConversion_RoundingStrategies(t *testing.T) {
+	matches := map[string]converter.UnitMatch{
+		"2.98 metres": {Value: 9.78, Unit: "feet", UnitType: converter.Length, Confidence: 0.9},
+		"1.61 km":     {Value: 1, Unit: "mile", UnitType: converter.Length, Confidence: 0.9},
+		"2.5°C":       {Value: 36.5, Unit: "fahrenheit", UnitType: converter.Temperature, Confidence: 0.9},
+		"176.7°C":     {Value: 350, Unit: "fahrenheit", UnitType: converter.Temperature, Confidence: 0.9},
+	}
+
+	tests := []struct {
+		name     string
+		strategy converter.RoundingStrategy
+		figures  int
+		match    string
+		expected string
+	}{
+		{"default_prefers_whole_numbers", converter.RoundingDefault, 0, "2.98 metres", "3 metres"},
+		{"nearest_half_rounds_up", converter.RoundingNearest, 0, "2.5°C", "3°C"},
+		{"bankers_half_rounds_to_even", converter.RoundingBankers, 0, "2.5°C", "2°C"},
+		{"bankers_away_from_half", converter.RoundingBankers, 0, "176.7°C", "177°C"},
+		{"significant_default_figures", converter.RoundingSignificant, 0, "176.7°C", "180°C"},
+		{"significant_keeps_decimals", converter.RoundingSignificant, 0, "2.5°C", "2.5°C"},
+		{"significant_three_figures", converter.RoundingSignificant, 3, "2.98 metres", "2.98 metres"},
+		{"significant_three_figures_km", converter.RoundingSignificant, 3, "1.61 km", "1.61 km"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conv := converter.NewBasicUnitConverter()
+			prefs := conv.GetPreferences()
+			prefs.RoundingStrategy = tt.strategy
+			prefs.SignificantFigures = tt.figures
+			conv.SetPreferences(prefs)
+
+			result, err := conv.Convert(matches[tt.match])
+			if err != nil {
+				t.Fatalf("Convert() error = %v", err)
+			}
+			if result.Formatted != tt.expected {
+				t.Errorf("Expected formatted '%s', got '%s'", tt.expected, result.Formatted)
+			}
+		})
+	}
+}
+
+// TestUnitConversion_NumberFormats tests grouping the thousands of converted values
+func TestUnitConversion_NumberFormats(t *testing.T) {
+	matches := map[string]converter.UnitMatch{
+		"4046.9 m²":      {Value: 1, Unit: "acre", UnitType: converter.Area, Confidence: 0.9},
+		"37854.1 litres": {Value: 10000, Unit: "gallons", UnitType: converter.Volume, Confidence: 0.9},
+		"-18°C":          {Value: 0, Unit: "fahrenheit", UnitType: converter.Temperature, Confidence: 0.9},
+		"907.2 kg":       {Value: 2000, Unit: "pounds", UnitType: converter.Mass, Confidence: 0.9},
+	}
+
+	tests := []struct {
+		name     string
+		format   converter.NumberFormat
+		match    string
+		expected string
+	}{
+		{"default_is_plain", "", "4046.9 m²", "4046.9 m²"},
+		{"plain", converter.NumberFormatPlain, "37854.1 litres", "37854.1 litres"},
+		{"comma", converter.NumberFormatGroupedComma, "4046.9 m²", "4,046.9 m²"},
+		{"comma_five_digits", converter.NumberFormatGroupedComma, "37854.1 litres", "37,854.1 litres"},
+		{"space", converter.NumberFormatGroupedSpace, "37854.1 litres", "37\u2009854.1 litres"},
+		{"small_values_unchanged", converter.NumberFormatGroupedComma, "907.2 kg", "907.2 kg"},
+		{"negative_temperature", converter.NumberFormatGroupedSpace, "-18°C", "-18°C"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conv := converter.NewBasicUnitConverter()
+			prefs := conv.GetPreferences()
+			prefs.NumberFormat = tt.format
+			conv.SetPreferences(prefs)
+
+			result, err := conv.Convert(matches[tt.match])
+			if err != nil {
+				t.Fatalf("Convert() error = %v", err)
+			}
+			if result.Formatted != tt.expected {
+				t.Errorf("Expected formatted '%s', got '%s'", tt.expected, result.Formatted)
+			}
+		})
+	}
+}
+
 // TestUnitConversion_EdgeCases tests edge cases and error conditions
 func TestUnitConversion_EdgeCases(t *testing.T) {
 	conv := converter.NewBasicUnitConverter()
@@ -461,7 +548,7 @@ func TestUnitDetection_RealWorldScenarios(t *testing.T) {
 		text            string
 		expectedMatches int
 	}{
-		{"construction_spec", examples[1], 5}, // 12 feet, 10 feet, 9-foot, 120 square feet, 2 tons
+		{"construction_spec", examples[1], 4}, // 12 feet by 10 feet, 9-foot, 120 square feet, 2 tons
 		{"recipe", examples[2], 4},            // 2 pounds, 16 ounces, 350°F, 9-inch
 		{"travel", examples[3], 3},            // 150 miles, 50 miles, 85°F
 		{"mixed_idioms", examples[4], 2},      // 6-foot, 200 pounds (miles ahead is idiomatic)
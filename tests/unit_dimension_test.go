@@ -0,0 +1,62 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+func TestUnitDimensions(t *testing.T) {
+	processor := converter.NewUnitProcessorWithConfig(converter.GetDefaultUnitConfig())
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"Unit on each component", "A 12 ft × 8 ft room.", "A 3.7 metres × 2.4 metres room."},
+		{"Components share the smallest one's unit", "A 3 ft x 4 ft table.", "A 91.4 cm x 122 cm table."},
+		{"Unit only on the last component", "A 3x4 feet rug.", "A 91.4x122 cm rug."},
+		{"Spaced with by", "A 10 by 12 feet room.", "A 3 by 3.7 metres room."},
+		{"Three components", "A 2 x 4 x 8 ft board.", "A 61 x 122 x 243.8 cm board."},
+		{"Yards", "A 100 x 50 yd field.", "A 91.4 x 45.7 metres field."},
+		{"Mixed units convert part by part", "A 6 ft x 4 in gap.", "A 1.8 metres x 10.2 cm gap."},
+		{"Multiplication sign with a shared unit", "A 10×12 ft room.", "A 3×3.7 metres room."},
+		{"Hyphenated unit", "A 10 x 12-foot room.", "A 3 x 3.7-metre room."},
+		{"Hyphenated unit on each component", "A 10-foot x 12-foot room.", "A 3-metre x 3.7-metre room."},
+		{"Variable x isn't a dimension", "Solve for 5 x feet.", "Solve for 5 x feet."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := processor.ProcessText(tt.input, false, ""); result != tt.expected {
+				t.Errorf("ProcessText(%q) = %q, expected %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestUnitDetectionSkipsTimes(t *testing.T) {
+	processor := converter.NewUnitProcessorWithConfig(converter.GetDefaultUnitConfig())
+
+	inputs := []string{
+		"The job runs every 5 min.",
+		"It took 30 mins in the end.",
+		"Meet at 10:30 in the lobby.",
+		"Started at 2024-05-01T12:00:00 in London.",
+		"The timeout is PT30M in production.",
+	}
+
+	for _, input := range inputs {
+		if result := processor.ProcessText(input, false, ""); result != input {
+			t.Errorf("ProcessText(%q) = %q, expected it unchanged", input, result)
+		}
+	}
+
+	// Lengths next to times are still converted
+	input := "At 10:30 the 12 ft × 8 ft room was ready."
+	expected := "At 10:30 the 3.7 metres × 2.4 metres room was ready."
+	if result := processor.ProcessText(input, false, ""); result != expected {
+		t.Errorf("ProcessText(%q) = %q, expected %q", input, result, expected)
+	}
+}
@@ -87,6 +87,112 @@ func TestUnitConversion_EndToEnd(t *testing.T) {
 	}
 }
 
+// TestUnitConversion_NegativeTemperaturesAndRanges tests signed Fahrenheit values and ranges
+func TestUnitConversion_NegativeTemperaturesAndRanges(t *testing.T) {
+	processor := converter.NewUnitProcessor()
+	processor.SetEnabled(true)
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "minus_forty_crossover",
+			input:    "It was -40°F outside",
+			expected: "It was -40°C outside",
+		},
+		{
+			name:     "body_temperature",
+			input:    "Normal body temperature is 98.6°F",
+			expected: "Normal body temperature is 37°C",
+		},
+		{
+			name:     "negative_in_parentheses",
+			input:    "The freezer runs cold (-4°F)",
+			expected: "The freezer runs cold (-20°C)",
+		},
+		{
+			name:     "typographic_minus_sign",
+			input:    "Lows of −13°F overnight",
+			expected: "Lows of -25°C overnight",
+		},
+		{
+			name:     "negative_degrees_fahrenheit",
+			input:    "The record low was -22 degrees Fahrenheit",
+			expected: "The record low was -30°C",
+		},
+		{
+			name:     "between_negative_and_positive",
+			input:    "Store between -5°F and 20°F",
+			expected: "Store between -21°C and -7°C",
+		},
+		{
+			name:     "hyphenated_range",
+			input:    "Keep it at 68-77°F",
+			expected: "Keep it at 20-25°C",
+		},
+		{
+			name:     "hyphenated_range_becoming_negative",
+			input:    "Expect 14-23°F tonight",
+			expected: "Expect -10 to -5°C tonight",
+		},
+		{
+			name:     "range_with_to",
+			input:    "Temperatures from -40 to 32 degrees Fahrenheit",
+			expected: "Temperatures from -40 to 0°C",
+		},
+		{
+			name:     "rounds_to_zero_without_sign",
+			input:    "It was 31.5°F",
+			expected: "It was 0°C",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := processor.ProcessText(tt.input, false, "")
+			if result != tt.expected {
+				t.Errorf("Temperature conversion failed:\nInput:    %s\nExpected: %s\nGot:      %s",
+					tt.input, tt.expected, result)
+			}
+		})
+	}
+}
+
+// TestUnitConversion_SpelledOutQuantities tests quantities written as "a" or a fraction
+func TestUnitConversion_SpelledOutQuantities(t *testing.T) {
+	processor := converter.NewUnitProcessor()
+	processor.SetEnabled(true)
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"article_with_measurement_word", "The board is a foot long", "The board is 30 cm long"},
+		{"half_a", "Add half a pound of butter", "Add 230 g of butter"},
+		{"half_an", "Trim half an inch off", "Trim 1.3 cm off"},
+		{"a_quarter", "It's a quarter mile further", "It's 400 metres further"},
+		{"three_quarters_of_a", "Pour in three quarters of a gallon", "Pour in 2.8 litres"},
+		{"weighs_a", "The parcel weighs a pound", "The parcel weighs 450 g"},
+		{"bare_article_unchanged", "We walked a mile and it cost a pound", "We walked a mile and it cost a pound"},
+		{"and_a_half_unchanged", "A foot and a half long", "A foot and a half long"},
+		{"idiom_unchanged", "He won by a mile with a foot in the door", "He won by a mile with a foot in the door"},
+		{"cold_feet_unchanged", "She got cold feet", "She got cold feet"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := processor.ProcessText(tt.input, false, "")
+			if result != tt.expected {
+				t.Errorf("Spelled-out quantity conversion failed:\nInput:    %s\nExpected: %s\nGot:      %s",
+					tt.input, tt.expected, result)
+			}
+		})
+	}
+}
+
 // TestUnitConversion_RealWorldScenarios tests with real-world text samples
 func TestUnitConversion_RealWorldScenarios(t *testing.T) {
 	// Load real world examples
@@ -109,7 +215,7 @@ func TestUnitConversion_RealWorldScenarios(t *testing.T) {
 		{
 			name:            "construction_specification",
 			input:           examples[1], // Construction spec
-			expectedMatches: 5,           // Should find multiple units
+			expectedMatches: 4,           // The "12 feet by 10 feet" dimension is one match
 			shouldConvert:   true,
 		},
 		{
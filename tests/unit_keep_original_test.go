@@ -0,0 +1,77 @@
+package tests
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+func TestUnitKeepOriginal(t *testing.T) {
+	config := converter.GetDefaultUnitConfig()
+	config.Preferences.KeepOriginal = true
+	processor := converter.NewUnitProcessorWithConfig(config)
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"Length", "The wall is 10 feet high.", "The wall is 10 feet (3 metres) high."},
+		{"Temperature", "It was 75°F outside.", "It was 75°F (24°C) outside."},
+		{"Mass", "A 5 pound bag.", "A 5 pound (2.3 kg) bag."},
+		{"Compound", "A 6-foot fence.", "A 6-foot (1.8 metres) fence."},
+		{"Range", "Heat to 350-375°F.", "Heat to 350-375°F (177-191°C)."},
+		{"Dimensions", "A 12 ft × 8 ft room.", "A 12 ft × 8 ft (3.7 metres × 2.4 metres) room."},
+		{"Already annotated", "A 10 feet (3 metres) wall.", "A 10 feet (3 metres) wall."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := processor.ProcessText(tt.input, false, "")
+			if result != tt.expected {
+				t.Errorf("ProcessText(%q) = %q, expected %q", tt.input, result, tt.expected)
+			}
+			// Converting again doesn't add a second parenthetical
+			if again := processor.ProcessText(result, false, ""); again != result {
+				t.Errorf("Second run changed %q to %q", result, again)
+			}
+		})
+	}
+}
+
+func TestConverterUnitKeepOriginal(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+	conv.SetUnitProcessingEnabled(true)
+	conv.SetUnitKeepOriginal(true)
+	if !conv.GetUnitProcessor().IsKeepOriginalEnabled() {
+		t.Error("Expected keep original to be enabled")
+	}
+
+	if result := conv.ConvertToBritish("The color wall is 10 feet high.", true); result != "The colour wall is 10 feet (3 metres) high." {
+		t.Errorf("Unexpected conversion %q", result)
+	}
+
+	conv.SetUnitKeepOriginal(false)
+	if result := conv.ConvertToBritish("The wall is 10 feet high.", true); result != "The wall is 3 metres high." {
+		t.Errorf("Expected units to be replaced when disabled, got %q", result)
+	}
+}
+
+func TestCLIUnitsKeepOriginal(t *testing.T) {
+	cliPath := buildTestCLI(t)
+
+	cmd := exec.Command(cliPath, "-raw", "-units", "-units-keep-original")
+	cmd.Stdin = strings.NewReader("The wall is 10 feet high.")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+	}
+	if strings.TrimSpace(string(output)) != "The wall is 10 feet (3 metres) high." {
+		t.Errorf("Expected the original to be kept, got %q", output)
+	}
+}
@@ -0,0 +1,101 @@
+package tests
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+func TestNormaliseUnits(t *testing.T) {
+	processor := converter.NewUnitProcessor()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"Adds a space", "It weighs 5kg.", "It weighs 5 kg."},
+		{"Lowercases the symbol", "A 10KM run.", "A 10 km run."},
+		{"Drops a plural s", "Lift 5Kgs and 20 cms.", "Lift 5 kg and 20 cm."},
+		{"Collapses extra spacing", "A 3  mm gap.", "A 3 mm gap."},
+		{"Speed symbols", "Drive at 30 kph or 50kmh.", "Drive at 30 km/h or 50 km/h."},
+		{"Area symbols", "A 4 km2 park.", "A 4 km² park."},
+		{"Temperatures take no space", "It was 20 °c today.", "It was 20°C today."},
+		{"Decimals", "A 2.5KG bag.", "A 2.5 kg bag."},
+		{"Millilitres keep their case", "Add 250mL of water.", "Add 250 mL of water."},
+		{"Money is not metres", "It cost £5m.", "It cost £5m."},
+		{"Networks are not grams", "Now on 5G.", "Now on 5G."},
+		{"Version numbers are left alone", "Use v1.5kg here.", "Use v1.5kg here."},
+		{"Part of a word is left alone", "The 5kgs2 model.", "The 5kgs2 model."},
+		{"Paths are left alone", "See /data/10km/route.", "See /data/10km/route."},
+		{"Non-breaking space is kept", "It weighs 5\u00a0KG.", "It weighs 5\u00a0kg."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := processor.NormaliseUnits(tt.input); result != tt.expected {
+				t.Errorf("NormaliseUnits(%q) = %q, expected %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNormaliseUnitsWithoutSpacePreference(t *testing.T) {
+	config := converter.GetDefaultUnitConfig()
+	config.Preferences.UseSpaceBetweenValueAndUnit = false
+	processor := converter.NewUnitProcessorWithConfig(config)
+
+	if result := processor.NormaliseUnits("A 10 KM run."); result != "A 10km run." {
+		t.Errorf("Expected no space between value and unit, got %q", result)
+	}
+}
+
+func TestConverterUnitNormalisation(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+	conv.SetUnitProcessingEnabled(false)
+	conv.SetUnitNormalisationEnabled(true)
+
+	// Normalising works without converting imperial units
+	input := "The color swatch is 5 feet wide and weighs 2KG."
+	expected := "The colour swatch is 5 feet wide and weighs 2 kg."
+	if result := conv.ConvertToBritish(input, true); result != expected {
+		t.Errorf("ConvertToBritish() = %q, expected %q", result, expected)
+	}
+
+	// Only comments are normalised in code
+	code := "// Max 10KG per color\nconst limit = \"10KG\"\n"
+	expectedCode := "// Max 10 kg per colour\nconst limit = \"10KG\"\n"
+	if result := conv.ConvertFileContent(code, "limits.go", true); result != expectedCode {
+		t.Errorf("ConvertFileContent() = %q, expected %q", result, expectedCode)
+	}
+
+	conv.SetUnitNormalisationEnabled(false)
+	if result := conv.ConvertToBritish("It weighs 2KG.", true); result != "It weighs 2KG." {
+		t.Errorf("Expected units to be left alone when disabled, got %q", result)
+	}
+}
+
+func TestCLINormaliseUnits(t *testing.T) {
+	cliPath := buildTestCLI(t)
+
+	cmd := exec.Command(cliPath, "-raw", "-normalise-units")
+	cmd.Stdin = strings.NewReader("A 10KM run in 20 °c heat.")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+	}
+	if strings.TrimSpace(string(output)) != "A 10 km run in 20°C heat." {
+		t.Errorf("Expected normalised units, got %q", output)
+	}
+
+	cmd = exec.Command(cliPath, "-raw")
+	cmd.Stdin = strings.NewReader("A 10KM run.")
+	if output, err := cmd.CombinedOutput(); err != nil || strings.TrimSpace(string(output)) != "A 10KM run." {
+		t.Errorf("Expected units to be left alone without the flag, got %q (%v)", output, err)
+	}
+}
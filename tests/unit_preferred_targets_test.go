@@ -0,0 +1,123 @@
+package tests
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+func TestUnitPreferredTargets(t *testing.T) {
+	config := converter.GetDefaultUnitConfig()
+	config.PreferredTargets = map[string]string{
+		"miles":         "keep",
+		"pounds >= 100": "stone",
+		"pounds>=2000":  "tonnes",
+		"feet > 1000":   "km",
+		"gallons":       "ml",
+		"psi":           "bar",
+	}
+	if err := converter.ValidateConfig(config); err != nil {
+		t.Fatalf("ValidateConfig failed: %v", err)
+	}
+	processor := converter.NewUnitProcessorWithConfig(config)
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"Miles kept", "The village is 5 miles from here.", "The village is 5 miles from here."},
+		{"Miles per hour still converted", "The limit is 30 mph.", "The limit is 48 km/h."},
+		{"Body weight in stone", "He weighs 170 pounds.", "He weighs 12 stone 2 lb."},
+		{"Whole stone", "She weighs 140 lb.", "She weighs 10 stone."},
+		{"Below the bound", "A 5 pound bag.", "A 2.3 kg bag."},
+		{"Highest bound wins", "The load is 3000 pounds.", "The load is 1.4 tonnes."},
+		{"Exclusive bound", "A 1000 feet drop.", "A 304.8 metres drop."},
+		{"Above an exclusive bound", "A 5000 feet climb.", "A 1.5 km climb."},
+		{"Volume", "Add 2 gallons of water.", "Add 7570.8 ml of water."},
+		{"Pressure", "Inflate to 30 psi.", "Inflate to 2 bar."},
+		{"Unaffected units", "It is 10 feet high.", "It is 3 metres high."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := processor.ProcessText(tt.input, false, ""); result != tt.expected {
+				t.Errorf("ProcessText(%q) = %q, expected %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestUnitPreferredTargetsKeepOriginal(t *testing.T) {
+	config := converter.GetDefaultUnitConfig()
+	config.Preferences.KeepOriginal = true
+	config.PreferredTargets = map[string]string{"pounds >= 100": "stone"}
+	processor := converter.NewUnitProcessorWithConfig(config)
+
+	if result := processor.ProcessText("He weighs 170 pounds.", false, ""); result != "He weighs 170 pounds (12 stone 2 lb)." {
+		t.Errorf("Unexpected conversion %q", result)
+	}
+}
+
+func TestValidateConfigPreferredTargets(t *testing.T) {
+	tests := []struct {
+		name    string
+		targets map[string]string
+		valid   bool
+	}{
+		{"Keep", map[string]string{"mile": "KEEP"}, true},
+		{"Metric unit", map[string]string{"ft": "cm"}, true},
+		{"Bounded", map[string]string{"lbs > 14.5": "stone"}, true},
+		{"Unknown unit", map[string]string{"furlongs": "km"}, false},
+		{"Wrong type", map[string]string{"pounds": "km"}, false},
+		{"Stone for length", map[string]string{"feet": "stone"}, false},
+		{"Temperature can only be kept", map[string]string{"°F": "kelvin"}, false},
+		{"Bad condition", map[string]string{"pounds < 100": "stone"}, false},
+		{"Duplicate condition", map[string]string{"pounds": "kg", "lb": "g"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := converter.GetDefaultUnitConfig()
+			config.PreferredTargets = tt.targets
+			if err := converter.ValidateConfig(config); (err == nil) != tt.valid {
+				t.Errorf("ValidateConfig() error = %v, expected valid = %v", err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestUnitPreferredTargetsJSON(t *testing.T) {
+	config := converter.GetDefaultUnitConfig()
+	if err := json.Unmarshal([]byte(`{"preferredTargets": {"miles": "keep"}}`), config); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if config.PreferredTargets["miles"] != "keep" {
+		t.Fatalf("Expected preferred targets to be decoded, got %v", config.PreferredTargets)
+	}
+
+	clone := config.Clone()
+	clone.PreferredTargets["miles"] = "km"
+	if config.PreferredTargets["miles"] != "keep" {
+		t.Error("Expected Clone to copy preferred targets")
+	}
+
+	merged := converter.GetDefaultUnitConfig()
+	merged.Merge(config)
+	if merged.PreferredTargets["miles"] != "keep" {
+		t.Errorf("Expected Merge to carry preferred targets, got %v", merged.PreferredTargets)
+	}
+
+	data, err := json.Marshal(config)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := decoded["preferredTargets"]; !ok {
+		t.Errorf("Expected preferredTargets in %s", data)
+	}
+}
@@ -0,0 +1,55 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+func TestUnitSpeedPressureArea(t *testing.T) {
+	processor := converter.NewUnitProcessorWithConfig(converter.GetDefaultUnitConfig())
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"mph", "The limit is 60 mph here.", "The limit is 97 km/h here."},
+		{"Miles per hour", "It reached 100 miles per hour.", "It reached 161 km/h."},
+		{"Miles an hour", "Going 30 miles an hour.", "Going 48 km/h."},
+		{"psi", "Inflate the tyres to 32 psi today.", "Inflate the tyres to 221 kPa today."},
+		{"Pounds per square inch", "Rated at 50 pounds per square inch.", "Rated at 345 kPa."},
+		{"High pressure in bar", "A cylinder filled to 3000 psi.", "A cylinder filled to 207 bar."},
+		{"sq ft", "A 500 sq ft flat.", "A 46.5 m² flat."},
+		{"sqft", "A 500 sqft flat.", "A 46.5 m² flat."},
+		{"ft²", "A 500 ft² flat.", "A 46.5 m² flat."},
+		{"ft2", "A 500 ft2 flat.", "A 46.5 m² flat."},
+		{"Square feet at end of sentence", "The flat is 500 square feet.", "The flat is 46.5 m²."},
+		{"Distance still converted", "We drove 10 miles.", "We drove 16 km."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := processor.ProcessText(tt.input, false, ""); result != tt.expected {
+				t.Errorf("ProcessText(%q) = %q, expected %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestUnitSpeedPressureConfig(t *testing.T) {
+	config := converter.GetDefaultUnitConfig()
+	if !config.IsUnitTypeEnabled(converter.Speed) || !config.IsUnitTypeEnabled(converter.Pressure) {
+		t.Error("Expected speed and pressure to be enabled by default")
+	}
+	if err := converter.ValidateConfig(config); err != nil {
+		t.Errorf("Expected the default config to be valid, got %v", err)
+	}
+
+	config.EnabledUnitTypes = []converter.UnitType{converter.Length}
+	processor := converter.NewUnitProcessorWithConfig(config)
+	input := "Drive at 60 mph for 10 miles."
+	if result := processor.ProcessText(input, false, ""); result != "Drive at 60 mph for 16 km." {
+		t.Errorf("Expected only lengths to be converted, got %q", result)
+	}
+}
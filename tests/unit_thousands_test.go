@@ -0,0 +1,39 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+func TestUnitThousandsSeparators(t *testing.T) {
+	processor := converter.NewUnitProcessorWithConfig(converter.GetDefaultUnitConfig())
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"Feet", "The trail climbs 12,000 feet.", "The trail climbs 3.7 km."},
+		{"Miles", "We drove 1,200 miles.", "We drove 1931.2 km."},
+		{"Pounds", "It weighs 1,500 pounds.", "It weighs 680.4 kg."},
+		{"Square feet", "A 2,500 square feet house.", "A 232.3 m² house."},
+		{"Millions with decimals", "About 1,000,000.5 gallons.", "About 3785413.7 litres."},
+		{"Price left alone", "It costs $1,000.", "It costs $1,000."},
+		{"Price before a unit left alone", "Pay $1,000 feet first.", "Pay $1,000 feet first."},
+		{"Not a thousands group", "Sizes 1,2 feet apart.", "Sizes 1,2 feet apart."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := processor.ProcessText(tt.input, false, ""); result != tt.expected {
+				t.Errorf("ProcessText(%q) = %q, expected %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+
+	matches := converter.NewContextualUnitDetector().DetectUnits("The trail climbs 12,000 feet.")
+	if len(matches) != 1 || matches[0].Value != 12000 {
+		t.Fatalf("Expected a single match of 12000 feet, got %+v", matches)
+	}
+}
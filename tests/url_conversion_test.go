@@ -0,0 +1,68 @@
+package tests
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+func TestURLSkipping(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		input       string
+		expected    string
+		convertURLs bool
+	}{
+		{"URL kept", "See https://example.com/color for details.", "See https://example.com/color for details.", false},
+		{"Word after a URL", "Visit https://example.com/color, the color guide.", "Visit https://example.com/color, the colour guide.", false},
+		{"Word joined after a bracket", "Docs (https://example.com/gray)color chart.", "Docs (https://example.com/gray)colour chart.", false},
+		{"Word joined after a quote", "Open \"https://example.com/gray\"color now.", "Open \"https://example.com/gray\"colour now.", false},
+		{"Word joined after an angle bracket", "Open <https://example.com/gray>color now.", "Open <https://example.com/gray>colour now.", false},
+		{"URL in brackets kept", "See (https://example.com/color).", "See (https://example.com/color).", false},
+		{"www word kept", "The www.color example.", "The www.color example.", false},
+		{"www word converted", "The www.color example.", "The www.colour example.", true},
+		{"URL converted", "See https://example.com/color-center for details.", "See https://example.com/colour-centre for details.", true},
+		{"Word after a URL converted too", "Docs (https://example.com/gray)color chart.", "Docs (https://example.com/grey)colour chart.", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conv.SetURLConversionEnabled(tt.convertURLs)
+			if result := conv.ConvertToBritish(tt.input, false); result != tt.expected {
+				t.Errorf("ConvertToBritish(%q) = %q, expected %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestURLConversionCLI(t *testing.T) {
+	cliPath := buildTestCLI(t)
+	input := "The www.color example at https://example.com/gray."
+
+	cmd := exec.Command(cliPath, "-raw")
+	cmd.Stdin = strings.NewReader(input)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+	}
+	if strings.TrimSpace(string(output)) != input {
+		t.Errorf("Expected URLs to be kept, got %q", output)
+	}
+
+	cmd = exec.Command(cliPath, "-raw", "-convert-urls")
+	cmd.Stdin = strings.NewReader(input)
+	output, err = cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+	}
+	if strings.TrimSpace(string(output)) != "The www.colour example at https://example.com/grey." {
+		t.Errorf("Expected URLs to convert with -convert-urls, got %q", output)
+	}
+}
@@ -0,0 +1,74 @@
+package tests
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+func TestConversionCounters(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+	conv.SetUnitProcessingEnabled(true)
+
+	conv.ConvertToBritish("I need a license. The color is 6 feet away.", false)
+	if counters := conv.TakeCounters(); counters != (converter.ConversionCounters{}) {
+		t.Errorf("Expected no counts before counters are enabled, got %+v", counters)
+	}
+
+	conv.SetCountersEnabled(true)
+	conv.ConvertToBritish("I need a license. The color is 6 feet away.", false)
+	counters := conv.TakeCounters()
+	if counters.DictionaryHits != 1 || counters.ContextualHits != 1 || counters.UnitConversions != 1 {
+		t.Errorf("Expected 1 dictionary hit, 1 contextual hit and 1 unit conversion, got %+v", counters)
+	}
+	if counters.PrefilterSkipped() {
+		t.Error("Expected text with an American spelling to get past the pre-filter")
+	}
+	if conv.TakeCounters() != (converter.ConversionCounters{}) {
+		t.Error("Expected TakeCounters to reset the counts")
+	}
+
+	conv.ConvertToBritish("Nothing to change here", false)
+	if counters := conv.TakeCounters(); !counters.PrefilterSkipped() || counters.DictionaryHits != 0 {
+		t.Errorf("Expected the pre-filter to skip British text, got %+v", counters)
+	}
+
+	// Copies made for files share the counters
+	conv.Clone().ConvertToBritish("flavor", false)
+	if counters := conv.TakeCounters(); counters.DictionaryHits != 1 {
+		t.Errorf("Expected a clone to count into the same counters, got %+v", counters)
+	}
+}
+
+func TestCLIVerbose(t *testing.T) {
+	cliPath := buildTestCLI(t)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "notes.txt")
+	if err := os.WriteFile(path, []byte("The color of the center.\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(cliPath, "-raw", "-verbose", path)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("CLI failed: %v\nStderr: %s", err, stderr.String())
+	}
+
+	if strings.TrimSpace(stdout.String()) != "The colour of the centre." {
+		t.Errorf("Expected only the converted text on stdout, got %q", stdout.String())
+	}
+	if !strings.Contains(stderr.String(), path+": ") || !strings.Contains(stderr.String(), "2 dictionary, 0 contextual, 0 units, pre-filter skipped: no") {
+		t.Errorf("Expected timing and rule counts on stderr, got %q", stderr.String())
+	}
+}
@@ -0,0 +1,130 @@
+package tests
+
+import (
+	"bufio"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// startWatch starts the CLI with -watch and the given arguments, waits until it's watching, and
+// returns a channel of the lines it prints afterwards
+func startWatch(t *testing.T, args ...string) <-chan string {
+	t.Helper()
+	cliPath := buildTestCLI(t)
+
+	cmd := exec.Command(cliPath, append([]string{"-watch"}, args...)...)
+	cmd.Env = append(os.Environ(), "HOME="+t.TempDir())
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmd.Stderr = cmd.Stdout
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("Failed to start the CLI: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = cmd.Process.Signal(os.Interrupt)
+		_ = cmd.Wait()
+	})
+
+	lines := make(chan string, 16)
+	go func() {
+		scanner := bufio.NewScanner(stdout)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+		close(lines)
+	}()
+
+	if line := nextWatchLine(t, lines); !strings.HasPrefix(line, "Watching ") {
+		t.Fatalf("Expected the CLI to start watching, got %q", line)
+	}
+	return lines
+}
+
+// nextWatchLine returns the next line printed by -watch, failing the test if there isn't one soon
+func nextWatchLine(t *testing.T, lines <-chan string) string {
+	t.Helper()
+	select {
+	case line, ok := <-lines:
+		if !ok {
+			t.Fatal("The CLI exited unexpectedly")
+		}
+		return line
+	case <-time.After(10 * time.Second):
+		t.Fatal("Timed out waiting for the CLI")
+	}
+	return ""
+}
+
+func TestWatchSave(t *testing.T) {
+	dir := t.TempDir()
+	lines := startWatch(t, "-save", dir)
+
+	// Several rapid writes are converted once, after the last
+	path := filepath.Join(dir, "notes.md")
+	for _, content := range []string{"The col", "The color", "The color and the center.\n"} {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	line := nextWatchLine(t, lines)
+	if !strings.Contains(line, "Saved changes to: "+path+" (2 spelling change(s))") || !strings.Contains(line, "color → colour") {
+		t.Errorf("Expected a summary of the saved changes, got %q", line)
+	}
+	if content, _ := os.ReadFile(path); string(content) != "The colour and the centre.\n" {
+		t.Errorf("Expected the file to be converted, got %q", content)
+	}
+
+	// Files in new directories are watched, and the save of notes.md wasn't reported again
+	sub := filepath.Join(dir, "sub")
+	if err := os.Mkdir(sub, 0755); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if err := os.WriteFile(filepath.Join(sub, "other.txt"), []byte("Nothing to change.\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if line := nextWatchLine(t, lines); !strings.HasSuffix(line, filepath.Join(sub, "other.txt")+": no changes needed") {
+		t.Errorf("Expected the new file to be reported unchanged, got %q", line)
+	}
+}
+
+func TestWatchReport(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "notes.md")
+	if err := os.WriteFile(path, []byte("Nothing yet.\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	lines := startWatch(t, path)
+
+	// Only the named file is watched, and it's left untouched
+	if err := os.WriteFile(filepath.Join(dir, "other.md"), []byte("The color.\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("The flavor.\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if line := nextWatchLine(t, lines); !strings.HasSuffix(line, path+": 1 spelling change(s) needed: flavor → flavour") {
+		t.Errorf("Expected a summary of the changes needed, got %q", line)
+	}
+	if content, _ := os.ReadFile(path); string(content) != "The flavor.\n" {
+		t.Errorf("Expected the file to be left untouched, got %q", content)
+	}
+}
+
+func TestWatchRejectsOtherModes(t *testing.T) {
+	cliPath := buildTestCLI(t)
+
+	for _, args := range [][]string{{"-watch", "-diff", "."}, {"-watch", "-exit-on-change", "."}, {"-watch"}} {
+		err := exec.Command(cliPath, args...).Run()
+		if exitErr, ok := err.(*exec.ExitError); !ok || exitErr.ExitCode() != 2 {
+			t.Errorf("Expected %v to fail with a usage error, got %v", args, err)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package tests
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+func TestWordAllowlist(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	input := "The color of the center is gray, and we analyze it."
+	if err := conv.SetWordAllowlist([]string{"colou?r", "cent(er|re)"}); err != nil {
+		t.Fatalf("SetWordAllowlist() failed: %v", err)
+	}
+
+	expected := "The colour of the centre is gray, and we analyze it."
+	if result := conv.ConvertToBritish(input, false); result != expected {
+		t.Errorf("ConvertToBritish() = %q, expected %q", result, expected)
+	}
+
+	t.Run("Case-insensitive whole words", func(t *testing.T) {
+		if result := conv.ConvertToBritish("Color, color and colors.", false); result != "Colour, colour and colors." {
+			t.Errorf("Unexpected conversion %q", result)
+		}
+	})
+
+	t.Run("Contextual words", func(t *testing.T) {
+		if err := conv.SetWordAllowlist([]string{"color"}); err != nil {
+			t.Fatal(err)
+		}
+		input := "I need a license to practice medicine in the color district."
+		if result := conv.ConvertToBritish(input, false); result != "I need a license to practice medicine in the colour district." {
+			t.Errorf("Expected contextual words outside the allowlist to be kept, got %q", result)
+		}
+
+		if err := conv.SetWordAllowlist([]string{"license"}); err != nil {
+			t.Fatal(err)
+		}
+		if result := conv.ConvertToBritish(input, false); result != "I need a licence to practice medicine in the color district." {
+			t.Errorf("Expected only the allowed contextual word to change, got %q", result)
+		}
+	})
+
+	t.Run("Empty allowlist restores all conversion", func(t *testing.T) {
+		if err := conv.SetWordAllowlist(nil); err != nil {
+			t.Fatal(err)
+		}
+		if result := conv.ConvertToBritish(input, false); result != "The colour of the centre is grey, and we analyse it." {
+			t.Errorf("Unexpected conversion %q", result)
+		}
+	})
+
+	t.Run("Combined with excluded words", func(t *testing.T) {
+		if err := conv.SetWordAllowlist([]string{"colou?r", "center"}); err != nil {
+			t.Fatal(err)
+		}
+		conv.SetExcludedWords([]string{"center"})
+		defer conv.SetExcludedWords(nil)
+		if result := conv.ConvertToBritish(input, false); result != "The colour of the center is gray, and we analyze it." {
+			t.Errorf("Expected excluded words to win, got %q", result)
+		}
+	})
+
+	t.Run("Invalid pattern", func(t *testing.T) {
+		if err := conv.SetWordAllowlist([]string{"colo(r"}); err == nil || !strings.Contains(err.Error(), "colo(r") {
+			t.Errorf("Expected an error naming the pattern, got %v", err)
+		}
+	})
+}
+
+func TestCLIOnlyWords(t *testing.T) {
+	cliPath := buildTestCLI(t)
+
+	cmd := exec.Command(cliPath, "-raw", "-only-words", "colou?r,cent(er|re)")
+	cmd.Stdin = strings.NewReader("The color of the center is gray.")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, output)
+	}
+	if strings.TrimSpace(string(output)) != "The colour of the centre is gray." {
+		t.Errorf("Expected only allowed words to change, got %q", output)
+	}
+
+	cmd = exec.Command(cliPath, "-raw", "-only-words=gr[ae]y")
+	cmd.Stdin = strings.NewReader("The color is gray.")
+	if output, err := cmd.CombinedOutput(); err != nil || strings.TrimSpace(string(output)) != "The color is grey." {
+		t.Errorf("Expected -only-words= to work, got %q (%v)", output, err)
+	}
+
+	cmd = exec.Command(cliPath, "-raw", "-only-words", "colo(r")
+	cmd.Stdin = strings.NewReader("The color.")
+	if output, err := cmd.CombinedOutput(); err == nil || !strings.Contains(string(output), "invalid word allowlist pattern") {
+		t.Errorf("Expected an invalid pattern error, got %q (%v)", output, err)
+	}
+}
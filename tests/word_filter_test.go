@@ -0,0 +1,74 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/converter"
+)
+
+// TestDictionaryPreFilterKeepsEveryWord checks that the line pre-filter never skips a line
+// containing a dictionary word, whatever its case or surrounding punctuation
+func TestDictionaryPreFilterKeepsEveryWord(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+	conv.SetUnitProcessingEnabled(false)
+	detector := conv.GetContextualWordDetector()
+	detector.SetEnabled(false)
+
+	contextual := make(map[string]bool)
+	for _, word := range detector.SupportedWords() {
+		contextual[strings.ToLower(word)] = true
+	}
+
+	forms := []func(string) string{
+		func(w string) string { return w },
+		strings.ToUpper,
+		func(w string) string { return strings.ToUpper(w[:1]) + w[1:] },
+		func(w string) string { return w + "." },
+		func(w string) string { return w + "," },
+		func(w string) string { return "well-" + w },
+		func(w string) string { return `"` + w + `"` },
+		func(w string) string { return "Café " + w + " — déjà vu" },
+	}
+
+	for american, british := range conv.GetAmericanToBritishDictionary() {
+		if contextual[american] || strings.EqualFold(american, british) || strings.ContainsAny(american, " -'\"") {
+			continue
+		}
+		for _, form := range forms {
+			input := "Before " + form(american) + " after"
+			if got := conv.ConvertToBritishSimple(input, false); got == input {
+				t.Errorf("Expected %q to be converted, got it unchanged", input)
+			}
+		}
+	}
+}
+
+func TestDictionaryPreFilterLeavesOtherTextUnchanged(t *testing.T) {
+	conv, err := converter.NewConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	tests := []string{
+		"",
+		"The programme was launched in the capital.\nEngineers signed off on the final report.",
+		"Ünïcödé text with no Americanisms — only British spellings like colour and centre.",
+		"\n\n  \t\n",
+	}
+	for _, input := range tests {
+		if got := conv.ConvertToBritish(input, false); got != input {
+			t.Errorf("Expected %q unchanged, got %q", input, got)
+		}
+	}
+
+	// Lines without dictionary words next to ones with them keep their exact content
+	input := "Nothing here.\nThe color.\n  Nothing here either.  "
+	expected := "Nothing here.\nThe colour.\n  Nothing here either.  "
+	if got := conv.ConvertToBritish(input, false); got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+}
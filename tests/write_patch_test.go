@@ -0,0 +1,171 @@
+package tests
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sammcj/m2e/pkg/report"
+)
+
+func TestPatchDiff(t *testing.T) {
+	lines := func(n int, changed map[int]string) string {
+		var b strings.Builder
+		for i := 1; i <= n; i++ {
+			if text, ok := changed[i]; ok {
+				b.WriteString(text + "\n")
+			} else {
+				b.WriteString("Line " + string(rune('a'+i-1)) + "\n")
+			}
+		}
+		return b.String()
+	}
+
+	tests := []struct {
+		name      string
+		original  string
+		converted string
+		expected  string
+	}{
+		{
+			name:      "No changes",
+			original:  "The colour.\n",
+			converted: "The colour.\n",
+			expected:  "",
+		},
+		{
+			name:      "Context around a change",
+			original:  lines(9, map[int]string{5: "The color."}),
+			converted: lines(9, map[int]string{5: "The colour."}),
+			expected: "diff --git a/docs/a.md b/docs/a.md\n--- a/docs/a.md\n+++ b/docs/a.md\n" +
+				"@@ -2,7 +2,7 @@\n Line b\n Line c\n Line d\n-The color.\n+The colour.\n Line f\n Line g\n Line h\n",
+		},
+		{
+			name:      "Nearby changes share a hunk",
+			original:  lines(8, map[int]string{1: "The color.", 7: "The center."}),
+			converted: lines(8, map[int]string{1: "The colour.", 7: "The centre."}),
+			expected: "diff --git a/docs/a.md b/docs/a.md\n--- a/docs/a.md\n+++ b/docs/a.md\n" +
+				"@@ -1,8 +1,8 @@\n-The color.\n+The colour.\n Line b\n Line c\n Line d\n Line e\n Line f\n" +
+				"-The center.\n+The centre.\n Line h\n",
+		},
+		{
+			name:      "Distant changes get their own hunks",
+			original:  lines(12, map[int]string{1: "The color.", 12: "The center."}),
+			converted: lines(12, map[int]string{1: "The colour.", 12: "The centre."}),
+			expected: "diff --git a/docs/a.md b/docs/a.md\n--- a/docs/a.md\n+++ b/docs/a.md\n" +
+				"@@ -1,4 +1,4 @@\n-The color.\n+The colour.\n Line b\n Line c\n Line d\n" +
+				"@@ -9,4 +9,4 @@\n Line i\n Line j\n Line k\n-The center.\n+The centre.\n",
+		},
+		{
+			name:      "No newline at end of file",
+			original:  "The color",
+			converted: "The colour",
+			expected: "diff --git a/docs/a.md b/docs/a.md\n--- a/docs/a.md\n+++ b/docs/a.md\n" +
+				"@@ -1 +1 @@\n-The color\n\\ No newline at end of file\n+The colour\n\\ No newline at end of file\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := report.PatchDiff(tt.original, tt.converted, "docs/a.md"); result != tt.expected {
+				t.Errorf("PatchDiff() =\n%s\nexpected\n%s", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatPatch(t *testing.T) {
+	patch := report.FormatPatch([]report.FileResult{
+		{FilePath: "a.md", Original: "The color.\n", Converted: "The colour.\n", HasChanges: true},
+		{FilePath: "b.md", Original: "The colour.\n", Converted: "The colour.\n"},
+		{FilePath: "c.md", Error: os.ErrNotExist},
+		{FilePath: "d.md", Original: "The flavor.\n", Converted: "The flavour.\n", HasChanges: true},
+	})
+	if strings.Count(patch, "diff --git") != 2 || !strings.Contains(patch, "a/a.md") || !strings.Contains(patch, "a/d.md") {
+		t.Errorf("Expected a patch covering a.md and d.md, got\n%s", patch)
+	}
+}
+
+func TestWritePatchCLI(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	cliPath := buildTestCLI(t)
+
+	repo := t.TempDir()
+	files := map[string]string{
+		"docs/a.md":      "# Guide\n\nPick a color.\n\nMore text.\n",
+		"docs/b.md":      "Nothing to change.\n",
+		"docs/sub/c.txt": "The flavor",
+		"notes/d.md":     "The center.\n",
+		"notes/other.md": "The gray cat.\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(repo, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	patchFile := filepath.Join(t.TempDir(), "m2e.patch")
+	cmd := exec.Command(cliPath, "-write-patch", patchFile, "docs", "notes/d.md")
+	cmd.Dir = repo
+	cmd.Env = append(os.Environ(), "HOME="+t.TempDir())
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, out)
+	}
+
+	// Nothing is modified
+	for name, content := range files {
+		if got, _ := os.ReadFile(filepath.Join(repo, name)); string(got) != content {
+			t.Errorf("Expected %s to be left alone, got %q", name, got)
+		}
+	}
+
+	patch, err := os.ReadFile(patchFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, header := range []string{"diff --git a/docs/a.md b/docs/a.md", "diff --git a/docs/sub/c.txt b/docs/sub/c.txt", "diff --git a/notes/d.md b/notes/d.md"} {
+		if !strings.Contains(string(patch), header) {
+			t.Errorf("Expected %q in the patch, got\n%s", header, patch)
+		}
+	}
+	if strings.Contains(string(patch), "b.md") || strings.Contains(string(patch), "other.md") {
+		t.Errorf("Expected only changed files in the patch, got\n%s", patch)
+	}
+
+	// The patch applies with git apply and makes the conversion
+	apply := exec.Command("git", "apply", patchFile)
+	apply.Dir = repo
+	if out, err := apply.CombinedOutput(); err != nil {
+		t.Fatalf("git apply failed: %v\n%s\nPatch:\n%s", err, out, patch)
+	}
+	expected := map[string]string{
+		"docs/a.md":      "# Guide\n\nPick a colour.\n\nMore text.\n",
+		"docs/sub/c.txt": "The flavour",
+		"notes/d.md":     "The centre.\n",
+	}
+	for name, content := range expected {
+		if got, _ := os.ReadFile(filepath.Join(repo, name)); string(got) != content {
+			t.Errorf("Expected %s to be %q after git apply, got %q", name, content, got)
+		}
+	}
+
+	// -exit-on-change reports the changes, and '-' writes the patch to stdout
+	cmd = exec.Command(cliPath, "-write-patch", "-", "-exit-on-change", "notes")
+	cmd.Dir = repo
+	cmd.Env = append(os.Environ(), "HOME="+t.TempDir())
+	out, err := cmd.Output()
+	if exitErr, ok := err.(*exec.ExitError); !ok || exitErr.ExitCode() != 1 {
+		t.Errorf("Expected exit code 1 with -exit-on-change, got %v", err)
+	}
+	if !strings.HasPrefix(string(out), "diff --git a/notes/other.md b/notes/other.md\n") {
+		t.Errorf("Expected the patch on stdout, got\n%s", out)
+	}
+}